@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/urfave/cli"
+)
+
+// ArchiveCommand contains the definition of the archive command-line
+// subcommand and its own subcommands.
+var ArchiveCommand cli.Command
+
+const (
+	groupFlag = "group"
+	allFlag   = "all"
+)
+
+const archiveDescription = `The archive command gives access to key shares of
+   stale groups archived in the storage data directory. The "list" subcommand
+   lists archived groups. The "purge" subcommand securely erases key shares
+   of the given archived group, or of all archived groups, regardless of the
+   configured retention policy. Every erasure is recorded in the audit log
+   kept in the storage data directory, before any file is overwritten and
+   once the erasure is over. Listing the archive does not modify it.`
+
+func init() {
+	ArchiveCommand = cli.Command{
+		Name:        "archive",
+		Usage:       `Manages key shares of archived groups`,
+		Description: archiveDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "list",
+				Usage:  "Lists archived groups.",
+				Action: listArchivedGroups,
+			},
+			{
+				Name:   "purge",
+				Usage:  "Securely erases key shares of archived groups.",
+				Action: purgeArchivedGroups,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  groupFlag,
+						Usage: "name of the archived group to purge",
+					},
+					&cli.BoolFlag{
+						Name:  allFlag,
+						Usage: "purge all archived groups",
+					},
+				},
+			},
+		},
+	}
+}
+
+// listArchivedGroups prints out groups archived in the storage data
+// directory.
+func listArchivedGroups(c *cli.Context) error {
+	archive, err := readArchive(c)
+	if err != nil {
+		return err
+	}
+
+	groups, err := archive.Groups()
+	if err != nil {
+		return fmt.Errorf("could not list archived groups: [%v]", err)
+	}
+
+	if len(groups) == 0 {
+		fmt.Printf("No archived groups.\n")
+		return nil
+	}
+
+	for _, group := range groups {
+		fmt.Printf(
+			"Group [%v] archived at [%v] with [%v] memberships.\n",
+			group.Name,
+			group.ArchivedAt.Format(time.RFC3339),
+			len(group.Files),
+		)
+	}
+
+	return nil
+}
+
+// purgeArchivedGroups securely erases key shares of the archived group given
+// with the group flag or of all archived groups if the all flag is set.
+func purgeArchivedGroups(c *cli.Context) error {
+	group := c.String(groupFlag)
+	all := c.Bool(allFlag)
+
+	if (group == "") == !all {
+		return fmt.Errorf(
+			"either --%v or --%v flag has to be provided",
+			groupFlag,
+			allFlag,
+		)
+	}
+
+	archive, err := readArchive(c)
+	if err != nil {
+		return err
+	}
+
+	const reason = "purged with the archive purge command"
+
+	if !all {
+		if err := archive.Erase(group, reason); err != nil {
+			return fmt.Errorf("could not purge archived group: [%v]", err)
+		}
+
+		fmt.Printf("Purged archived group [%v].\n", group)
+		return nil
+	}
+
+	erased, err := archive.EraseArchivedBefore(time.Now(), reason)
+	for _, group := range erased {
+		fmt.Printf("Purged archived group [%v].\n", group)
+	}
+	if err != nil {
+		return fmt.Errorf("could not purge all archived groups: [%v]", err)
+	}
+
+	fmt.Printf("Purged [%v] archived groups.\n", len(erased))
+
+	return nil
+}
+
+func readArchive(c *cli.Context) (*registry.Archive, error) {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	return registry.NewArchive(cfg.Storage.DataDir), nil
+}
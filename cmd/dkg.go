@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/urfave/cli"
+)
+
+// DKGCommand contains the definition of the dkg command-line subcommand and
+// its own subcommands.
+var DKGCommand cli.Command
+
+const dkgDescription = `The dkg command inspects distributed key generations
+   the client took part in. Transcripts are recorded only if enabled in the
+   Storage.Transcripts section of the configuration. The "replay" subcommand
+   reads the transcript of DKG kept in the transcripts directory of the storage data directory, in
+   the dkg_<seed>_<index> directory given as the argument, and repeats the
+   verification the member performed during the key generation without
+   connecting to the network. It prints out phases of DKG with their block
+   heights, accusations published by members and explains why each member
+   has been marked as inactive or disqualified. The transcript is decrypted
+   with the password of the operator key file configured in the config file.`
+
+func init() {
+	DKGCommand = cli.Command{
+		Name:        "dkg",
+		Usage:       `Inspects distributed key generations`,
+		Description: dkgDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:      "replay",
+				Usage:     "Replays DKG recorded in the transcript.",
+				ArgsUsage: "[dkg_<seed>_<index>]",
+				Action:    replayDKG,
+			},
+		},
+	}
+}
+
+// replayDKG replays DKG recorded in the transcript kept in the directory given
+// as the argument and prints out the report.
+func replayDKG(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("directory of the transcript has to be provided")
+	}
+
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	transcripts, err := transcriptStorage(cfg)
+	if err != nil {
+		return fmt.Errorf("could not access transcript storage: [%v]", err)
+	}
+
+	transcript, err := transcripts.Read(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	signing, err := ethereum.NewSigning(cfg.Ethereum)
+	if err != nil {
+		return fmt.Errorf("could not create operator signing: [%v]", err)
+	}
+
+	report, err := dkg.Replay(transcript, signing)
+	if err != nil {
+		return fmt.Errorf("could not replay DKG: [%v]", err)
+	}
+
+	fmt.Printf(
+		"DKG of member [%v] of the group selected with seed [0x%x], "+
+			"started at block [%v].\n",
+		transcript.Index+1,
+		transcript.Seed,
+		transcript.StartBlockHeight,
+	)
+
+	fmt.Printf("\nKey generation:\n")
+	printPhases(transcript.KeyGeneration.Machine())
+
+	fmt.Printf("\nAccusations:\n")
+	if len(report.KeyGeneration.Accusations) == 0 {
+		fmt.Printf("  none\n")
+	}
+	for _, accusation := range report.KeyGeneration.Accusations {
+		subject := "secret shares"
+		if accusation.Points {
+			subject = "public key share points"
+		}
+
+		fmt.Printf(
+			"  member [%v] accused member [%v] of sending invalid %v "+
+				"in phase [%v]\n",
+			accusation.AccuserID,
+			accusation.AccusedID,
+			subject,
+			accusation.Phase,
+		)
+	}
+
+	fmt.Printf("\nDecisions:\n")
+	if len(report.KeyGeneration.Decisions) == 0 {
+		fmt.Printf("  none\n")
+	}
+	for _, decision := range report.KeyGeneration.Decisions {
+		verdict := "disqualified"
+		if decision.Inactive {
+			verdict = "marked as inactive"
+		}
+
+		fmt.Printf(
+			"  member [%v] %v in phase [%v]: %v\n",
+			decision.MemberID,
+			verdict,
+			decision.Phase,
+			decision.Explanation,
+		)
+	}
+
+	fmt.Printf("\nResult:\n")
+	if result := report.KeyGeneration.Result; result != nil {
+		groupPublicKey, err := result.GroupPublicKeyBytes()
+		if err != nil {
+			fmt.Printf("  group public key could not be computed: [%v]\n", err)
+		} else {
+			fmt.Printf("  group public key [0x%x]\n", groupPublicKey)
+		}
+		fmt.Printf(
+			"  inactive members %v\n  disqualified members %v\n",
+			result.Group.InactiveMemberIDs(),
+			result.Group.DisqualifiedMemberIDs(),
+		)
+	} else {
+		fmt.Printf("  key generation did not complete\n")
+	}
+
+	fmt.Printf("\nResult publication:\n")
+	if transcript.ResultPublication == nil {
+		fmt.Printf("  not started\n")
+		return nil
+	}
+	printPhases(transcript.ResultPublication)
+	for resultHash, supporters := range report.ResultSupporters {
+		fmt.Printf(
+			"  result [0x%x] signed by members %v\n",
+			resultHash,
+			supporters,
+		)
+	}
+
+	return nil
+}
+
+func printPhases(transcript *state.Transcript) {
+	for index, phase := range transcript.Phases {
+		received, sent := 0, 0
+		for _, message := range transcript.Messages {
+			if message.StateIndex != index {
+				continue
+			}
+			if message.Sent {
+				sent++
+			} else {
+				received++
+			}
+		}
+
+		fmt.Printf(
+			"  phase [%v] started at block [%v]; [%v] messages received, "+
+				"[%v] sent\n",
+			phase.State,
+			phase.StartBlockHeight,
+			received,
+			sent,
+		)
+	}
+
+	if transcript.EndBlockHeight != 0 {
+		fmt.Printf("  ended at block [%v]\n", transcript.EndBlockHeight)
+	}
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/urfave/cli"
+)
+
+// MembershipsCommand contains the definition of the memberships command-line
+// subcommand and its own subcommands.
+var MembershipsCommand cli.Command
+
+const fileFlag = "file"
+
+// passphraseEnvVariable is the name of the environment variable holding the
+// passphrase the membership export is encrypted with.
+const passphraseEnvVariable = "KEEP_MEMBERSHIPS_PASSPHRASE"
+
+const membershipsDescription = `The memberships command moves group memberships
+   of the operator between machines. The "export" subcommand writes all
+   memberships kept in the storage data directory to a versioned archive
+   encrypted with the passphrase set in the ` + passphraseEnvVariable + `
+   environment variable. The "import" subcommand decrypts the archive with the
+   same passphrase, checks every membership belongs to a group registered
+   on-chain and to the operator configured in the config file, and only then
+   writes the memberships to the storage data directory.`
+
+func init() {
+	fileFlagDefinition := &cli.StringFlag{
+		Name:  fileFlag,
+		Usage: "path of the membership export",
+	}
+
+	MembershipsCommand = cli.Command{
+		Name:        "memberships",
+		Usage:       `Exports and imports group memberships`,
+		Description: membershipsDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:   "export",
+				Usage:  "Exports memberships to an encrypted file.",
+				Action: exportMemberships,
+				Flags:  []cli.Flag{fileFlagDefinition},
+			},
+			{
+				Name:   "import",
+				Usage:  "Imports memberships from an encrypted file.",
+				Action: importMemberships,
+				Flags:  []cli.Flag{fileFlagDefinition},
+			},
+		},
+	}
+}
+
+// exportMemberships writes memberships kept in the storage data directory to
+// the encrypted export file.
+func exportMemberships(c *cli.Context) error {
+	path, passphrase, err := membershipsExportParams(c)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	handle, err := membershipsPersistence(cfg)
+	if err != nil {
+		return err
+	}
+
+	memberships, err := registry.ReadMemberships(handle)
+	if err != nil {
+		return fmt.Errorf("could not read memberships: [%v]", err)
+	}
+
+	exported, err := registry.ExportMemberships(memberships, passphrase)
+	if err != nil {
+		return fmt.Errorf("could not export memberships: [%v]", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("could not create export file: [%v]", err)
+	}
+	if _, err := file.Write(exported); err != nil {
+		file.Close()
+		return fmt.Errorf("could not write export file: [%v]", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("could not write export file: [%v]", err)
+	}
+
+	fmt.Printf("Exported [%v] memberships to [%v].\n", len(memberships), path)
+
+	return nil
+}
+
+// importMemberships verifies memberships from the encrypted export file
+// against the chain and writes them to the storage data directory.
+func importMemberships(c *cli.Context) error {
+	path, passphrase, err := membershipsExportParams(c)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	exported, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read export file: [%v]", err)
+	}
+
+	memberships, err := registry.ImportMemberships(exported, passphrase)
+	if err != nil {
+		return fmt.Errorf("could not import memberships: [%v]", err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	chainProvider, err := ethereum.Connect(ctx, cfg.Ethereum)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	handle, err := membershipsPersistence(cfg)
+	if err != nil {
+		return err
+	}
+
+	err = registry.SaveMemberships(
+		memberships,
+		chainProvider.ThresholdRelay(),
+		common.HexToAddress(cfg.Ethereum.Account.Address).Bytes(),
+		handle,
+	)
+	if err != nil {
+		return fmt.Errorf("could not import memberships: [%v]", err)
+	}
+
+	fmt.Printf("Imported [%v] memberships from [%v].\n", len(memberships), path)
+
+	return nil
+}
+
+func membershipsExportParams(c *cli.Context) (string, string, error) {
+	path := c.String(fileFlag)
+	if path == "" {
+		return "", "", fmt.Errorf("--%v flag has to be provided", fileFlag)
+	}
+
+	passphrase := os.Getenv(passphraseEnvVariable)
+	if passphrase == "" {
+		return "", "", fmt.Errorf(
+			"passphrase has to be set in the [%v] environment variable",
+			passphraseEnvVariable,
+		)
+	}
+
+	return path, passphrase, nil
+}
+
+func membershipsPersistence(cfg *config.Config) (persistence.Handle, error) {
+	handle, err := persistence.NewDiskHandle(cfg.Storage.DataDir)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed while creating a storage disk handler: [%v]",
+			err,
+		)
+	}
+
+	return persistence.NewEncryptedPersistence(
+		handle,
+		cfg.Ethereum.Account.KeyFilePassword,
+	), nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
@@ -51,7 +52,10 @@ func relayRequest(c *cli.Context) error {
 		return fmt.Errorf("error reading config file: [%v]", err)
 	}
 
-	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	utility, err := ethereum.ConnectUtility(ctx, cfg.Ethereum)
 	if err != nil {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
@@ -91,7 +95,10 @@ func genesis(c *cli.Context) error {
 		return fmt.Errorf("error reading config file: [%v]", err)
 	}
 
-	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	utility, err := ethereum.ConnectUtility(ctx, cfg.Ethereum)
 	if err != nil {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
@@ -2,21 +2,33 @@ package cmd
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
 	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/status"
 	"github.com/urfave/cli"
 )
 
@@ -32,10 +44,57 @@ const (
 	portShort         = "p"
 	waitForStakeFlag  = "wait-for-stake"
 	waitForStakeShort = "w"
+	observerFlag      = "observer"
 )
 
+// checkpointDirName is the name of the directory, in the storage data
+// directory, holding checkpoints of DKG in progress.
+const checkpointDirName = "checkpoints"
+
+// observerKeyFileName is the name of the file, in the storage data directory,
+// holding the key of the client started in the observer mode.
+const observerKeyFileName = "observer_key"
+
+// networkKeyFileName is the name of the file, in the storage data directory,
+// holding the network key of the client whose operator key is held by an
+// external signer.
+const networkKeyFileName = "network_key"
+
+// transcriptDirName is the name of the directory, in the storage data
+// directory, holding transcripts of DKG executed by the client.
+const transcriptDirName = "transcripts"
+
+// stopSignals are the signals on which the client shuts down gracefully.
+var stopSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+
 const startDescription = `Starts the Keep client in the foreground. Currently this only consists of the
-   threshold relay client for the Keep random beacon.`
+   threshold relay client for the Keep random beacon.
+
+   With the --observer flag, the client runs in the read-only observer mode.
+   It follows the random beacon and forwards network messages without taking
+   part in any protocol, so it needs no KEEP stake. The observer does not use
+   the operator account and needs no password; it generates its own key in the
+   storage data directory and logs its address at startup. Staked clients
+   accept the observer as a peer only if this address is listed in the
+   LibP2P.Observers section of their configuration, so the observer mode is
+   meant only for private deployments whose operators all list the observer.
+   On a public network the observer can not connect to other clients.
+
+   If the Ethereum.Signer section of the configuration selects an external
+   signer, like Clef, the operator key is never decrypted by the client. The
+   client generates its own network key in the storage data directory instead
+   and, on every start, asks the external signer to sign an attestation that
+   the network key acts on behalf of the operator. Peers check the stake of
+   the operator, not of the network key.
+
+   The client shuts down gracefully on SIGINT or SIGTERM. It stops handling
+   chain events and waits for DKG and relay entry signing in progress to
+   complete before disconnecting from the network. DKG in progress is
+   checkpointed and resumed when the client starts again, as long as DKG has
+   not ended in the meantime. If enabled in the Storage.Transcripts section of
+   the configuration, transcripts of DKG are kept in the storage data
+   directory for the configured number of days and can be replayed with the
+   "dkg replay" command.`
 
 func init() {
 	StartCommand =
@@ -51,35 +110,102 @@ func init() {
 				&cli.IntFlag{
 					Name: waitForStakeFlag + "," + waitForStakeShort,
 				},
+				&cli.BoolFlag{
+					Name: observerFlag,
+					Usage: "follow the random beacon without taking part in it; " +
+						"private deployments only, peers must list the " +
+						"observer in LibP2P.Observers",
+				},
 			},
 		}
 }
 
 // Start starts a node; if it's not a bootstrap node it will get the Node.URLs
-// from the config file
+// from the config file. The node runs until it receives SIGINT or SIGTERM.
 func Start(c *cli.Context) error {
-	config, err := config.ReadConfig(c.GlobalString("config"))
+	observer := c.Bool(observerFlag)
+
+	readConfig := config.ReadConfig
+	if observer {
+		readConfig = config.ReadObserverConfig
+	}
+
+	config, err := readConfig(c.GlobalString("config"))
 	if err != nil {
 		return fmt.Errorf("error reading config file: %v", err)
 	}
 
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	go cancelOnSignal(cancelCtx)
+
 	if c.Int(portFlag) > 0 {
 		config.LibP2P.Port = c.Int(portFlag)
 	}
 
-	// FIXME This needs to happen inside the `pkg/chain/ethereum` scope,
-	// FIXME probably.
-	operatorPrivateKey, operatorPublicKey, err := loadStaticKey(
-		config.Ethereum.Account.KeyFile,
-		config.Ethereum.Account.KeyFilePassword,
+	var (
+		chainProvider     chain.Handle
+		networkPrivateKey *key.NetworkPrivate
+		networkOptions    []libp2p.ConnectOption
+		observerAddress   string
 	)
-	if err != nil {
-		return fmt.Errorf("error loading static peer's key [%v]", err)
-	}
+	switch {
+	case observer:
+		// The observer has its own key, so it does not decrypt the operator
+		// key file and its network identity is not tied to the operator.
+		observerAccountKey, err := observerKey(config.Storage.DataDir)
+		if err != nil {
+			return err
+		}
+		observerAddress = observerAccountKey.Address.Hex()
 
-	chainProvider, err := ethereum.Connect(config.Ethereum)
-	if err != nil {
-		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+		networkPrivateKey, _ = key.OperatorKeyToNetworkKey(
+			operator.EthereumKeyToOperatorKey(observerAccountKey),
+		)
+
+		chainProvider, err = ethereum.ConnectWithKey(
+			ctx,
+			config.Ethereum,
+			observerAccountKey,
+		)
+		if err != nil {
+			return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+		}
+	case config.Ethereum.Signer.IsExternal():
+		// The operator key is held by the external signer, so it can not be
+		// the network key. The client uses its own network key attested by
+		// the operator key instead.
+		chainProvider, err = ethereum.Connect(ctx, config.Ethereum)
+		if err != nil {
+			return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+		}
+
+		var attestation []byte
+		networkPrivateKey, attestation, err = attestedNetworkKey(
+			config.Storage.DataDir,
+			chainProvider.Signing(),
+		)
+		if err != nil {
+			return err
+		}
+		networkOptions = append(
+			networkOptions,
+			libp2p.WithOperatorAttestation(attestation),
+		)
+	default:
+		accountKey, err := operatorKey(config.Ethereum)
+		if err != nil {
+			return err
+		}
+
+		networkPrivateKey, _ = key.OperatorKeyToNetworkKey(
+			operator.EthereumKeyToOperatorKey(accountKey),
+		)
+
+		chainProvider, err = ethereum.ConnectWithKey(ctx, config.Ethereum, accountKey)
+		if err != nil {
+			return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+		}
 	}
 
 	blockCounter, err := chainProvider.BlockCounter()
@@ -91,91 +217,305 @@ func Start(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error obtaining stake monitor handle [%v]", err)
 	}
-	if c.Int(waitForStakeFlag) != 0 {
-		err = waitForStake(stakeMonitor, config.Ethereum.Account.Address, c.Int(waitForStakeFlag))
-		if err != nil {
+	if !observer {
+		if err := checkStake(
+			stakeMonitor,
+			config.Ethereum.Account.Address,
+			c.Int(waitForStakeFlag),
+		); err != nil {
 			return err
 		}
 	}
-	hasMinimumStake, err := stakeMonitor.HasMinimumStake(
-		config.Ethereum.Account.Address,
+
+	if err := metrics.Start(ctx, config.Metrics); err != nil {
+		return fmt.Errorf("error starting metrics endpoint: [%v]", err)
+	}
+
+	// DKG and relay entry signing still in progress when the client starts
+	// shutting down need the network, so the network is managed by its own
+	// context, done only when the client is about to exit.
+	networkCtx, cancelNetworkCtx := context.WithCancel(context.Background())
+	defer cancelNetworkCtx()
+
+	minimumStakePolicy, err := firewall.MinimumStakePolicy(
+		networkCtx,
+		stakeMonitor,
 	)
 	if err != nil {
-		return fmt.Errorf("could not check the stake [%v]", err)
-	}
-	if !hasMinimumStake {
-		return fmt.Errorf(
-			"no minimum KEEP stake or operator is not authorized to use it; " +
-				"please make sure the operator address in the configuration " +
-				"is correct and it has KEEP tokens delegated and the operator " +
-				"contract has been authorized to operate on the stake",
-		)
+		return fmt.Errorf("error creating firewall: [%v]", err)
 	}
 
-	ctx := context.Background()
-	networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
-		operatorPrivateKey, operatorPublicKey,
-	)
 	netProvider, err := libp2p.Connect(
-		ctx,
+		networkCtx,
 		config.LibP2P,
 		networkPrivateKey,
-		firewall.MinimumStakePolicy(stakeMonitor),
-		retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
+		firewall.AllowObservers(config.LibP2P.Observers, minimumStakePolicy),
+		retransmission.NewTicker(blockCounter.WatchBlocks(networkCtx)),
+		networkOptions...,
 	)
 	if err != nil {
 		return err
 	}
+	defer closeNetwork(netProvider)
 
 	nodeHeader(netProvider.ConnectionManager().AddrStrings(), config.LibP2P.Port)
 
+	if observer {
+		logger.Infof(
+			"starting in the observer mode with address [%v]; "+
+				"peers accept the observer only if they list this address "+
+				"in LibP2P.Observers",
+			observerAddress,
+		)
+
+		err = beacon.Observe(ctx, chainProvider, netProvider)
+		if err != nil {
+			return fmt.Errorf("error starting observer: [%v]", err)
+		}
+
+		err = status.Start(
+			ctx,
+			config.Status,
+			nil,
+			netProvider.ConnectionManager(),
+			blockCounter,
+		)
+		if err != nil {
+			return fmt.Errorf("error starting status API: [%v]", err)
+		}
+
+		<-ctx.Done()
+		logger.Infof("observer shutting down")
+
+		return nil
+	}
+
 	handle, err := persistence.NewDiskHandle(config.Storage.DataDir)
 	if err != nil {
 		return fmt.Errorf("failed while creating a storage disk handler: [%v]", err)
 	}
+
+	// DKG checkpoints and transcripts are kept apart from group memberships
+	// so that they are not read as memberships.
+	checkpointPersistence, err := dataSubdirPersistence(
+		config,
+		checkpointDirName,
+	)
+	if err != nil {
+		return fmt.Errorf("failed while creating a checkpoint storage: [%v]", err)
+	}
+
+	var transcripts *dkg.TranscriptStorage
+	if config.Storage.Transcripts.Record {
+		transcripts, err = transcriptStorage(config)
+		if err != nil {
+			return fmt.Errorf(
+				"failed while creating a transcript storage: [%v]",
+				err,
+			)
+		}
+	}
+
 	persistence := persistence.NewEncryptedPersistence(
 		handle,
 		config.Ethereum.Account.KeyFilePassword,
 	)
 
-	err = beacon.Initialize(
+	retention, err := registry.NewRetention(
+		config.Storage.Retention,
+		registry.NewArchive(config.Storage.DataDir),
+	)
+	if err != nil {
+		return fmt.Errorf("invalid storage retention: [%v]", err)
+	}
+
+	beaconStatus, err := beacon.Initialize(
 		ctx,
 		config.Ethereum.Account.Address,
 		chainProvider,
 		netProvider,
-		persistence,
+		beacon.Config{
+			BatchMessages:         config.LibP2P.BatchMessages,
+			Persistence:           persistence,
+			CheckpointPersistence: checkpointPersistence,
+			CheckpointArchive: registry.NewArchive(
+				filepath.Join(config.Storage.DataDir, checkpointDirName),
+			),
+			Transcripts: transcripts,
+			Retention:   retention,
+		},
 	)
 	if err != nil {
 		return fmt.Errorf("error initializing beacon: [%v]", err)
 	}
 
-	select {
-	case <-ctx.Done():
+	err = status.Start(
+		ctx,
+		config.Status,
+		beaconStatus,
+		netProvider.ConnectionManager(),
+		blockCounter,
+	)
+	if err != nil {
+		return fmt.Errorf("error starting status API: [%v]", err)
+	}
+
+	<-ctx.Done()
+	<-beaconStatus.Stopped()
+	logger.Infof("beacon stopped")
+
+	return nil
+}
+
+// cancelOnSignal cancels the root context of the client once the client
+// receives one of the stop signals. A second signal terminates the client
+// immediately.
+func cancelOnSignal(cancel context.CancelFunc) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, stopSignals...)
+
+	receivedSignal := <-signals
+	logger.Infof(
+		"received [%v]; shutting down gracefully, "+
+			"repeat to terminate immediately",
+		receivedSignal,
+	)
+	signal.Reset(stopSignals...)
+
+	cancel()
+}
+
+// closeNetwork disconnects the client from the network.
+func closeNetwork(netProvider net.Provider) {
+	if err := netProvider.Close(); err != nil {
+		logger.Errorf("could not close the network provider: [%v]", err)
+	}
+}
+
+// checkStake makes sure the operator has a minimum stake, waiting for it
+// for the given number of seconds first, if it is not zero.
+func checkStake(
+	stakeMonitor chain.StakeMonitor,
+	address string,
+	waitForStakeSeconds int,
+) error {
+	if waitForStakeSeconds != 0 {
+		err := waitForStake(stakeMonitor, address, waitForStakeSeconds)
 		if err != nil {
 			return err
 		}
+	}
+	hasMinimumStake, err := stakeMonitor.HasMinimumStake(
+		address,
+	)
+	if err != nil {
+		return fmt.Errorf("could not check the stake [%v]", err)
+	}
+	if !hasMinimumStake {
+		return fmt.Errorf(
+			"no minimum KEEP stake or operator is not authorized to use it; " +
+				"please make sure the operator address in the configuration " +
+				"is correct and it has KEEP tokens delegated and the operator " +
+				"contract has been authorized to operate on the stake",
+		)
+	}
+
+	return nil
+}
 
-		return fmt.Errorf("uh-oh, we went boom boom for no reason")
+// operatorKey decrypts the operator key from the configured key file.
+func operatorKey(ethereumConfig ethereum.Config) (*keystore.Key, error) {
+	operatorKey, err := ethutil.DecryptKeyFile(
+		ethereumConfig.Account.KeyFile,
+		ethereumConfig.Account.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to read KeyFile: %s [%v]",
+			ethereumConfig.Account.KeyFile,
+			err,
+		)
+	}
+
+	return operatorKey, nil
+}
+
+// observerKey returns the key of the observer kept in the storage data
+// directory, generating it on the first start. The key is not related to any
+// operator, holds no stake and never signs anything on the chain; it only
+// identifies the observer in the network. Removing the key file gives the
+// observer a new identity.
+func observerKey(dataDir string) (*keystore.Key, error) {
+	privateKey, err := storedKey(dataDir, observerKeyFileName)
+	if err != nil {
+		return nil, fmt.Errorf("could not get observer key: [%v]", err)
 	}
+
+	return &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}, nil
 }
 
-func loadStaticKey(
-	keyFile string,
-	keyFilePassword string,
-) (*operator.PrivateKey, *operator.PublicKey, error) {
-	ethereumKey, err := ethutil.DecryptKeyFile(
-		keyFile,
-		keyFilePassword,
+// attestedNetworkKey returns the network key of the client whose operator key
+// is held by an external signer, along with the attestation of the network
+// key signed with the operator key. The network key is kept in the storage
+// data directory and generated on the first start. The attestation is signed
+// on every start, so the external signer may ask to approve it.
+func attestedNetworkKey(
+	dataDir string,
+	signing chain.Signing,
+) (*key.NetworkPrivate, []byte, error) {
+	privateKey, err := storedKey(dataDir, networkKeyFileName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get network key: [%v]", err)
+	}
+
+	networkPrivateKey, networkPublicKey := key.OperatorKeyToNetworkKey(
+		privateKey,
+		&privateKey.PublicKey,
+	)
+
+	attestation, err := signing.Sign(
+		key.OperatorAttestationMessage(networkPublicKey),
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf(
-			"failed to read KeyFile: %s [%v]", keyFile, err,
+			"could not attest network key with operator key: [%v]",
+			err,
 		)
 	}
 
-	privateKey, publicKey := operator.EthereumKeyToOperatorKey(ethereumKey)
+	return networkPrivateKey, attestation, nil
+}
+
+// storedKey returns the key kept in the file with the given name in the
+// storage data directory, generating it if the file does not exist.
+func storedKey(dataDir string, fileName string) (*ecdsa.PrivateKey, error) {
+	keyFile := filepath.Join(dataDir, fileName)
+
+	privateKey, err := crypto.LoadECDSA(keyFile)
+	if os.IsNotExist(err) {
+		privateKey, err = crypto.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("could not generate key: [%v]", err)
+		}
+
+		if err := os.MkdirAll(dataDir, 0700); err != nil {
+			return nil, fmt.Errorf(
+				"failed while creating a directory: [%v]",
+				err,
+			)
+		}
+
+		if err := crypto.SaveECDSA(keyFile, privateKey); err != nil {
+			return nil, fmt.Errorf("could not save key: [%v]", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("could not load key: [%v]", err)
+	}
 
-	return privateKey, publicKey, nil
+	return privateKey, nil
 }
 
 func waitForStake(stakeMonitor chain.StakeMonitor, address string, timeout int) error {
@@ -194,3 +534,47 @@ func waitForStake(stakeMonitor chain.StakeMonitor, address string, timeout int)
 	}
 	return fmt.Errorf("timed out waiting for %s to have required minimum stake", address)
 }
+
+// transcriptStorage returns the storage of DKG transcripts kept in the
+// transcripts directory of the storage data directory. Transcripts are
+// erased once the configured retention period is over.
+func transcriptStorage(cfg *config.Config) (*dkg.TranscriptStorage, error) {
+	transcriptPersistence, err := dataSubdirPersistence(cfg, transcriptDirName)
+	if err != nil {
+		return nil, err
+	}
+
+	return dkg.NewTranscriptStorage(
+		transcriptPersistence,
+		registry.NewArchive(
+			filepath.Join(cfg.Storage.DataDir, transcriptDirName),
+		),
+		cfg.Storage.Transcripts.RetentionDays,
+	), nil
+}
+
+// dataSubdirPersistence returns an encrypted persistence handle for the
+// directory with the given name in the storage data directory. The directory
+// is created if it does not exist.
+func dataSubdirPersistence(
+	cfg *config.Config,
+	dirName string,
+) (persistence.Handle, error) {
+	dir := filepath.Join(cfg.Storage.DataDir, dirName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed while creating a directory: [%v]", err)
+	}
+
+	handle, err := persistence.NewDiskHandle(dir)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed while creating a disk handler: [%v]",
+			err,
+		)
+	}
+
+	return persistence.NewEncryptedPersistence(
+		handle,
+		cfg.Ethereum.Account.KeyFilePassword,
+	), nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/urfave/cli"
+)
+
+// VerifyEntriesCommand contains the definition of the verify-entries
+// command-line subcommand.
+var VerifyEntriesCommand cli.Command
+
+const fromBlockFlag = "from-block"
+
+const verifyEntriesDescription = `The verify-entries command audits relay
+   entries submitted to the chain starting from the given block. Every entry
+   is checked to be a valid signature of the previous entry created by the
+   group selected to sign it, and every request is checked to continue the
+   chain of entries. Entries are read from the transactions submitting them.
+   The command fails if the chain of entries is broken.`
+
+func init() {
+	VerifyEntriesCommand = cli.Command{
+		Name:        "verify-entries",
+		Usage:       `Verifies relay entries submitted to the chain`,
+		Description: verifyEntriesDescription,
+		Action:      verifyEntries,
+		Flags: []cli.Flag{
+			&cli.Uint64Flag{
+				Name:  fromBlockFlag,
+				Usage: "block to start verification from",
+			},
+		},
+	}
+}
+
+// verifyEntries verifies relay entries submitted starting from the given
+// block and prints out the result.
+func verifyEntries(c *cli.Context) error {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	chainProvider, err := ethereum.Connect(ctx, cfg.Ethereum)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	fromBlock := c.Uint64(fromBlockFlag)
+
+	verifier := beacon.NewEntryVerifier(func(chainBreak *beacon.EntryChainBreak) {
+		fmt.Printf(
+			"Chain of entries broken at block [%v]: [%v].\n",
+			chainBreak.BlockNumber,
+			chainBreak.Reason,
+		)
+	})
+	err = verifier.Audit(
+		ctx,
+		chainProvider.ThresholdRelay(),
+		fromBlock,
+	)
+	if err != nil {
+		return fmt.Errorf("error verifying relay entries: [%v]", err)
+	}
+
+	fmt.Printf(
+		"Verified [%v] relay entries submitted since block [%v].\n",
+		verifier.VerifiedEntries(),
+		fromBlock,
+	)
+
+	if chainBreaks := verifier.ChainBreaks(); chainBreaks > 0 {
+		return fmt.Errorf("chain of entries broken [%v] times", chainBreaks)
+	}
+
+	return nil
+}
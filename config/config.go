@@ -8,7 +8,12 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	ethereumchain "github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/status"
 	"golang.org/x/crypto/ssh/terminal"
 )
 
@@ -16,14 +21,20 @@ const passwordEnvVariable = "KEEP_ETHEREUM_PASSWORD"
 
 // Config is the top level config structure.
 type Config struct {
-	Ethereum ethereum.Config
+	Ethereum ethereumchain.Config
 	LibP2P   libp2p.Config
 	Storage  Storage
+	Status   status.Config
+	Metrics  metrics.Config
 }
 
 // Storage stores meta-info about keeping data on disk
 type Storage struct {
 	DataDir string
+	// Retention of key shares of stale groups.
+	Retention registry.RetentionConfig
+	// Recording and retention of DKG transcripts.
+	Transcripts dkg.TranscriptConfig
 }
 
 var (
@@ -35,9 +46,9 @@ var (
 // valid config stored there, or an error if something fails while reading the
 // file or the config is invalid in a known way.
 func ReadConfig(filePath string) (*Config, error) {
-	config := &Config{}
-	if _, err := toml.DecodeFile(filePath, config); err != nil {
-		return nil, fmt.Errorf("unable to decode .toml file [%s] error [%s]", filePath, err)
+	config, err := ReadObserverConfig(filePath)
+	if err != nil {
+		return nil, err
 	}
 
 	envPassword := os.Getenv(passwordEnvVariable)
@@ -63,6 +74,19 @@ func ReadConfig(filePath string) (*Config, error) {
 		)
 	}
 
+	return config, nil
+}
+
+// ReadObserverConfig reads in the configuration file at `filePath` the same
+// way as ReadConfig does but it does not read the Ethereum account password.
+// The observer does not use the operator account so it does not need the
+// password.
+func ReadObserverConfig(filePath string) (*Config, error) {
+	config := &Config{}
+	if _, err := toml.DecodeFile(filePath, config); err != nil {
+		return nil, fmt.Errorf("unable to decode .toml file [%s] error [%s]", filePath, err)
+	}
+
 	if config.LibP2P.Port == 0 {
 		return nil, fmt.Errorf("missing value for port; see node section in config file or use --port flag")
 	}
@@ -71,6 +95,14 @@ func ReadConfig(filePath string) (*Config, error) {
 		return nil, fmt.Errorf("missing value for storage directory data")
 	}
 
+	if err := config.Storage.Retention.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid storage retention: [%v]", err)
+	}
+
+	if err := config.Storage.Transcripts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid storage transcripts: [%v]", err)
+	}
+
 	return config, nil
 }
 
@@ -88,7 +120,7 @@ func ReadEthereumConfig(filePath string) (ethereum.Config, error) {
 		return ethereum.Config{}, err
 	}
 
-	return config.Ethereum, nil
+	return config.Ethereum.Config, nil
 }
 
 // ReadPassword prompts a user to enter a password.   The read password uses
@@ -4,6 +4,13 @@ import (
 	"os"
 	"reflect"
 	"testing"
+
+	relayconfig "github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/status"
 )
 
 func TestReadConfig(t *testing.T) {
@@ -46,10 +53,115 @@ func TestReadConfig(t *testing.T) {
 				"KeepRandomBeaconOperator":  "0xcf64c2a367341170cb4e09cf8c0ed137d8473ceb",
 			},
 		},
+		"Ethereum.ConfirmationDepth": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.ConfirmationDepth },
+			expectedValue: uint64(12),
+		},
+		"Ethereum.Endpoints": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.Endpoints },
+			expectedValue: []string{
+				"ws://192.168.0.159:8546",
+				"ws://192.168.0.160:8546",
+			},
+		},
+		"Ethereum.Failover": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.Failover },
+			expectedValue: ethereum.FailoverConfig{
+				HealthCheckIntervalSeconds: 30,
+				MaxBlockLag:                5,
+			},
+		},
+		"Ethereum.Transactions": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.Transactions },
+			expectedValue: ethereum.TransactionsConfig{
+				ResubmitAfterBlocks: 4,
+				GasPriceBumpPercent: 25,
+				MaxResubmissions:    3,
+			},
+		},
+		"Ethereum.Gas": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.Gas },
+			expectedValue: ethereum.GasConfig{
+				MaxGasPrice:           50000000000,
+				EstimateMarginPercent: 30,
+				ExpectedGroupEntries:  4,
+				Budgets: ethereum.GasBudgetsConfig{
+					TicketSubmission:     300000,
+					RelayEntrySubmission: 500000,
+					DKGResultSubmission:  1800000,
+				},
+			},
+		},
+		"Ethereum.Timing": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.Timing },
+			expectedValue: ethereum.TimingConfig{
+				DKGTimeout: 92,
+				GroupSelection: relayconfig.GroupSelectionTiming{
+					RoundBlocks:     6,
+					MiningLagBlocks: 12,
+				},
+				DKG: relayconfig.DKGTiming{
+					Combination: relayconfig.PhaseTiming{ActiveBlocks: 40},
+				},
+			},
+		},
+		"Ethereum.Retry": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.Retry },
+			expectedValue: map[string]ethereum.RetryConfig{
+				"SelectedParticipants": {
+					MaxAttempts:                12,
+					InitialBackoffMilliseconds: 100,
+					MaxBackoffMilliseconds:     1000,
+					TimeoutSeconds:             20,
+				},
+			},
+		},
+		"Ethereum.Signer": {
+			readValueFunc: func(c *Config) interface{} { return c.Ethereum.Signer },
+			expectedValue: ethereum.SignerConfig{
+				Type: "external",
+				URL:  "http://127.0.0.1:8550",
+			},
+		},
+		"LibP2P.Observers": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.Observers },
+			expectedValue: []string{"0x524f2e0176350d950fa630d9a5a59a0a190daf48"},
+		},
+		"LibP2P.BatchMessages": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.BatchMessages },
+			expectedValue: true,
+		},
+		"Status": {
+			readValueFunc: func(c *Config) interface{} { return c.Status },
+			expectedValue: status.Config{
+				Port: 8081,
+				Host: "127.0.0.1",
+			},
+		},
+		"Metrics": {
+			readValueFunc: func(c *Config) interface{} { return c.Metrics },
+			expectedValue: metrics.Config{
+				Port: 9601,
+			},
+		},
 		"Storage.DataDir": {
 			readValueFunc: func(c *Config) interface{} { return c.Storage.DataDir },
 			expectedValue: "/my/secure/location",
 		},
+		"Storage.Retention": {
+			readValueFunc: func(c *Config) interface{} { return c.Storage.Retention },
+			expectedValue: registry.RetentionConfig{
+				Policy: registry.RetentionPolicyErase,
+				Days:   30,
+			},
+		},
+		"Storage.Transcripts": {
+			readValueFunc: func(c *Config) interface{} { return c.Storage.Transcripts },
+			expectedValue: dkg.TranscriptConfig{
+				Record:        true,
+				RetentionDays: 7,
+			},
+		},
 	}
 
 	for testName, test := range configReadTests {
@@ -29,7 +29,9 @@ require (
 	github.com/multiformats/go-multiaddr v0.2.0
 	github.com/pborman/uuid v1.2.0
 	github.com/pkg/errors v0.9.1 // indirect
+	github.com/prometheus/client_golang v1.1.0
 	github.com/urfave/cli v1.22.1
 	golang.org/x/crypto v0.0.0-20200208060501-ecb85df21340
 	golang.org/x/sys v0.0.0-20200202164722-d101bd2416d5 // indirect
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -60,8 +60,12 @@ func main() {
 	app.Commands = []cli.Command{
 		cmd.StartCommand,
 		cmd.RelayCommand,
+		cmd.VerifyEntriesCommand,
 		cmd.PingCommand,
 		cmd.EthereumCommand,
+		cmd.ArchiveCommand,
+		cmd.MembershipsCommand,
+		cmd.DKGCommand,
 	}
 
 	cli.AppHelpTemplate = fmt.Sprintf(`%s
@@ -3,57 +3,121 @@ package beacon
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ipfs/go-log"
 
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/pkg/beacon/relay"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 var logger = log.Logger("keep-beacon")
 
+// shutdownTimeout is the maximum time the beacon waits for DKG and relay
+// entry signing in progress to complete once it is shutting down. Work which
+// does not complete on time is abandoned.
+const shutdownTimeout = 10 * time.Minute
+
+// retentionCheckInterval is how often key shares of stale groups kept in the
+// archive and DKG transcripts are checked against their retention periods.
+const retentionCheckInterval = time.Hour
+
+// Config contains storage and networking settings of the random beacon.
+type Config struct {
+	// BatchMessages tells whether messages of several members of one group
+	// controlled by the client are combined into batches.
+	BatchMessages bool
+
+	// Persistence stores group memberships of the client.
+	Persistence persistence.Handle
+
+	// CheckpointPersistence stores DKG progress checkpoints. Checkpoints are
+	// erased from CheckpointArchive once DKG is over.
+	CheckpointPersistence persistence.Handle
+	CheckpointArchive     *registry.Archive
+
+	// Transcripts stores transcripts of DKG executed by the client until
+	// their retention period is over. Transcripts are not recorded if it is
+	// nil.
+	Transcripts *dkg.TranscriptStorage
+
+	// Retention tells how long key shares of stale groups are kept in the
+	// archive before they are erased. They are kept archived forever if it
+	// is nil.
+	Retention *registry.Retention
+}
+
 // Initialize kicks off the random beacon by initializing internal state,
 // ensuring preconditions like staking are met, and then kicking off the
 // internal random beacon implementation. Returns an error if this failed,
-// otherwise enters a blocked loop.
+// otherwise returns a read-only view of the work the beacon is doing.
+//
+// DKG progress is checkpointed to the configured checkpoint persistence. On
+// start, DKG interrupted when the client was stopped is resumed, and group
+// selections and relay requests the client may still need to serve are
+// picked up from the chain.
+//
+// The beacon shuts down when the given context is done. It stops handling
+// chain events and waits for DKG and relay entry signing in progress to
+// complete, up to the shutdown timeout. The returned status reports when the
+// shutdown is over.
 func Initialize(
 	ctx context.Context,
 	stakingID string,
 	chainHandle chain.Handle,
 	netProvider net.Provider,
-	persistence persistence.Handle,
-) error {
+	config Config,
+) (*Status, error) {
 	relayChain := chainHandle.ThresholdRelay()
 	chainConfig, err := relayChain.GetConfig()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := chainConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid chain config: [%v]", err)
 	}
 
 	stakeMonitor, err := chainHandle.StakeMonitor()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	staker, err := stakeMonitor.StakerFor(stakingID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	blockCounter, err := chainHandle.BlockCounter()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	signing := chainHandle.Signing()
 
-	groupRegistry := registry.NewGroupRegistry(relayChain, persistence)
+	groupRegistry := registry.NewGroupRegistry(
+		relayChain,
+		config.Persistence,
+		config.Retention,
+	)
 	groupRegistry.LoadExistingGroups()
+	groupRegistry.EnforceRetention()
+	if config.Transcripts != nil {
+		config.Transcripts.EraseExpired()
+	}
+
+	checkpoints := dkg.NewCheckpointStorage(
+		config.CheckpointPersistence,
+		config.CheckpointArchive,
+	)
 
 	node := relay.NewNode(
 		staker,
@@ -61,6 +125,9 @@ func Initialize(
 		blockCounter,
 		chainConfig,
 		groupRegistry,
+		checkpoints,
+		config.Transcripts,
+		config.BatchMessages,
 	)
 
 	pendingGroupSelections := &event.GroupSelectionTrack{
@@ -73,8 +140,50 @@ func Initialize(
 		Mutex: &sync.Mutex{},
 	}
 
-	relayChain.OnRelayEntryRequested(func(request *event.Request) {
+	subscriptions := make([]subscription.EventSubscription, 0)
+	subscribe := func(
+		eventSubscription subscription.EventSubscription,
+		err error,
+	) error {
+		if err != nil {
+			return err
+		}
+
+		subscriptions = append(subscriptions, eventSubscription)
+		return nil
+	}
+
+	entryVerifier := NewEntryVerifier(countEntryChainBreak)
+	if err := subscribe(entryVerifier.Watch(ctx, relayChain)); err != nil {
+		return nil, err
+	}
+
+	// DKG and relay entry signing must not be abandoned as soon as the
+	// beacon starts shutting down, so they are not executed with the given
+	// context. The work context is cancelled once the shutdown is over.
+	workCtx, cancelWork := context.WithCancel(context.Background())
+
+	// Background work, other than DKG and relay entry signing, which must
+	// complete before the beacon stops, e.g. archiving stale groups.
+	var background sync.WaitGroup
+
+	// Work started in response to chain events is cancelled when the event
+	// is removed from the chain as a result of a chain reorganization.
+	relayRequestCancellations := newCancellations()
+	groupSelectionCancellations := newCancellations()
+
+	onRelayEntryRequested := func(request *event.Request) {
 		previousEntry := hex.EncodeToString(request.PreviousEntry[:])
+		requestCtx, ok := relayRequestCancellations.add(workCtx, previousEntry)
+		if !ok {
+			logger.Warningf(
+				"relay entry request with previous entry [0x%x] "+
+					"is already being processed",
+				request.PreviousEntry,
+			)
+			return
+		}
+
 		if node.IsInGroup(request.GroupPublicKey) {
 			go func() {
 				if ok := pendingRelayRequests.Add(previousEntry); !ok {
@@ -95,6 +204,7 @@ func Initialize(
 					request.PreviousEntry,
 				)
 				node.GenerateRelayEntry(
+					requestCtx,
 					request.PreviousEntry,
 					relayChain,
 					signing,
@@ -106,15 +216,91 @@ func Initialize(
 			go node.ForwardSignatureShares(request.GroupPublicKey)
 		}
 
-		go node.MonitorRelayEntry(
-			relayChain,
+		go func() {
+			defer relayRequestCancellations.remove(previousEntry)
+
+			node.MonitorRelayEntry(
+				requestCtx,
+				relayChain,
+				request.BlockNumber,
+				chainConfig,
+			)
+		}()
+	}
+	err = subscribe(relayChain.OnRelayEntryRequested(onRelayEntryRequested))
+	if err != nil {
+		cancelWork()
+		return nil, err
+	}
+
+	err = subscribe(relayChain.OnRelayEntryRequestRemoved(func(request *event.Request) {
+		logger.Warningf(
+			"relay entry request from block [%v] using previous entry [0x%x] "+
+				"removed from the chain; cancelling relay entry generation",
 			request.BlockNumber,
-			chainConfig,
+			request.PreviousEntry,
 		)
-	})
+		relayRequestCancellations.cancel(
+			hex.EncodeToString(request.PreviousEntry[:]),
+		)
+	}))
+	if err != nil {
+		cancelWork()
+		return nil, err
+	}
 
-	relayChain.OnGroupSelectionStarted(func(event *event.GroupSelectionStart) {
+	onGroupSelectionStarted := func(event *event.GroupSelectionStart) {
+		newEntry := event.NewEntry.Text(16)
+		// The group selection context covers both the ticket submission and
+		// DKG of the selected group so that both are cancelled when the group
+		// selection is removed from the chain. DKG must not be abandoned as
+		// soon as the beacon starts shutting down, so the context is derived
+		// from the work context.
+		groupSelectionCtx, ok := groupSelectionCancellations.add(workCtx, newEntry)
+		if !ok {
+			logger.Warningf(
+				"group selection with seed [0x%x] is already being processed",
+				event.NewEntry,
+			)
+			return
+		}
+
+		// Tickets are no longer submitted once the beacon starts shutting
+		// down.
+		ticketSubmissionCtx, cancelTicketSubmission := context.WithCancel(
+			groupSelectionCtx,
+		)
+		go func() {
+			select {
+			case <-ctx.Done():
+				cancelTicketSubmission()
+			case <-ticketSubmissionCtx.Done():
+			}
+		}()
+
+		// The group selection can be cancelled until DKG of the selected
+		// group is over.
 		onGroupSelected := func(group *groupselection.Result) {
+			defer groupSelectionCancellations.remove(newEntry)
+
+			if err := groupSelectionCtx.Err(); err != nil {
+				logger.Infof(
+					"not joining the group selected with seed [0x%x]; "+
+						"group selection cancelled: [%v]",
+					event.NewEntry,
+					err,
+				)
+				return
+			}
+			if err := ctx.Err(); err != nil {
+				logger.Infof(
+					"not joining the group selected with seed [0x%x]; "+
+						"shutting down",
+					event.NewEntry,
+				)
+				return
+			}
+
 			for index, staker := range group.SelectedStakers {
 				logger.Infof(
 					"new candidate group member [0x%v] with index [%v]",
@@ -123,6 +309,7 @@ func Initialize(
 				)
 			}
 			node.JoinGroupIfEligible(
+				groupSelectionCtx,
 				relayChain,
 				signing,
 				group,
@@ -130,13 +317,15 @@ func Initialize(
 			)
 		}
 
-		newEntry := event.NewEntry.Text(16)
 		go func() {
+			defer cancelTicketSubmission()
+
 			if ok := pendingGroupSelections.Add(newEntry); !ok {
 				logger.Errorf(
 					"group selection event with seed [0x%x] has been registered already",
 					event.NewEntry,
 				)
+				groupSelectionCancellations.remove(newEntry)
 				return
 			}
 
@@ -149,28 +338,208 @@ func Initialize(
 			)
 
 			err := groupselection.CandidateToNewGroup(
+				ticketSubmissionCtx,
 				relayChain,
 				blockCounter,
 				chainConfig,
 				staker,
+				stakeMonitor,
 				event.NewEntry,
 				event.BlockNumber,
 				onGroupSelected,
 			)
 			if err != nil {
 				logger.Errorf("Tickets submission failed: [%v]", err)
+				// onGroupSelected is not called when the group selection
+				// fails so the cancellation must be released here.
+				groupSelectionCancellations.remove(newEntry)
 			}
 		}()
-	})
+	}
+	err = subscribe(relayChain.OnGroupSelectionStarted(onGroupSelectionStarted))
+	if err != nil {
+		cancelWork()
+		return nil, err
+	}
 
-	relayChain.OnGroupRegistered(func(registration *event.GroupRegistration) {
+	err = subscribe(relayChain.OnGroupSelectionStartRemoved(func(event *event.GroupSelectionStart) {
+		logger.Warningf(
+			"group selection with seed [0x%x] started at block [%v] "+
+				"removed from the chain; cancelling group selection and DKG",
+			event.NewEntry,
+			event.BlockNumber,
+		)
+		groupSelectionCancellations.cancel(event.NewEntry.Text(16))
+	}))
+	if err != nil {
+		cancelWork()
+		return nil, err
+	}
+
+	err = subscribe(relayChain.OnGroupRegistered(func(registration *event.GroupRegistration) {
 		logger.Infof(
 			"new group with public key [0x%x] registered on-chain at block [%v]",
 			registration.GroupPublicKey,
 			registration.BlockNumber,
 		)
-		go groupRegistry.UnregisterStaleGroups()
-	})
 
-	return nil
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			groupRegistry.UnregisterStaleGroups()
+		}()
+	}))
+	if err != nil {
+		cancelWork()
+		return nil, err
+	}
+
+	recoverWork(
+		workCtx,
+		&node,
+		relayChain,
+		signing,
+		blockCounter,
+		chainConfig,
+		checkpoints,
+		onRelayEntryRequested,
+		onGroupSelectionStarted,
+	)
+
+	go enforceRetention(ctx, groupRegistry, config.Transcripts)
+
+	stopped := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		shutdown(
+			subscriptions,
+			func() {
+				node.WaitForInFlight()
+				background.Wait()
+			},
+			cancelWork,
+			shutdownTimeout,
+		)
+		close(stopped)
+	}()
+
+	return &Status{
+		groupRegistry:          groupRegistry,
+		pendingGroupSelections: pendingGroupSelections,
+		pendingRelayRequests:   pendingRelayRequests,
+		stopped:                stopped,
+	}, nil
+}
+
+// enforceRetention periodically erases key shares of stale groups kept in the
+// archive for longer than the retention policy allows and DKG transcripts
+// kept for longer than their retention period, until the context is done.
+func enforceRetention(
+	ctx context.Context,
+	groupRegistry *registry.Groups,
+	transcripts *dkg.TranscriptStorage,
+) {
+	ticker := time.NewTicker(retentionCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			groupRegistry.EnforceRetention()
+			if transcripts != nil {
+				transcripts.EraseExpired()
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// shutdown unsubscribes from chain events and waits for work in progress to
+// complete. Work which does not complete before the timeout is abandoned by
+// cancelling it.
+func shutdown(
+	subscriptions []subscription.EventSubscription,
+	waitForInFlight func(),
+	cancelWork context.CancelFunc,
+	timeout time.Duration,
+) {
+	logger.Infof("shutting down; no longer handling chain events")
+
+	for _, eventSubscription := range subscriptions {
+		eventSubscription.Unsubscribe()
+	}
+
+	completed := make(chan struct{})
+	go func() {
+		waitForInFlight()
+		close(completed)
+	}()
+
+	logger.Infof("waiting up to [%v] for work in progress to complete", timeout)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-completed:
+		logger.Infof("work in progress completed")
+	case <-timer.C:
+		logger.Warningf(
+			"work in progress did not complete in [%v]; abandoning it",
+			timeout,
+		)
+	}
+
+	cancelWork()
+}
+
+// cancellations keeps cancel functions of the work started in response to
+// chain events, keyed by the event identifier.
+type cancellations struct {
+	mutex       sync.Mutex
+	cancelFuncs map[string]context.CancelFunc
+}
+
+func newCancellations() *cancellations {
+	return &cancellations{
+		cancelFuncs: make(map[string]context.CancelFunc),
+	}
+}
+
+// add creates a context derived from the parent one which is cancelled
+// when the work identified by the given key is cancelled. It returns false
+// if the work identified by the given key is already in progress.
+func (c *cancellations) add(
+	parent context.Context,
+	key string,
+) (context.Context, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.cancelFuncs[key]; ok {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	c.cancelFuncs[key] = cancel
+
+	return ctx, true
+}
+
+// cancel cancels the work identified by the given key.
+func (c *cancellations) cancel(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cancel, ok := c.cancelFuncs[key]; ok {
+		cancel()
+		delete(c.cancelFuncs, key)
+	}
+}
+
+// remove releases resources associated with the completed work identified
+// by the given key.
+func (c *cancellations) remove(key string) {
+	c.cancel(key)
 }
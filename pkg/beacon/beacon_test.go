@@ -0,0 +1,79 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+func TestShutdown(t *testing.T) {
+	var tests = map[string]struct {
+		workDuration        time.Duration
+		timeout             time.Duration
+		expectWorkCancelled bool
+	}{
+		"work completes before timeout": {
+			workDuration:        10 * time.Millisecond,
+			timeout:             time.Second,
+			expectWorkCancelled: false,
+		},
+		"work does not complete before timeout": {
+			workDuration:        time.Second,
+			timeout:             10 * time.Millisecond,
+			expectWorkCancelled: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			unsubscribed := 0
+			subscriptions := []subscription.EventSubscription{
+				subscription.NewEventSubscription(func() { unsubscribed++ }),
+				subscription.NewEventSubscription(func() { unsubscribed++ }),
+			}
+
+			workCtx, cancelWork := context.WithCancel(context.Background())
+			workCompleted := make(chan struct{})
+			workCancelled := false
+
+			shutdown(
+				subscriptions,
+				func() {
+					select {
+					case <-time.After(test.workDuration):
+						close(workCompleted)
+					case <-workCtx.Done():
+					}
+				},
+				func() {
+					select {
+					case <-workCompleted:
+					default:
+						workCancelled = true
+					}
+					cancelWork()
+				},
+				test.timeout,
+			)
+
+			if unsubscribed != len(subscriptions) {
+				t.Errorf(
+					"unexpected number of unsubscribed events\n"+
+						"expected: [%v]\nactual:   [%v]",
+					len(subscriptions),
+					unsubscribed,
+				)
+			}
+
+			if test.expectWorkCancelled != workCancelled {
+				t.Errorf(
+					"unexpected work cancellation\nexpected: [%v]\nactual:   [%v]",
+					test.expectWorkCancelled,
+					workCancelled,
+				)
+			}
+		})
+	}
+}
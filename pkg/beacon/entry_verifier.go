@@ -0,0 +1,455 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+// EntryChainBreak describes a submitted relay entry or a relay request which
+// does not continue the verified chain of entries.
+type EntryChainBreak struct {
+	BlockNumber uint64
+	Reason      error
+}
+
+// EntryVerifier independently verifies relay entries submitted to the chain
+// and keeps track of the verified chain of entries.
+//
+// Every submitted entry is expected to be a valid BLS signature of the
+// previous entry created by the group selected to sign it, as seen in the
+// relay request the entry has been submitted for. An entry is verified as
+// soon as its submission is seen. The next relay request is expected to carry
+// the submitted entry as its previous entry, and a request following a
+// request which has not been answered is expected to carry the same previous
+// entry. Entries and requests breaking these rules are reported as breaks of
+// the chain.
+//
+// If the submitted entry could not be read from the chain, it is verified
+// once the next relay request, carrying it as its previous entry, is made.
+type EntryVerifier struct {
+	onChainBreak func(chainBreak *EntryChainBreak)
+
+	// Serializes processing of watched relay requests and submissions.
+	watchMutex sync.Mutex
+
+	mutex sync.Mutex
+	// The latest relay request seen; nil if there is none.
+	lastRequest *event.Request
+	// Submission of the entry for the latest relay request; nil if the entry
+	// has not been submitted yet.
+	pendingSubmission *event.EntrySubmitted
+	verifiedEntries   int
+	chainBreaks       int
+}
+
+// NewEntryVerifier creates a new relay entry verifier. Each break of the
+// chain of entries is logged and passed to the given handler, if it is not
+// nil. The handler is called synchronously and should not block.
+func NewEntryVerifier(
+	onChainBreak func(chainBreak *EntryChainBreak),
+) *EntryVerifier {
+	return &EntryVerifier{
+		onChainBreak: onChainBreak,
+	}
+}
+
+// Watch starts verifying relay entries submitted to the chain from now on.
+// Relay requests and entry submissions are watched. Submissions made since
+// the latest request are read from the chain on each request and requests
+// made since the latest request are read on each submission, so requests and
+// entries are always processed in the chain order. Entries submitted for
+// requests seen before Watch has been called can not be verified and are
+// skipped. Requests and entries are no longer read once the given context
+// is done.
+func (ev *EntryVerifier) Watch(
+	ctx context.Context,
+	relayChain relaychain.RelayEntryInterface,
+) (subscription.EventSubscription, error) {
+	requestSubscription, err := relayChain.OnRelayEntryRequested(
+		func(request *event.Request) {
+			ev.watchRequest(ctx, relayChain, request)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not watch relay requests: [%v]", err)
+	}
+
+	submissionSubscription, err := relayChain.OnRelayEntrySubmitted(
+		func(submission *event.EntrySubmitted) {
+			ev.watchSubmission(ctx, relayChain, submission)
+		},
+	)
+	if err != nil {
+		requestSubscription.Unsubscribe()
+		return nil, fmt.Errorf("could not watch relay entries: [%v]", err)
+	}
+
+	return subscription.NewEventSubscription(func() {
+		requestSubscription.Unsubscribe()
+		submissionSubscription.Unsubscribe()
+	}), nil
+}
+
+// Audit verifies relay entries submitted to the chain starting from the
+// given block. Entries submitted for requests made before the given block
+// can not be verified and are skipped. Reading entries is abandoned when the
+// given context is done.
+func (ev *EntryVerifier) Audit(
+	ctx context.Context,
+	relayChain relaychain.RelayEntryInterface,
+	fromBlock uint64,
+) error {
+	requests, err := relayChain.PastRelayEntryRequests(ctx, fromBlock)
+	if err != nil {
+		return err
+	}
+
+	submissions, err := relayChain.PastRelayEntriesSubmitted(ctx, fromBlock)
+	if err != nil {
+		return err
+	}
+
+	ev.mutex.Lock()
+	defer ev.mutex.Unlock()
+
+	ev.process(requests, submissions)
+
+	return nil
+}
+
+// VerifiedEntries returns the number of entries verified so far.
+func (ev *EntryVerifier) VerifiedEntries() int {
+	ev.mutex.Lock()
+	defer ev.mutex.Unlock()
+
+	return ev.verifiedEntries
+}
+
+// ChainBreaks returns the number of breaks of the chain of entries found so
+// far.
+func (ev *EntryVerifier) ChainBreaks() int {
+	ev.mutex.Lock()
+	defer ev.mutex.Unlock()
+
+	return ev.chainBreaks
+}
+
+// watchRequest reads entries submitted since the latest relay request and
+// processes them along with the given request.
+func (ev *EntryVerifier) watchRequest(
+	ctx context.Context,
+	relayChain relaychain.RelayEntryInterface,
+	request *event.Request,
+) {
+	ev.watchMutex.Lock()
+	defer ev.watchMutex.Unlock()
+
+	ev.mutex.Lock()
+	lastRequest := ev.lastRequest
+	ev.mutex.Unlock()
+
+	// Entries submitted after the request are read again with the next one.
+	var submissions []*event.EntrySubmitted
+	if lastRequest != nil {
+		submitted, err := relayChain.PastRelayEntriesSubmitted(
+			ctx,
+			lastRequest.BlockNumber+1,
+		)
+		if err != nil {
+			logger.Warningf(
+				"could not read relay entries submitted since block [%v]; "+
+					"skipping verification of the entry for the relay "+
+					"request from block [%v]: [%v]",
+				lastRequest.BlockNumber+1,
+				lastRequest.BlockNumber,
+				err,
+			)
+			lastRequest = nil
+		}
+
+		for _, submission := range submitted {
+			if submission.BlockNumber <= request.BlockNumber {
+				submissions = append(submissions, submission)
+			}
+		}
+	}
+
+	ev.mutex.Lock()
+	defer ev.mutex.Unlock()
+
+	if lastRequest == nil {
+		ev.lastRequest = nil
+		ev.pendingSubmission = nil
+	}
+
+	ev.process([]*event.Request{request}, submissions)
+}
+
+// watchSubmission reads relay requests made since the latest relay request
+// and processes them along with the given submission, so that the entry is
+// verified against the request it has been submitted for.
+func (ev *EntryVerifier) watchSubmission(
+	ctx context.Context,
+	relayChain relaychain.RelayEntryInterface,
+	submission *event.EntrySubmitted,
+) {
+	ev.watchMutex.Lock()
+	defer ev.watchMutex.Unlock()
+
+	ev.mutex.Lock()
+	lastRequest := ev.lastRequest
+	ev.mutex.Unlock()
+
+	var requests []*event.Request
+	if lastRequest != nil {
+		requested, err := relayChain.PastRelayEntryRequests(
+			ctx,
+			lastRequest.BlockNumber+1,
+		)
+		if err != nil {
+			// The submission is read again with the next request.
+			logger.Warningf(
+				"could not read relay requests made since block [%v]; "+
+					"deferring verification of the entry submitted at "+
+					"block [%v]: [%v]",
+				lastRequest.BlockNumber+1,
+				submission.BlockNumber,
+				err,
+			)
+			return
+		}
+
+		// An entry can not be submitted in the same block it has been
+		// requested in, so a request from the block of the submission
+		// follows the submission.
+		for _, request := range requested {
+			if request.BlockNumber < submission.BlockNumber {
+				requests = append(requests, request)
+			}
+		}
+	}
+
+	ev.mutex.Lock()
+	defer ev.mutex.Unlock()
+
+	ev.process(requests, []*event.EntrySubmitted{submission})
+}
+
+// process processes the given requests and submissions, each in the chain
+// order, merged into a single chain order. An entry can not be submitted in
+// the same block it has been requested in, so a submission from the block of
+// a request precedes the request.
+func (ev *EntryVerifier) process(
+	requests []*event.Request,
+	submissions []*event.EntrySubmitted,
+) {
+	for _, request := range requests {
+		for len(submissions) > 0 &&
+			submissions[0].BlockNumber <= request.BlockNumber {
+			ev.addSubmission(submissions[0])
+			submissions = submissions[1:]
+		}
+
+		ev.addRequest(request)
+	}
+
+	for _, submission := range submissions {
+		ev.addSubmission(submission)
+	}
+}
+
+// addSubmission verifies the entry submitted for the latest relay request
+// and records the submission. Submissions already processed are ignored.
+func (ev *EntryVerifier) addSubmission(submission *event.EntrySubmitted) {
+	if ev.lastRequest == nil {
+		logger.Warningf(
+			"no relay request known for relay entry submitted at block [%v]; "+
+				"skipping its verification",
+			submission.BlockNumber,
+		)
+		return
+	}
+
+	// Submissions are read again when requests are watched; the submission
+	// of the entry for an earlier request has been already processed.
+	if submission.BlockNumber <= ev.lastRequest.BlockNumber {
+		return
+	}
+
+	if ev.pendingSubmission != nil {
+		if ev.pendingSubmission.BlockNumber == submission.BlockNumber {
+			return
+		}
+
+		logger.Warningf(
+			"relay entry for the relay request from block [%v] already "+
+				"submitted at block [%v]; skipping entry submitted at "+
+				"block [%v]",
+			ev.lastRequest.BlockNumber,
+			ev.pendingSubmission.BlockNumber,
+			submission.BlockNumber,
+		)
+		return
+	}
+
+	ev.pendingSubmission = submission
+
+	if submission.Entry == nil {
+		logger.Infof(
+			"relay entry submitted at block [%v] could not be read; "+
+				"it will be verified once the next relay request is made",
+			submission.BlockNumber,
+		)
+		return
+	}
+
+	ev.verifySubmittedEntry(ev.lastRequest, submission, submission.Entry)
+}
+
+// addRequest checks the given request carries the entry submitted for the
+// latest relay request as its previous entry, or the same previous entry as
+// the latest request if no entry has been submitted for it. If the submitted
+// entry could not be read, it is verified as the previous entry of the given
+// request. The given request becomes the latest one. Requests already
+// processed are ignored.
+func (ev *EntryVerifier) addRequest(request *event.Request) {
+	lastRequest := ev.lastRequest
+	submission := ev.pendingSubmission
+
+	if lastRequest != nil && request.BlockNumber <= lastRequest.BlockNumber {
+		return
+	}
+
+	ev.lastRequest = request
+	ev.pendingSubmission = nil
+
+	if lastRequest == nil {
+		return
+	}
+
+	if submission == nil {
+		if !bytes.Equal(request.PreviousEntry, lastRequest.PreviousEntry) {
+			ev.reportChainBreak(
+				request.BlockNumber,
+				fmt.Errorf(
+					"previous entry [0x%x] of the relay request is not the "+
+						"previous entry [0x%x] of the unanswered relay "+
+						"request from block [%v]",
+					request.PreviousEntry,
+					lastRequest.PreviousEntry,
+					lastRequest.BlockNumber,
+				),
+			)
+		}
+		return
+	}
+
+	if submission.Entry == nil {
+		ev.verifySubmittedEntry(lastRequest, submission, request.PreviousEntry)
+		return
+	}
+
+	if !bytes.Equal(request.PreviousEntry, submission.Entry) {
+		ev.reportChainBreak(
+			request.BlockNumber,
+			fmt.Errorf(
+				"previous entry [0x%x] of the relay request is not the "+
+					"entry [0x%x] submitted at block [%v]",
+				request.PreviousEntry,
+				submission.Entry,
+				submission.BlockNumber,
+			),
+		)
+	}
+}
+
+// verifySubmittedEntry verifies the given entry of the given submission
+// against the relay request it has been submitted for.
+func (ev *EntryVerifier) verifySubmittedEntry(
+	request *event.Request,
+	submission *event.EntrySubmitted,
+	entry []byte,
+) {
+	if err := verifyEntry(
+		request.GroupPublicKey,
+		request.PreviousEntry,
+		entry,
+	); err != nil {
+		ev.reportChainBreak(
+			submission.BlockNumber,
+			fmt.Errorf(
+				"entry [0x%x] submitted for the relay request from block "+
+					"[%v] is not valid: [%v]",
+				entry,
+				request.BlockNumber,
+				err,
+			),
+		)
+		return
+	}
+
+	logger.Debugf(
+		"verified relay entry [0x%x] submitted at block [%v]",
+		entry,
+		submission.BlockNumber,
+	)
+
+	ev.verifiedEntries++
+}
+
+func (ev *EntryVerifier) reportChainBreak(blockNumber uint64, reason error) {
+	logger.Errorf(
+		"chain of relay entries broken at block [%v]: [%v]",
+		blockNumber,
+		reason,
+	)
+
+	ev.chainBreaks++
+
+	if ev.onChainBreak != nil {
+		ev.onChainBreak(&EntryChainBreak{
+			BlockNumber: blockNumber,
+			Reason:      reason,
+		})
+	}
+}
+
+// verifyEntry checks if the given entry is a valid BLS signature of the given
+// previous entry created with the private key of the given group.
+func verifyEntry(
+	groupPublicKeyBytes []byte,
+	previousEntryBytes []byte,
+	entryBytes []byte,
+) error {
+	groupPublicKey := new(bn256.G2)
+	if _, err := groupPublicKey.Unmarshal(groupPublicKeyBytes); err != nil {
+		return fmt.Errorf("could not unmarshal group public key: [%v]", err)
+	}
+
+	previousEntry := new(bn256.G1)
+	if _, err := previousEntry.Unmarshal(previousEntryBytes); err != nil {
+		return fmt.Errorf("could not unmarshal previous entry: [%v]", err)
+	}
+
+	entry := new(bn256.G1)
+	if _, err := entry.Unmarshal(entryBytes); err != nil {
+		return fmt.Errorf("could not unmarshal entry: [%v]", err)
+	}
+
+	if !bls.VerifyG1(groupPublicKey, previousEntry, entry) {
+		return fmt.Errorf(
+			"entry is not a valid signature of group [0x%x]",
+			groupPublicKeyBytes,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,312 @@
+package beacon
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+func TestAuditEntries(t *testing.T) {
+	groupPrivateKey := big.NewInt(123)
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(groupPrivateKey).Marshal()
+	otherGroupPublicKey := new(bn256.G2).ScalarBaseMult(big.NewInt(456)).Marshal()
+
+	genesisEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(7))
+	entry1 := bls.SignG1(groupPrivateKey, genesisEntry)
+	entry2 := bls.SignG1(groupPrivateKey, entry1)
+
+	var tests = map[string]struct {
+		requests                []*event.Request
+		submissions             []*event.EntrySubmitted
+		expectedVerifiedEntries int
+		expectedChainBreaks     []uint64
+	}{
+		"valid chain of entries": {
+			requests: []*event.Request{
+				newTestRequest(genesisEntry.Marshal(), groupPublicKey, 10),
+				// Requested in the same block the previous entry has been
+				// submitted in.
+				newTestRequest(entry1.Marshal(), groupPublicKey, 15),
+				newTestRequest(entry2.Marshal(), groupPublicKey, 25),
+			},
+			submissions: []*event.EntrySubmitted{
+				newTestSubmission(entry1.Marshal(), 15),
+				newTestSubmission(entry2.Marshal(), 20),
+			},
+			expectedVerifiedEntries: 2,
+		},
+		"entry submitted after request timeout": {
+			requests: []*event.Request{
+				newTestRequest(genesisEntry.Marshal(), otherGroupPublicKey, 10),
+				newTestRequest(genesisEntry.Marshal(), groupPublicKey, 30),
+				newTestRequest(entry1.Marshal(), groupPublicKey, 40),
+			},
+			submissions: []*event.EntrySubmitted{
+				newTestSubmission(entry1.Marshal(), 35),
+			},
+			expectedVerifiedEntries: 1,
+		},
+		"entry not signed by the selected group": {
+			requests: []*event.Request{
+				newTestRequest(genesisEntry.Marshal(), otherGroupPublicKey, 10),
+				newTestRequest(entry1.Marshal(), groupPublicKey, 20),
+			},
+			submissions: []*event.EntrySubmitted{
+				newTestSubmission(entry1.Marshal(), 15),
+			},
+			expectedVerifiedEntries: 0,
+			expectedChainBreaks:     []uint64{15},
+		},
+		"request not following the unanswered request": {
+			requests: []*event.Request{
+				newTestRequest(genesisEntry.Marshal(), groupPublicKey, 10),
+				newTestRequest(entry1.Marshal(), groupPublicKey, 20),
+			},
+			expectedVerifiedEntries: 0,
+			expectedChainBreaks:     []uint64{20},
+		},
+		"entry not followed by a request": {
+			requests: []*event.Request{
+				newTestRequest(genesisEntry.Marshal(), groupPublicKey, 10),
+			},
+			submissions: []*event.EntrySubmitted{
+				newTestSubmission(entry1.Marshal(), 15),
+			},
+			expectedVerifiedEntries: 1,
+		},
+		"request not following the submitted entry": {
+			requests: []*event.Request{
+				newTestRequest(genesisEntry.Marshal(), groupPublicKey, 10),
+				newTestRequest(entry2.Marshal(), groupPublicKey, 20),
+			},
+			submissions: []*event.EntrySubmitted{
+				newTestSubmission(entry1.Marshal(), 15),
+			},
+			expectedVerifiedEntries: 1,
+			expectedChainBreaks:     []uint64{20},
+		},
+		"entry not read from the chain": {
+			requests: []*event.Request{
+				newTestRequest(genesisEntry.Marshal(), groupPublicKey, 10),
+				newTestRequest(entry1.Marshal(), groupPublicKey, 20),
+			},
+			submissions: []*event.EntrySubmitted{
+				newTestSubmission(nil, 15),
+			},
+			expectedVerifiedEntries: 1,
+		},
+		"entry not read from the chain and not followed by a request": {
+			requests: []*event.Request{
+				newTestRequest(genesisEntry.Marshal(), groupPublicKey, 10),
+			},
+			submissions: []*event.EntrySubmitted{
+				newTestSubmission(nil, 15),
+			},
+			expectedVerifiedEntries: 0,
+		},
+		"entry requested before the audited blocks": {
+			requests: []*event.Request{
+				newTestRequest(entry1.Marshal(), groupPublicKey, 15),
+				newTestRequest(entry2.Marshal(), groupPublicKey, 25),
+			},
+			submissions: []*event.EntrySubmitted{
+				newTestSubmission(entry1.Marshal(), 15),
+				newTestSubmission(entry2.Marshal(), 20),
+			},
+			expectedVerifiedEntries: 1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var chainBreaks []uint64
+			verifier := NewEntryVerifier(func(chainBreak *EntryChainBreak) {
+				chainBreaks = append(chainBreaks, chainBreak.BlockNumber)
+			})
+
+			err := verifier.Audit(
+				context.Background(),
+				&stubEntryHistory{
+					requests:    test.requests,
+					submissions: test.submissions,
+				},
+				0,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if verifier.VerifiedEntries() != test.expectedVerifiedEntries {
+				t.Errorf(
+					"unexpected number of verified entries\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedVerifiedEntries,
+					verifier.VerifiedEntries(),
+				)
+			}
+
+			if len(chainBreaks) != len(test.expectedChainBreaks) ||
+				verifier.ChainBreaks() != len(test.expectedChainBreaks) {
+				t.Fatalf(
+					"unexpected chain breaks\nexpected: %v\nactual:   %v",
+					test.expectedChainBreaks,
+					chainBreaks,
+				)
+			}
+			for i, blockNumber := range test.expectedChainBreaks {
+				if chainBreaks[i] != blockNumber {
+					t.Errorf(
+						"unexpected chain breaks\nexpected: %v\nactual:   %v",
+						test.expectedChainBreaks,
+						chainBreaks,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestWatchEntries(t *testing.T) {
+	groupPrivateKey := big.NewInt(123)
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(groupPrivateKey).Marshal()
+
+	genesisEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(7))
+	entry1 := bls.SignG1(groupPrivateKey, genesisEntry)
+	entry2 := bls.SignG1(groupPrivateKey, entry1)
+
+	var chainBreaks []uint64
+	verifier := NewEntryVerifier(func(chainBreak *EntryChainBreak) {
+		chainBreaks = append(chainBreaks, chainBreak.BlockNumber)
+	})
+
+	request1 := newTestRequest(genesisEntry.Marshal(), groupPublicKey, 10)
+	// Requested in the same block the previous entry has been submitted in.
+	request2 := newTestRequest(entry1.Marshal(), groupPublicKey, 15)
+	submission1 := newTestSubmission(entry1.Marshal(), 15)
+	submission2 := newTestSubmission(entry2.Marshal(), 20)
+
+	// All the requests and entries are already on the chain when they are
+	// delivered.
+	relayChain := &stubEntryHistory{
+		requests:    []*event.Request{request1, request2},
+		submissions: []*event.EntrySubmitted{submission1, submission2},
+	}
+
+	subscription, err := verifier.Watch(context.Background(), relayChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	assertVerifiedEntries := func(expectedVerifiedEntries int) {
+		if verifier.VerifiedEntries() != expectedVerifiedEntries {
+			t.Errorf(
+				"unexpected number of verified entries\n"+
+					"expected: [%v]\nactual:   [%v]",
+				expectedVerifiedEntries,
+				verifier.VerifiedEntries(),
+			)
+		}
+	}
+
+	relayChain.requestHandler(request1)
+	relayChain.submissionHandler(submission1)
+
+	// The entry is verified as soon as it is submitted.
+	assertVerifiedEntries(1)
+
+	// The request preceding the submission has not been delivered yet; it
+	// is read from the chain.
+	relayChain.submissionHandler(submission2)
+
+	assertVerifiedEntries(2)
+
+	// Requests and entries delivered late have been already processed.
+	relayChain.requestHandler(request2)
+	relayChain.submissionHandler(submission1)
+
+	assertVerifiedEntries(2)
+
+	if len(chainBreaks) != 0 {
+		t.Errorf(
+			"unexpected chain breaks\nexpected: %v\nactual:   %v",
+			[]uint64{},
+			chainBreaks,
+		)
+	}
+}
+
+func newTestRequest(
+	previousEntry []byte,
+	groupPublicKey []byte,
+	blockNumber uint64,
+) *event.Request {
+	return &event.Request{
+		PreviousEntry:  previousEntry,
+		GroupPublicKey: groupPublicKey,
+		BlockNumber:    blockNumber,
+	}
+}
+
+func newTestSubmission(entry []byte, blockNumber uint64) *event.EntrySubmitted {
+	return &event.EntrySubmitted{
+		Entry:       entry,
+		BlockNumber: blockNumber,
+	}
+}
+
+type stubEntryHistory struct {
+	relaychain.RelayEntryInterface
+
+	requests    []*event.Request
+	submissions []*event.EntrySubmitted
+
+	requestHandler    func(request *event.Request)
+	submissionHandler func(submission *event.EntrySubmitted)
+}
+
+func (seh *stubEntryHistory) OnRelayEntryRequested(
+	handler func(request *event.Request),
+) (subscription.EventSubscription, error) {
+	seh.requestHandler = handler
+	return subscription.NewEventSubscription(func() {}), nil
+}
+
+func (seh *stubEntryHistory) OnRelayEntrySubmitted(
+	handler func(submission *event.EntrySubmitted),
+) (subscription.EventSubscription, error) {
+	seh.submissionHandler = handler
+	return subscription.NewEventSubscription(func() {}), nil
+}
+
+func (seh *stubEntryHistory) PastRelayEntryRequests(
+	ctx context.Context,
+	fromBlock uint64,
+) ([]*event.Request, error) {
+	var requests []*event.Request
+	for _, request := range seh.requests {
+		if request.BlockNumber >= fromBlock {
+			requests = append(requests, request)
+		}
+	}
+	return requests, nil
+}
+
+func (seh *stubEntryHistory) PastRelayEntriesSubmitted(
+	ctx context.Context,
+	fromBlock uint64,
+) ([]*event.EntrySubmitted, error) {
+	var submissions []*event.EntrySubmitted
+	for _, submission := range seh.submissions {
+		if submission.BlockNumber >= fromBlock {
+			submissions = append(submissions, submission)
+		}
+	}
+	return submissions, nil
+}
@@ -0,0 +1,29 @@
+package beacon
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/keep-network/keep-core/pkg/metrics"
+)
+
+var (
+	entryChainBreaks = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "relay_entry",
+			Name:      "chain_breaks_total",
+			Help: "Submitted relay entries and relay requests which break " +
+				"the verified chain of entries.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(entryChainBreaks)
+}
+
+// countEntryChainBreak counts the given break of the chain of entries so it
+// can be alerted on.
+func countEntryChainBreak(chainBreak *EntryChainBreak) {
+	entryChainBreaks.Inc()
+}
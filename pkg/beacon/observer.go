@@ -0,0 +1,333 @@
+package beacon
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+// Observe kicks off the random beacon in the observer mode. The observer
+// follows relay requests, group selections, DKG result submissions, group
+// registrations and relay entries seen on the chain and reports relay entry
+// timeouts and groups which failed to respond. It forwards broadcast messages
+// of groups and DKG channels so that the network stays well connected, but it
+// never takes part in any protocol nor submits anything to the chain. The
+// observer needs no operator stake and no key shares.
+func Observe(
+	ctx context.Context,
+	chainHandle chain.Handle,
+	netProvider net.Provider,
+) error {
+	relayChain := chainHandle.ThresholdRelay()
+	chainConfig, err := relayChain.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	blockCounter, err := chainHandle.BlockCounter()
+	if err != nil {
+		return err
+	}
+
+	observer := newObserver(relayChain, blockCounter, chainConfig, netProvider)
+
+	return observer.watch(ctx)
+}
+
+type observer struct {
+	relayChain   relaychain.Interface
+	blockCounter chain.BlockCounter
+	chainConfig  *config.Chain
+	netProvider  net.Provider
+
+	relayRequestCancellations *cancellations
+
+	failuresMutex sync.Mutex
+	// Number of relay entries each group failed to submit on time, keyed
+	// by the hexadecimal representation of the group public key.
+	failures map[string]int
+}
+
+func newObserver(
+	relayChain relaychain.Interface,
+	blockCounter chain.BlockCounter,
+	chainConfig *config.Chain,
+	netProvider net.Provider,
+) *observer {
+	return &observer{
+		relayChain:                relayChain,
+		blockCounter:              blockCounter,
+		chainConfig:               chainConfig,
+		netProvider:               netProvider,
+		relayRequestCancellations: newCancellations(),
+		failures:                  make(map[string]int),
+	}
+}
+
+// watch subscribes to chain events followed by the observer. Subscriptions
+// are cancelled when the given context is done.
+func (o *observer) watch(ctx context.Context) error {
+	subscriptions := make([]subscription.EventSubscription, 0)
+	defer func() {
+		go func() {
+			<-ctx.Done()
+			for _, eventSubscription := range subscriptions {
+				eventSubscription.Unsubscribe()
+			}
+		}()
+	}()
+
+	entryVerifier := NewEntryVerifier(countEntryChainBreak)
+	verifierSubscription, err := entryVerifier.Watch(ctx, o.relayChain)
+	if err != nil {
+		return err
+	}
+	subscriptions = append(subscriptions, verifierSubscription)
+
+	requestSubscription, err := o.relayChain.OnRelayEntryRequested(func(request *event.Request) {
+		logger.Infof(
+			"[observer] relay entry requested at block [%v] from group [0x%x] "+
+				"using previous entry [0x%x]",
+			request.BlockNumber,
+			request.GroupPublicKey,
+			request.PreviousEntry,
+		)
+
+		go o.forwardSignatureShares(request.GroupPublicKey)
+
+		previousEntry := hex.EncodeToString(request.PreviousEntry[:])
+		requestCtx, ok := o.relayRequestCancellations.add(ctx, previousEntry)
+		if !ok {
+			return
+		}
+
+		go func() {
+			defer o.relayRequestCancellations.remove(previousEntry)
+			o.monitorRelayEntry(requestCtx, request)
+		}()
+	})
+	if err != nil {
+		return fmt.Errorf("could not watch relay requests: [%v]", err)
+	}
+	subscriptions = append(subscriptions, requestSubscription)
+
+	requestRemovedSubscription, err := o.relayChain.OnRelayEntryRequestRemoved(func(request *event.Request) {
+		logger.Warningf(
+			"[observer] relay entry request from block [%v] using previous "+
+				"entry [0x%x] removed from the chain",
+			request.BlockNumber,
+			request.PreviousEntry,
+		)
+		o.relayRequestCancellations.cancel(
+			hex.EncodeToString(request.PreviousEntry[:]),
+		)
+	})
+	if err != nil {
+		return fmt.Errorf("could not watch removed relay requests: [%v]", err)
+	}
+	subscriptions = append(subscriptions, requestRemovedSubscription)
+
+	groupSelectionSubscription, err := o.relayChain.OnGroupSelectionStarted(
+		func(groupSelection *event.GroupSelectionStart) {
+			logger.Infof(
+				"[observer] group selection started with seed [0x%x] at block [%v]",
+				groupSelection.NewEntry,
+				groupSelection.BlockNumber,
+			)
+
+			go o.netProvider.BroadcastChannelForwarderFor(
+				relay.DKGChannelName(groupSelection.NewEntry),
+			)
+			go o.reportSelectedGroup(ctx, groupSelection)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not watch group selections: [%v]", err)
+	}
+	subscriptions = append(subscriptions, groupSelectionSubscription)
+
+	dkgResultSubscription, err := o.relayChain.OnDKGResultSubmitted(
+		func(submission *event.DKGResultSubmission) {
+			logger.Infof(
+				"[observer] DKG result for group [0x%x] submitted by member "+
+					"[%v] at block [%v]; misbehaved members: [0x%x]",
+				submission.GroupPublicKey,
+				submission.MemberIndex,
+				submission.BlockNumber,
+				submission.Misbehaved,
+			)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not watch DKG result submissions: [%v]", err)
+	}
+	subscriptions = append(subscriptions, dkgResultSubscription)
+
+	groupRegistrationSubscription, err := o.relayChain.OnGroupRegistered(
+		func(registration *event.GroupRegistration) {
+			logger.Infof(
+				"[observer] group with public key [0x%x] registered on-chain "+
+					"at block [%v]",
+				registration.GroupPublicKey,
+				registration.BlockNumber,
+			)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not watch group registrations: [%v]", err)
+	}
+	subscriptions = append(subscriptions, groupRegistrationSubscription)
+
+	return nil
+}
+
+// forwardSignatureShares forwards signature shares of the given group to
+// other nodes.
+func (o *observer) forwardSignatureShares(groupPublicKey []byte) {
+	name, err := relay.ChannelNameForPublicKeyBytes(groupPublicKey)
+	if err != nil {
+		logger.Warningf(
+			"[observer] could not forward signature shares: [%v]",
+			err,
+		)
+		return
+	}
+
+	o.netProvider.BroadcastChannelForwarderFor(name)
+}
+
+// monitorRelayEntry waits for the relay entry for the given request and
+// reports the group if it did not submit the entry before the timeout.
+func (o *observer) monitorRelayEntry(
+	ctx context.Context,
+	request *event.Request,
+) {
+	timeoutWaiter, err := o.blockCounter.BlockHeightWaiter(
+		request.BlockNumber + o.chainConfig.RelayEntryTimeout,
+	)
+	if err != nil {
+		logger.Errorf(
+			"[observer] waiter for a relay entry timeout block failed: [%v]",
+			err,
+		)
+		return
+	}
+
+	entrySubmitted := make(chan *event.EntrySubmitted, 1)
+	entrySubscription, err := o.relayChain.OnRelayEntrySubmitted(
+		func(entry *event.EntrySubmitted) {
+			select {
+			case entrySubmitted <- entry:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		logger.Errorf(
+			"[observer] could not watch for a relay entry submission: [%v]",
+			err,
+		)
+		return
+	}
+	defer entrySubscription.Unsubscribe()
+
+	select {
+	case blockNumber := <-timeoutWaiter:
+		failures := o.recordFailure(request.GroupPublicKey)
+		logger.Warningf(
+			"[observer] group [0x%x] failed to submit relay entry requested "+
+				"at block [%v] before timeout at block [%v]; "+
+				"entries missed by the group so far: [%v]",
+			request.GroupPublicKey,
+			request.BlockNumber,
+			blockNumber,
+			failures,
+		)
+	case entry := <-entrySubmitted:
+		logger.Infof(
+			"[observer] relay entry submitted by group [0x%x] "+
+				"at block [%v]",
+			request.GroupPublicKey,
+			entry.BlockNumber,
+		)
+	case <-ctx.Done():
+	}
+}
+
+// reportSelectedGroup logs members of the group selected in the given group
+// selection once the ticket submission is over.
+func (o *observer) reportSelectedGroup(
+	ctx context.Context,
+	groupSelection *event.GroupSelectionStart,
+) {
+	submissionEndWaiter, err := o.blockCounter.BlockHeightWaiter(
+		groupSelection.BlockNumber + o.chainConfig.TicketSubmissionTimeout,
+	)
+	if err != nil {
+		logger.Errorf(
+			"[observer] waiter for the end of ticket submission failed: [%v]",
+			err,
+		)
+		return
+	}
+
+	select {
+	case <-submissionEndWaiter:
+	case <-ctx.Done():
+		return
+	}
+
+	selectedStakers, err := o.relayChain.GetSelectedParticipants()
+	if err != nil {
+		logger.Errorf(
+			"[observer] could not get selected participants: [%v]",
+			err,
+		)
+		return
+	}
+
+	for index, staker := range selectedStakers {
+		logger.Infof(
+			"[observer] group selected with seed [0x%x] has member [0x%v] "+
+				"with index [%v]",
+			groupSelection.NewEntry,
+			hex.EncodeToString(staker),
+			index,
+		)
+	}
+}
+
+// recordFailure records the given group failed to submit a relay entry on
+// time and returns the number of entries the group has failed to submit.
+func (o *observer) recordFailure(groupPublicKey []byte) int {
+	o.failuresMutex.Lock()
+	defer o.failuresMutex.Unlock()
+
+	group := hex.EncodeToString(groupPublicKey)
+	o.failures[group]++
+
+	return o.failures[group]
+}
+
+// failedGroups returns the number of relay entries each group failed to
+// submit on time, keyed by the hexadecimal group public key.
+func (o *observer) failedGroups() map[string]int {
+	o.failuresMutex.Lock()
+	defer o.failuresMutex.Unlock()
+
+	failures := make(map[string]int, len(o.failures))
+	for group, count := range o.failures {
+		failures[group] = count
+	}
+
+	return failures
+}
@@ -0,0 +1,89 @@
+package beacon
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+func TestObserverReportsGroupsFailingToRespond(t *testing.T) {
+	groupPublicKey := []byte{0x01, 0x02, 0x03}
+
+	var tests = map[string]struct {
+		submitEntry      bool
+		expectedFailures map[string]int
+	}{
+		"entry submitted on time": {
+			submitEntry:      true,
+			expectedFailures: map[string]int{},
+		},
+		"entry not submitted": {
+			submitEntry: false,
+			expectedFailures: map[string]int{
+				hex.EncodeToString(groupPublicKey): 1,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chainHandle := local.Connect(1, 1, big.NewInt(200))
+			relayChain := chainHandle.ThresholdRelay()
+
+			chainConfig, err := relayChain.GetConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			blockCounter, err := chainHandle.BlockCounter()
+			if err != nil {
+				t.Fatal(err)
+			}
+			currentBlock, err := blockCounter.CurrentBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			observer := newObserver(relayChain, blockCounter, chainConfig, nil)
+
+			request := newTestRequest([]byte{0x10}, groupPublicKey, currentBlock)
+
+			monitoringDone := make(chan struct{})
+			go func() {
+				observer.monitorRelayEntry(context.Background(), request)
+				close(monitoringDone)
+			}()
+
+			if test.submitEntry {
+				// let the observer subscribe for relay entries first
+				if err := blockCounter.WaitForBlockHeight(currentBlock + 1); err != nil {
+					t.Fatal(err)
+				}
+				relayChain.SubmitRelayEntry([]byte{0x11})
+			}
+
+			<-monitoringDone
+
+			failures := observer.failedGroups()
+			if len(failures) != len(test.expectedFailures) {
+				t.Fatalf(
+					"unexpected failed groups\nexpected: [%v]\nactual:   [%v]",
+					test.expectedFailures,
+					failures,
+				)
+			}
+			for group, expectedCount := range test.expectedFailures {
+				if failures[group] != expectedCount {
+					t.Errorf(
+						"unexpected failures of group [%v]\nexpected: [%v]\nactual:   [%v]",
+						group,
+						expectedCount,
+						failures[group],
+					)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// recoverWork resumes work interrupted when the client was stopped. DKG
+// checkpointed before the client was stopped is resumed if its deadline has
+// not passed yet. The chain is checked for the latest confirmed group
+// selection and the latest confirmed relay request the client may still need
+// to serve; they are handled with the given handlers as if they were just
+// seen on the chain. Events not confirmed yet are passed to the handlers by
+// event subscriptions once they are confirmed. Failures are logged and do not
+// prevent the client from starting.
+func recoverWork(
+	ctx context.Context,
+	node *relay.Node,
+	relayChain relaychain.Interface,
+	signing chain.Signing,
+	blockCounter chain.BlockCounter,
+	chainConfig *config.Chain,
+	checkpoints *dkg.CheckpointStorage,
+	onRelayEntryRequested func(*event.Request),
+	onGroupSelectionStarted func(*event.GroupSelectionStart),
+) {
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Errorf(
+			"could not read the current block; not recovering "+
+				"interrupted work: [%v]",
+			err,
+		)
+		return
+	}
+
+	resumedSeeds := resumeDKG(
+		ctx,
+		node,
+		relayChain,
+		signing,
+		chainConfig,
+		checkpoints,
+		currentBlock,
+	)
+
+	recoverGroupSelection(
+		ctx,
+		node,
+		relayChain,
+		signing,
+		chainConfig,
+		currentBlock,
+		resumedSeeds,
+		onGroupSelectionStarted,
+	)
+
+	recoverRelayRequest(
+		ctx,
+		relayChain,
+		chainConfig,
+		currentBlock,
+		onRelayEntryRequested,
+	)
+}
+
+// resumeDKG resumes checkpointed DKG executions whose deadline has not passed
+// yet. Checkpoints of other executions are erased. Members of the same group
+// are resumed together. It returns seeds of group selections, as hexadecimal
+// strings, whose DKG has been resumed.
+func resumeDKG(
+	ctx context.Context,
+	node *relay.Node,
+	relayChain relaychain.Interface,
+	signing chain.Signing,
+	chainConfig *config.Chain,
+	checkpoints *dkg.CheckpointStorage,
+	currentBlock uint64,
+) map[string]bool {
+	checkpointsBySeed := make(map[string][]*dkg.Checkpoint)
+
+	for _, checkpoint := range checkpoints.ReadAll() {
+		deadline := dkg.DeadlineBlockHeight(
+			checkpoint.StartBlockHeight,
+			chainConfig,
+		)
+		if currentBlock >= deadline {
+			logger.Infof(
+				"[member:%v] DKG for group selected with seed [0x%x] "+
+					"ended at block [%v]; erasing checkpoint",
+				checkpoint.Index+1,
+				checkpoint.Seed,
+				deadline,
+			)
+
+			err := checkpoints.Erase(
+				checkpoint,
+				"DKG deadline passed before it could be resumed",
+			)
+			if err != nil {
+				logger.Errorf(
+					"could not erase DKG checkpoint: [%v]",
+					err,
+				)
+			}
+			continue
+		}
+
+		seed := checkpoint.Seed.Text(16)
+		checkpointsBySeed[seed] = append(checkpointsBySeed[seed], checkpoint)
+	}
+
+	resumedSeeds := make(map[string]bool)
+
+	for seed, groupCheckpoints := range checkpointsBySeed {
+		err := node.ResumeDKG(ctx, relayChain, signing, groupCheckpoints)
+		if err != nil {
+			logger.Errorf(
+				"could not resume DKG for group selected with seed [0x%v]: [%v]",
+				seed,
+				err,
+			)
+			continue
+		}
+
+		resumedSeeds[seed] = true
+	}
+
+	return resumedSeeds
+}
+
+// recoverGroupSelection handles the latest group selection seen on-chain if
+// the client can still take part in it. If the ticket submission is still
+// in progress, the group selection is handled as a new one. If the group has
+// already been selected and DKG has just started, the client joins DKG,
+// unless DKG of that group has been resumed from a checkpoint.
+func recoverGroupSelection(
+	ctx context.Context,
+	node *relay.Node,
+	relayChain relaychain.Interface,
+	signing chain.Signing,
+	chainConfig *config.Chain,
+	currentBlock uint64,
+	resumedSeeds map[string]bool,
+	onGroupSelectionStarted func(*event.GroupSelectionStart),
+) {
+	lookback := chainConfig.TicketSubmissionTimeout + chainConfig.DKGTiming.JoinBlocks()
+
+	var fromBlock uint64
+	if currentBlock > lookback {
+		fromBlock = currentBlock - lookback
+	}
+
+	groupSelections, err := relayChain.PastGroupSelectionsStarted(ctx, fromBlock)
+	if err != nil {
+		logger.Errorf("could not check past group selections: [%v]", err)
+		return
+	}
+
+	if len(groupSelections) == 0 {
+		return
+	}
+
+	groupSelection := groupSelections[len(groupSelections)-1]
+	groupSelectionEndBlock := groupSelection.BlockNumber +
+		chainConfig.TicketSubmissionTimeout
+
+	if currentBlock < groupSelectionEndBlock {
+		logger.Infof(
+			"recovering group selection with seed [0x%x] started at block [%v]",
+			groupSelection.NewEntry,
+			groupSelection.BlockNumber,
+		)
+		onGroupSelectionStarted(groupSelection)
+		return
+	}
+
+	if resumedSeeds[groupSelection.NewEntry.Text(16)] ||
+		currentBlock >= groupSelectionEndBlock+chainConfig.DKGTiming.JoinBlocks() {
+		return
+	}
+
+	selectedStakers, err := relayChain.GetSelectedParticipants()
+	if err != nil {
+		logger.Errorf("could not get selected participants: [%v]", err)
+		return
+	}
+
+	logger.Infof(
+		"recovering DKG for group selected with seed [0x%x] at block [%v]",
+		groupSelection.NewEntry,
+		groupSelectionEndBlock,
+	)
+
+	go node.JoinGroupIfEligible(
+		ctx,
+		relayChain,
+		signing,
+		&groupselection.Result{
+			SelectedStakers:        selectedStakers,
+			GroupSelectionEndBlock: groupSelectionEndBlock,
+		},
+		groupSelection.NewEntry,
+	)
+}
+
+// recoverRelayRequest handles the latest relay request seen on-chain as a new
+// one if it has not timed out yet and no relay entry has been submitted for
+// it.
+func recoverRelayRequest(
+	ctx context.Context,
+	relayChain relaychain.Interface,
+	chainConfig *config.Chain,
+	currentBlock uint64,
+	onRelayEntryRequested func(*event.Request),
+) {
+	var fromBlock uint64
+	if currentBlock > chainConfig.RelayEntryTimeout {
+		fromBlock = currentBlock - chainConfig.RelayEntryTimeout
+	}
+
+	requests, err := relayChain.PastRelayEntryRequests(ctx, fromBlock)
+	if err != nil {
+		logger.Errorf("could not check past relay requests: [%v]", err)
+		return
+	}
+
+	if len(requests) == 0 {
+		return
+	}
+
+	request := requests[len(requests)-1]
+
+	entries, err := relayChain.PastRelayEntriesSubmitted(ctx, request.BlockNumber)
+	if err != nil {
+		logger.Errorf("could not check past relay entries: [%v]", err)
+		return
+	}
+
+	if len(entries) > 0 {
+		return
+	}
+
+	logger.Infof(
+		"recovering relay request from block [%v] using previous entry [0x%x]",
+		request.BlockNumber,
+		request.PreviousEntry,
+	)
+	onRelayEntryRequested(request)
+}
@@ -1,6 +1,9 @@
 package chain
 
 import (
+	"context"
+	"math/big"
+
 	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/gen/async"
@@ -22,7 +25,9 @@ type RelayEntryInterface interface {
 	// SubmitRelayEntry submits an entry in the threshold relay and returns a
 	// promise to track the submission progress. The promise is fulfilled when
 	// the entry has been successfully submitted to the on-chain, or failed if
-	// the entry submission failed.
+	// the entry submission failed. If the entry has already been submitted
+	// by other member, the submission is skipped and the promise is fulfilled
+	// with the existing submission.
 	SubmitRelayEntry(entry []byte) *async.EventEntrySubmittedPromise
 	// OnRelayEntrySubmitted is a callback that is invoked when an on-chain
 	// notification of a new, valid relay entry is seen.
@@ -34,10 +39,33 @@ type RelayEntryInterface interface {
 	OnRelayEntryRequested(
 		func(request *event.Request),
 	) (subscription.EventSubscription, error)
+	// OnRelayEntryRequestRemoved is a callback that is invoked when a relay
+	// request, already passed to OnRelayEntryRequested callbacks, is removed
+	// from the chain as a result of a chain reorganization.
+	OnRelayEntryRequestRemoved(
+		func(request *event.Request),
+	) (subscription.EventSubscription, error)
 	// ReportRelayEntryTimeout notifies the chain when a selected group which was
 	// supposed to submit a relay entry, did not deliver it within a specified
 	// time frame (relayEntryTimeout) counted in blocks.
 	ReportRelayEntryTimeout() error
+	// PastRelayEntryRequests returns relay entry requests seen on-chain
+	// starting from the given block, in the order they were requested.
+	// Only requests confirmed the same way as events passed to
+	// OnRelayEntryRequested callbacks are returned. Reading is abandoned
+	// when the given context is done.
+	PastRelayEntryRequests(
+		ctx context.Context,
+		fromBlock uint64,
+	) ([]*event.Request, error)
+	// PastRelayEntriesSubmitted returns relay entries submitted on-chain
+	// starting from the given block, in the order they were submitted.
+	// Only confirmed submissions are returned. Reading is abandoned when the
+	// given context is done.
+	PastRelayEntriesSubmitted(
+		ctx context.Context,
+		fromBlock uint64,
+	) ([]*event.EntrySubmitted, error)
 }
 
 // GroupSelectionInterface defines the subset of the relay chain interface that
@@ -48,6 +76,21 @@ type GroupSelectionInterface interface {
 	OnGroupSelectionStarted(
 		func(groupSelectionStarted *event.GroupSelectionStart),
 	) (subscription.EventSubscription, error)
+	// OnGroupSelectionStartRemoved is a callback that is invoked when a group
+	// selection start, already passed to OnGroupSelectionStarted callbacks,
+	// is removed from the chain as a result of a chain reorganization.
+	OnGroupSelectionStartRemoved(
+		func(groupSelectionStarted *event.GroupSelectionStart),
+	) (subscription.EventSubscription, error)
+	// PastGroupSelectionsStarted returns group selections started on-chain
+	// starting from the given block, in the order they were started. Only
+	// group selections confirmed the same way as events passed to
+	// OnGroupSelectionStarted callbacks are returned. Reading is abandoned
+	// when the given context is done.
+	PastGroupSelectionsStarted(
+		ctx context.Context,
+		fromBlock uint64,
+	) ([]*event.GroupSelectionStart, error)
 	// SubmitTicket submits a ticket corresponding to the virtual staker to
 	// the chain, and returns a promise to track the submission. The promise
 	// is fulfilled with the entry as seen on-chain, or failed if there is an
@@ -58,6 +101,35 @@ type GroupSelectionInterface interface {
 	// GetSelectedParticipants returns `GroupSize` slice of addresses of
 	// candidates which have been selected to the currently assembling group.
 	GetSelectedParticipants() ([]StakerAddress, error)
+	// GetTicketSubmissionEconomics returns the current cost of submitting
+	// a single ticket and the reward expected for a single seat in the
+	// group.
+	GetTicketSubmissionEconomics() (*TicketSubmissionEconomics, error)
+}
+
+// TicketSubmissionEconomics describes whether it pays off to submit a ticket.
+// Both values are expressed in the smallest unit of the chain currency.
+type TicketSubmissionEconomics struct {
+	// SubmissionCost is the cost of submitting a single ticket.
+	SubmissionCost *big.Int
+	// ExpectedReward is the reward expected for a single seat in the group.
+	ExpectedReward *big.Int
+}
+
+// IsProfitable tells whether the reward expected for the given number of
+// seats in the group covers the cost of submitting the given number of
+// tickets.
+func (tse *TicketSubmissionEconomics) IsProfitable(tickets, seats int) bool {
+	totalCost := new(big.Int).Mul(
+		tse.SubmissionCost,
+		big.NewInt(int64(tickets)),
+	)
+	totalReward := new(big.Int).Mul(
+		tse.ExpectedReward,
+		big.NewInt(int64(seats)),
+	)
+
+	return totalReward.Cmp(totalCost) >= 0
 }
 
 // GroupRegistrationInterface defines the subset of the relay chain interface
@@ -93,7 +165,9 @@ type DistributedKeyGenerationInterface interface {
 	// SubmitDKGResult sends DKG result to a chain, along with signatures over
 	// result hash from group participants supporting the result.
 	// Signatures over DKG result hash are collected in a map keyed by signer's
-	// member index.
+	// member index. If a result has already been submitted by other member,
+	// the submission is skipped and the returned promise is fulfilled with
+	// the existing submission.
 	SubmitDKGResult(
 		participantIndex GroupMemberIndex,
 		dkgResult *DKGResult,
@@ -118,7 +192,7 @@ type Interface interface {
 	// GetConfig returns the expected configuration of the threshold relay.
 	GetConfig() (*config.Chain, error)
 	// GetKeys returns the key pair used to attest for messages being sent to
-	// the chain.
+	// the chain. The private key is nil if it is held by an external signer.
 	GetKeys() (*operator.PrivateKey, *operator.PublicKey)
 
 	GroupInterface
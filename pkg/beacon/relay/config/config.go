@@ -1,6 +1,9 @@
 package config
 
-import "math/big"
+import (
+	"fmt"
+	"math/big"
+)
 
 // Chain contains the config data needed for the relay to operate.
 type Chain struct {
@@ -27,6 +30,54 @@ type Chain struct {
 	// entry to be published by the selected group. Blocks are
 	// counted from the moment relay request occur.
 	RelayEntryTimeout uint64
+	// DKGTimeout is the duration (in blocks) of DKG phases 1-13 the operator
+	// contract assumes; it is T_dkg of the result publication. DKG phases
+	// executed by the client have to take exactly that long.
+	DKGTimeout uint64
+	// GroupSelectionTiming is the timing of ticket submission rounds.
+	GroupSelectionTiming GroupSelectionTiming
+	// DKGTiming is the timing of DKG phases.
+	DKGTiming DKGTiming
+	// EventConfirmationDepth is the number of blocks which must be mined on
+	// top of the block containing a chain event before the event is delivered
+	// to the relay. Timeouts waiting for chain events have to cover it.
+	EventConfirmationDepth uint64
+}
+
+// Validate checks whether the timing of group selection and DKG phases is
+// consistent with timeouts of the chain. Clients using timing inconsistent
+// with the chain could not complete group selection or DKG in time.
+func (c *Chain) Validate() error {
+	selection := c.GroupSelectionTiming
+	if selection.RoundBlocks == 0 {
+		return fmt.Errorf("ticket submission round has to take at least one block")
+	}
+	if c.TicketSubmissionTimeout <=
+		selection.MiningLagBlocks+selection.RoundBlocks {
+		return fmt.Errorf(
+			"ticket submission timeout [%v] is too short for rounds of [%v] "+
+				"blocks and mining lag of [%v] blocks",
+			c.TicketSubmissionTimeout,
+			selection.RoundBlocks,
+			selection.MiningLagBlocks,
+		)
+	}
+
+	for name, phase := range c.DKGTiming.phases() {
+		if phase.ActiveBlocks == 0 {
+			return fmt.Errorf("DKG phase [%v] has no active blocks", name)
+		}
+	}
+
+	if c.DKGTiming.Blocks() != c.DKGTimeout {
+		return fmt.Errorf(
+			"DKG phases take [%v] blocks but the DKG timeout is [%v] blocks",
+			c.DKGTiming.Blocks(),
+			c.DKGTimeout,
+		)
+	}
+
+	return nil
 }
 
 // DishonestThreshold is the maximum number of misbehaving participants for
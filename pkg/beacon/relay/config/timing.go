@@ -0,0 +1,162 @@
+package config
+
+// DefaultDKGTimeout is the DKG timeout the operator contract is deployed with.
+// It matches the default timing of DKG phases.
+const DefaultDKGTimeout = 72
+
+// Default timing assumes ~15 second Ethereum blocks and matches the timing
+// the operator contract is deployed with.
+const (
+	defaultTicketSubmissionRoundBlocks     = 3
+	defaultTicketSubmissionMiningLagBlocks = 6
+)
+
+// PhaseTiming is the timing of a protocol phase, in blocks.
+type PhaseTiming struct {
+	// DelayBlocks is the number of blocks the phase waits for before it
+	// becomes active.
+	DelayBlocks uint64
+	// ActiveBlocks is the number of blocks the phase is active for.
+	ActiveBlocks uint64
+}
+
+// Blocks returns the total number of blocks the phase takes.
+func (pt PhaseTiming) Blocks() uint64 {
+	return pt.DelayBlocks + pt.ActiveBlocks
+}
+
+// GroupSelectionTiming is the timing of ticket submission in group selection.
+//
+// Tickets are submitted in rounds, each round taking RoundBlocks blocks.
+// After all rounds, there are MiningLagBlocks blocks left until the ticket
+// submission timeout, allowing all outstanding ticket submissions to have
+// a higher chance of being mined before the deadline.
+type GroupSelectionTiming struct {
+	RoundBlocks     uint64
+	MiningLagBlocks uint64
+}
+
+// DefaultGroupSelectionTiming returns the default timing of ticket
+// submission.
+func DefaultGroupSelectionTiming() GroupSelectionTiming {
+	return GroupSelectionTiming{
+		RoundBlocks:     defaultTicketSubmissionRoundBlocks,
+		MiningLagBlocks: defaultTicketSubmissionMiningLagBlocks,
+	}
+}
+
+// WithDefaults returns the timing with values which have not been set
+// replaced with defaults.
+func (gst GroupSelectionTiming) WithDefaults() GroupSelectionTiming {
+	defaults := DefaultGroupSelectionTiming()
+
+	if gst.RoundBlocks == 0 {
+		gst.RoundBlocks = defaults.RoundBlocks
+	}
+	if gst.MiningLagBlocks == 0 {
+		gst.MiningLagBlocks = defaults.MiningLagBlocks
+	}
+
+	return gst
+}
+
+// DKGTiming is the timing of DKG phases in which members exchange messages.
+// Phases in which members only compute take no blocks.
+type DKGTiming struct {
+	// EphemeralKeyPair is the timing of phase 1 of the key generation.
+	// Members which have not broadcast their ephemeral public keys by the
+	// end of the phase are considered inactive.
+	EphemeralKeyPair PhaseTiming
+	// Commitment is the timing of phase 3 of the key generation.
+	Commitment PhaseTiming
+	// CommitmentVerification is the timing of phase 4 of the key generation.
+	CommitmentVerification PhaseTiming
+	// PointsShare is the timing of phase 7 of the key generation.
+	PointsShare PhaseTiming
+	// PointsValidation is the timing of phase 8 of the key generation.
+	PointsValidation PhaseTiming
+	// KeyReveal is the timing of phase 10 of the key generation.
+	KeyReveal PhaseTiming
+	// Combination is the timing of phases 11 and 12 of the key generation.
+	Combination PhaseTiming
+	// ResultSigning is the timing of phase 13, signing of the DKG result.
+	ResultSigning PhaseTiming
+
+	// WaitForActiveBlocks makes the member wait for the active blocks of
+	// each phase to be over even if it has received all the messages it
+	// expects in the phase. It does not change the timing of phases and
+	// members waiting for active blocks stay compatible with members which
+	// complete phases early.
+	WaitForActiveBlocks bool
+}
+
+// DefaultDKGTiming returns the default timing of DKG phases.
+func DefaultDKGTiming() DKGTiming {
+	return DKGTiming{
+		EphemeralKeyPair:       PhaseTiming{DelayBlocks: 1, ActiveBlocks: 5},
+		Commitment:             PhaseTiming{DelayBlocks: 1, ActiveBlocks: 5},
+		CommitmentVerification: PhaseTiming{DelayBlocks: 1, ActiveBlocks: 10},
+		PointsShare:            PhaseTiming{DelayBlocks: 1, ActiveBlocks: 5},
+		PointsValidation:       PhaseTiming{DelayBlocks: 1, ActiveBlocks: 10},
+		KeyReveal:              PhaseTiming{DelayBlocks: 1, ActiveBlocks: 5},
+		Combination:            PhaseTiming{DelayBlocks: 0, ActiveBlocks: 20},
+		ResultSigning:          PhaseTiming{DelayBlocks: 1, ActiveBlocks: 5},
+	}
+}
+
+// WithDefaults returns the timing with phases which have not been set,
+// that is phases with no active blocks, replaced with defaults.
+func (dt DKGTiming) WithDefaults() DKGTiming {
+	defaults := DefaultDKGTiming()
+	defaultPhases := defaults.phases()
+
+	for name, phase := range dt.phases() {
+		if phase.ActiveBlocks == 0 {
+			*phase = *defaultPhases[name]
+		}
+	}
+
+	return dt
+}
+
+// phases returns pointers to timings of all the phases, keyed by the phase
+// name.
+func (dt *DKGTiming) phases() map[string]*PhaseTiming {
+	return map[string]*PhaseTiming{
+		"EphemeralKeyPair":       &dt.EphemeralKeyPair,
+		"Commitment":             &dt.Commitment,
+		"CommitmentVerification": &dt.CommitmentVerification,
+		"PointsShare":            &dt.PointsShare,
+		"PointsValidation":       &dt.PointsValidation,
+		"KeyReveal":              &dt.KeyReveal,
+		"Combination":            &dt.Combination,
+		"ResultSigning":          &dt.ResultSigning,
+	}
+}
+
+// KeyGenerationBlocks returns the total number of blocks it takes to execute
+// all the phases of the key generation.
+func (dt DKGTiming) KeyGenerationBlocks() uint64 {
+	return dt.EphemeralKeyPair.Blocks() +
+		dt.Commitment.Blocks() +
+		dt.CommitmentVerification.Blocks() +
+		dt.PointsShare.Blocks() +
+		dt.PointsValidation.Blocks() +
+		dt.KeyReveal.Blocks() +
+		dt.Combination.Blocks()
+}
+
+// JoinBlocks returns the number of blocks, counted from the start of the key
+// generation, members have to join the key generation. Members which have
+// not broadcast their ephemeral public keys by then are considered inactive
+// by other members.
+func (dt DKGTiming) JoinBlocks() uint64 {
+	return dt.EphemeralKeyPair.Blocks()
+}
+
+// Blocks returns the total number of blocks it takes to execute the key
+// generation and to get ready for the result publication. It has to be equal
+// to the DKG timeout of the chain.
+func (dt DKGTiming) Blocks() uint64 {
+	return dt.KeyGenerationBlocks() + dt.ResultSigning.Blocks()
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefaultDKGTimingMatchesDefaultTimeout(t *testing.T) {
+	blocks := DefaultDKGTiming().Blocks()
+	if blocks != DefaultDKGTimeout {
+		t.Errorf(
+			"unexpected number of blocks\nexpected: [%v]\nactual:   [%v]",
+			DefaultDKGTimeout,
+			blocks,
+		)
+	}
+}
+
+func TestDKGTimingWithDefaults(t *testing.T) {
+	timing := DKGTiming{
+		Commitment:  PhaseTiming{DelayBlocks: 2, ActiveBlocks: 10},
+		Combination: PhaseTiming{DelayBlocks: 3},
+	}
+
+	expectedTiming := DefaultDKGTiming()
+	expectedTiming.Commitment = PhaseTiming{DelayBlocks: 2, ActiveBlocks: 10}
+
+	actualTiming := timing.WithDefaults()
+
+	if !reflect.DeepEqual(expectedTiming, actualTiming) {
+		t.Errorf(
+			"unexpected timing\nexpected: [%+v]\nactual:   [%+v]",
+			expectedTiming,
+			actualTiming,
+		)
+	}
+}
+
+func TestGroupSelectionTimingWithDefaults(t *testing.T) {
+	timing := GroupSelectionTiming{RoundBlocks: 10}
+
+	expectedTiming := GroupSelectionTiming{
+		RoundBlocks:     10,
+		MiningLagBlocks: defaultTicketSubmissionMiningLagBlocks,
+	}
+
+	actualTiming := timing.WithDefaults()
+
+	if expectedTiming != actualTiming {
+		t.Errorf(
+			"unexpected timing\nexpected: [%+v]\nactual:   [%+v]",
+			expectedTiming,
+			actualTiming,
+		)
+	}
+}
+
+func TestValidateChain(t *testing.T) {
+	var tests = map[string]struct {
+		updateChain   func(chain *Chain)
+		expectedError bool
+	}{
+		"default timing": {
+			updateChain:   func(chain *Chain) {},
+			expectedError: false,
+		},
+		"longer DKG phase with longer DKG timeout": {
+			updateChain: func(chain *Chain) {
+				chain.DKGTiming.Combination.ActiveBlocks += 20
+				chain.DKGTimeout += 20
+			},
+			expectedError: false,
+		},
+		"longer DKG phase with default DKG timeout": {
+			updateChain: func(chain *Chain) {
+				chain.DKGTiming.Combination.ActiveBlocks += 20
+			},
+			expectedError: true,
+		},
+		"DKG phase with no active blocks": {
+			updateChain: func(chain *Chain) {
+				chain.DKGTiming.KeyReveal.ActiveBlocks = 0
+				chain.DKGTiming.KeyReveal.DelayBlocks += 5
+			},
+			expectedError: true,
+		},
+		"ticket submission round with no blocks": {
+			updateChain: func(chain *Chain) {
+				chain.GroupSelectionTiming.RoundBlocks = 0
+			},
+			expectedError: true,
+		},
+		"ticket submission timeout too short": {
+			updateChain: func(chain *Chain) {
+				chain.TicketSubmissionTimeout = 9
+			},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chain := &Chain{
+				TicketSubmissionTimeout: 39,
+				DKGTimeout:              DefaultDKGTimeout,
+				GroupSelectionTiming:    DefaultGroupSelectionTiming(),
+				DKGTiming:               DefaultDKGTiming(),
+			}
+			test.updateChain(chain)
+
+			err := chain.Validate()
+
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected error: [%v]\nactual error:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package dkg
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+)
+
+// checkpointFileName is the name of the file holding the checkpoint in the
+// directory of the member.
+const checkpointFileName = "/checkpoint"
+
+// Checkpoint captures the progress of DKG executed by one member so that the
+// execution can be resumed after the client restarts.
+type Checkpoint struct {
+	// Seed of the group selection which selected the group.
+	Seed *big.Int
+	// Index of the member in the group; starts with 0.
+	Index uint8
+	// Stakers selected to the group, in the order of member indexes.
+	SelectedStakers []relayChain.StakerAddress
+	// Block at which DKG started.
+	StartBlockHeight uint64
+	// Progress of the key generation; nil if the key generation has not
+	// started yet.
+	KeyGeneration *gjkr.Checkpoint
+}
+
+// directory returns the name of the directory holding the checkpoint. There is
+// a separate directory for each member of each group.
+func (c *Checkpoint) directory() string {
+	return fmt.Sprintf("dkg_%s_%d", c.Seed.Text(16), c.Index)
+}
+
+// DeadlineBlockHeight returns the last block at which DKG started at the
+// given block may still be in progress. After that block, DKG result
+// publication is over and there is nothing left to resume.
+func DeadlineBlockHeight(
+	startBlockHeight uint64,
+	chainConfig *config.Chain,
+) uint64 {
+	return startBlockHeight +
+		chainConfig.DKGTiming.Blocks() +
+		uint64(chainConfig.GroupSize)*chainConfig.ResultPublicationBlockStep
+}
+
+// ArchiveEraser securely erases directories moved to the archive by the
+// persistence handle, e.g. registry.Archive kept in the same data directory.
+type ArchiveEraser interface {
+	// MarkArchived records the directory with the given name has just been
+	// archived.
+	MarkArchived(name string) error
+	// Erase securely erases the archived directory with the given name,
+	// stating the given reason.
+	Erase(name string, reason string) error
+}
+
+// CheckpointStorage persists DKG checkpoints. Checkpoints contain secrets of
+// members so the storage should use an encrypted persistence handle and
+// checkpoints are erased once DKG is over.
+type CheckpointStorage struct {
+	handle persistence.Handle
+	eraser ArchiveEraser
+}
+
+// NewCheckpointStorage returns a new checkpoint storage using the given
+// persistence handle. Checkpoints are erased from the archive of the handle
+// with the given eraser.
+func NewCheckpointStorage(
+	handle persistence.Handle,
+	eraser ArchiveEraser,
+) *CheckpointStorage {
+	return &CheckpointStorage{
+		handle: handle,
+		eraser: eraser,
+	}
+}
+
+// Save persists the checkpoint, replacing the one previously saved for the
+// same member of the same group.
+func (cs *CheckpointStorage) Save(checkpoint *Checkpoint) error {
+	checkpointBytes, err := checkpoint.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling of the checkpoint failed: [%v]", err)
+	}
+
+	return cs.handle.Save(
+		checkpointBytes,
+		checkpoint.directory(),
+		checkpointFileName,
+	)
+}
+
+// Erase moves the checkpoint to the archive so that it is not resumed anymore
+// and securely erases it from the archive, stating the given reason.
+func (cs *CheckpointStorage) Erase(checkpoint *Checkpoint, reason string) error {
+	directory := checkpoint.directory()
+
+	if err := cs.handle.Archive(directory); err != nil {
+		return fmt.Errorf("could not archive checkpoint: [%v]", err)
+	}
+
+	if err := cs.eraser.MarkArchived(directory); err != nil {
+		return fmt.Errorf(
+			"could not record archiving time of checkpoint: [%v]",
+			err,
+		)
+	}
+
+	return cs.eraser.Erase(directory, reason)
+}
+
+// ReadAll returns all the checkpoints which have not been erased.
+// Checkpoints which could not be read are reported and skipped.
+func (cs *CheckpointStorage) ReadAll() []*Checkpoint {
+	checkpoints := make([]*Checkpoint, 0)
+	readAll(cs.handle, "DKG checkpoint", func(descriptor persistence.DataDescriptor) {
+		content, err := descriptor.Content()
+		if err != nil {
+			logger.Errorf(
+				"could not read DKG checkpoint from file [%v] "+
+					"in directory [%v]: [%v]",
+				descriptor.Name(),
+				descriptor.Directory(),
+				err,
+			)
+			return
+		}
+
+		checkpoint := &Checkpoint{}
+		if err := checkpoint.Unmarshal(content); err != nil {
+			logger.Errorf(
+				"could not unmarshal DKG checkpoint from file [%v] "+
+					"in directory [%v]: [%v]",
+				descriptor.Name(),
+				descriptor.Directory(),
+				err,
+			)
+			return
+		}
+
+		checkpoints = append(checkpoints, checkpoint)
+	})
+
+	return checkpoints
+}
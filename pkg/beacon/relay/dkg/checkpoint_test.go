@@ -0,0 +1,134 @@
+package dkg
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/internal/pbutils"
+)
+
+func TestCheckpointRoundtrip(t *testing.T) {
+	checkpoint := &Checkpoint{
+		Seed:  big.NewInt(31337),
+		Index: 3,
+		SelectedStakers: []relayChain.StakerAddress{
+			[]byte{0x01, 0x02},
+			[]byte{0x03, 0x04},
+		},
+		StartBlockHeight: 1500,
+	}
+	unmarshaled := &Checkpoint{}
+
+	err := pbutils.RoundTrip(checkpoint, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(checkpoint, unmarshaled) {
+		t.Fatalf(
+			"unexpected content of unmarshaled checkpoint\n"+
+				"expected: [%+v]\nactual:   [%+v]",
+			checkpoint,
+			unmarshaled,
+		)
+	}
+}
+
+func TestCheckpointStorage(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "dkg_checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	handle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eraser := &stubArchiveEraser{}
+	storage := NewCheckpointStorage(handle, eraser)
+
+	selectedStakers := []relayChain.StakerAddress{
+		[]byte{0x01, 0x02},
+		[]byte{0x03, 0x04},
+	}
+
+	checkpoint1 := &Checkpoint{
+		Seed:             big.NewInt(100),
+		Index:            0,
+		SelectedStakers:  selectedStakers,
+		StartBlockHeight: 10,
+	}
+	checkpoint2 := &Checkpoint{
+		Seed:             big.NewInt(100),
+		Index:            1,
+		SelectedStakers:  selectedStakers,
+		StartBlockHeight: 10,
+	}
+	updatedCheckpoint1 := &Checkpoint{
+		Seed:             big.NewInt(100),
+		Index:            0,
+		SelectedStakers:  selectedStakers,
+		StartBlockHeight: 12,
+	}
+
+	for _, checkpoint := range []*Checkpoint{
+		checkpoint1,
+		checkpoint2,
+		updatedCheckpoint1,
+	} {
+		if err := storage.Save(checkpoint); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := storage.Erase(checkpoint2, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedErased := []string{checkpoint2.directory()}
+	if !reflect.DeepEqual(expectedErased, eraser.erased) {
+		t.Errorf(
+			"unexpected erased checkpoints\nexpected: %v\nactual:   %v",
+			expectedErased,
+			eraser.erased,
+		)
+	}
+
+	checkpoints := storage.ReadAll()
+	if len(checkpoints) != 1 {
+		t.Fatalf(
+			"unexpected number of checkpoints\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(checkpoints),
+		)
+	}
+
+	if !reflect.DeepEqual(updatedCheckpoint1, checkpoints[0]) {
+		t.Errorf(
+			"unexpected checkpoint\nexpected: [%+v]\nactual:   [%+v]",
+			updatedCheckpoint1,
+			checkpoints[0],
+		)
+	}
+}
+
+type stubArchiveEraser struct {
+	erased []string
+}
+
+func (sae *stubArchiveEraser) MarkArchived(name string) error {
+	return nil
+}
+
+func (sae *stubArchiveEraser) Erase(name string, reason string) error {
+	sae.erased = append(sae.erased, name)
+	return nil
+}
@@ -2,6 +2,7 @@ package dkg
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/big"
 
@@ -12,40 +13,105 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
 var logger = log.Logger("keep-dkg")
 
-// ExecuteDKG runs the full distributed key generation lifecycle.
+// ExecutionConfig contains the parameters of a single DKG execution by one
+// member of the group.
+type ExecutionConfig struct {
+	// Seed is the seed of the group selection the group has been selected
+	// in.
+	Seed *big.Int
+
+	// Index is the index of the member in the group, starting with 0.
+	Index uint8
+
+	GroupSize           int
+	DishonestThreshold  int
+	MembershipValidator group.MembershipValidator
+
+	// StartBlockHeight is the block height of the clock at which DKG starts.
+	StartBlockHeight uint64
+
+	// KeyGenerationCheckpoint, if not nil, is the key generation to resume.
+	KeyGenerationCheckpoint *gjkr.Checkpoint
+
+	// OnCheckpoint, if not nil, is called each time the key generation
+	// progresses.
+	OnCheckpoint func(*gjkr.Checkpoint)
+
+	// TranscriptRecorder, if not nil, records the key generation and the
+	// result publication.
+	TranscriptRecorder *TranscriptRecorder
+
+	// VerificationCache, if not nil, lets the key generation share the
+	// verification of messages with other members using the same cache.
+	VerificationCache *gjkr.VerificationCache
+}
+
+// ExecuteDKG runs the full distributed key generation lifecycle. The
+// execution is abandoned when the given context is done.
+//
+// Phases of the key generation and the result signing are measured with the
+// given clock, starting at the configured block height of the clock. The
+// result is submitted to the chain in turns measured with the chain block
+// counter. Both are usually the same block counter.
 func ExecuteDKG(
-	seed *big.Int,
-	index uint8, // starts with 0
-	groupSize int,
-	dishonestThreshold int,
-	membershipValidator group.MembershipValidator,
-	startBlockHeight uint64,
+	ctx context.Context,
+	clock state.Clock,
 	blockCounter chain.BlockCounter,
 	relayChain relayChain.Interface,
 	signing chain.Signing,
 	channel net.BroadcastChannel,
+	executionConfig ExecutionConfig,
 ) (*ThresholdSigner, error) {
+	membershipValidator := executionConfig.MembershipValidator
+	startBlockHeight := executionConfig.StartBlockHeight
+	transcriptRecorder := executionConfig.TranscriptRecorder
+
 	// The staker index should begin with 1
-	playerIndex := group.MemberIndex(index + 1)
+	playerIndex := group.MemberIndex(executionConfig.Index + 1)
+
+	chainConfig, err := relayChain.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"[member:%v] could not get chain config [%v]",
+			playerIndex,
+			err,
+		)
+	}
+
+	var keyGenerationRecorder *gjkr.TranscriptRecorder
+	var resultPublicationRecorder *state.TranscriptRecorder
+	if transcriptRecorder != nil {
+		keyGenerationRecorder = transcriptRecorder.keyGeneration
+		resultPublicationRecorder = transcriptRecorder.resultPublication
+	}
 
 	gjkr.RegisterUnmarshallers(channel)
 	dkgResult.RegisterUnmarshallers(channel)
 
 	gjkrResult, gjkrEndBlockHeight, err := gjkr.Execute(
-		playerIndex,
-		groupSize,
-		blockCounter,
+		ctx,
+		clock,
 		channel,
-		dishonestThreshold,
-		seed,
-		membershipValidator,
-		startBlockHeight,
+		gjkr.ExecutionConfig{
+			MemberIndex:         playerIndex,
+			GroupSize:           executionConfig.GroupSize,
+			DishonestThreshold:  executionConfig.DishonestThreshold,
+			Seed:                executionConfig.Seed,
+			MembershipValidator: membershipValidator,
+			Timing:              chainConfig.DKGTiming,
+			StartBlockHeight:    startBlockHeight,
+			Checkpoint:          executionConfig.KeyGenerationCheckpoint,
+			OnCheckpoint:        executionConfig.OnCheckpoint,
+			TranscriptRecorder:  keyGenerationRecorder,
+			VerificationCache:   executionConfig.VerificationCache,
+		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -55,7 +121,20 @@ func ExecuteDKG(
 		)
 	}
 
-	startPublicationBlockHeight := gjkrEndBlockHeight
+	// Phases of the key generation may complete before their blocks are over
+	// but the result publication is bound to the DKG timeout of the chain.
+	// It starts at the block at which the key generation ends if all its
+	// phases wait for their blocks to be over.
+	startPublicationBlockHeight := startBlockHeight +
+		chainConfig.DKGTiming.KeyGenerationBlocks()
+
+	logger.Infof(
+		"[member:%v] key generation completed at block [%v]; "+
+			"result publication starts at block [%v]",
+		playerIndex,
+		gjkrEndBlockHeight,
+		startPublicationBlockHeight,
+	)
 
 	dkgResultChannel := make(chan *event.DKGResultSubmission)
 	dkgResultSubscription, err := relayChain.OnDKGResultSubmitted(
@@ -73,6 +152,7 @@ func ExecuteDKG(
 	defer dkgResultSubscription.Unsubscribe()
 
 	err = dkgResult.Publish(
+		ctx,
 		playerIndex,
 		gjkrResult.Group,
 		membershipValidator,
@@ -80,8 +160,11 @@ func ExecuteDKG(
 		channel,
 		relayChain,
 		signing,
+		clock,
 		blockCounter,
+		chainConfig.DKGTiming,
 		startPublicationBlockHeight,
+		resultPublicationRecorder,
 	)
 	if err != nil {
 		// Result publication failed. It means that either the result this
@@ -97,11 +180,13 @@ func ExecuteDKG(
 		)
 
 		if err := decideMemberFate(
+			ctx,
 			playerIndex,
 			gjkrResult,
 			dkgResultChannel,
 			startPublicationBlockHeight,
 			relayChain,
+			clock,
 			blockCounter,
 		); err != nil {
 			return nil, err
@@ -121,17 +206,21 @@ func ExecuteDKG(
 // supports the same group public key as the one registered on-chain and
 // the member is not considered as misbehaving by the group.
 func decideMemberFate(
+	ctx context.Context,
 	playerIndex group.MemberIndex,
 	gjkrResult *gjkr.Result,
 	dkgResultChannel chan *event.DKGResultSubmission,
 	startPublicationBlockHeight uint64,
 	relayChain relayChain.Interface,
+	clock state.Clock,
 	blockCounter chain.BlockCounter,
 ) error {
 	dkgResultEvent, err := waitForDkgResultEvent(
+		ctx,
 		dkgResultChannel,
 		startPublicationBlockHeight,
 		relayChain,
+		clock,
 		blockCounter,
 	)
 	if err != nil {
@@ -167,10 +256,17 @@ func decideMemberFate(
 	return nil
 }
 
+// waitForDkgResultEvent waits for the DKG result to be submitted to the
+// chain. The result signing is measured with the given clock and the result
+// submission that follows is measured with the chain block counter. It times
+// out when all the members had their turn to submit the result and the event
+// of a result submitted in the last turn had time to be confirmed.
 func waitForDkgResultEvent(
+	ctx context.Context,
 	dkgResultChannel chan *event.DKGResultSubmission,
 	startPublicationBlockHeight uint64,
 	relayChain relayChain.Interface,
+	clock state.Clock,
 	blockCounter chain.BlockCounter,
 ) (*event.DKGResultSubmission, error) {
 	config, err := relayChain.GetConfig()
@@ -178,9 +274,41 @@ func waitForDkgResultEvent(
 		return nil, err
 	}
 
-	timeoutBlock := startPublicationBlockHeight +
-		dkgResult.PrePublicationBlocks() +
-		(uint64(config.GroupSize) * config.ResultPublicationBlockStep)
+	submissionStartBlockHeight := startPublicationBlockHeight +
+		config.DKGTiming.ResultSigning.Blocks()
+
+	// If the result signing is not measured in chain blocks, the submission
+	// turns start with the chain block at which the signing is over.
+	if clock != state.Clock(blockCounter) {
+		signingEndBlockChannel, err := clock.BlockHeightWaiter(
+			submissionStartBlockHeight,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case dkgResultEvent := <-dkgResultChannel:
+			return dkgResultEvent, nil
+		case <-signingEndBlockChannel:
+		case <-ctx.Done():
+			return nil, fmt.Errorf(
+				"waiting for DKG result publication cancelled: [%v]",
+				ctx.Err(),
+			)
+		}
+
+		submissionStartBlockHeight, err = blockCounter.CurrentBlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The event of a result submitted in the last turn is delivered only
+	// once it is confirmed, so the timeout covers the confirmation depth.
+	timeoutBlock := submissionStartBlockHeight +
+		(uint64(config.GroupSize) * config.ResultPublicationBlockStep) +
+		config.EventConfirmationDepth
 
 	timeoutBlockChannel, err := blockCounter.BlockHeightWaiter(timeoutBlock)
 	if err != nil {
@@ -192,5 +320,10 @@ func waitForDkgResultEvent(
 		return dkgResultEvent, nil
 	case <-timeoutBlockChannel:
 		return nil, fmt.Errorf("DKG result publication timed out")
+	case <-ctx.Done():
+		return nil, fmt.Errorf(
+			"waiting for DKG result publication cancelled: [%v]",
+			ctx.Err(),
+		)
 	}
 }
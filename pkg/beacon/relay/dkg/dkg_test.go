@@ -1,12 +1,15 @@
 package dkg
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
@@ -43,12 +46,14 @@ func TestDecideMemberFate_HappyPath(t *testing.T) {
 	}
 
 	err := decideMemberFate(
+		context.Background(),
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
 		startPublicationBlockHeight,
 		localChain.ThresholdRelay(),
 		blockCounter,
+		blockCounter,
 	)
 
 	if err != nil {
@@ -70,12 +75,14 @@ func TestDecideMemberFate_NotSameGroupPublicKey(t *testing.T) {
 	}
 
 	err := decideMemberFate(
+		context.Background(),
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
 		startPublicationBlockHeight,
 		localChain.ThresholdRelay(),
 		blockCounter,
+		blockCounter,
 	)
 
 	expectedError := fmt.Errorf(
@@ -101,12 +108,14 @@ func TestDecideMemberFate_MemberIsMisbehaved(t *testing.T) {
 	}
 
 	err := decideMemberFate(
+		context.Background(),
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
 		startPublicationBlockHeight,
 		localChain.ThresholdRelay(),
 		blockCounter,
+		blockCounter,
 	)
 
 	expectedError := fmt.Errorf(
@@ -127,12 +136,14 @@ func TestDecideMemberFate_Timeout(t *testing.T) {
 	setup()
 
 	err := decideMemberFate(
+		context.Background(),
 		playerIndex,
 		gjkrResult,
 		dkgResultChannel,
 		startPublicationBlockHeight,
 		localChain.ThresholdRelay(),
 		blockCounter,
+		blockCounter,
 	)
 
 	expectedError := fmt.Errorf("DKG result publication timed out")
@@ -144,3 +155,72 @@ func TestDecideMemberFate_Timeout(t *testing.T) {
 		)
 	}
 }
+
+func TestWaitForDkgResultEvent_ConfirmedAfterLastTurn(t *testing.T) {
+	setup()
+
+	startPublicationBlockHeight, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	relayChain := &confirmingRelayChain{
+		Interface:         localChain.ThresholdRelay(),
+		confirmationDepth: 4,
+	}
+
+	chainConfig, err := relayChain.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastTurnEndBlock := startPublicationBlockHeight +
+		chainConfig.DKGTiming.ResultSigning.Blocks() +
+		uint64(chainConfig.GroupSize)*chainConfig.ResultPublicationBlockStep
+
+	submittedEvent := &event.DKGResultSubmission{MemberIndex: 5}
+
+	// The result submitted in the last turn is confirmed a few blocks after
+	// all the members had their turn.
+	go func() {
+		blockCounter.WaitForBlockHeight(lastTurnEndBlock + 1)
+		dkgResultChannel <- submittedEvent
+	}()
+
+	receivedEvent, err := waitForDkgResultEvent(
+		context.Background(),
+		dkgResultChannel,
+		startPublicationBlockHeight,
+		relayChain,
+		blockCounter,
+		blockCounter,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if receivedEvent != submittedEvent {
+		t.Errorf(
+			"unexpected event\nexpected: [%v]\nactual:   [%v]",
+			submittedEvent,
+			receivedEvent,
+		)
+	}
+}
+
+type confirmingRelayChain struct {
+	relaychain.Interface
+	confirmationDepth uint64
+}
+
+func (crc *confirmingRelayChain) GetConfig() (*config.Chain, error) {
+	chainConfig, err := crc.Interface.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	confirmingConfig := *chainConfig
+	confirmingConfig.EventConfirmationDepth = crc.confirmationDepth
+
+	return &confirmingConfig, nil
+}
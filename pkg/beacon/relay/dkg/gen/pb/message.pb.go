@@ -0,0 +1,1155 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: pb/message.proto
+
+package pb
+
+import (
+	bytes "bytes"
+	fmt "fmt"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strings "strings"
+
+	proto "github.com/gogo/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type Checkpoint struct {
+	Seed             []byte   `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	Index            uint32   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	SelectedStakers  [][]byte `protobuf:"bytes,3,rep,name=selectedStakers,proto3" json:"selectedStakers,omitempty"`
+	StartBlockHeight uint64   `protobuf:"varint,4,opt,name=startBlockHeight,proto3" json:"startBlockHeight,omitempty"`
+	KeyGeneration    []byte   `protobuf:"bytes,5,opt,name=keyGeneration,proto3" json:"keyGeneration,omitempty"`
+}
+
+func (m *Checkpoint) Reset()      { *m = Checkpoint{} }
+func (*Checkpoint) ProtoMessage() {}
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{0}
+}
+func (m *Checkpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Checkpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint.Merge(m, src)
+}
+func (m *Checkpoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *Checkpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint proto.InternalMessageInfo
+
+func (m *Checkpoint) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetSelectedStakers() [][]byte {
+	if m != nil {
+		return m.SelectedStakers
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetStartBlockHeight() uint64 {
+	if m != nil {
+		return m.StartBlockHeight
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetKeyGeneration() []byte {
+	if m != nil {
+		return m.KeyGeneration
+	}
+	return nil
+}
+
+type Transcript struct {
+	Seed               []byte   `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	Index              uint32   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	SelectedStakers    [][]byte `protobuf:"bytes,3,rep,name=selectedStakers,proto3" json:"selectedStakers,omitempty"`
+	GroupSize          uint32   `protobuf:"varint,4,opt,name=groupSize,proto3" json:"groupSize,omitempty"`
+	DishonestThreshold uint32   `protobuf:"varint,5,opt,name=dishonestThreshold,proto3" json:"dishonestThreshold,omitempty"`
+	StartBlockHeight   uint64   `protobuf:"varint,6,opt,name=startBlockHeight,proto3" json:"startBlockHeight,omitempty"`
+	KeyGeneration      []byte   `protobuf:"bytes,7,opt,name=keyGeneration,proto3" json:"keyGeneration,omitempty"`
+	ResultPublication  []byte   `protobuf:"bytes,8,opt,name=resultPublication,proto3" json:"resultPublication,omitempty"`
+}
+
+func (m *Transcript) Reset()      { *m = Transcript{} }
+func (*Transcript) ProtoMessage() {}
+func (*Transcript) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{1}
+}
+func (m *Transcript) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Transcript) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Transcript.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Transcript) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transcript.Merge(m, src)
+}
+func (m *Transcript) XXX_Size() int {
+	return m.Size()
+}
+func (m *Transcript) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transcript.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transcript proto.InternalMessageInfo
+
+func (m *Transcript) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *Transcript) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *Transcript) GetSelectedStakers() [][]byte {
+	if m != nil {
+		return m.SelectedStakers
+	}
+	return nil
+}
+
+func (m *Transcript) GetGroupSize() uint32 {
+	if m != nil {
+		return m.GroupSize
+	}
+	return 0
+}
+
+func (m *Transcript) GetDishonestThreshold() uint32 {
+	if m != nil {
+		return m.DishonestThreshold
+	}
+	return 0
+}
+
+func (m *Transcript) GetStartBlockHeight() uint64 {
+	if m != nil {
+		return m.StartBlockHeight
+	}
+	return 0
+}
+
+func (m *Transcript) GetKeyGeneration() []byte {
+	if m != nil {
+		return m.KeyGeneration
+	}
+	return nil
+}
+
+func (m *Transcript) GetResultPublication() []byte {
+	if m != nil {
+		return m.ResultPublication
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Checkpoint)(nil), "dkg.Checkpoint")
+	proto.RegisterType((*Transcript)(nil), "dkg.Transcript")
+}
+
+func init() { proto.RegisterFile("pb/message.proto", fileDescriptor_8447775385e7eb85) }
+
+var fileDescriptor_8447775385e7eb85 = []byte{
+	// 327 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x92, 0x3d, 0x4e, 0xeb, 0x40,
+	0x14, 0x85, 0x3d, 0xf9, 0x7b, 0xef, 0x5d, 0xc5, 0x7a, 0x61, 0x44, 0xe1, 0x02, 0x5d, 0x59, 0x11,
+	0x85, 0x85, 0x50, 0x28, 0x68, 0xa8, 0x43, 0x01, 0x25, 0x72, 0x52, 0xd1, 0xf9, 0xe7, 0xca, 0x1e,
+	0xd9, 0x78, 0xac, 0x99, 0x89, 0x04, 0x54, 0x2c, 0x81, 0x65, 0x50, 0x51, 0xb0, 0x0a, 0xca, 0x94,
+	0x29, 0xc9, 0xa4, 0xa1, 0xcc, 0x12, 0x90, 0x9c, 0x02, 0x41, 0x52, 0xd0, 0xd0, 0xcd, 0x7c, 0xe7,
+	0x14, 0x9f, 0x8e, 0x2e, 0x0c, 0xea, 0xf8, 0xe4, 0x86, 0xb4, 0x8e, 0x32, 0x1a, 0xd5, 0x4a, 0x1a,
+	0xc9, 0xdb, 0x69, 0x91, 0x0d, 0x5f, 0x18, 0xc0, 0x79, 0x4e, 0x49, 0x51, 0x4b, 0x51, 0x19, 0xce,
+	0xa1, 0xa3, 0x89, 0x52, 0x8f, 0xf9, 0x2c, 0xe8, 0x87, 0xcd, 0x9b, 0xef, 0x43, 0x57, 0x54, 0x29,
+	0xdd, 0x7a, 0x2d, 0x9f, 0x05, 0x6e, 0xb8, 0xf9, 0xf0, 0x00, 0xfe, 0x6b, 0x2a, 0x29, 0x31, 0x94,
+	0x4e, 0x4c, 0x54, 0x90, 0xd2, 0x5e, 0xdb, 0x6f, 0x07, 0xfd, 0xf0, 0x3b, 0xe6, 0x47, 0x30, 0xd0,
+	0x26, 0x52, 0x66, 0x5c, 0xca, 0xa4, 0xb8, 0x24, 0x91, 0xe5, 0xc6, 0xeb, 0xf8, 0x2c, 0xe8, 0x84,
+	0x5b, 0x9c, 0x1f, 0x82, 0x5b, 0xd0, 0xdd, 0x05, 0x55, 0xa4, 0x22, 0x23, 0x64, 0xe5, 0x75, 0x1b,
+	0x91, 0xaf, 0x70, 0xf8, 0xdc, 0x02, 0x98, 0xaa, 0xa8, 0xd2, 0x89, 0x12, 0xf5, 0xef, 0x48, 0x1f,
+	0xc0, 0xbf, 0x4c, 0xc9, 0x59, 0x3d, 0x11, 0xf7, 0xd4, 0xd8, 0xba, 0xe1, 0x27, 0xe0, 0x23, 0xe0,
+	0xa9, 0xd0, 0xb9, 0xac, 0x48, 0x9b, 0x69, 0xae, 0x48, 0xe7, 0xb2, 0x4c, 0x1b, 0x57, 0x37, 0xdc,
+	0x91, 0xec, 0x9c, 0xa0, 0xf7, 0xd3, 0x09, 0xfe, 0xec, 0x98, 0x80, 0x1f, 0xc3, 0x9e, 0x22, 0x3d,
+	0x2b, 0xcd, 0xd5, 0x2c, 0x2e, 0x45, 0xb2, 0x69, 0xfe, 0x6d, 0x9a, 0xdb, 0xc1, 0xf8, 0x6c, 0xbe,
+	0x44, 0x67, 0xb1, 0x44, 0x67, 0xbd, 0x44, 0xf6, 0x60, 0x91, 0x3d, 0x59, 0x64, 0xaf, 0x16, 0xd9,
+	0xdc, 0x22, 0x7b, 0xb3, 0xc8, 0xde, 0x2d, 0x3a, 0x6b, 0x8b, 0xec, 0x71, 0x85, 0xce, 0x7c, 0x85,
+	0xce, 0x62, 0x85, 0xce, 0x75, 0xab, 0x8e, 0xe3, 0x5e, 0x73, 0x2b, 0xa7, 0x1f, 0x03, 0x00, 0x43,
+	0x94, 0xba, 0x75, 0x3f, 0x02, 0x00, 0x00,
+}
+
+func (this *Checkpoint) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Checkpoint)
+	if !ok {
+		that2, ok := that.(Checkpoint)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Seed, that1.Seed) {
+		return false
+	}
+	if this.Index != that1.Index {
+		return false
+	}
+	if len(this.SelectedStakers) != len(that1.SelectedStakers) {
+		return false
+	}
+	for i := range this.SelectedStakers {
+		if !bytes.Equal(this.SelectedStakers[i], that1.SelectedStakers[i]) {
+			return false
+		}
+	}
+	if this.StartBlockHeight != that1.StartBlockHeight {
+		return false
+	}
+	if !bytes.Equal(this.KeyGeneration, that1.KeyGeneration) {
+		return false
+	}
+	return true
+}
+func (this *Transcript) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Transcript)
+	if !ok {
+		that2, ok := that.(Transcript)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Seed, that1.Seed) {
+		return false
+	}
+	if this.Index != that1.Index {
+		return false
+	}
+	if len(this.SelectedStakers) != len(that1.SelectedStakers) {
+		return false
+	}
+	for i := range this.SelectedStakers {
+		if !bytes.Equal(this.SelectedStakers[i], that1.SelectedStakers[i]) {
+			return false
+		}
+	}
+	if this.GroupSize != that1.GroupSize {
+		return false
+	}
+	if this.DishonestThreshold != that1.DishonestThreshold {
+		return false
+	}
+	if this.StartBlockHeight != that1.StartBlockHeight {
+		return false
+	}
+	if !bytes.Equal(this.KeyGeneration, that1.KeyGeneration) {
+		return false
+	}
+	if !bytes.Equal(this.ResultPublication, that1.ResultPublication) {
+		return false
+	}
+	return true
+}
+func (this *Checkpoint) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.Checkpoint{")
+	s = append(s, "Seed: "+fmt.Sprintf("%#v", this.Seed)+",\n")
+	s = append(s, "Index: "+fmt.Sprintf("%#v", this.Index)+",\n")
+	s = append(s, "SelectedStakers: "+fmt.Sprintf("%#v", this.SelectedStakers)+",\n")
+	s = append(s, "StartBlockHeight: "+fmt.Sprintf("%#v", this.StartBlockHeight)+",\n")
+	s = append(s, "KeyGeneration: "+fmt.Sprintf("%#v", this.KeyGeneration)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Transcript) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 12)
+	s = append(s, "&pb.Transcript{")
+	s = append(s, "Seed: "+fmt.Sprintf("%#v", this.Seed)+",\n")
+	s = append(s, "Index: "+fmt.Sprintf("%#v", this.Index)+",\n")
+	s = append(s, "SelectedStakers: "+fmt.Sprintf("%#v", this.SelectedStakers)+",\n")
+	s = append(s, "GroupSize: "+fmt.Sprintf("%#v", this.GroupSize)+",\n")
+	s = append(s, "DishonestThreshold: "+fmt.Sprintf("%#v", this.DishonestThreshold)+",\n")
+	s = append(s, "StartBlockHeight: "+fmt.Sprintf("%#v", this.StartBlockHeight)+",\n")
+	s = append(s, "KeyGeneration: "+fmt.Sprintf("%#v", this.KeyGeneration)+",\n")
+	s = append(s, "ResultPublication: "+fmt.Sprintf("%#v", this.ResultPublication)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringMessage(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *Checkpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Checkpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.KeyGeneration) > 0 {
+		i -= len(m.KeyGeneration)
+		copy(dAtA[i:], m.KeyGeneration)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.KeyGeneration)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.StartBlockHeight != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.StartBlockHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.SelectedStakers) > 0 {
+		for iNdEx := len(m.SelectedStakers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.SelectedStakers[iNdEx])
+			copy(dAtA[i:], m.SelectedStakers[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.SelectedStakers[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Index != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Seed) > 0 {
+		i -= len(m.Seed)
+		copy(dAtA[i:], m.Seed)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Seed)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Transcript) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Transcript) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Transcript) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ResultPublication) > 0 {
+		i -= len(m.ResultPublication)
+		copy(dAtA[i:], m.ResultPublication)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.ResultPublication)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.KeyGeneration) > 0 {
+		i -= len(m.KeyGeneration)
+		copy(dAtA[i:], m.KeyGeneration)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.KeyGeneration)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.StartBlockHeight != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.StartBlockHeight))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.DishonestThreshold != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.DishonestThreshold))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.GroupSize != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.GroupSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.SelectedStakers) > 0 {
+		for iNdEx := len(m.SelectedStakers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.SelectedStakers[iNdEx])
+			copy(dAtA[i:], m.SelectedStakers[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.SelectedStakers[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Index != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Seed) > 0 {
+		i -= len(m.Seed)
+		copy(dAtA[i:], m.Seed)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Seed)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
+	offset -= sovMessage(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Checkpoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Seed)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovMessage(uint64(m.Index))
+	}
+	if len(m.SelectedStakers) > 0 {
+		for _, b := range m.SelectedStakers {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	if m.StartBlockHeight != 0 {
+		n += 1 + sovMessage(uint64(m.StartBlockHeight))
+	}
+	l = len(m.KeyGeneration)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	return n
+}
+
+func (m *Transcript) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Seed)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovMessage(uint64(m.Index))
+	}
+	if len(m.SelectedStakers) > 0 {
+		for _, b := range m.SelectedStakers {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	if m.GroupSize != 0 {
+		n += 1 + sovMessage(uint64(m.GroupSize))
+	}
+	if m.DishonestThreshold != 0 {
+		n += 1 + sovMessage(uint64(m.DishonestThreshold))
+	}
+	if m.StartBlockHeight != 0 {
+		n += 1 + sovMessage(uint64(m.StartBlockHeight))
+	}
+	l = len(m.KeyGeneration)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.ResultPublication)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	return n
+}
+
+func sovMessage(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozMessage(x uint64) (n int) {
+	return sovMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Checkpoint) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Checkpoint{`,
+		`Seed:` + fmt.Sprintf("%v", this.Seed) + `,`,
+		`Index:` + fmt.Sprintf("%v", this.Index) + `,`,
+		`SelectedStakers:` + fmt.Sprintf("%v", this.SelectedStakers) + `,`,
+		`StartBlockHeight:` + fmt.Sprintf("%v", this.StartBlockHeight) + `,`,
+		`KeyGeneration:` + fmt.Sprintf("%v", this.KeyGeneration) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Transcript) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Transcript{`,
+		`Seed:` + fmt.Sprintf("%v", this.Seed) + `,`,
+		`Index:` + fmt.Sprintf("%v", this.Index) + `,`,
+		`SelectedStakers:` + fmt.Sprintf("%v", this.SelectedStakers) + `,`,
+		`GroupSize:` + fmt.Sprintf("%v", this.GroupSize) + `,`,
+		`DishonestThreshold:` + fmt.Sprintf("%v", this.DishonestThreshold) + `,`,
+		`StartBlockHeight:` + fmt.Sprintf("%v", this.StartBlockHeight) + `,`,
+		`KeyGeneration:` + fmt.Sprintf("%v", this.KeyGeneration) + `,`,
+		`ResultPublication:` + fmt.Sprintf("%v", this.ResultPublication) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringMessage(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Checkpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Checkpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Checkpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seed", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Seed = append(m.Seed[:0], dAtA[iNdEx:postIndex]...)
+			if m.Seed == nil {
+				m.Seed = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelectedStakers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SelectedStakers = append(m.SelectedStakers, make([]byte, postIndex-iNdEx))
+			copy(m.SelectedStakers[len(m.SelectedStakers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartBlockHeight", wireType)
+			}
+			m.StartBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartBlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyGeneration", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyGeneration = append(m.KeyGeneration[:0], dAtA[iNdEx:postIndex]...)
+			if m.KeyGeneration == nil {
+				m.KeyGeneration = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Transcript) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Transcript: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Transcript: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seed", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Seed = append(m.Seed[:0], dAtA[iNdEx:postIndex]...)
+			if m.Seed == nil {
+				m.Seed = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelectedStakers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SelectedStakers = append(m.SelectedStakers, make([]byte, postIndex-iNdEx))
+			copy(m.SelectedStakers[len(m.SelectedStakers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupSize", wireType)
+			}
+			m.GroupSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DishonestThreshold", wireType)
+			}
+			m.DishonestThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DishonestThreshold |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartBlockHeight", wireType)
+			}
+			m.StartBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartBlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyGeneration", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyGeneration = append(m.KeyGeneration[:0], dAtA[iNdEx:postIndex]...)
+			if m.KeyGeneration == nil {
+				m.KeyGeneration = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultPublication", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResultPublication = append(m.ResultPublication[:0], dAtA[iNdEx:postIndex]...)
+			if m.ResultPublication == nil {
+				m.ResultPublication = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipMessage(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthMessage
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupMessage
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthMessage
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthMessage        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowMessage          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupMessage = fmt.Errorf("proto: unexpected end of group")
+)
@@ -2,11 +2,16 @@ package dkg
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	dkgpb "github.com/keep-network/keep-core/pkg/beacon/relay/dkg/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 )
 
 // Marshal converts ThresholdSigner to byte array.
@@ -82,3 +87,172 @@ func unmarshalGroupPublicKeyShares(
 
 	return unmarshalled, nil
 }
+
+// Marshal converts Checkpoint to a byte array. The result contains secrets of
+// the member and must be persisted only in an encrypted form.
+func (c *Checkpoint) Marshal() ([]byte, error) {
+	selectedStakers := make([][]byte, 0, len(c.SelectedStakers))
+	for _, staker := range c.SelectedStakers {
+		selectedStakers = append(selectedStakers, staker)
+	}
+
+	var keyGeneration []byte
+	if c.KeyGeneration != nil {
+		var err error
+		keyGeneration, err = c.KeyGeneration.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not marshal key generation checkpoint [%v]",
+				err,
+			)
+		}
+	}
+
+	return (&dkgpb.Checkpoint{
+		Seed:             c.Seed.Bytes(),
+		Index:            uint32(c.Index),
+		SelectedStakers:  selectedStakers,
+		StartBlockHeight: c.StartBlockHeight,
+		KeyGeneration:    keyGeneration,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array back to Checkpoint.
+func (c *Checkpoint) Unmarshal(bytes []byte) error {
+	pbCheckpoint := dkgpb.Checkpoint{}
+	if err := pbCheckpoint.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	if pbCheckpoint.Index > math.MaxUint8 {
+		return fmt.Errorf("invalid member index [%v]", pbCheckpoint.Index)
+	}
+
+	selectedStakers := make(
+		[]relayChain.StakerAddress,
+		0,
+		len(pbCheckpoint.SelectedStakers),
+	)
+	for _, staker := range pbCheckpoint.SelectedStakers {
+		selectedStakers = append(selectedStakers, staker)
+	}
+
+	var keyGeneration *gjkr.Checkpoint
+	if len(pbCheckpoint.KeyGeneration) > 0 {
+		keyGeneration = &gjkr.Checkpoint{}
+		if err := keyGeneration.Unmarshal(pbCheckpoint.KeyGeneration); err != nil {
+			return fmt.Errorf(
+				"could not unmarshal key generation checkpoint [%v]",
+				err,
+			)
+		}
+	}
+
+	c.Seed = new(big.Int).SetBytes(pbCheckpoint.Seed)
+	c.Index = uint8(pbCheckpoint.Index)
+	c.SelectedStakers = selectedStakers
+	c.StartBlockHeight = pbCheckpoint.StartBlockHeight
+	c.KeyGeneration = keyGeneration
+
+	return nil
+}
+
+// Marshal converts Transcript to a byte array. The result contains secrets of
+// the member and must be persisted only in an encrypted form.
+func (t *Transcript) Marshal() ([]byte, error) {
+	selectedStakers := make([][]byte, 0, len(t.SelectedStakers))
+	for _, staker := range t.SelectedStakers {
+		selectedStakers = append(selectedStakers, staker)
+	}
+
+	var keyGeneration []byte
+	if t.KeyGeneration != nil {
+		var err error
+		keyGeneration, err = t.KeyGeneration.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not marshal key generation transcript [%v]",
+				err,
+			)
+		}
+	}
+
+	var resultPublication []byte
+	if t.ResultPublication != nil {
+		var err error
+		resultPublication, err = t.ResultPublication.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not marshal result publication transcript [%v]",
+				err,
+			)
+		}
+	}
+
+	return (&dkgpb.Transcript{
+		Seed:               t.Seed.Bytes(),
+		Index:              uint32(t.Index),
+		SelectedStakers:    selectedStakers,
+		GroupSize:          uint32(t.GroupSize),
+		DishonestThreshold: uint32(t.DishonestThreshold),
+		StartBlockHeight:   t.StartBlockHeight,
+		KeyGeneration:      keyGeneration,
+		ResultPublication:  resultPublication,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array back to Transcript.
+func (t *Transcript) Unmarshal(bytes []byte) error {
+	pbTranscript := dkgpb.Transcript{}
+	if err := pbTranscript.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	if pbTranscript.Index > math.MaxUint8 {
+		return fmt.Errorf("invalid member index [%v]", pbTranscript.Index)
+	}
+
+	selectedStakers := make(
+		[]relayChain.StakerAddress,
+		0,
+		len(pbTranscript.SelectedStakers),
+	)
+	for _, staker := range pbTranscript.SelectedStakers {
+		selectedStakers = append(selectedStakers, staker)
+	}
+
+	var keyGeneration *gjkr.Transcript
+	if len(pbTranscript.KeyGeneration) > 0 {
+		keyGeneration = &gjkr.Transcript{}
+		if err := keyGeneration.Unmarshal(pbTranscript.KeyGeneration); err != nil {
+			return fmt.Errorf(
+				"could not unmarshal key generation transcript [%v]",
+				err,
+			)
+		}
+	}
+
+	var resultPublication *state.Transcript
+	if len(pbTranscript.ResultPublication) > 0 {
+		resultPublication = &state.Transcript{}
+		if err := resultPublication.Unmarshal(
+			pbTranscript.ResultPublication,
+		); err != nil {
+			return fmt.Errorf(
+				"could not unmarshal result publication transcript [%v]",
+				err,
+			)
+		}
+	}
+
+	t.Seed = new(big.Int).SetBytes(pbTranscript.Seed)
+	t.Index = uint8(pbTranscript.Index)
+	t.SelectedStakers = selectedStakers
+	t.GroupSize = int(pbTranscript.GroupSize)
+	t.DishonestThreshold = int(pbTranscript.DishonestThreshold)
+	t.StartBlockHeight = pbTranscript.StartBlockHeight
+	t.KeyGeneration = keyGeneration
+	t.ResultPublication = resultPublication
+
+	return nil
+}
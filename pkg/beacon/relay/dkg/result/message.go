@@ -25,3 +25,8 @@ type DKGResultHashSignatureMessage struct {
 func (m *DKGResultHashSignatureMessage) SenderID() group.MemberIndex {
 	return m.senderIndex
 }
+
+// ResultHash returns the hash of the DKG result preferred by the sender.
+func (m *DKGResultHashSignatureMessage) ResultHash() chain.DKGResultHash {
+	return m.resultHash
+}
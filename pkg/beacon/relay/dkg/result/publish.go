@@ -1,9 +1,11 @@
 package result
 
 import (
+	"context"
 	"fmt"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
@@ -25,8 +27,15 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 // chosen result is hashed, signed, and sent over a broadcast channel. Then, all
 // other signatures and results are received and accounted for. Those that match
 // our own result and added to the list of votes. Finally, we submit the result
-// along with everyone's votes.
+// along with everyone's votes. The result is signed within the result signing
+// timing of the given DKG timing, measured with the given clock. The result is
+// submitted to the chain in turns measured with the chain block counter; if
+// the clock is not the block counter, the turns are counted from the chain
+// block at which the submission starts. The publication is abandoned when the
+// given context is done. If the transcript recorder is not nil, the
+// publication is recorded to it.
 func Publish(
+	ctx context.Context,
 	memberIndex group.MemberIndex,
 	dkgGroup *group.Group,
 	membershipValidator group.MembershipValidator,
@@ -34,23 +43,38 @@ func Publish(
 	channel net.BroadcastChannel,
 	relayChain relayChain.Interface,
 	signing chain.Signing,
+	clock state.Clock,
 	blockCounter chain.BlockCounter,
+	timing config.DKGTiming,
 	startBlockHeight uint64,
+	transcriptRecorder *state.TranscriptRecorder,
 ) error {
+	if transcriptRecorder != nil {
+		channel = transcriptRecorder.Channel(channel)
+	}
+
 	initialState := &resultSigningState{
 		channel:                 channel,
 		relayChain:              relayChain,
 		signing:                 signing,
+		clock:                   clock,
 		blockCounter:            blockCounter,
 		member:                  NewSigningMember(memberIndex, dkgGroup, membershipValidator),
 		result:                  convertGjkrResult(result),
 		signatureMessages:       make([]*DKGResultHashSignatureMessage, 0),
+		timing:                  timing.ResultSigning,
 		signingStartBlockHeight: startBlockHeight,
 	}
 
-	stateMachine := state.NewMachine(channel, blockCounter, initialState)
+	stateMachine := state.NewMachine(channel, clock, initialState)
+	if timing.WaitForActiveBlocks {
+		stateMachine.WaitForActiveBlocks()
+	}
+	if transcriptRecorder != nil {
+		stateMachine.RecordTranscript(transcriptRecorder)
+	}
 
-	lastState, _, err := stateMachine.Execute(startBlockHeight)
+	lastState, _, err := stateMachine.Execute(ctx, startBlockHeight)
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 	"context"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 	"github.com/keep-network/keep-core/pkg/chain"
@@ -14,17 +15,6 @@ import (
 // represents a given state in the state machine for signing dkg results
 type signingState = state.State
 
-const resultSigningStateDelayBlocks = 1
-const resultSigningStateActiveBlocks = 5
-
-// PrePublicationBlocks returns the total number of blocks it takes to execute
-// all the required work to get ready for the result publication or to decide
-// to skip the publication because there are not enough supporters of
-// the given result.
-func PrePublicationBlocks() uint64 {
-	return resultSigningStateDelayBlocks + resultSigningStateActiveBlocks
-}
-
 // resultSigningState is the state during which group members sign their preferred
 // dkg result (by hashing their dkg result, and then signing the result), and
 // share this over the broadcast channel.
@@ -34,6 +24,7 @@ type resultSigningState struct {
 	channel      net.BroadcastChannel
 	relayChain   relayChain.Interface
 	signing      chain.Signing
+	clock        state.Clock
 	blockCounter chain.BlockCounter
 
 	member *SigningMember
@@ -42,15 +33,16 @@ type resultSigningState struct {
 
 	signatureMessages []*DKGResultHashSignatureMessage
 
+	timing                  config.PhaseTiming
 	signingStartBlockHeight uint64
 }
 
 func (rss *resultSigningState) DelayBlocks() uint64 {
-	return resultSigningStateDelayBlocks
+	return rss.timing.DelayBlocks
 }
 
 func (rss *resultSigningState) ActiveBlocks() uint64 {
-	return resultSigningStateActiveBlocks
+	return rss.timing.ActiveBlocks
 }
 
 func (rss *resultSigningState) Initiate(ctx context.Context) error {
@@ -97,12 +89,25 @@ func (rss *resultSigningState) Receive(msg net.Message) error {
 	return nil
 }
 
+func (rss *resultSigningState) IsComplete() bool {
+	senders := make([]group.MemberIndex, 0)
+	for _, message := range rss.signatureMessages {
+		senders = append(senders, message.SenderID())
+	}
+
+	return rss.member.group.IsReceivedFromAllOperatingMembers(
+		rss.member.index,
+		senders,
+	)
+}
+
 func (rss *resultSigningState) Next() signingState {
 	// set up the verification state, phase 13 part 2
 	return &signaturesVerificationState{
 		channel:           rss.channel,
 		relayChain:        rss.relayChain,
 		signing:           rss.signing,
+		clock:             rss.clock,
 		blockCounter:      rss.blockCounter,
 		member:            rss.member,
 		result:            rss.result,
@@ -128,6 +133,7 @@ type signaturesVerificationState struct {
 	channel      net.BroadcastChannel
 	relayChain   relayChain.Interface
 	signing      chain.Signing
+	clock        state.Clock
 	blockCounter chain.BlockCounter
 
 	member *SigningMember
@@ -169,6 +175,7 @@ func (svs *signaturesVerificationState) Next() signingState {
 	return &resultSubmissionState{
 		channel:      svs.channel,
 		relayChain:   svs.relayChain,
+		clock:        svs.clock,
 		blockCounter: svs.blockCounter,
 		member:       NewSubmittingMember(svs.member.index),
 		result:       svs.result,
@@ -191,6 +198,7 @@ func (svs *signaturesVerificationState) MemberIndex() group.MemberIndex {
 type resultSubmissionState struct {
 	channel      net.BroadcastChannel
 	relayChain   relayChain.Interface
+	clock        state.Clock
 	blockCounter chain.BlockCounter
 
 	member *SubmittingMember
@@ -214,12 +222,24 @@ func (rss *resultSubmissionState) ActiveBlocks() uint64 {
 }
 
 func (rss *resultSubmissionState) Initiate(ctx context.Context) error {
+	// Members take turns in submitting the result measured in chain blocks.
+	// If the previous states are not measured in chain blocks, the turns
+	// start with the current chain block.
+	submissionStartBlockHeight := rss.submissionStartBlockHeight
+	if rss.clock != state.Clock(rss.blockCounter) {
+		currentBlock, err := rss.blockCounter.CurrentBlock()
+		if err != nil {
+			return err
+		}
+		submissionStartBlockHeight = currentBlock
+	}
+
 	return rss.member.SubmitDKGResult(
 		rss.result,
 		rss.signatures,
 		rss.relayChain,
 		rss.blockCounter,
-		rss.submissionStartBlockHeight,
+		submissionStartBlockHeight,
 	)
 }
 
@@ -0,0 +1,34 @@
+package dkg
+
+import (
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+// readAll passes descriptors of all the data kept by the given persistence
+// handle to the given function, one by one, and returns once all of them have
+// been passed. Errors of reading the data are reported as errors of reading
+// the given kind of data and skipped. Content of the data is read only if the
+// given function asks the descriptor for it.
+func readAll(
+	handle persistence.Handle,
+	dataKind string,
+	read func(descriptor persistence.DataDescriptor),
+) {
+	inputData, inputErrors := handle.ReadAll()
+
+	// Data and errors are written concurrently so errors are consumed in
+	// a separate goroutine.
+	errorsDone := make(chan struct{})
+	go func() {
+		defer close(errorsDone)
+		for err := range inputErrors {
+			logger.Errorf("could not read %v: [%v]", dataKind, err)
+		}
+	}()
+
+	for descriptor := range inputData {
+		read(descriptor)
+	}
+
+	<-errorsDone
+}
@@ -0,0 +1,344 @@
+package dkg
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	dkgResult "github.com/keep-network/keep-core/pkg/beacon/relay/dkg/result"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// transcriptFileName is the name of the file holding the transcript in the
+// directory of the member.
+const transcriptFileName = "/transcript"
+
+// savedAtFileName is the name of the file holding the time the transcript was
+// saved, in the directory of the member.
+const savedAtFileName = "/saved_at"
+
+// TranscriptConfig is the configuration of DKG transcript recording.
+type TranscriptConfig struct {
+	// Record enables recording of DKG transcripts. Transcripts hold secrets
+	// from which the key share of the member can be reconstructed, so they
+	// are not recorded unless enabled.
+	Record bool
+	// RetentionDays is the number of days transcripts are kept before they
+	// are securely erased. Required if transcripts are recorded.
+	RetentionDays int
+}
+
+// Validate checks if the transcript configuration is correct.
+func (tc *TranscriptConfig) Validate() error {
+	if tc.Record && tc.RetentionDays <= 0 {
+		return fmt.Errorf(
+			"transcript retention days must be positive " +
+				"if transcripts are recorded",
+		)
+	}
+
+	return nil
+}
+
+// Transcript is the record of DKG executed by one member. It holds all
+// messages the member received and sent during the key generation and the
+// result publication, along with boundaries of protocol phases and block
+// heights, so that DKG can be audited after it is over. Transcript contains
+// secrets of the member and must be persisted only in an encrypted form.
+type Transcript struct {
+	// Seed of the group selection which selected the group.
+	Seed *big.Int
+	// Index of the member in the group; starts with 0.
+	Index uint8
+	// Stakers selected to the group, in the order of member indexes.
+	SelectedStakers    []relayChain.StakerAddress
+	GroupSize          int
+	DishonestThreshold int
+	// Block at which DKG started.
+	StartBlockHeight uint64
+	// Transcript of the key generation; nil if the key generation has not
+	// started.
+	KeyGeneration *gjkr.Transcript
+	// Transcript of the result publication; nil if the result publication
+	// has not started.
+	ResultPublication *state.Transcript
+}
+
+// directory returns the name of the directory holding the transcript. There
+// is a separate directory for each member of each group.
+func (t *Transcript) directory() string {
+	return fmt.Sprintf("dkg_%s_%d", t.Seed.Text(16), t.Index)
+}
+
+// TranscriptRecorder records the transcript of DKG executed by one member.
+type TranscriptRecorder struct {
+	keyGeneration     *gjkr.TranscriptRecorder
+	resultPublication *state.TranscriptRecorder
+}
+
+// NewTranscriptRecorder returns a recorder reading block heights of recorded
+// messages from the given clock, the one DKG is executed with.
+func NewTranscriptRecorder(clock state.Clock) *TranscriptRecorder {
+	return &TranscriptRecorder{
+		keyGeneration:     gjkr.NewTranscriptRecorder(clock),
+		resultPublication: state.NewTranscriptRecorder(clock),
+	}
+}
+
+// Record sets the key generation and the result publication recorded so far
+// in the given transcript.
+func (tr *TranscriptRecorder) Record(transcript *Transcript) {
+	transcript.KeyGeneration = tr.keyGeneration.Transcript()
+
+	resultPublication := tr.resultPublication.Transcript()
+	if len(resultPublication.Phases) > 0 {
+		transcript.ResultPublication = resultPublication
+	} else {
+		transcript.ResultPublication = nil
+	}
+}
+
+// TranscriptStorage persists DKG transcripts. Transcripts contain secrets of
+// members so the storage should use an encrypted persistence handle and
+// transcripts are erased once the retention period is over.
+type TranscriptStorage struct {
+	handle    persistence.Handle
+	eraser    ArchiveEraser
+	retention time.Duration
+}
+
+// NewTranscriptStorage returns a new transcript storage using the given
+// persistence handle. Transcripts kept for longer than the given number of
+// retention days are erased from the archive of the handle with the given
+// eraser.
+func NewTranscriptStorage(
+	handle persistence.Handle,
+	eraser ArchiveEraser,
+	retentionDays int,
+) *TranscriptStorage {
+	return &TranscriptStorage{
+		handle:    handle,
+		eraser:    eraser,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+// Save persists the transcript, replacing the one previously saved for the
+// same member of the same group. The retention period of the transcript
+// starts when it is saved.
+func (ts *TranscriptStorage) Save(transcript *Transcript) error {
+	transcriptBytes, err := transcript.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling of the transcript failed: [%v]", err)
+	}
+
+	savedAtBytes, err := time.Now().UTC().MarshalText()
+	if err != nil {
+		return fmt.Errorf("marshalling of the saving time failed: [%v]", err)
+	}
+
+	err = ts.handle.Save(
+		transcriptBytes,
+		transcript.directory(),
+		transcriptFileName,
+	)
+	if err != nil {
+		return err
+	}
+
+	return ts.handle.Save(
+		savedAtBytes,
+		transcript.directory(),
+		savedAtFileName,
+	)
+}
+
+// EraseExpired securely erases transcripts kept for longer than the retention
+// period. Transcripts which could not be erased are reported and skipped.
+func (ts *TranscriptStorage) EraseExpired() {
+	deadline := time.Now().Add(-ts.retention)
+
+	expired := make([]string, 0)
+	readAll(ts.handle, "DKG transcripts", func(descriptor persistence.DataDescriptor) {
+		if "/"+descriptor.Name() != savedAtFileName {
+			return
+		}
+
+		content, err := descriptor.Content()
+		if err != nil {
+			logger.Errorf(
+				"could not read saving time of transcript [%v]: [%v]",
+				descriptor.Directory(),
+				err,
+			)
+			return
+		}
+
+		var savedAt time.Time
+		if err := savedAt.UnmarshalText(content); err != nil {
+			logger.Errorf(
+				"could not unmarshal saving time of transcript [%v]: [%v]",
+				descriptor.Directory(),
+				err,
+			)
+			return
+		}
+
+		if savedAt.Before(deadline) {
+			expired = append(expired, descriptor.Directory())
+		}
+	})
+
+	for _, directory := range expired {
+		if err := ts.erase(directory, "retention period expired"); err != nil {
+			logger.Errorf(
+				"could not erase DKG transcript [%v]: [%v]",
+				directory,
+				err,
+			)
+		}
+	}
+}
+
+// erase moves the transcript kept in the given directory to the archive and
+// securely erases it from the archive, stating the given reason.
+func (ts *TranscriptStorage) erase(directory string, reason string) error {
+	if err := ts.handle.Archive(directory); err != nil {
+		return fmt.Errorf("could not archive transcript: [%v]", err)
+	}
+
+	if err := ts.eraser.MarkArchived(directory); err != nil {
+		return fmt.Errorf(
+			"could not record archiving time of transcript: [%v]",
+			err,
+		)
+	}
+
+	return ts.eraser.Erase(directory, reason)
+}
+
+// Read returns the transcript saved in the given directory. The directory is
+// named after the group selection seed and the member index, as
+// dkg_<seed>_<index>.
+func (ts *TranscriptStorage) Read(directory string) (*Transcript, error) {
+	var transcript *Transcript
+	var readErr error
+	readAll(ts.handle, "DKG transcripts", func(descriptor persistence.DataDescriptor) {
+		// Only the content of the requested transcript is read and
+		// decrypted; other transcripts are skipped by their directory.
+		if descriptor.Directory() != directory {
+			return
+		}
+
+		if "/"+descriptor.Name() != transcriptFileName ||
+			transcript != nil ||
+			readErr != nil {
+			return
+		}
+
+		content, err := descriptor.Content()
+		if err != nil {
+			readErr = fmt.Errorf("could not read transcript: [%v]", err)
+			return
+		}
+
+		transcript = &Transcript{}
+		if err := transcript.Unmarshal(content); err != nil {
+			readErr = fmt.Errorf("could not unmarshal transcript: [%v]", err)
+			transcript = nil
+		}
+	})
+
+	if readErr != nil {
+		return nil, readErr
+	}
+	if transcript == nil {
+		return nil, fmt.Errorf("no transcript in directory [%v]", directory)
+	}
+
+	return transcript, nil
+}
+
+// ReplayReport describes decisions made by a member when DKG recorded in its
+// transcript is replayed.
+type ReplayReport struct {
+	// KeyGeneration is the report of the replayed key generation.
+	KeyGeneration *gjkr.ReplayReport
+	// ResultSupporters are members which signed each of DKG results in the
+	// result publication, in the order their signatures were received.
+	ResultSupporters map[relayChain.DKGResultHash][]group.MemberIndex
+}
+
+// Replay repeats the verification performed by the member during the key
+// generation recorded in the given transcript and collects DKG results
+// supported by members during the result publication. Nothing is sent to the
+// network. The given signing is used to validate memberships of message
+// senders.
+func Replay(transcript *Transcript, signing chain.Signing) (*ReplayReport, error) {
+	if transcript.KeyGeneration == nil {
+		return nil, fmt.Errorf("transcript does not contain the key generation")
+	}
+
+	keyGeneration, err := gjkr.Replay(
+		group.MemberIndex(transcript.Index+1),
+		transcript.GroupSize,
+		transcript.DishonestThreshold,
+		transcript.Seed,
+		group.NewStakersMembershipValidator(transcript.SelectedStakers, signing),
+		transcript.KeyGeneration,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not replay key generation: [%v]", err)
+	}
+
+	report := &ReplayReport{
+		KeyGeneration:    keyGeneration,
+		ResultSupporters: make(map[relayChain.DKGResultHash][]group.MemberIndex),
+	}
+
+	if transcript.ResultPublication == nil {
+		return report, nil
+	}
+
+	unmarshalers := map[string]func() net.TaggedUnmarshaler{
+		(&dkgResult.DKGResultHashSignatureMessage{}).Type(): func() net.TaggedUnmarshaler {
+			return &dkgResult.DKGResultHashSignatureMessage{}
+		},
+	}
+
+	for _, recorded := range transcript.ResultPublication.Messages {
+		// Key generation messages of late members may be received during
+		// the result publication as well; they are not a part of it.
+		if _, ok := unmarshalers[recorded.Type]; recorded.Sent || !ok {
+			continue
+		}
+
+		msg, err := recorded.NetMessage(unmarshalers)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not replay result publication message: [%v]",
+				err,
+			)
+		}
+
+		signatureMessage, ok := msg.Payload().(*dkgResult.DKGResultHashSignatureMessage)
+		if !ok {
+			continue
+		}
+
+		resultHash := signatureMessage.ResultHash()
+		report.ResultSupporters[resultHash] = append(
+			report.ResultSupporters[resultHash],
+			signatureMessage.SenderID(),
+		)
+	}
+
+	return report, nil
+}
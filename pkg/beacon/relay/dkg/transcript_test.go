@@ -0,0 +1,251 @@
+package dkg
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	"github.com/keep-network/keep-core/pkg/internal/pbutils"
+)
+
+func TestTranscriptRoundtrip(t *testing.T) {
+	transcript := &Transcript{
+		Seed:  big.NewInt(31337),
+		Index: 3,
+		SelectedStakers: []relayChain.StakerAddress{
+			[]byte{0x01, 0x02},
+			[]byte{0x03, 0x04},
+		},
+		GroupSize:          5,
+		DishonestThreshold: 2,
+		StartBlockHeight:   1500,
+		ResultPublication: &state.Transcript{
+			StartBlockHeight: 1560,
+			EndBlockHeight:   1580,
+			Phases: []*state.TranscriptPhase{
+				{State: "*result.resultSigningState", StartBlockHeight: 1560},
+			},
+			Messages: []*state.TranscriptMessage{
+				{
+					RecordedMessage: state.RecordedMessage{
+						Type:    "result/dkg_result_hash_signature_message",
+						Payload: []byte{0x05, 0x06},
+					},
+					Sent:        true,
+					BlockHeight: 1561,
+				},
+			},
+		},
+	}
+	unmarshaled := &Transcript{}
+
+	err := pbutils.RoundTrip(transcript, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(transcript, unmarshaled) {
+		t.Fatalf(
+			"unexpected content of unmarshaled transcript\n"+
+				"expected: [%+v]\nactual:   [%+v]",
+			transcript,
+			unmarshaled,
+		)
+	}
+}
+
+func TestTranscriptStorage(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "dkg_transcripts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	handle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storage := NewTranscriptStorage(
+		persistence.NewEncryptedPersistence(handle, "password"),
+		&stubArchiveEraser{},
+		1,
+	)
+
+	transcript := &Transcript{
+		Seed:               big.NewInt(100),
+		Index:              1,
+		SelectedStakers:    []relayChain.StakerAddress{[]byte{0x01, 0x02}},
+		GroupSize:          1,
+		DishonestThreshold: 0,
+		StartBlockHeight:   10,
+	}
+	updatedTranscript := &Transcript{
+		Seed:               big.NewInt(100),
+		Index:              1,
+		SelectedStakers:    []relayChain.StakerAddress{[]byte{0x01, 0x02}},
+		GroupSize:          1,
+		DishonestThreshold: 0,
+		StartBlockHeight:   12,
+	}
+
+	for _, transcript := range []*Transcript{transcript, updatedTranscript} {
+		if err := storage.Save(transcript); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saved, err := storage.Read(transcript.directory())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(updatedTranscript, saved) {
+		t.Errorf(
+			"unexpected transcript\nexpected: [%+v]\nactual:   [%+v]",
+			updatedTranscript,
+			saved,
+		)
+	}
+
+	if _, err := storage.Read("dkg_64_2"); err == nil {
+		t.Errorf("expected error for a missing transcript")
+	}
+}
+
+func TestTranscriptStorageReadsOnlyRequestedTranscript(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "dkg_transcripts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	handle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storage := NewTranscriptStorage(
+		persistence.NewEncryptedPersistence(handle, "password"),
+		&stubArchiveEraser{},
+		1,
+	)
+	// The other transcript can not be decrypted with the password of the
+	// storage so reading it would fail.
+	otherStorage := NewTranscriptStorage(
+		persistence.NewEncryptedPersistence(handle, "other password"),
+		&stubArchiveEraser{},
+		1,
+	)
+
+	transcript := &Transcript{
+		Seed:             big.NewInt(100),
+		Index:            1,
+		SelectedStakers:  []relayChain.StakerAddress{[]byte{0x01, 0x02}},
+		GroupSize:        1,
+		StartBlockHeight: 10,
+	}
+	otherTranscript := &Transcript{
+		Seed:             big.NewInt(100),
+		Index:            2,
+		SelectedStakers:  []relayChain.StakerAddress{[]byte{0x01, 0x02}},
+		GroupSize:        1,
+		StartBlockHeight: 10,
+	}
+
+	if err := storage.Save(transcript); err != nil {
+		t.Fatal(err)
+	}
+	if err := otherStorage.Save(otherTranscript); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := storage.Read(transcript.directory())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(transcript, saved) {
+		t.Errorf(
+			"unexpected transcript\nexpected: [%+v]\nactual:   [%+v]",
+			transcript,
+			saved,
+		)
+	}
+}
+
+func TestEraseExpiredTranscripts(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "dkg_transcripts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	handle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transcript := &Transcript{
+		Seed:               big.NewInt(100),
+		Index:              1,
+		SelectedStakers:    []relayChain.StakerAddress{[]byte{0x01, 0x02}},
+		GroupSize:          1,
+		DishonestThreshold: 0,
+		StartBlockHeight:   10,
+	}
+
+	var tests = map[string]struct {
+		retentionDays  int
+		expectedErased []string
+	}{
+		"retention period not over": {
+			retentionDays:  1,
+			expectedErased: nil,
+		},
+		"retention period over": {
+			retentionDays:  0,
+			expectedErased: []string{transcript.directory()},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			eraser := &stubArchiveEraser{}
+			storage := NewTranscriptStorage(
+				persistence.NewEncryptedPersistence(handle, "password"),
+				eraser,
+				test.retentionDays,
+			)
+
+			if err := storage.Save(transcript); err != nil {
+				t.Fatal(err)
+			}
+
+			storage.EraseExpired()
+
+			if !reflect.DeepEqual(test.expectedErased, eraser.erased) {
+				t.Errorf(
+					"unexpected erased transcripts\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedErased,
+					eraser.erased,
+				)
+			}
+
+			_, err := storage.Read(transcript.directory())
+			if erased := err != nil; erased != (len(test.expectedErased) > 0) {
+				t.Errorf(
+					"unexpected transcript presence after erasure: [%v]",
+					err,
+				)
+			}
+		})
+	}
+}
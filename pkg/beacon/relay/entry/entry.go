@@ -3,6 +3,8 @@ package entry
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 
@@ -29,23 +31,46 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 
 // SignAndSubmit triggers the threshold signature process for the
 // previous relay entry and publishes the signature to the chain as
-// a new relay entry.
+// a new relay entry. The process is abandoned when the given context is done.
+//
+// All the given signers have to be members of the same group. They take part
+// in the process together: signature shares of all of them are broadcast,
+// received signature shares are verified once for all of them, and the
+// signature is submitted once, in the turn of the signer with the lowest
+// member index.
 func SignAndSubmit(
+	parentCtx context.Context,
 	blockCounter chain.BlockCounter,
 	channel net.BroadcastChannel,
 	relayChain relayChain.Interface,
 	previousEntryBytes []byte,
 	honestThreshold int,
-	signer *dkg.ThresholdSigner,
+	signers []*dkg.ThresholdSigner,
 	startBlockHeight uint64,
 ) error {
-	ctx, cancelCtx := context.WithCancel(context.Background())
+	if len(signers) == 0 {
+		return fmt.Errorf("no signers provided")
+	}
+
+	// Signers are ordered by member index so that the first signer is the
+	// one which is the first eligible to submit the relay entry.
+	signers = sortedByMemberID(signers)
+	signer := signers[0]
+	memberIDs := make([]group.MemberIndex, len(signers))
+	for i, s := range signers {
+		memberIDs[i] = s.MemberID()
+	}
+
+	ctx, cancelCtx := context.WithCancel(parentCtx)
 	defer cancelCtx()
 
-	relayEntrySubmittedChannel := make(chan uint64)
+	relayEntrySubmittedChannel := make(chan uint64, 1)
 	subscription, err := relayChain.OnRelayEntrySubmitted(
 		func(event *event.EntrySubmitted) {
-			relayEntrySubmittedChannel <- event.BlockNumber
+			select {
+			case relayEntrySubmittedChannel <- event.BlockNumber:
+			default:
+			}
 		},
 	)
 	if err != nil {
@@ -71,59 +96,73 @@ func SignAndSubmit(
 		return err
 	}
 
-	selfShare := signer.CalculateSignatureShare(previousEntry)
+	signingStartTime := time.Now()
+
+	receivedShares := newSignatureShares()
+	for _, s := range signers {
+		selfShare := s.CalculateSignatureShare(previousEntry)
+		receivedShares.valid[s.MemberID()] = selfShare
 
-	go broadcastShare(ctx, signer.MemberID(), selfShare, channel)
+		go broadcastShare(ctx, s.MemberID(), selfShare, channel)
+	}
 
 	receiveChannel := make(chan net.Message, 64)
 	channel.Recv(ctx, func(netMessage net.Message) {
 		receiveChannel <- netMessage
 	})
 
-	receivedValidShares := map[group.MemberIndex]*bn256.G1{
-		signer.MemberID(): selfShare,
-	}
-
 	// Run the message loop until the number of received and valid signature
 	// shares is equal to the honest threshold. Message loop will be also
 	// terminated if an other member submits the result or the relay entry
 	// timeout block is reached.
-	for len(receivedValidShares) < honestThreshold {
+	for len(receivedShares.valid) < honestThreshold {
 		select {
 		case netMessage := <-receiveChannel:
 			message, ok := netMessage.Payload().(*SignatureShareMessage)
-			if !ok || group.IsMessageFromSelf(signer.MemberID(), message) {
+			if !ok {
 				continue
 			}
 
-			share, err := extractAndValidateShare(
-				message,
-				signer.GroupPublicKeyShares(),
-				previousEntry,
-			)
+			// Only the first share of each member is considered. Shares of
+			// the local signers and shares already accepted, waiting for
+			// verification or rejected are not verified again.
+			if receivedShares.has(message.senderID) {
+				continue
+			}
+
+			sharesReceived.Inc()
+
+			share, err := extractShare(message, signer.GroupPublicKeyShares())
 			if err != nil {
+				receivedShares.rejected[message.senderID] = true
+				sharesRejected.Inc()
 				logger.Warningf(
-					"[member:%v] rejecting signature share from "+
+					"[members:%v] rejecting signature share from "+
 						"member [%v]: [%v]",
-					signer.MemberID(),
+					memberIDs,
 					message.senderID,
 					err,
 				)
 				continue
 			}
 
-			logger.Debugf(
-				"[member:%v] accepting signature share from member [%v]",
-				signer.MemberID(),
-				message.senderID,
-			)
+			receivedShares.pending[message.senderID] = share
 
-			receivedValidShares[message.senderID] = share
+			if len(receivedShares.valid)+len(receivedShares.pending) <
+				honestThreshold {
+				continue
+			}
+
+			receivedShares.validatePending(
+				memberIDs,
+				signer.GroupPublicKeyShares(),
+				previousEntry,
+			)
 		case blockNumber := <-relayEntrySubmittedChannel:
 			logger.Infof(
-				"[member:%v] leaving message loop; "+
+				"[members:%v] leaving message loop; "+
 					"relay entry submitted by other member at block [%v]",
-				signer.MemberID(),
+				memberIDs,
 				blockNumber,
 			)
 			return nil
@@ -132,10 +171,18 @@ func SignAndSubmit(
 				"relay entry timed out at block [%v]",
 				blockNumber,
 			)
+		case <-ctx.Done():
+			return fmt.Errorf("relay entry signing cancelled: [%v]", ctx.Err())
 		}
 	}
 
-	signature, err := completeSignature(signer, receivedValidShares, honestThreshold)
+	timeToThreshold.Observe(time.Since(signingStartTime).Seconds())
+
+	signature, err := completeSignature(
+		signer,
+		receivedShares.valid,
+		honestThreshold,
+	)
 	if err != nil {
 		return err
 	}
@@ -152,6 +199,7 @@ func SignAndSubmit(
 	// still a possibility those signals appear in the future so the submitter
 	// must be aware of them and break the execution if they occur.
 	return submitter.submitRelayEntry(
+		ctx,
 		signature.Marshal(),
 		signer.GroupPublicKeyBytes(),
 		startBlockHeight,
@@ -180,10 +228,9 @@ func broadcastShare(
 	}
 }
 
-func extractAndValidateShare(
+func extractShare(
 	message *SignatureShareMessage,
 	groupPublicKeyShares map[group.MemberIndex]*bn256.G2,
-	previousEntry *bn256.G1,
 ) (*bn256.G1, error) {
 	share := new(bn256.G1)
 	_, err := share.Unmarshal(message.shareBytes)
@@ -194,19 +241,131 @@ func extractAndValidateShare(
 		)
 	}
 
-	publicKeyShare, ok := groupPublicKeyShares[message.senderID]
-	if !ok {
+	if _, ok := groupPublicKeyShares[message.senderID]; !ok {
 		return nil, fmt.Errorf(
 			"could not validate signature share; " +
 				"group public key share for sender not found",
 		)
 	}
 
-	if !bls.VerifyG1(publicKeyShare, previousEntry, share) {
-		return nil, fmt.Errorf("invalid signature share")
+	return share, nil
+}
+
+// signatureShares keeps signature shares received from members along with
+// the verdict on them. Received shares are not verified one by one as they
+// arrive. They are collected as pending until there are enough of them to
+// reach the honest threshold and then verified together in a batch.
+type signatureShares struct {
+	valid    map[group.MemberIndex]*bn256.G1
+	pending  map[group.MemberIndex]*bn256.G1
+	rejected map[group.MemberIndex]bool
+}
+
+func newSignatureShares() *signatureShares {
+	return &signatureShares{
+		valid:    make(map[group.MemberIndex]*bn256.G1),
+		pending:  make(map[group.MemberIndex]*bn256.G1),
+		rejected: make(map[group.MemberIndex]bool),
 	}
+}
 
-	return share, nil
+// has tells whether a share of the given member has been already received,
+// no matter if it has been accepted, rejected or is waiting for
+// verification.
+func (ss *signatureShares) has(memberID group.MemberIndex) bool {
+	_, isValid := ss.valid[memberID]
+	_, isPending := ss.pending[memberID]
+	return isValid || isPending || ss.rejected[memberID]
+}
+
+// validatePending verifies the pending shares for the previous entry and
+// moves each of them to either valid or rejected shares.
+func (ss *signatureShares) validatePending(
+	memberIDs []group.MemberIndex,
+	groupPublicKeyShares map[group.MemberIndex]*bn256.G2,
+	previousEntry *bn256.G1,
+) {
+	validShares := validateShares(
+		memberIDs,
+		ss.pending,
+		groupPublicKeyShares,
+		previousEntry,
+	)
+
+	for memberID := range ss.pending {
+		if share, ok := validShares[memberID]; ok {
+			ss.valid[memberID] = share
+		} else {
+			ss.rejected[memberID] = true
+		}
+	}
+
+	ss.pending = make(map[group.MemberIndex]*bn256.G1)
+}
+
+// validateShares returns those of the given signature shares which are valid
+// for the previous entry. All shares are verified together in a batch first.
+// Only if the batch verification fails, shares are verified one by one to
+// find out which of them are not valid. Every share has to have a group
+// public key share of its sender.
+func validateShares(
+	memberIDs []group.MemberIndex,
+	shares map[group.MemberIndex]*bn256.G1,
+	groupPublicKeyShares map[group.MemberIndex]*bn256.G2,
+	previousEntry *bn256.G1,
+) map[group.MemberIndex]*bn256.G1 {
+	senders := make([]group.MemberIndex, 0, len(shares))
+	signatures := make([]*bn256.G1, 0, len(shares))
+	publicKeys := make([]*bn256.G2, 0, len(shares))
+	for senderID, share := range shares {
+		senders = append(senders, senderID)
+		signatures = append(signatures, share)
+		publicKeys = append(publicKeys, groupPublicKeyShares[senderID])
+	}
+
+	valid, err := bls.BatchVerifyG1(publicKeys, previousEntry, signatures)
+	if err != nil {
+		logger.Warningf(
+			"[members:%v] could not verify signature shares in a batch: [%v]",
+			memberIDs,
+			err,
+		)
+	}
+
+	if valid {
+		for _, senderID := range senders {
+			logger.Debugf(
+				"[members:%v] accepting signature share from member [%v]",
+				memberIDs,
+				senderID,
+			)
+		}
+		return shares
+	}
+
+	validShares := make(map[group.MemberIndex]*bn256.G1)
+	for i, senderID := range senders {
+		if !bls.VerifyG1(publicKeys[i], previousEntry, signatures[i]) {
+			sharesRejected.Inc()
+			logger.Warningf(
+				"[members:%v] rejecting signature share from "+
+					"member [%v]: [invalid signature share]",
+				memberIDs,
+				senderID,
+			)
+			continue
+		}
+
+		logger.Debugf(
+			"[members:%v] accepting signature share from member [%v]",
+			memberIDs,
+			senderID,
+		)
+
+		validShares[senderID] = signatures[i]
+	}
+
+	return validShares
 }
 
 func completeSignature(
@@ -236,3 +395,12 @@ func completeSignature(
 
 	return signature, nil
 }
+
+func sortedByMemberID(signers []*dkg.ThresholdSigner) []*dkg.ThresholdSigner {
+	sorted := make([]*dkg.ThresholdSigner, len(signers))
+	copy(sorted, signers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MemberID() < sorted[j].MemberID()
+	})
+	return sorted
+}
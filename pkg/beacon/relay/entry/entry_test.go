@@ -0,0 +1,141 @@
+package entry
+
+import (
+	"crypto/rand"
+	"math/big"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/bls"
+)
+
+func TestValidateShares(t *testing.T) {
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(1337))
+
+	groupPublicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+	validShares := make(map[group.MemberIndex]*bn256.G1)
+	for memberID := group.MemberIndex(1); memberID <= 5; memberID++ {
+		secretKeyShare, publicKeyShare, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		groupPublicKeyShares[memberID] = publicKeyShare
+		validShares[memberID] = bls.SignG1(secretKeyShare, previousEntry)
+	}
+
+	invalidShare := bls.SignG1(big.NewInt(123), previousEntry)
+
+	var tests = map[string]struct {
+		invalidMembers []group.MemberIndex
+	}{
+		"all shares valid": {
+			invalidMembers: []group.MemberIndex{},
+		},
+		"one share invalid": {
+			invalidMembers: []group.MemberIndex{3},
+		},
+		"all shares invalid": {
+			invalidMembers: []group.MemberIndex{1, 2, 3, 4, 5},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			shares := make(map[group.MemberIndex]*bn256.G1)
+			expectedShares := make(map[group.MemberIndex]*bn256.G1)
+			for memberID, share := range validShares {
+				shares[memberID] = share
+				expectedShares[memberID] = share
+			}
+			for _, memberID := range test.invalidMembers {
+				shares[memberID] = invalidShare
+				delete(expectedShares, memberID)
+			}
+
+			actualShares := validateShares(
+				[]group.MemberIndex{1},
+				shares,
+				groupPublicKeyShares,
+				previousEntry,
+			)
+
+			if !reflect.DeepEqual(expectedShares, actualShares) {
+				t.Errorf(
+					"unexpected valid shares\nexpected: [%v]\nactual:   [%v]",
+					expectedShares,
+					actualShares,
+				)
+			}
+		})
+	}
+}
+
+func TestSignatureSharesKeepFirstVerdict(t *testing.T) {
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(1337))
+
+	groupPublicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+	validShares := make(map[group.MemberIndex]*bn256.G1)
+	for memberID := group.MemberIndex(1); memberID <= 3; memberID++ {
+		secretKeyShare, publicKeyShare, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		groupPublicKeyShares[memberID] = publicKeyShare
+		validShares[memberID] = bls.SignG1(secretKeyShare, previousEntry)
+	}
+
+	invalidShare := bls.SignG1(big.NewInt(123), previousEntry)
+
+	shares := newSignatureShares()
+	shares.pending[1] = validShares[1]
+	shares.pending[2] = invalidShare
+
+	shares.validatePending(
+		[]group.MemberIndex{3},
+		groupPublicKeyShares,
+		previousEntry,
+	)
+
+	expectedValidShares := map[group.MemberIndex]*bn256.G1{1: validShares[1]}
+	if !reflect.DeepEqual(expectedValidShares, shares.valid) {
+		t.Errorf(
+			"unexpected valid shares\nexpected: [%v]\nactual:   [%v]",
+			expectedValidShares,
+			shares.valid,
+		)
+	}
+
+	var tests = map[string]struct {
+		memberID    group.MemberIndex
+		expectedHas bool
+	}{
+		"member with accepted share": {
+			memberID:    1,
+			expectedHas: true,
+		},
+		"member with rejected share": {
+			memberID:    2,
+			expectedHas: true,
+		},
+		"member with no share": {
+			memberID:    3,
+			expectedHas: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if shares.has(test.memberID) != test.expectedHas {
+				t.Errorf(
+					"unexpected share presence\nexpected: [%v]\nactual:   [%v]",
+					test.expectedHas,
+					shares.has(test.memberID),
+				)
+			}
+		})
+	}
+}
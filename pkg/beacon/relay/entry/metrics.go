@@ -0,0 +1,42 @@
+package entry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/keep-network/keep-core/pkg/metrics"
+)
+
+var (
+	sharesReceived = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "relay_entry",
+			Name:      "shares_received_total",
+			Help:      "Signature shares received from other group members.",
+		},
+	)
+
+	sharesRejected = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "relay_entry",
+			Name:      "shares_rejected_total",
+			Help:      "Received signature shares which were not valid.",
+		},
+	)
+
+	timeToThreshold = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "relay_entry",
+			Name:      "time_to_threshold_seconds",
+			Help: "Time from broadcasting own signature share to " +
+				"collecting the threshold of valid shares.",
+			Buckets: prometheus.ExponentialBuckets(0.5, 2, 10),
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(sharesReceived, sharesRejected, timeToThreshold)
+}
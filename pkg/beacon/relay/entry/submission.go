@@ -1,6 +1,7 @@
 package entry
 
 import (
+	"context"
 	"fmt"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
@@ -21,8 +22,9 @@ type relayEntrySubmitter struct {
 // Group member with index 1 tries to submit as the first one, group member 2
 // tries to submit after a few blocks if member 1 did not submit and so on.
 // Relay entry submit process starts at block height defined by startBlockheight
-// parameter.
+// parameter. Submission is abandoned when the context is done.
 func (res *relayEntrySubmitter) submitRelayEntry(
+	ctx context.Context,
 	newEntry []byte,
 	groupPublicKey []byte,
 	startBlockHeight uint64,
@@ -37,9 +39,6 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 		)
 	}
 
-	// TODO: we should eventually check if entry has been already submitted
-	// but we may skip this check for V1.
-
 	// Wait until the current member is eligible to submit the entry.
 	eligibleToSubmitWaiter, err := res.waitForSubmissionEligibility(
 		startBlockHeight,
@@ -69,8 +68,8 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 				func(entry *event.EntrySubmitted, err error) {
 					if err == nil {
 						logger.Infof(
-							"[member:%v] successfully submitted "+
-								"relay entry at block: [%v]",
+							"[member:%v] relay entry submitted "+
+								"at block: [%v]",
 							res.index,
 							entry.BlockNumber,
 						)
@@ -91,6 +90,11 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 				"relay entry timed out at block [%v]",
 				blockNumber,
 			)
+		case <-ctx.Done():
+			return fmt.Errorf(
+				"relay entry submission cancelled: [%v]",
+				ctx.Err(),
+			)
 		}
 	}
 }
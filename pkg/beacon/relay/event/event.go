@@ -10,7 +10,11 @@ import (
 // EntrySubmitted indicates that valid relay entry has been submitted to the
 // chain for the currently processed relay request. This event is intended to
 // be used by operators for tracking entry generation and submission progress.
+//
+// The entry is read from the submission if the chain does not emit it along
+// with the event; it is nil if it could not be read.
 type EntrySubmitted struct {
+	Entry       []byte
 	BlockNumber uint64
 }
 
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"reflect"
 	"sync"
 	"testing"
 )
@@ -142,3 +143,38 @@ func TestRelayRequestTrack_WhenEmpty(t *testing.T) {
 		t.Error("RelayEntryRequested event wasn't emitted before; should be added successfully")
 	}
 }
+
+func TestPendingEvents(t *testing.T) {
+	gst := &GroupSelectionTrack{
+		Data:  make(map[string]bool),
+		Mutex: &sync.Mutex{},
+	}
+	rrt := &RelayRequestTrack{
+		Data:  make(map[string]bool),
+		Mutex: &sync.Mutex{},
+	}
+
+	for _, entry := range []string{"0x67891", "0x12345", "0x44444"} {
+		gst.Add(entry)
+		rrt.Add(entry)
+	}
+	gst.Remove("0x44444")
+	rrt.Remove("0x44444")
+
+	expected := []string{"0x12345", "0x67891"}
+
+	if !reflect.DeepEqual(expected, gst.Pending()) {
+		t.Errorf(
+			"unexpected pending group selections\nexpected: %v\nactual:   %v",
+			expected,
+			gst.Pending(),
+		)
+	}
+	if !reflect.DeepEqual(expected, rrt.Pending()) {
+		t.Errorf(
+			"unexpected pending relay requests\nexpected: %v\nactual:   %v",
+			expected,
+			rrt.Pending(),
+		)
+	}
+}
@@ -27,49 +27,6 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
-type Join struct {
-	SenderID uint32 `protobuf:"varint,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
-}
-
-func (m *Join) Reset()      { *m = Join{} }
-func (*Join) ProtoMessage() {}
-func (*Join) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8447775385e7eb85, []int{0}
-}
-func (m *Join) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *Join) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_Join.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
-}
-func (m *Join) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Join.Merge(m, src)
-}
-func (m *Join) XXX_Size() int {
-	return m.Size()
-}
-func (m *Join) XXX_DiscardUnknown() {
-	xxx_messageInfo_Join.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_Join proto.InternalMessageInfo
-
-func (m *Join) GetSenderID() uint32 {
-	if m != nil {
-		return m.SenderID
-	}
-	return 0
-}
-
 type EphemeralPublicKey struct {
 	SenderID            uint32            `protobuf:"varint,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
 	ReceiverID          uint32            `protobuf:"varint,2,opt,name=receiverID,proto3" json:"receiverID,omitempty"`
@@ -79,7 +36,7 @@ type EphemeralPublicKey struct {
 func (m *EphemeralPublicKey) Reset()      { *m = EphemeralPublicKey{} }
 func (*EphemeralPublicKey) ProtoMessage() {}
 func (*EphemeralPublicKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8447775385e7eb85, []int{1}
+	return fileDescriptor_8447775385e7eb85, []int{0}
 }
 func (m *EphemeralPublicKey) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -137,7 +94,7 @@ type MemberCommitments struct {
 func (m *MemberCommitments) Reset()      { *m = MemberCommitments{} }
 func (*MemberCommitments) ProtoMessage() {}
 func (*MemberCommitments) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8447775385e7eb85, []int{2}
+	return fileDescriptor_8447775385e7eb85, []int{1}
 }
 func (m *MemberCommitments) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -188,7 +145,7 @@ type PeerShares struct {
 func (m *PeerShares) Reset()      { *m = PeerShares{} }
 func (*PeerShares) ProtoMessage() {}
 func (*PeerShares) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8447775385e7eb85, []int{3}
+	return fileDescriptor_8447775385e7eb85, []int{2}
 }
 func (m *PeerShares) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -239,7 +196,7 @@ type PeerShares_Shares struct {
 func (m *PeerShares_Shares) Reset()      { *m = PeerShares_Shares{} }
 func (*PeerShares_Shares) ProtoMessage() {}
 func (*PeerShares_Shares) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8447775385e7eb85, []int{3, 0}
+	return fileDescriptor_8447775385e7eb85, []int{2, 0}
 }
 func (m *PeerShares_Shares) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -290,7 +247,7 @@ type SecretSharesAccusations struct {
 func (m *SecretSharesAccusations) Reset()      { *m = SecretSharesAccusations{} }
 func (*SecretSharesAccusations) ProtoMessage() {}
 func (*SecretSharesAccusations) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8447775385e7eb85, []int{4}
+	return fileDescriptor_8447775385e7eb85, []int{3}
 }
 func (m *SecretSharesAccusations) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -341,7 +298,7 @@ type MemberPublicKeySharePoints struct {
 func (m *MemberPublicKeySharePoints) Reset()      { *m = MemberPublicKeySharePoints{} }
 func (*MemberPublicKeySharePoints) ProtoMessage() {}
 func (*MemberPublicKeySharePoints) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8447775385e7eb85, []int{5}
+	return fileDescriptor_8447775385e7eb85, []int{4}
 }
 func (m *MemberPublicKeySharePoints) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -392,7 +349,7 @@ type PointsAccusations struct {
 func (m *PointsAccusations) Reset()      { *m = PointsAccusations{} }
 func (*PointsAccusations) ProtoMessage() {}
 func (*PointsAccusations) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8447775385e7eb85, []int{6}
+	return fileDescriptor_8447775385e7eb85, []int{5}
 }
 func (m *PointsAccusations) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -443,7 +400,7 @@ type MisbehavedEphemeralKeys struct {
 func (m *MisbehavedEphemeralKeys) Reset()      { *m = MisbehavedEphemeralKeys{} }
 func (*MisbehavedEphemeralKeys) ProtoMessage() {}
 func (*MisbehavedEphemeralKeys) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8447775385e7eb85, []int{7}
+	return fileDescriptor_8447775385e7eb85, []int{6}
 }
 func (m *MisbehavedEphemeralKeys) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -486,8 +443,141 @@ func (m *MisbehavedEphemeralKeys) GetPrivateKeys() map[uint32][]byte {
 	return nil
 }
 
+type Checkpoint struct {
+	EphemeralPrivateKeys map[uint32][]byte `protobuf:"bytes,1,rep,name=ephemeralPrivateKeys,proto3" json:"ephemeralPrivateKeys,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CoefficientsA        [][]byte          `protobuf:"bytes,2,rep,name=coefficientsA,proto3" json:"coefficientsA,omitempty"`
+	CoefficientsB        [][]byte          `protobuf:"bytes,3,rep,name=coefficientsB,proto3" json:"coefficientsB,omitempty"`
+	Machine              []byte            `protobuf:"bytes,4,opt,name=machine,proto3" json:"machine,omitempty"`
+}
+
+func (m *Checkpoint) Reset()      { *m = Checkpoint{} }
+func (*Checkpoint) ProtoMessage() {}
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{7}
+}
+func (m *Checkpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Checkpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint.Merge(m, src)
+}
+func (m *Checkpoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *Checkpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint proto.InternalMessageInfo
+
+func (m *Checkpoint) GetEphemeralPrivateKeys() map[uint32][]byte {
+	if m != nil {
+		return m.EphemeralPrivateKeys
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetCoefficientsA() [][]byte {
+	if m != nil {
+		return m.CoefficientsA
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetCoefficientsB() [][]byte {
+	if m != nil {
+		return m.CoefficientsB
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetMachine() []byte {
+	if m != nil {
+		return m.Machine
+	}
+	return nil
+}
+
+type Transcript struct {
+	EphemeralPrivateKeys map[uint32][]byte `protobuf:"bytes,1,rep,name=ephemeralPrivateKeys,proto3" json:"ephemeralPrivateKeys,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CoefficientsA        [][]byte          `protobuf:"bytes,2,rep,name=coefficientsA,proto3" json:"coefficientsA,omitempty"`
+	CoefficientsB        [][]byte          `protobuf:"bytes,3,rep,name=coefficientsB,proto3" json:"coefficientsB,omitempty"`
+	Machine              []byte            `protobuf:"bytes,4,opt,name=machine,proto3" json:"machine,omitempty"`
+}
+
+func (m *Transcript) Reset()      { *m = Transcript{} }
+func (*Transcript) ProtoMessage() {}
+func (*Transcript) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{8}
+}
+func (m *Transcript) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Transcript) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Transcript.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Transcript) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transcript.Merge(m, src)
+}
+func (m *Transcript) XXX_Size() int {
+	return m.Size()
+}
+func (m *Transcript) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transcript.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transcript proto.InternalMessageInfo
+
+func (m *Transcript) GetEphemeralPrivateKeys() map[uint32][]byte {
+	if m != nil {
+		return m.EphemeralPrivateKeys
+	}
+	return nil
+}
+
+func (m *Transcript) GetCoefficientsA() [][]byte {
+	if m != nil {
+		return m.CoefficientsA
+	}
+	return nil
+}
+
+func (m *Transcript) GetCoefficientsB() [][]byte {
+	if m != nil {
+		return m.CoefficientsB
+	}
+	return nil
+}
+
+func (m *Transcript) GetMachine() []byte {
+	if m != nil {
+		return m.Machine
+	}
+	return nil
+}
+
 func init() {
-	proto.RegisterType((*Join)(nil), "gjkr.Join")
 	proto.RegisterType((*EphemeralPublicKey)(nil), "gjkr.EphemeralPublicKey")
 	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.EphemeralPublicKey.EphemeralPublicKeysEntry")
 	proto.RegisterType((*MemberCommitments)(nil), "gjkr.MemberCommitments")
@@ -501,72 +591,57 @@ func init() {
 	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.PointsAccusations.AccusedMembersKeysEntry")
 	proto.RegisterType((*MisbehavedEphemeralKeys)(nil), "gjkr.MisbehavedEphemeralKeys")
 	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.MisbehavedEphemeralKeys.PrivateKeysEntry")
+	proto.RegisterType((*Checkpoint)(nil), "gjkr.Checkpoint")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Checkpoint.EphemeralPrivateKeysEntry")
+	proto.RegisterType((*Transcript)(nil), "gjkr.Transcript")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Transcript.EphemeralPrivateKeysEntry")
 }
 
 func init() { proto.RegisterFile("pb/message.proto", fileDescriptor_8447775385e7eb85) }
 
 var fileDescriptor_8447775385e7eb85 = []byte{
-	// 533 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x54, 0x4f, 0x8b, 0xd3, 0x40,
-	0x14, 0xcf, 0xb4, 0x6b, 0x91, 0x97, 0x8a, 0xdd, 0xb8, 0xd0, 0x12, 0x64, 0x28, 0x39, 0xf5, 0x62,
-	0x16, 0xab, 0xc2, 0xe2, 0x41, 0x58, 0xb5, 0x82, 0xca, 0x42, 0x4c, 0x3d, 0x89, 0x20, 0x49, 0xfa,
-	0xd8, 0xc6, 0x6d, 0xfe, 0x30, 0x93, 0x16, 0x7a, 0xf3, 0x23, 0xec, 0xc7, 0xf0, 0x9b, 0xe8, 0xb1,
-	0x37, 0xf7, 0x68, 0xd3, 0x8b, 0xc7, 0xfd, 0x02, 0x82, 0x74, 0x26, 0xb4, 0xa1, 0x4d, 0xa2, 0x7b,
-	0xf2, 0xd4, 0x99, 0xf7, 0x7e, 0xbf, 0xdf, 0x7b, 0xef, 0x37, 0xaf, 0x81, 0x56, 0xec, 0x1e, 0x07,
-	0xc8, 0xb9, 0x73, 0x8e, 0x66, 0xcc, 0xa2, 0x24, 0xd2, 0x0e, 0xce, 0x3f, 0x5f, 0x30, 0xc3, 0x80,
-	0x83, 0x37, 0x91, 0x1f, 0x6a, 0x3a, 0xdc, 0xe6, 0x18, 0x8e, 0x90, 0xbd, 0x7e, 0xd9, 0x21, 0x5d,
-	0xd2, 0xbb, 0x63, 0x6f, 0xee, 0xc6, 0x6f, 0x02, 0xda, 0x20, 0x1e, 0x63, 0x80, 0xcc, 0x99, 0x58,
-	0x53, 0x77, 0xe2, 0x7b, 0x6f, 0x71, 0x5e, 0x45, 0xd1, 0x28, 0x00, 0x43, 0x0f, 0xfd, 0x99, 0xc8,
-	0xd6, 0x44, 0x36, 0x17, 0xd1, 0x3c, 0xb8, 0x87, 0x7b, 0x8a, 0xbc, 0x53, 0xef, 0xd6, 0x7b, 0x6a,
-	0xff, 0xa1, 0xb9, 0x6e, 0xcd, 0xdc, 0x2f, 0x59, 0x10, 0xe2, 0x83, 0x30, 0x61, 0x73, 0xbb, 0x48,
-	0x4d, 0x7f, 0x05, 0x9d, 0x32, 0x82, 0xd6, 0x82, 0xfa, 0x05, 0xce, 0xb3, 0xbe, 0xd7, 0x47, 0xed,
-	0x08, 0x6e, 0xcd, 0x9c, 0xc9, 0x14, 0x45, 0xb7, 0x4d, 0x5b, 0x5e, 0x9e, 0xd6, 0x4e, 0x88, 0xf1,
-	0x0e, 0x0e, 0xcf, 0x30, 0x70, 0x91, 0xbd, 0x88, 0x82, 0xc0, 0x4f, 0x02, 0x0c, 0x13, 0x5e, 0x39,
-	0x7d, 0x17, 0x54, 0x6f, 0x0b, 0xed, 0xd4, 0xba, 0xf5, 0x5e, 0xd3, 0xce, 0x87, 0x8c, 0xcb, 0x1a,
-	0x80, 0x85, 0xc8, 0x86, 0x63, 0x87, 0x61, 0xb5, 0xd8, 0x63, 0x68, 0x70, 0x81, 0x12, 0x3a, 0x6a,
-	0xff, 0xbe, 0x74, 0x67, 0xcb, 0x36, 0xe5, 0x8f, 0x34, 0x22, 0xc3, 0xea, 0x1f, 0xa1, 0x91, 0x69,
-	0xf7, 0xe0, 0x2e, 0x86, 0x1e, 0x9b, 0xc7, 0x09, 0x8e, 0x44, 0x68, 0x28, 0x4a, 0x34, 0xed, 0xdd,
-	0xf0, 0x3e, 0xf2, 0x7d, 0xe6, 0xc5, 0x6e, 0x58, 0xb7, 0x41, 0xcd, 0x15, 0x2d, 0x30, 0xf3, 0x41,
-	0xde, 0x4c, 0xb5, 0xdf, 0x2e, 0xe9, 0x39, 0xef, 0xf2, 0x8a, 0x40, 0x7b, 0x88, 0x1e, 0xc3, 0x44,
-	0xe6, 0x4e, 0x3d, 0x6f, 0xca, 0x9d, 0xc4, 0x8f, 0xc2, 0x6a, 0x7f, 0x10, 0x34, 0x67, 0x0d, 0xc5,
-	0x91, 0x7c, 0x24, 0x2e, 0x36, 0x49, 0x7a, 0xf5, 0x44, 0xd6, 0x2d, 0x91, 0x35, 0x4f, 0xf7, 0x78,
-	0xd2, 0xc4, 0x02, 0x41, 0x7d, 0x00, 0xed, 0x12, 0xf8, 0x8d, 0x76, 0x69, 0x02, 0xba, 0xe4, 0x6f,
-	0x16, 0x52, 0xb4, 0x65, 0x45, 0xfe, 0xdf, 0x96, 0xaa, 0x0f, 0x47, 0x71, 0x01, 0x27, 0xdb, 0xae,
-	0xc2, 0x9c, 0xf1, 0x83, 0xc0, 0xa1, 0x3c, 0xfe, 0xab, 0x9b, 0x9f, 0x2a, 0xdc, 0x3c, 0xce, 0x5e,
-	0x71, 0x57, 0xf0, 0x7f, 0xf8, 0xf8, 0x8d, 0x40, 0xfb, 0xcc, 0xe7, 0x2e, 0x8e, 0x9d, 0x19, 0x8e,
-	0x36, 0x7f, 0xf3, 0xb5, 0x58, 0xe5, 0x7c, 0x16, 0xa8, 0x31, 0xf3, 0x67, 0x4e, 0x82, 0xb9, 0xc1,
-	0x4c, 0x39, 0x58, 0x89, 0x9e, 0x69, 0x6d, 0x09, 0x72, 0xae, 0xbc, 0x84, 0xfe, 0x0c, 0x5a, 0xbb,
-	0x80, 0x9b, 0x4c, 0xf2, 0xfc, 0x64, 0xb1, 0xa4, 0xca, 0xd5, 0x92, 0x2a, 0xd7, 0x4b, 0x4a, 0xbe,
-	0xa4, 0x94, 0x7c, 0x4d, 0x29, 0xf9, 0x9e, 0x52, 0xb2, 0x48, 0x29, 0xf9, 0x99, 0x52, 0xf2, 0x2b,
-	0xa5, 0xca, 0x75, 0x4a, 0xc9, 0xe5, 0x8a, 0x2a, 0x8b, 0x15, 0x55, 0xae, 0x56, 0x54, 0xf9, 0x50,
-	0x8b, 0x5d, 0xb7, 0x21, 0x3e, 0xe4, 0x8f, 0xfe, 0x04, 0x00, 0x00, 0xff, 0xff, 0x94, 0xa2, 0x04,
-	0xcc, 0xdc, 0x05, 0x00, 0x00,
-}
-
-func (this *Join) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*Join)
-	if !ok {
-		that2, ok := that.(Join)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.SenderID != that1.SenderID {
-		return false
-	}
-	return true
+	// 622 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x55, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xce, 0x3a, 0xa5, 0xa0, 0x71, 0x2b, 0x5a, 0x53, 0x29, 0xc6, 0x42, 0xab, 0x28, 0xe2, 0x10,
+	0x21, 0xe1, 0x8a, 0x00, 0x52, 0xc5, 0x01, 0xa9, 0x2d, 0x01, 0x21, 0x54, 0x29, 0xb8, 0x3d, 0x21,
+	0x04, 0xb2, 0x37, 0xd3, 0xc6, 0x34, 0xfe, 0xd1, 0xda, 0x8d, 0x94, 0x1b, 0x8f, 0xd0, 0x3b, 0x2f,
+	0xc0, 0x9b, 0xc0, 0xb1, 0x37, 0x7a, 0xa4, 0xce, 0x85, 0x63, 0x5f, 0x00, 0x09, 0x79, 0xd7, 0xd4,
+	0x26, 0xb1, 0x43, 0x7b, 0x42, 0xe2, 0x14, 0xef, 0xcc, 0x37, 0xdf, 0x7c, 0xf3, 0x79, 0xb2, 0x86,
+	0x95, 0xd0, 0x59, 0xf7, 0x30, 0x8a, 0xec, 0x03, 0x34, 0x43, 0x1e, 0xc4, 0x81, 0xb6, 0x70, 0xf0,
+	0xe1, 0x90, 0xb7, 0x7e, 0x12, 0xd0, 0xba, 0xe1, 0x00, 0x3d, 0xe4, 0xf6, 0xb0, 0x77, 0xe4, 0x0c,
+	0x5d, 0xf6, 0x0a, 0xc7, 0x9a, 0x01, 0x37, 0x22, 0xf4, 0xfb, 0xc8, 0x5f, 0x3e, 0xd3, 0x49, 0x93,
+	0xb4, 0x97, 0xad, 0x8b, 0xb3, 0x46, 0x01, 0x38, 0x32, 0x74, 0x47, 0x22, 0xab, 0x88, 0x6c, 0x21,
+	0xa2, 0x31, 0xb8, 0x85, 0x33, 0x8c, 0x91, 0x5e, 0x6f, 0xd6, 0xdb, 0x6a, 0xe7, 0x81, 0x99, 0xb6,
+	0x35, 0x67, 0x5b, 0x96, 0x84, 0xa2, 0xae, 0x1f, 0xf3, 0xb1, 0x55, 0xc6, 0x66, 0x3c, 0x07, 0xbd,
+	0xaa, 0x40, 0x5b, 0x81, 0xfa, 0x21, 0x8e, 0x33, 0xdd, 0xe9, 0xa3, 0xb6, 0x06, 0xd7, 0x46, 0xf6,
+	0xf0, 0x08, 0x85, 0xda, 0x25, 0x4b, 0x1e, 0x9e, 0x28, 0x1b, 0xa4, 0xf5, 0x1a, 0x56, 0x77, 0xd0,
+	0x73, 0x90, 0x6f, 0x07, 0x9e, 0xe7, 0xc6, 0x1e, 0xfa, 0x71, 0x34, 0x77, 0xfa, 0x26, 0xa8, 0x2c,
+	0x87, 0xea, 0x4a, 0xb3, 0xde, 0x5e, 0xb2, 0x8a, 0xa1, 0xd6, 0xb1, 0x02, 0xd0, 0x43, 0xe4, 0xbb,
+	0x03, 0x9b, 0xe3, 0x7c, 0xb2, 0x47, 0xb0, 0x18, 0x09, 0x94, 0xe0, 0x51, 0x3b, 0x77, 0xa4, 0x3b,
+	0x79, 0xb5, 0x29, 0x7f, 0xa4, 0x11, 0x19, 0xd6, 0x78, 0x0b, 0x8b, 0x19, 0x77, 0x1b, 0x6e, 0xa2,
+	0xcf, 0xf8, 0x38, 0x8c, 0xb1, 0x2f, 0x42, 0xbb, 0xa2, 0xc5, 0x92, 0x35, 0x1d, 0x9e, 0x45, 0xee,
+	0x65, 0x5e, 0x4c, 0x87, 0x0d, 0x0b, 0xd4, 0x42, 0xd3, 0x12, 0x33, 0xef, 0x17, 0xcd, 0x54, 0x3b,
+	0x8d, 0x0a, 0xcd, 0x45, 0x97, 0x27, 0x04, 0x1a, 0xbb, 0xc8, 0x38, 0xc6, 0x32, 0xb7, 0xc9, 0xd8,
+	0x51, 0x64, 0xc7, 0x6e, 0xe0, 0xcf, 0xf7, 0x07, 0x41, 0xb3, 0x53, 0x28, 0xf6, 0xe5, 0x4b, 0x8a,
+	0xc4, 0x26, 0x49, 0xaf, 0x1e, 0xcb, 0xbe, 0x15, 0xb4, 0xe6, 0xe6, 0x4c, 0x9d, 0x34, 0xb1, 0x84,
+	0xd0, 0xe8, 0x42, 0xa3, 0x02, 0x7e, 0xa5, 0x5d, 0x1a, 0x82, 0x21, 0xeb, 0x2f, 0x16, 0x52, 0xc8,
+	0xea, 0x05, 0xee, 0xdf, 0x96, 0xaa, 0x03, 0x6b, 0x61, 0x49, 0x4d, 0xb6, 0x5d, 0xa5, 0xb9, 0xd6,
+	0x37, 0x02, 0xab, 0xf2, 0xf1, 0xb2, 0x6e, 0xbe, 0x9f, 0xe3, 0xe6, 0x7a, 0xf6, 0x16, 0xa7, 0x09,
+	0xff, 0x85, 0x8f, 0x5f, 0x08, 0x34, 0x76, 0xdc, 0xc8, 0xc1, 0x81, 0x3d, 0xc2, 0xfe, 0xc5, 0xdf,
+	0x3c, 0x25, 0x9b, 0x3b, 0x5f, 0x0f, 0xd4, 0x90, 0xbb, 0x23, 0x3b, 0xc6, 0xc2, 0x60, 0xa6, 0x1c,
+	0xac, 0x82, 0xcf, 0xec, 0xe5, 0x05, 0x72, 0xae, 0x22, 0x85, 0xf1, 0x14, 0x56, 0xa6, 0x01, 0x57,
+	0x9a, 0xe4, 0x93, 0x02, 0xb0, 0x3d, 0x40, 0x76, 0x18, 0xa6, 0xbe, 0x6a, 0xef, 0x60, 0x2d, 0xbf,
+	0xcb, 0x0a, 0x4a, 0x89, 0x50, 0x7a, 0x4f, 0x2a, 0xcd, 0xf1, 0x66, 0xb7, 0x04, 0x2c, 0x55, 0x96,
+	0xf2, 0x68, 0x77, 0x61, 0x99, 0x05, 0xb8, 0xbf, 0xef, 0x32, 0x37, 0xbd, 0x89, 0x36, 0xb3, 0xfd,
+	0xf9, 0x33, 0x38, 0x8d, 0xda, 0xd2, 0xeb, 0xb3, 0xa8, 0x2d, 0x4d, 0x87, 0xeb, 0x9e, 0xcd, 0x06,
+	0xae, 0x8f, 0xfa, 0x82, 0x18, 0xeb, 0xf7, 0xd1, 0x78, 0x01, 0xb7, 0x2b, 0x85, 0x5d, 0xd9, 0x9d,
+	0x3d, 0x6e, 0xfb, 0x11, 0xe3, 0x6e, 0x78, 0x49, 0x77, 0x72, 0xfc, 0x7f, 0xee, 0xce, 0xd6, 0xc6,
+	0xc9, 0x19, 0xad, 0x9d, 0x9e, 0xd1, 0xda, 0xf9, 0x19, 0x25, 0x1f, 0x13, 0x4a, 0x3e, 0x27, 0x94,
+	0x7c, 0x4d, 0x28, 0x39, 0x49, 0x28, 0xf9, 0x9e, 0x50, 0xf2, 0x23, 0xa1, 0xb5, 0xf3, 0x84, 0x92,
+	0xe3, 0x09, 0xad, 0x9d, 0x4c, 0x68, 0xed, 0x74, 0x42, 0x6b, 0x6f, 0x94, 0xd0, 0x71, 0x16, 0xc5,
+	0x07, 0xfe, 0xe1, 0xaf, 0x01, 0x00, 0xa0, 0x4e, 0x1c, 0xc4, 0xf4, 0x07, 0x00, 0x00,
 }
+
 func (this *EphemeralPublicKey) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -821,15 +896,101 @@ func (this *MisbehavedEphemeralKeys) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *Join) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *Checkpoint) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.Join{")
-	s = append(s, "SenderID: "+fmt.Sprintf("%#v", this.SenderID)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+
+	that1, ok := that.(*Checkpoint)
+	if !ok {
+		that2, ok := that.(Checkpoint)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.EphemeralPrivateKeys) != len(that1.EphemeralPrivateKeys) {
+		return false
+	}
+	for i := range this.EphemeralPrivateKeys {
+		if !bytes.Equal(this.EphemeralPrivateKeys[i], that1.EphemeralPrivateKeys[i]) {
+			return false
+		}
+	}
+	if len(this.CoefficientsA) != len(that1.CoefficientsA) {
+		return false
+	}
+	for i := range this.CoefficientsA {
+		if !bytes.Equal(this.CoefficientsA[i], that1.CoefficientsA[i]) {
+			return false
+		}
+	}
+	if len(this.CoefficientsB) != len(that1.CoefficientsB) {
+		return false
+	}
+	for i := range this.CoefficientsB {
+		if !bytes.Equal(this.CoefficientsB[i], that1.CoefficientsB[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.Machine, that1.Machine) {
+		return false
+	}
+	return true
+}
+func (this *Transcript) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Transcript)
+	if !ok {
+		that2, ok := that.(Transcript)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.EphemeralPrivateKeys) != len(that1.EphemeralPrivateKeys) {
+		return false
+	}
+	for i := range this.EphemeralPrivateKeys {
+		if !bytes.Equal(this.EphemeralPrivateKeys[i], that1.EphemeralPrivateKeys[i]) {
+			return false
+		}
+	}
+	if len(this.CoefficientsA) != len(that1.CoefficientsA) {
+		return false
+	}
+	for i := range this.CoefficientsA {
+		if !bytes.Equal(this.CoefficientsA[i], that1.CoefficientsA[i]) {
+			return false
+		}
+	}
+	if len(this.CoefficientsB) != len(that1.CoefficientsB) {
+		return false
+	}
+	for i := range this.CoefficientsB {
+		if !bytes.Equal(this.CoefficientsB[i], that1.CoefficientsB[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.Machine, that1.Machine) {
+		return false
+	}
+	return true
 }
 func (this *EphemeralPublicKey) GoString() string {
 	if this == nil {
@@ -980,6 +1141,56 @@ func (this *MisbehavedEphemeralKeys) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *Checkpoint) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.Checkpoint{")
+	keysForEphemeralPrivateKeys := make([]uint32, 0, len(this.EphemeralPrivateKeys))
+	for k, _ := range this.EphemeralPrivateKeys {
+		keysForEphemeralPrivateKeys = append(keysForEphemeralPrivateKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForEphemeralPrivateKeys)
+	mapStringForEphemeralPrivateKeys := "map[uint32][]byte{"
+	for _, k := range keysForEphemeralPrivateKeys {
+		mapStringForEphemeralPrivateKeys += fmt.Sprintf("%#v: %#v,", k, this.EphemeralPrivateKeys[k])
+	}
+	mapStringForEphemeralPrivateKeys += "}"
+	if this.EphemeralPrivateKeys != nil {
+		s = append(s, "EphemeralPrivateKeys: "+mapStringForEphemeralPrivateKeys+",\n")
+	}
+	s = append(s, "CoefficientsA: "+fmt.Sprintf("%#v", this.CoefficientsA)+",\n")
+	s = append(s, "CoefficientsB: "+fmt.Sprintf("%#v", this.CoefficientsB)+",\n")
+	s = append(s, "Machine: "+fmt.Sprintf("%#v", this.Machine)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Transcript) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.Transcript{")
+	keysForEphemeralPrivateKeys := make([]uint32, 0, len(this.EphemeralPrivateKeys))
+	for k, _ := range this.EphemeralPrivateKeys {
+		keysForEphemeralPrivateKeys = append(keysForEphemeralPrivateKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForEphemeralPrivateKeys)
+	mapStringForEphemeralPrivateKeys := "map[uint32][]byte{"
+	for _, k := range keysForEphemeralPrivateKeys {
+		mapStringForEphemeralPrivateKeys += fmt.Sprintf("%#v: %#v,", k, this.EphemeralPrivateKeys[k])
+	}
+	mapStringForEphemeralPrivateKeys += "}"
+	if this.EphemeralPrivateKeys != nil {
+		s = append(s, "EphemeralPrivateKeys: "+mapStringForEphemeralPrivateKeys+",\n")
+	}
+	s = append(s, "CoefficientsA: "+fmt.Sprintf("%#v", this.CoefficientsA)+",\n")
+	s = append(s, "CoefficientsB: "+fmt.Sprintf("%#v", this.CoefficientsB)+",\n")
+	s = append(s, "Machine: "+fmt.Sprintf("%#v", this.Machine)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringMessage(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -988,7 +1199,7 @@ func valueToGoStringMessage(v interface{}, typ string) string {
 	pv := reflect.Indirect(rv).Interface()
 	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
 }
-func (m *Join) Marshal() (dAtA []byte, err error) {
+func (m *EphemeralPublicKey) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -998,40 +1209,12 @@ func (m *Join) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Join) MarshalTo(dAtA []byte) (int, error) {
+func (m *EphemeralPublicKey) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Join) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.SenderID != 0 {
-		i = encodeVarintMessage(dAtA, i, uint64(m.SenderID))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *EphemeralPublicKey) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *EphemeralPublicKey) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *EphemeralPublicKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *EphemeralPublicKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -1372,6 +1555,140 @@ func (m *MisbehavedEphemeralKeys) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *Checkpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Checkpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Machine) > 0 {
+		i -= len(m.Machine)
+		copy(dAtA[i:], m.Machine)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Machine)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.CoefficientsB) > 0 {
+		for iNdEx := len(m.CoefficientsB) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CoefficientsB[iNdEx])
+			copy(dAtA[i:], m.CoefficientsB[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.CoefficientsB[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.CoefficientsA) > 0 {
+		for iNdEx := len(m.CoefficientsA) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CoefficientsA[iNdEx])
+			copy(dAtA[i:], m.CoefficientsA[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.CoefficientsA[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.EphemeralPrivateKeys) > 0 {
+		for k := range m.EphemeralPrivateKeys {
+			v := m.EphemeralPrivateKeys[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintMessage(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintMessage(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintMessage(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Transcript) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Transcript) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Transcript) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Machine) > 0 {
+		i -= len(m.Machine)
+		copy(dAtA[i:], m.Machine)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Machine)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.CoefficientsB) > 0 {
+		for iNdEx := len(m.CoefficientsB) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CoefficientsB[iNdEx])
+			copy(dAtA[i:], m.CoefficientsB[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.CoefficientsB[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.CoefficientsA) > 0 {
+		for iNdEx := len(m.CoefficientsA) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CoefficientsA[iNdEx])
+			copy(dAtA[i:], m.CoefficientsA[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.CoefficientsA[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.EphemeralPrivateKeys) > 0 {
+		for k := range m.EphemeralPrivateKeys {
+			v := m.EphemeralPrivateKeys[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintMessage(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintMessage(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintMessage(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
 	offset -= sovMessage(v)
 	base := offset
@@ -1383,18 +1700,6 @@ func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	return base
 }
-func (m *Join) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.SenderID != 0 {
-		n += 1 + sovMessage(uint64(m.SenderID))
-	}
-	return n
-}
-
 func (m *EphemeralPublicKey) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1572,22 +1877,86 @@ func (m *MisbehavedEphemeralKeys) Size() (n int) {
 	return n
 }
 
+func (m *Checkpoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.EphemeralPrivateKeys) > 0 {
+		for k, v := range m.EphemeralPrivateKeys {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovMessage(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovMessage(uint64(k)) + l
+			n += mapEntrySize + 1 + sovMessage(uint64(mapEntrySize))
+		}
+	}
+	if len(m.CoefficientsA) > 0 {
+		for _, b := range m.CoefficientsA {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	if len(m.CoefficientsB) > 0 {
+		for _, b := range m.CoefficientsB {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	l = len(m.Machine)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	return n
+}
+
+func (m *Transcript) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.EphemeralPrivateKeys) > 0 {
+		for k, v := range m.EphemeralPrivateKeys {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovMessage(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovMessage(uint64(k)) + l
+			n += mapEntrySize + 1 + sovMessage(uint64(mapEntrySize))
+		}
+	}
+	if len(m.CoefficientsA) > 0 {
+		for _, b := range m.CoefficientsA {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	if len(m.CoefficientsB) > 0 {
+		for _, b := range m.CoefficientsB {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	l = len(m.Machine)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	return n
+}
+
 func sovMessage(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
 func sozMessage(x uint64) (n int) {
 	return sovMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
-func (this *Join) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&Join{`,
-		`SenderID:` + fmt.Sprintf("%v", this.SenderID) + `,`,
-		`}`,
-	}, "")
-	return s
-}
 func (this *EphemeralPublicKey) String() string {
 	if this == nil {
 		return "nil"
@@ -1727,6 +2096,52 @@ func (this *MisbehavedEphemeralKeys) String() string {
 	}, "")
 	return s
 }
+func (this *Checkpoint) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForEphemeralPrivateKeys := make([]uint32, 0, len(this.EphemeralPrivateKeys))
+	for k, _ := range this.EphemeralPrivateKeys {
+		keysForEphemeralPrivateKeys = append(keysForEphemeralPrivateKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForEphemeralPrivateKeys)
+	mapStringForEphemeralPrivateKeys := "map[uint32][]byte{"
+	for _, k := range keysForEphemeralPrivateKeys {
+		mapStringForEphemeralPrivateKeys += fmt.Sprintf("%v: %v,", k, this.EphemeralPrivateKeys[k])
+	}
+	mapStringForEphemeralPrivateKeys += "}"
+	s := strings.Join([]string{`&Checkpoint{`,
+		`EphemeralPrivateKeys:` + mapStringForEphemeralPrivateKeys + `,`,
+		`CoefficientsA:` + fmt.Sprintf("%v", this.CoefficientsA) + `,`,
+		`CoefficientsB:` + fmt.Sprintf("%v", this.CoefficientsB) + `,`,
+		`Machine:` + fmt.Sprintf("%v", this.Machine) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Transcript) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForEphemeralPrivateKeys := make([]uint32, 0, len(this.EphemeralPrivateKeys))
+	for k, _ := range this.EphemeralPrivateKeys {
+		keysForEphemeralPrivateKeys = append(keysForEphemeralPrivateKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForEphemeralPrivateKeys)
+	mapStringForEphemeralPrivateKeys := "map[uint32][]byte{"
+	for _, k := range keysForEphemeralPrivateKeys {
+		mapStringForEphemeralPrivateKeys += fmt.Sprintf("%v: %v,", k, this.EphemeralPrivateKeys[k])
+	}
+	mapStringForEphemeralPrivateKeys += "}"
+	s := strings.Join([]string{`&Transcript{`,
+		`EphemeralPrivateKeys:` + mapStringForEphemeralPrivateKeys + `,`,
+		`CoefficientsA:` + fmt.Sprintf("%v", this.CoefficientsA) + `,`,
+		`CoefficientsB:` + fmt.Sprintf("%v", this.CoefficientsB) + `,`,
+		`Machine:` + fmt.Sprintf("%v", this.Machine) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringMessage(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -1735,7 +2150,7 @@ func valueToStringMessage(v interface{}) string {
 	pv := reflect.Indirect(rv).Interface()
 	return fmt.Sprintf("*%v", pv)
 }
-func (m *Join) Unmarshal(dAtA []byte) error {
+func (m *EphemeralPublicKey) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1758,10 +2173,10 @@ func (m *Join) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Join: wiretype end group for non-group")
+			return fmt.Errorf("proto: EphemeralPublicKey: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Join: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EphemeralPublicKey: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1783,83 +2198,11 @@ func (m *Join) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipMessage(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthMessage
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthMessage
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *EphemeralPublicKey) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowMessage
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: EphemeralPublicKey: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EphemeralPublicKey: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SenderID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceiverID", wireType)
 			}
-			m.SenderID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMessage
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SenderID |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReceiverID", wireType)
-			}
-			m.ReceiverID = 0
+			m.ReceiverID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMessage
@@ -3086,6 +3429,536 @@ func (m *MisbehavedEphemeralKeys) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Checkpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Checkpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Checkpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EphemeralPrivateKeys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EphemeralPrivateKeys == nil {
+				m.EphemeralPrivateKeys = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMessage
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMessage
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMessage
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthMessage
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthMessage
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipMessage(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthMessage
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.EphemeralPrivateKeys[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CoefficientsA", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CoefficientsA = append(m.CoefficientsA, make([]byte, postIndex-iNdEx))
+			copy(m.CoefficientsA[len(m.CoefficientsA)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CoefficientsB", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CoefficientsB = append(m.CoefficientsB, make([]byte, postIndex-iNdEx))
+			copy(m.CoefficientsB[len(m.CoefficientsB)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Machine", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Machine = append(m.Machine[:0], dAtA[iNdEx:postIndex]...)
+			if m.Machine == nil {
+				m.Machine = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Transcript) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Transcript: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Transcript: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EphemeralPrivateKeys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EphemeralPrivateKeys == nil {
+				m.EphemeralPrivateKeys = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMessage
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMessage
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMessage
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthMessage
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthMessage
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipMessage(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthMessage
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.EphemeralPrivateKeys[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CoefficientsA", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CoefficientsA = append(m.CoefficientsA, make([]byte, postIndex-iNdEx))
+			copy(m.CoefficientsA[len(m.CoefficientsA)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CoefficientsB", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CoefficientsB = append(m.CoefficientsB, make([]byte, postIndex-iNdEx))
+			copy(m.CoefficientsB[len(m.CoefficientsB)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Machine", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Machine = append(m.Machine[:0], dAtA[iNdEx:postIndex]...)
+			if m.Machine == nil {
+				m.Machine = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipMessage(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
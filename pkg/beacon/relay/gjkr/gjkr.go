@@ -1,85 +1,198 @@
 package gjkr
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
 	"github.com/ipfs/go-log"
 
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
-	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
 var logger = log.Logger("keep-gjkr")
 
+// unmarshallers returns unmarshallers of all the protocol messages.
+func unmarshallers() []func() net.TaggedUnmarshaler {
+	return []func() net.TaggedUnmarshaler{
+		func() net.TaggedUnmarshaler { return &EphemeralPublicKeyMessage{} },
+		func() net.TaggedUnmarshaler { return &MemberCommitmentsMessage{} },
+		func() net.TaggedUnmarshaler { return &PeerSharesMessage{} },
+		func() net.TaggedUnmarshaler { return &SecretSharesAccusationsMessage{} },
+		func() net.TaggedUnmarshaler { return &MemberPublicKeySharePointsMessage{} },
+		func() net.TaggedUnmarshaler { return &PointsAccusationsMessage{} },
+		func() net.TaggedUnmarshaler { return &MisbehavedEphemeralKeysMessage{} },
+	}
+}
+
 // RegisterUnmarshallers initializes the given broadcast channel to be able to
 // perform DKG protocol interactions by registering all the required protocol
 // message unmarshallers.
 // The channel needs to be fully initialized before Execute is called.
 func RegisterUnmarshallers(channel net.BroadcastChannel) {
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &EphemeralPublicKeyMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &MemberCommitmentsMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &PeerSharesMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &SecretSharesAccusationsMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &MemberPublicKeySharePointsMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &PointsAccusationsMessage{}
-	})
-	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
-		return &MisbehavedEphemeralKeysMessage{}
-	})
+	for _, unmarshaller := range unmarshallers() {
+		channel.RegisterUnmarshaler(unmarshaller)
+	}
+}
+
+// Checkpoint captures the progress of the protocol execution so that it can
+// be resumed after the client restarts. Checkpoint contains member's secrets
+// and must be persisted only in an encrypted form.
+type Checkpoint struct {
+	secrets *memberSecrets
+	machine *state.Checkpoint
+}
+
+// StartBlockHeight returns the block at which the checkpointed protocol
+// execution started.
+func (c *Checkpoint) StartBlockHeight() uint64 {
+	return c.machine.StartBlockHeight
+}
+
+// ExecutionConfig contains the parameters of a single execution of the
+// protocol by one member of the group.
+type ExecutionConfig struct {
+	// MemberIndex is the index of the member in the group.
+	MemberIndex         group.MemberIndex
+	GroupSize           int
+	DishonestThreshold  int
+	Seed                *big.Int
+	MembershipValidator group.MembershipValidator
+
+	// Timing is the timing of protocol phases counted in blocks of the
+	// clock.
+	Timing config.DKGTiming
+
+	// StartBlockHeight is the block height at which the protocol should
+	// start.
+	StartBlockHeight uint64
+
+	// Checkpoint, if not nil, is the execution to resume. The start block
+	// height is then taken from the checkpoint.
+	Checkpoint *Checkpoint
+
+	// OnCheckpoint, if not nil, is called with a new checkpoint before the
+	// execution starts and each time the execution progresses.
+	OnCheckpoint func(*Checkpoint)
+
+	// TranscriptRecorder, if not nil, records the execution.
+	TranscriptRecorder *TranscriptRecorder
+
+	// VerificationCache, if not nil, lets the member share the verification
+	// of messages with other members of the group using the same cache.
+	VerificationCache *VerificationCache
 }
 
-// Execute runs the GJKR distributed key generation  protocol, given a
-// broadcast channel to mediate with, a block counter used for time tracking,
-// a player index to use in the group, dishonest threshold, and block height
-// when DKG protocol should start.
+// Execute runs the GJKR distributed key generation protocol, given a
+// broadcast channel to mediate with, a clock used for time tracking, usually
+// the chain block counter, and the configuration of the execution. The
+// execution is abandoned when the given context is done.
+//
 // If the generation is successful, it returns a threshold group member which
 // can participate in the signing group; if the generation fails, it returns an
 // error.
 func Execute(
-	memberIndex group.MemberIndex,
-	groupSize int,
-	blockCounter chain.BlockCounter,
+	ctx context.Context,
+	clock state.Clock,
 	channel net.BroadcastChannel,
-	dishonestThreshold int,
-	seed *big.Int,
-	membershipValidator group.MembershipValidator,
-	startBlockHeight uint64,
+	executionConfig ExecutionConfig,
 ) (*Result, uint64, error) {
+	memberIndex := executionConfig.MemberIndex
+	timing := executionConfig.Timing
+	startBlockHeight := executionConfig.StartBlockHeight
+	checkpoint := executionConfig.Checkpoint
+	onCheckpoint := executionConfig.OnCheckpoint
+	transcriptRecorder := executionConfig.TranscriptRecorder
+
 	logger.Debugf("[member:%v] initializing member", memberIndex)
 
 	member, err := NewMember(
 		memberIndex,
-		groupSize,
-		dishonestThreshold,
-		membershipValidator,
-		seed,
+		executionConfig.GroupSize,
+		executionConfig.DishonestThreshold,
+		executionConfig.MembershipValidator,
+		executionConfig.Seed,
+		timing,
 	)
 	if err != nil {
 		return nil, 0, fmt.Errorf("cannot create a new member: [%v]", err)
 	}
+	member.verificationCache = executionConfig.VerificationCache
+
+	if transcriptRecorder != nil {
+		channel = transcriptRecorder.machine.Channel(channel)
+	}
+
+	var stateMachine *state.Machine
+	if checkpoint != nil {
+		logger.Infof(
+			"[member:%v] resuming execution started at block [%v]",
+			memberIndex,
+			checkpoint.StartBlockHeight(),
+		)
+
+		member.secrets = checkpoint.secrets
+		startBlockHeight = checkpoint.StartBlockHeight()
+
+		unmarshalers := make(map[string]func() net.TaggedUnmarshaler)
+		for _, unmarshaller := range unmarshallers() {
+			unmarshalers[unmarshaller().Type()] = unmarshaller
+		}
+
+		stateMachine = state.ResumeMachine(
+			channel,
+			clock,
+			checkpoint.machine,
+			unmarshalers,
+			func(channel net.BroadcastChannel) state.State {
+				return &ephemeralKeyPairGenerationState{
+					channel: channel,
+					member:  member.InitializeEphemeralKeysGeneration(),
+				}
+			},
+		)
+	} else {
+		checkpoint = &Checkpoint{
+			secrets: member.secrets,
+			machine: &state.Checkpoint{StartBlockHeight: startBlockHeight},
+		}
+
+		stateMachine = state.NewMachine(
+			channel,
+			clock,
+			&ephemeralKeyPairGenerationState{
+				channel: channel,
+				member:  member.InitializeEphemeralKeysGeneration(),
+			},
+		)
+	}
 
-	initialState := &ephemeralKeyPairGenerationState{
-		channel: channel,
-		member:  member.InitializeEphemeralKeysGeneration(),
+	if timing.WaitForActiveBlocks {
+		stateMachine.WaitForActiveBlocks()
 	}
 
-	stateMachine := state.NewMachine(channel, blockCounter, initialState)
+	if transcriptRecorder != nil {
+		transcriptRecorder.setSecrets(member.secrets)
+		stateMachine.RecordTranscript(transcriptRecorder.machine)
+	}
+
+	if onCheckpoint != nil {
+		// Secrets are checkpointed before anything derived from them is
+		// sent so that the resumed execution is consistent with what other
+		// members have already received.
+		onCheckpoint(checkpoint)
+		stateMachine.OnCheckpoint(func(machineCheckpoint *state.Checkpoint) {
+			onCheckpoint(&Checkpoint{
+				secrets: member.secrets,
+				machine: machineCheckpoint,
+			})
+		})
+	}
 
-	lastState, endBlockHeight, err := stateMachine.Execute(startBlockHeight)
+	lastState, endBlockHeight, err := stateMachine.Execute(ctx, startBlockHeight)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -4,8 +4,10 @@ package gjkr_test
 
 import (
 	"math/big"
+	"reflect"
 	"sync"
 	"testing"
+	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-core/pkg/altbn128"
@@ -40,6 +42,128 @@ func TestExecute_HappyPath(t *testing.T) {
 	dkgtest.AssertValidGroupPublicKey(t, result)
 }
 
+func TestExecute_HappyPath_WallClock(t *testing.T) {
+	t.Parallel()
+
+	groupSize := 5
+	honestThreshold := 3
+	seed := dkgtest.RandomSeed(t)
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+
+	result, err := dkgtest.RunTestWithWallClock(
+		groupSize,
+		honestThreshold,
+		seed,
+		interceptor,
+		100*time.Millisecond,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgtest.AssertDkgResultPublished(t, result)
+	dkgtest.AssertSuccessfulSignersCount(t, result, groupSize)
+	dkgtest.AssertMemberFailuresCount(t, result, 0)
+	dkgtest.AssertSamePublicKey(t, result)
+	dkgtest.AssertNoMisbehavingMembers(t, result)
+	dkgtest.AssertValidGroupPublicKey(t, result)
+}
+
+func TestExecute_CompletedEarly(t *testing.T) {
+	t.Parallel()
+
+	groupSize := 5
+	honestThreshold := 3
+
+	noInterception := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+	inactiveMember1 := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		publicKeyMessage, ok := msg.(*gjkr.EphemeralPublicKeyMessage)
+		if ok && publicKeyMessage.SenderID() == group.MemberIndex(1) {
+			return nil
+		}
+
+		return msg
+	}
+
+	var tests = map[string]struct {
+		interceptor    func(msg net.TaggedMarshaler) net.TaggedMarshaler
+		earlyMembers   []group.MemberIndex
+		waitingMembers []group.MemberIndex
+		misbehaving    []group.MemberIndex
+	}{
+		"all members complete phases early": {
+			interceptor:  noInterception,
+			earlyMembers: []group.MemberIndex{1, 2, 3, 4, 5},
+		},
+		"some members wait for active blocks": {
+			interceptor:    noInterception,
+			earlyMembers:   []group.MemberIndex{1, 3, 5},
+			waitingMembers: []group.MemberIndex{2, 4},
+		},
+		"all members wait for active blocks": {
+			interceptor:    noInterception,
+			waitingMembers: []group.MemberIndex{1, 2, 3, 4, 5},
+		},
+		"inactive member, all other members complete phases early": {
+			interceptor:  inactiveMember1,
+			earlyMembers: []group.MemberIndex{2, 3, 4, 5},
+			misbehaving:  []group.MemberIndex{1},
+		},
+		"inactive member, some members wait for active blocks": {
+			interceptor:    inactiveMember1,
+			earlyMembers:   []group.MemberIndex{2, 4},
+			waitingMembers: []group.MemberIndex{3, 5},
+			misbehaving:    []group.MemberIndex{1},
+		},
+	}
+
+	for testName, test := range tests {
+		test := test
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			seed := dkgtest.RandomSeed(t)
+
+			result, err := dkgtest.RunTestWithWaitingMembers(
+				groupSize,
+				honestThreshold,
+				seed,
+				test.interceptor,
+				test.waitingMembers...,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			operatingMembers := append(
+				append([]group.MemberIndex{}, test.earlyMembers...),
+				test.waitingMembers...,
+			)
+
+			dkgtest.AssertDkgResultPublished(t, result)
+			dkgtest.AssertSuccessfulSigners(t, result, operatingMembers...)
+			dkgtest.AssertMemberFailuresCount(t, result, len(test.misbehaving))
+			dkgtest.AssertSamePublicKey(t, result)
+			dkgtest.AssertMisbehavingMembers(t, result, test.misbehaving...)
+			dkgtest.AssertValidGroupPublicKey(t, result)
+			// Members sign the result only if they have all come up with the
+			// same group public key and the same misbehaving members.
+			dkgtest.AssertResultSupportingMembers(t, result, operatingMembers...)
+			dkgtest.AssertKeyGenerationCompletedEarly(t, result, test.earlyMembers...)
+			dkgtest.AssertKeyGenerationWaitedForActiveBlocks(
+				t,
+				result,
+				test.waitingMembers...,
+			)
+		})
+	}
+}
+
 func TestExecute_IA_member1_phase1(t *testing.T) {
 	t.Parallel()
 
@@ -1215,6 +1339,128 @@ func TestExecute_InvalidMemberIndex(t *testing.T) {
 	dkgtest.AssertResultSupportingMembers(t, result, []group.MemberIndex{2, 3, 4, 5}...)
 }
 
+// Replay test case - a member performs a false accusation in phase 4 and is
+// disqualified in phase 5. Replayed transcripts of the accused member and of
+// other members explain the disqualification.
+func TestReplay_DQ_member4_falseAccusation_phase5(t *testing.T) {
+	t.Parallel()
+
+	groupSize := 5
+	honestThreshold := 3
+	seed := dkgtest.RandomSeed(t)
+
+	manInTheMiddle, err := newManInTheMiddle(
+		group.MemberIndex(4), // sender
+		groupSize,
+		honestThreshold,
+		seed,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		manInTheMiddle.interceptCommunication(msg)
+
+		accusationsMessage, ok := msg.(*gjkr.SecretSharesAccusationsMessage)
+		if ok && accusationsMessage.SenderID() == group.MemberIndex(4) {
+			accusedMembersKeys := make(map[group.MemberIndex]*ephemeral.PrivateKey)
+			accusedMembersKeys[group.MemberIndex(1)] =
+				manInTheMiddle.ephemeralKeyPairs[group.MemberIndex(1)].PrivateKey
+			accusationsMessage.SetAccusedMemberKeys(accusedMembersKeys)
+			return accusationsMessage
+		}
+
+		return msg
+	}
+
+	result, err := dkgtest.RunTest(groupSize, honestThreshold, seed, interceptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgtest.AssertDkgResultPublished(t, result)
+
+	expectedAccusations := []*gjkr.Accusation{
+		{
+			Phase:     "*gjkr.commitmentsVerificationState",
+			AccuserID: group.MemberIndex(4),
+			AccusedID: group.MemberIndex(1),
+		},
+	}
+
+	var tests = map[string]struct {
+		memberIndex       group.MemberIndex
+		expectedDecisions []*gjkr.Decision
+	}{
+		"replayed by the accused member": {
+			memberIndex: group.MemberIndex(1),
+			expectedDecisions: []*gjkr.Decision{
+				{
+					Phase:    "*gjkr.sharesJustificationState",
+					MemberID: group.MemberIndex(4),
+					Explanation: "accused this member of sending invalid " +
+						"secret shares; this member considers itself honest",
+				},
+			},
+		},
+		"replayed by other member": {
+			memberIndex: group.MemberIndex(2),
+			expectedDecisions: []*gjkr.Decision{
+				{
+					Phase:    "*gjkr.sharesJustificationState",
+					MemberID: group.MemberIndex(4),
+					Explanation: "accusation against member [1] about invalid " +
+						"secret shares could not be confirmed; either the " +
+						"accused member sent valid secret shares, the accused " +
+						"member was already inactive or disqualified, or the " +
+						"revealed ephemeral private key did not match the " +
+						"public key",
+				},
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			report, err := result.ReplayTranscript(test.memberIndex)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(
+				expectedAccusations,
+				report.KeyGeneration.Accusations,
+			) {
+				t.Errorf(
+					"unexpected accusations\nexpected: [%v]\nactual:   [%v]",
+					expectedAccusations,
+					report.KeyGeneration.Accusations,
+				)
+			}
+
+			if !reflect.DeepEqual(
+				test.expectedDecisions,
+				report.KeyGeneration.Decisions,
+			) {
+				t.Errorf(
+					"unexpected decisions\nexpected: [%v]\nactual:   [%v]",
+					test.expectedDecisions,
+					report.KeyGeneration.Decisions,
+				)
+			}
+
+			if report.KeyGeneration.Result == nil {
+				t.Fatal("key generation result is nil")
+			}
+
+			if len(report.ResultSupporters) == 0 {
+				t.Errorf("no supporters of the result")
+			}
+		})
+	}
+}
+
 // manInTheMiddle is a helper tool allowing to easily intercept communication
 // of a chosen member with the rest of the members for all phases of DKG.
 // Man in the middle sets up symmetric keys, member shares, and commitments
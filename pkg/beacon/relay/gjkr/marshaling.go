@@ -2,10 +2,12 @@ package gjkr
 
 import (
 	"fmt"
+	"math/big"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr/gen/pb"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 	"github.com/keep-network/keep-core/pkg/net/ephemeral"
 )
 
@@ -426,3 +428,157 @@ func unmarshalPrivateKeyMap(
 
 	return unmarshalled, nil
 }
+
+// Marshal converts this Checkpoint to a byte array. The result contains
+// member's secrets and must be persisted only in an encrypted form.
+func (c *Checkpoint) Marshal() ([]byte, error) {
+	marshalledPrivateKeys, err := marshalEphemeralPrivateKeys(c.secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	machine, err := c.machine.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal machine checkpoint [%v]", err)
+	}
+
+	return (&pb.Checkpoint{
+		EphemeralPrivateKeys: marshalledPrivateKeys,
+		CoefficientsA:        marshalCoefficients(c.secrets.coefficientsA),
+		CoefficientsB:        marshalCoefficients(c.secrets.coefficientsB),
+		Machine:              machine,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a Checkpoint.
+func (c *Checkpoint) Unmarshal(bytes []byte) error {
+	pbCheckpoint := pb.Checkpoint{}
+	if err := pbCheckpoint.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	secrets, err := unmarshalSecrets(
+		pbCheckpoint.EphemeralPrivateKeys,
+		pbCheckpoint.CoefficientsA,
+		pbCheckpoint.CoefficientsB,
+	)
+	if err != nil {
+		return err
+	}
+
+	machine := &state.Checkpoint{}
+	if err := machine.Unmarshal(pbCheckpoint.Machine); err != nil {
+		return fmt.Errorf("could not unmarshal machine checkpoint [%v]", err)
+	}
+
+	c.secrets = secrets
+	c.machine = machine
+
+	return nil
+}
+
+// Marshal converts this Transcript to a byte array. The result contains
+// member's secrets and must be persisted only in an encrypted form.
+func (t *Transcript) Marshal() ([]byte, error) {
+	marshalledPrivateKeys, err := marshalEphemeralPrivateKeys(t.secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	machine, err := t.machine.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal machine transcript [%v]", err)
+	}
+
+	return (&pb.Transcript{
+		EphemeralPrivateKeys: marshalledPrivateKeys,
+		CoefficientsA:        marshalCoefficients(t.secrets.coefficientsA),
+		CoefficientsB:        marshalCoefficients(t.secrets.coefficientsB),
+		Machine:              machine,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a Transcript.
+func (t *Transcript) Unmarshal(bytes []byte) error {
+	pbTranscript := pb.Transcript{}
+	if err := pbTranscript.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	secrets, err := unmarshalSecrets(
+		pbTranscript.EphemeralPrivateKeys,
+		pbTranscript.CoefficientsA,
+		pbTranscript.CoefficientsB,
+	)
+	if err != nil {
+		return err
+	}
+
+	machine := &state.Transcript{}
+	if err := machine.Unmarshal(pbTranscript.Machine); err != nil {
+		return fmt.Errorf("could not unmarshal machine transcript [%v]", err)
+	}
+
+	t.secrets = secrets
+	t.machine = machine
+
+	return nil
+}
+
+func marshalEphemeralPrivateKeys(
+	secrets *memberSecrets,
+) (map[uint32][]byte, error) {
+	ephemeralPrivateKeys := make(
+		map[group.MemberIndex]*ephemeral.PrivateKey,
+		len(secrets.ephemeralKeyPairs),
+	)
+	for memberID, keyPair := range secrets.ephemeralKeyPairs {
+		ephemeralPrivateKeys[memberID] = keyPair.PrivateKey
+	}
+
+	return marshalPrivateKeyMap(ephemeralPrivateKeys)
+}
+
+func unmarshalSecrets(
+	marshalledPrivateKeys map[uint32][]byte,
+	coefficientsA [][]byte,
+	coefficientsB [][]byte,
+) (*memberSecrets, error) {
+	ephemeralPrivateKeys, err := unmarshalPrivateKeyMap(marshalledPrivateKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeralKeyPairs := make(
+		map[group.MemberIndex]*ephemeral.KeyPair,
+		len(ephemeralPrivateKeys),
+	)
+	for memberID, privateKey := range ephemeralPrivateKeys {
+		ephemeralKeyPairs[memberID] = &ephemeral.KeyPair{
+			PrivateKey: privateKey,
+			PublicKey:  (*ephemeral.PublicKey)(&privateKey.PublicKey),
+		}
+	}
+
+	return &memberSecrets{
+		ephemeralKeyPairs: ephemeralKeyPairs,
+		coefficientsA:     unmarshalCoefficients(coefficientsA),
+		coefficientsB:     unmarshalCoefficients(coefficientsB),
+	}, nil
+}
+
+func marshalCoefficients(coefficients []*big.Int) [][]byte {
+	marshalled := make([][]byte, 0, len(coefficients))
+	for _, coefficient := range coefficients {
+		marshalled = append(marshalled, coefficient.Bytes())
+	}
+	return marshalled
+}
+
+func unmarshalCoefficients(coefficients [][]byte) []*big.Int {
+	unmarshalled := make([]*big.Int, 0, len(coefficients))
+	for _, coefficientBytes := range coefficients {
+		unmarshalled = append(unmarshalled, new(big.Int).SetBytes(coefficientBytes))
+	}
+	return unmarshalled
+}
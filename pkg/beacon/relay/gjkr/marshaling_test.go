@@ -9,6 +9,7 @@ import (
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 	"github.com/keep-network/keep-core/pkg/internal/pbutils"
 	"github.com/keep-network/keep-core/pkg/net/ephemeral"
 )
@@ -406,3 +407,147 @@ func TestFuzzMisbehavedEphemeralKeysMessageRoundtrip(t *testing.T) {
 func TestFuzzMisbehavedEphemeralKeysMessageUnmarshaler(t *testing.T) {
 	pbutils.FuzzUnmarshaler(&MisbehavedEphemeralKeysMessage{})
 }
+
+func TestCheckpointRoundtrip(t *testing.T) {
+	secrets, err := generateMemberSecrets(
+		group.MemberIndex(2),
+		group.NewDkgGroup(2, 5),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint := &Checkpoint{
+		secrets: secrets,
+		machine: &state.Checkpoint{
+			StartBlockHeight: 120,
+			StateIndex:       3,
+			Initiated:        true,
+			Messages: []*state.RecordedMessage{
+				{
+					StateIndex:        2,
+					Type:              "gjkr/member_commitments",
+					SenderPublicKey:   []byte{0x01, 0x02},
+					TransportSenderID: "peer_1",
+					Seqno:             3,
+					Payload:           []byte{0x03, 0x04},
+				},
+			},
+		},
+	}
+	unmarshaled := &Checkpoint{}
+
+	err = pbutils.RoundTrip(checkpoint, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(checkpoint.machine, unmarshaled.machine) {
+		t.Errorf("unexpected content of unmarshaled machine checkpoint")
+	}
+
+	assertSecretsEqual(t, secrets, unmarshaled.secrets)
+}
+
+func TestTranscriptRoundtrip(t *testing.T) {
+	secrets, err := generateMemberSecrets(
+		group.MemberIndex(2),
+		group.NewDkgGroup(2, 5),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transcript := &Transcript{
+		secrets: secrets,
+		machine: &state.Transcript{
+			StartBlockHeight: 120,
+			EndBlockHeight:   150,
+			Phases: []*state.TranscriptPhase{
+				{State: "*gjkr.ephemeralKeyPairGenerationState", StartBlockHeight: 120},
+				{State: "*gjkr.symmetricKeyGenerationState", StartBlockHeight: 125},
+			},
+			Messages: []*state.TranscriptMessage{
+				{
+					RecordedMessage: state.RecordedMessage{
+						StateIndex:        0,
+						Type:              "gjkr/ephemeral_public_key",
+						SenderPublicKey:   []byte{0x01, 0x02},
+						TransportSenderID: "peer_1",
+						Seqno:             3,
+						Payload:           []byte{0x03, 0x04},
+					},
+					BlockHeight: 121,
+				},
+				{
+					RecordedMessage: state.RecordedMessage{
+						StateIndex: 0,
+						Type:       "gjkr/ephemeral_public_key",
+						Payload:    []byte{0x05, 0x06},
+					},
+					Sent:        true,
+					BlockHeight: 120,
+				},
+			},
+		},
+	}
+	unmarshaled := &Transcript{}
+
+	err = pbutils.RoundTrip(transcript, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(transcript.machine, unmarshaled.machine) {
+		t.Errorf("unexpected content of unmarshaled machine transcript")
+	}
+
+	assertSecretsEqual(t, secrets, unmarshaled.secrets)
+}
+
+func assertSecretsEqual(t *testing.T, expected, actual *memberSecrets) {
+	if !reflect.DeepEqual(
+		expected.coefficientsA,
+		actual.coefficientsA,
+	) {
+		t.Errorf("unexpected content of unmarshaled coefficients A")
+	}
+
+	if !reflect.DeepEqual(
+		expected.coefficientsB,
+		actual.coefficientsB,
+	) {
+		t.Errorf("unexpected content of unmarshaled coefficients B")
+	}
+
+	if len(expected.ephemeralKeyPairs) !=
+		len(actual.ephemeralKeyPairs) {
+		t.Fatalf(
+			"unexpected number of ephemeral key pairs\n"+
+				"expected: [%v]\nactual:   [%v]",
+			len(expected.ephemeralKeyPairs),
+			len(actual.ephemeralKeyPairs),
+		)
+	}
+
+	for memberID, keyPair := range expected.ephemeralKeyPairs {
+		unmarshaledKeyPair, ok := actual.ephemeralKeyPairs[memberID]
+		if !ok {
+			t.Fatalf("missing ephemeral key pair for member [%v]", memberID)
+		}
+
+		if !reflect.DeepEqual(
+			keyPair.PrivateKey.Marshal(),
+			unmarshaledKeyPair.PrivateKey.Marshal(),
+		) {
+			t.Errorf("unexpected private key for member [%v]", memberID)
+		}
+
+		if !reflect.DeepEqual(
+			keyPair.PublicKey.Marshal(),
+			unmarshaledKeyPair.PublicKey.Marshal(),
+		) {
+			t.Errorf("unexpected public key for member [%v]", memberID)
+		}
+	}
+}
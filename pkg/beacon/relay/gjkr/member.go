@@ -1,9 +1,11 @@
 package gjkr
 
 import (
+	"fmt"
 	"math/big"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/net/ephemeral"
 )
@@ -25,6 +27,72 @@ type memberCore struct {
 
 	// Cryptographic protocol parameters, the same for all members in the group.
 	protocolParameters *protocolParameters
+
+	// Timing of protocol phases, the same for all members in the group.
+	timing config.DKGTiming
+
+	// Cache shared with other members of the group controlled by the same
+	// node, letting them verify messages once for all of them. If not set,
+	// the member verifies all the messages on its own.
+	verificationCache *VerificationCache
+
+	// Random values used by the member in the protocol, generated upfront so
+	// that they can be checkpointed. If not set, values are generated when
+	// needed.
+	//
+	// These are private values and should not be exposed.
+	secrets *memberSecrets
+}
+
+// memberSecrets are random values generated by the member for the protocol
+// execution.
+type memberSecrets struct {
+	// Ephemeral key pairs generated individually for each other group member.
+	ephemeralKeyPairs map[group.MemberIndex]*ephemeral.KeyPair
+	// Coefficients of the shares polynomial `a` and the hiding polynomial `b`.
+	coefficientsA, coefficientsB []*big.Int
+}
+
+// generateMemberSecrets generates random values used by the given member
+// in the protocol.
+func generateMemberSecrets(
+	memberID group.MemberIndex,
+	dkgGroup *group.Group,
+) (*memberSecrets, error) {
+	ephemeralKeyPairs := make(map[group.MemberIndex]*ephemeral.KeyPair)
+	for _, otherMember := range dkgGroup.MemberIDs() {
+		if otherMember == memberID {
+			continue
+		}
+
+		ephemeralKeyPair, err := ephemeral.GenerateKeyPair()
+		if err != nil {
+			return nil, err
+		}
+
+		ephemeralKeyPairs[otherMember] = ephemeralKeyPair
+	}
+
+	coefficientsA, err := generatePolynomial(dkgGroup.DishonestThreshold())
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not generate shares polynomial [%v]",
+			err,
+		)
+	}
+	coefficientsB, err := generatePolynomial(dkgGroup.DishonestThreshold())
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not generate hiding polynomial [%v]",
+			err,
+		)
+	}
+
+	return &memberSecrets{
+		ephemeralKeyPairs: ephemeralKeyPairs,
+		coefficientsA:     coefficientsA,
+		coefficientsB:     coefficientsB,
+	}, nil
 }
 
 // LocalMember represents one member in a threshold group, prior to the
@@ -230,14 +298,24 @@ func NewMember(
 	dishonestThreshold int,
 	membershipValidator group.MembershipValidator,
 	seed *big.Int,
+	timing config.DKGTiming,
 ) (*LocalMember, error) {
+	dkgGroup := group.NewDkgGroup(dishonestThreshold, groupSize)
+
+	secrets, err := generateMemberSecrets(memberID, dkgGroup)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate member secrets: [%v]", err)
+	}
+
 	return &LocalMember{
 		memberCore: &memberCore{
-			memberID,
-			group.NewDkgGroup(dishonestThreshold, groupSize),
-			membershipValidator,
-			newDkgEvidenceLog(),
-			newProtocolParameters(seed),
+			ID:                  memberID,
+			group:               dkgGroup,
+			membershipValidator: membershipValidator,
+			evidenceLog:         newDkgEvidenceLog(),
+			protocolParameters:  newProtocolParameters(seed),
+			timing:              timing,
+			secrets:             secrets,
 		},
 	}, nil
 }
@@ -90,6 +90,15 @@ func (mc *memberCore) messageFilter() *group.InactiveMemberFilter {
 	return group.NewInactiveMemberFilter(mc.ID, mc.group)
 }
 
+// isReceivedFromAllOperatingMembers returns true if the given senders of
+// messages received in the current phase include all the other members
+// operating in the group.
+func (mc *memberCore) isReceivedFromAllOperatingMembers(
+	senderIDs []group.MemberIndex,
+) bool {
+	return mc.group.IsReceivedFromAllOperatingMembers(mc.ID, senderIDs)
+}
+
 func (mc *memberCore) IsSenderAccepted(senderID group.MemberIndex) bool {
 	return mc.group.IsOperating(senderID)
 }
@@ -38,9 +38,15 @@ func (em *EphemeralKeyPairGeneratingMember) GenerateEphemeralKeyPair() (
 			continue
 		}
 
-		ephemeralKeyPair, err := ephemeral.GenerateKeyPair()
-		if err != nil {
-			return nil, err
+		var ephemeralKeyPair *ephemeral.KeyPair
+		if em.secrets != nil {
+			ephemeralKeyPair = em.secrets.ephemeralKeyPairs[member]
+		} else {
+			var err error
+			ephemeralKeyPair, err = ephemeral.GenerateKeyPair()
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		// save the generated ephemeral key to our state
@@ -154,20 +160,27 @@ func (cm *CommittingMember) CalculateMembersSharesAndCommitments() (
 	*MemberCommitmentsMessage,
 	error,
 ) {
-	polynomialDegree := cm.group.DishonestThreshold()
-	coefficientsA, err := generatePolynomial(polynomialDegree)
-	if err != nil {
-		return nil, nil, fmt.Errorf(
-			"could not generate shares polynomial [%v]",
-			err,
-		)
-	}
-	coefficientsB, err := generatePolynomial(polynomialDegree)
-	if err != nil {
-		return nil, nil, fmt.Errorf(
-			"could not generate hiding polynomial [%v]",
-			err,
-		)
+	var coefficientsA, coefficientsB []*big.Int
+	if cm.secrets != nil {
+		coefficientsA = cm.secrets.coefficientsA
+		coefficientsB = cm.secrets.coefficientsB
+	} else {
+		polynomialDegree := cm.group.DishonestThreshold()
+		var err error
+		coefficientsA, err = generatePolynomial(polynomialDegree)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"could not generate shares polynomial [%v]",
+				err,
+			)
+		}
+		coefficientsB, err = generatePolynomial(polynomialDegree)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"could not generate hiding polynomial [%v]",
+				err,
+			)
+		}
 	}
 
 	cm.secretCoefficients = coefficientsA
@@ -493,15 +506,23 @@ func (cm *CommittingMember) areSharesValidAgainstCommitments(
 		return false
 	}
 
-	var sum *bn256.G1                // Σ (C_j[k] * (i^k)) for k in [0..T]
-	for k, ck := range commitments { // k, C_j[k]
-		ci := new(bn256.G1).ScalarMult(ck, pow(memberID, k)) // C_j[k] * (i^k)
-		if sum == nil {
-			sum = ci
-		} else {
-			sum = new(bn256.G1).Add(sum, ci)
-		}
-	}
+	// Σ (C_j[k] * (i^k)) for k in [0..T]
+	sum := cm.verificationCache.evaluateCommitments(
+		commitments,
+		memberID,
+		func() *bn256.G1 {
+			var sum *bn256.G1
+			for k, ck := range commitments { // k, C_j[k]
+				ci := new(bn256.G1).ScalarMult(ck, pow(memberID, k)) // C_j[k] * (i^k)
+				if sum == nil {
+					sum = ci
+				} else {
+					sum = new(bn256.G1).Add(sum, ci)
+				}
+			}
+			return sum
+		},
+	)
 
 	commitment := cm.calculateCommitment(shareS, shareT) // G * s_ji + H * t_ji
 
@@ -927,17 +948,23 @@ func (sm *SharingMember) publicKeyShare(
 	shareReceiverID group.MemberIndex,
 	publicKeySharePoints []*bn256.G2,
 ) *bn256.G2 {
-	var sum *bn256.G2
-	// Σ ( A_j[k] * (i^k) ) for `k` in `[0..T]`
-	for k, a := range publicKeySharePoints {
-		aj := new(bn256.G2).ScalarMult(a, pow(shareReceiverID, k)) // A_j[k] * (i^k)
-		if sum == nil {
-			sum = aj
-		} else {
-			sum = new(bn256.G2).Add(sum, aj)
-		}
-	}
-	return sum
+	return sm.verificationCache.evaluatePublicKeySharePoints(
+		publicKeySharePoints,
+		shareReceiverID,
+		func() *bn256.G2 {
+			var sum *bn256.G2
+			// Σ ( A_j[k] * (i^k) ) for `k` in `[0..T]`
+			for k, a := range publicKeySharePoints {
+				aj := new(bn256.G2).ScalarMult(a, pow(shareReceiverID, k)) // A_j[k] * (i^k)
+				if sum == nil {
+					sum = aj
+				} else {
+					sum = new(bn256.G2).Add(sum, aj)
+				}
+			}
+			return sum
+		},
+	)
 }
 
 // ResolvePublicKeySharePointsAccusationsMessages resolves complaints received
@@ -0,0 +1,374 @@
+package gjkr
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// ReplayReport describes decisions made by a member when the protocol
+// execution recorded in its transcript is replayed.
+type ReplayReport struct {
+	// Accusations published in the protocol execution, in the order they were
+	// received.
+	Accusations []*Accusation
+	// Decisions about marking other members as inactive or disqualified, in
+	// the order they were made.
+	Decisions []*Decision
+	// Result of the protocol execution; nil if the transcript ends before the
+	// protocol execution completed.
+	Result *Result
+}
+
+// Accusation is an accusation published by one member against another.
+type Accusation struct {
+	// Phase is the name of the state in which the accusation was received.
+	Phase     string
+	AccuserID group.MemberIndex
+	AccusedID group.MemberIndex
+	// Points is true for accusations of sending invalid public key share
+	// points; false for accusations of sending invalid secret shares.
+	Points bool
+}
+
+// Decision is a decision of the member to mark another member as inactive or
+// disqualified.
+type Decision struct {
+	// Phase is the name of the state in which the decision was made.
+	Phase    string
+	MemberID group.MemberIndex
+	// Inactive is true if the member has been marked as inactive and false if
+	// the member has been disqualified.
+	Inactive    bool
+	Explanation string
+}
+
+// Replay repeats the protocol execution recorded in the given transcript by
+// the member with the given index, without sending anything to the network.
+// States are initiated with member's secrets taken from the transcript and
+// receive recorded messages in the phases they were received in. It returns
+// the report of accusations published by members and decisions made by the
+// member.
+func Replay(
+	memberIndex group.MemberIndex,
+	groupSize int,
+	dishonestThreshold int,
+	seed *big.Int,
+	membershipValidator group.MembershipValidator,
+	transcript *Transcript,
+) (*ReplayReport, error) {
+	member, err := NewMember(
+		memberIndex,
+		groupSize,
+		dishonestThreshold,
+		membershipValidator,
+		seed,
+		// States are not bound to blocks when replayed.
+		config.DKGTiming{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a new member: [%v]", err)
+	}
+	member.secrets = transcript.secrets
+
+	unmarshalers := make(map[string]func() net.TaggedUnmarshaler)
+	for _, unmarshaller := range unmarshallers() {
+		unmarshalers[unmarshaller().Type()] = unmarshaller
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	report := &ReplayReport{
+		Accusations: make([]*Accusation, 0),
+		Decisions:   make([]*Decision, 0),
+	}
+
+	var currentState state.State = &ephemeralKeyPairGenerationState{
+		channel: &silentChannel{},
+		member:  member.InitializeEphemeralKeysGeneration(),
+	}
+
+	machine := transcript.machine
+	for stateIndex := 0; stateIndex < len(machine.Phases); stateIndex++ {
+		phase := fmt.Sprintf("%T", currentState)
+		if machine.Phases[stateIndex].State != phase {
+			return nil, fmt.Errorf(
+				"transcript phase [%v] is [%v] but the replayed state is [%v]",
+				stateIndex,
+				machine.Phases[stateIndex].State,
+				phase,
+			)
+		}
+
+		inactiveBefore := len(member.group.InactiveMemberIDs())
+		disqualifiedBefore := len(member.group.DisqualifiedMemberIDs())
+
+		if err := currentState.Initiate(ctx); err != nil {
+			return nil, fmt.Errorf(
+				"failed to replay initiation of state [%v]: [%v]",
+				phase,
+				err,
+			)
+		}
+
+		for _, recorded := range machine.Messages {
+			if recorded.Sent || recorded.StateIndex != stateIndex {
+				continue
+			}
+
+			msg, err := recorded.NetMessage(unmarshalers)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"failed to replay message in state [%v]: [%v]",
+					phase,
+					err,
+				)
+			}
+
+			report.Accusations = append(
+				report.Accusations,
+				accusations(phase, msg)...,
+			)
+
+			if err := currentState.Receive(msg); err != nil {
+				logger.Warningf(
+					"[member:%v] replayed state [%v] failed to receive "+
+						"a message: [%v]",
+					memberIndex,
+					phase,
+					err,
+				)
+			}
+		}
+
+		nextState := currentState.Next()
+
+		for _, memberID := range member.group.InactiveMemberIDs()[inactiveBefore:] {
+			report.Decisions = append(report.Decisions, &Decision{
+				Phase:       phase,
+				MemberID:    memberID,
+				Inactive:    true,
+				Explanation: explainInactivity(phase),
+			})
+		}
+		for _, memberID := range member.group.DisqualifiedMemberIDs()[disqualifiedBefore:] {
+			report.Decisions = append(report.Decisions, &Decision{
+				Phase:    phase,
+				MemberID: memberID,
+				Explanation: explainDisqualification(
+					phase,
+					memberIndex,
+					memberID,
+					report.Accusations,
+				),
+			})
+		}
+
+		if final, ok := currentState.(*finalizationState); ok {
+			report.Result = final.result()
+			break
+		}
+
+		if nextState == nil {
+			break
+		}
+		currentState = nextState
+	}
+
+	return report, nil
+}
+
+// accusations returns accusations carried by the given message, if any.
+// Accusations retransmitted to members which already moved on to a later
+// state are not processed by the protocol and are not returned.
+func accusations(phase string, msg net.Message) []*Accusation {
+	result := make([]*Accusation, 0)
+
+	switch payload := msg.Payload().(type) {
+	case *SecretSharesAccusationsMessage:
+		if phase != "*gjkr.commitmentsVerificationState" {
+			break
+		}
+		for accusedID := range payload.accusedMembersKeys {
+			result = append(result, &Accusation{
+				Phase:     phase,
+				AccuserID: payload.senderID,
+				AccusedID: accusedID,
+			})
+		}
+	case *PointsAccusationsMessage:
+		if phase != "*gjkr.pointsValidationState" {
+			break
+		}
+		for accusedID := range payload.accusedMembersKeys {
+			result = append(result, &Accusation{
+				Phase:     phase,
+				AccuserID: payload.senderID,
+				AccusedID: accusedID,
+				Points:    true,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].AccusedID < result[j].AccusedID
+	})
+
+	return result
+}
+
+// inactivityExplanations explains marking members as inactive, keyed by the
+// state in which members are marked as inactive.
+var inactivityExplanations = map[string]string{
+	"*gjkr.symmetricKeyGenerationState": "did not broadcast ephemeral " +
+		"public keys in phase 1",
+	"*gjkr.commitmentsVerificationState": "did not broadcast both peer " +
+		"shares and commitments in phase 3",
+	"*gjkr.sharesJustificationState": "did not broadcast secret shares " +
+		"accusations in phase 4",
+	"*gjkr.pointsValidationState": "did not broadcast public key share " +
+		"points in phase 7",
+	"*gjkr.pointsJustificationState": "did not broadcast public key share " +
+		"points accusations in phase 8",
+	"*gjkr.reconstructionState": "did not broadcast ephemeral keys of " +
+		"misbehaved members in phase 10",
+}
+
+// disqualificationExplanations explains disqualifying members not involved
+// in accusations, keyed by the state in which members are disqualified.
+var disqualificationExplanations = map[string]string{
+	"*gjkr.symmetricKeyGenerationState": "broadcast ephemeral public keys " +
+		"not covering all other members in phase 1",
+	"*gjkr.commitmentsVerificationState": "broadcast invalid commitments or " +
+		"peer shares in phase 3",
+	"*gjkr.pointsValidationState": "broadcast invalid public key share " +
+		"points in phase 7",
+	"*gjkr.reconstructionState": "revealed in phase 10 ephemeral keys which " +
+		"do not match its public keys or were generated for members which " +
+		"have not been disqualified",
+}
+
+func explainInactivity(phase string) string {
+	if explanation, ok := inactivityExplanations[phase]; ok {
+		return explanation
+	}
+
+	return "did not broadcast the expected message"
+}
+
+// explainDisqualification explains why the given member has been
+// disqualified in the given phase, looking at accusations the member was
+// involved in. In phases resolving accusations, accusations published in the
+// previous phase are taken into account. In other phases, only accusations
+// published by the member replaying the transcript are, since those are
+// published along with the decision.
+func explainDisqualification(
+	phase string,
+	selfID group.MemberIndex,
+	memberID group.MemberIndex,
+	accusations []*Accusation,
+) string {
+	resolvedPhase := previousPhase(phase)
+
+	for _, accusation := range accusations {
+		subject := "secret shares"
+		if accusation.Points {
+			subject = "public key share points"
+		}
+
+		if resolvedPhase == "" {
+			if accusation.Phase == phase &&
+				accusation.AccuserID == selfID &&
+				accusation.AccusedID == memberID {
+				return fmt.Sprintf(
+					"sent %v which this member found invalid; this "+
+						"member accused them",
+					subject,
+				)
+			}
+			continue
+		}
+
+		if accusation.Phase != resolvedPhase {
+			continue
+		}
+
+		switch {
+		case accusation.AccusedID == selfID && accusation.AccuserID == memberID:
+			return fmt.Sprintf(
+				"accused this member of sending invalid %v; this member "+
+					"considers itself honest",
+				subject,
+			)
+		case accusation.AccusedID == memberID:
+			return fmt.Sprintf(
+				"accusation of member [%v] about invalid %v has been "+
+					"confirmed",
+				accusation.AccuserID,
+				subject,
+			)
+		case accusation.AccuserID == memberID:
+			return fmt.Sprintf(
+				"accusation against member [%v] about invalid %v could "+
+					"not be confirmed; either the accused member sent "+
+					"valid %v, the accused member was already inactive or "+
+					"disqualified, or the revealed ephemeral private key "+
+					"did not match the public key",
+				accusation.AccusedID,
+				subject,
+				subject,
+			)
+		}
+	}
+
+	if explanation, ok := disqualificationExplanations[phase]; ok {
+		return explanation
+	}
+
+	return "misbehaved in the protocol"
+}
+
+// previousPhase returns the name of the state in which accusations resolved
+// in the given state are published.
+func previousPhase(phase string) string {
+	switch phase {
+	case "*gjkr.sharesJustificationState":
+		return "*gjkr.commitmentsVerificationState"
+	case "*gjkr.pointsJustificationState":
+		return "*gjkr.pointsValidationState"
+	}
+
+	return ""
+}
+
+// silentChannel is a broadcast channel given to replayed states. Messages
+// sent by replayed states have already been sent during the recorded
+// execution so they are dropped.
+type silentChannel struct{}
+
+func (sc *silentChannel) Name() string {
+	return "replay"
+}
+
+func (sc *silentChannel) Send(ctx context.Context, m net.TaggedMarshaler) error {
+	return nil
+}
+
+func (sc *silentChannel) Recv(ctx context.Context, handler func(m net.Message)) {}
+
+func (sc *silentChannel) RegisterUnmarshaler(
+	unmarshaler func() net.TaggedUnmarshaler,
+) error {
+	return nil
+}
+
+func (sc *silentChannel) SetFilter(filter net.BroadcastChannelFilter) error {
+	return nil
+}
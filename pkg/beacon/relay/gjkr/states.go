@@ -13,27 +13,6 @@ type keyGenerationState = state.State
 const (
 	silentStateDelayBlocks  = 0
 	silentStateActiveBlocks = 0
-
-	ephemeralKeyPairStateDelayBlocks  = 1
-	ephemeralKeyPairStateActiveBlocks = 5
-
-	commitmentStateDelayBlocks  = 1
-	commitmentStateActiveBlocks = 5
-
-	commitmentVerificationStateDelayBlocks  = 1
-	commitmentVerificationStateActiveBlocks = 10
-
-	pointsShareStateDelayBlocks  = 1
-	pointsShareStateActiveBlocks = 5
-
-	pointsValidationStateDelayBlocks  = 1
-	pointsValidationStateActiveBlocks = 10
-
-	keyRevealStateDelayBlocks  = 1
-	keyRevealStateActiveBlocks = 5
-
-	combinationStateDelayBlocks  = 0
-	combinationStateActiveBlocks = 20
 )
 
 // ephemeralKeyPairGenerationState is the state during which members broadcast
@@ -49,11 +28,11 @@ type ephemeralKeyPairGenerationState struct {
 }
 
 func (ekpgs *ephemeralKeyPairGenerationState) DelayBlocks() uint64 {
-	return ephemeralKeyPairStateDelayBlocks
+	return ekpgs.member.timing.EphemeralKeyPair.DelayBlocks
 }
 
 func (ekpgs *ephemeralKeyPairGenerationState) ActiveBlocks() uint64 {
-	return ephemeralKeyPairStateActiveBlocks
+	return ekpgs.member.timing.EphemeralKeyPair.ActiveBlocks
 }
 
 func (ekpgs *ephemeralKeyPairGenerationState) Initiate(ctx context.Context) error {
@@ -81,6 +60,15 @@ func (ekpgs *ephemeralKeyPairGenerationState) Receive(msg net.Message) error {
 	return nil
 }
 
+func (ekpgs *ephemeralKeyPairGenerationState) IsComplete() bool {
+	phaseSenders := make([]group.MemberIndex, 0)
+	for _, message := range ekpgs.phaseMessages {
+		phaseSenders = append(phaseSenders, message.SenderID())
+	}
+
+	return ekpgs.member.isReceivedFromAllOperatingMembers(phaseSenders)
+}
+
 func (ekpgs *ephemeralKeyPairGenerationState) Next() keyGenerationState {
 	return &symmetricKeyGenerationState{
 		channel:               ekpgs.channel,
@@ -148,11 +136,11 @@ type commitmentState struct {
 }
 
 func (cs *commitmentState) DelayBlocks() uint64 {
-	return commitmentStateDelayBlocks
+	return cs.member.timing.Commitment.DelayBlocks
 }
 
 func (cs *commitmentState) ActiveBlocks() uint64 {
-	return commitmentStateActiveBlocks
+	return cs.member.timing.Commitment.ActiveBlocks
 }
 
 func (cs *commitmentState) Initiate(ctx context.Context) error {
@@ -195,6 +183,21 @@ func (cs *commitmentState) Receive(msg net.Message) error {
 	return nil
 }
 
+func (cs *commitmentState) IsComplete() bool {
+	sharesSenders := make([]group.MemberIndex, 0)
+	for _, message := range cs.phaseSharesMessages {
+		sharesSenders = append(sharesSenders, message.SenderID())
+	}
+
+	commitmentsSenders := make([]group.MemberIndex, 0)
+	for _, message := range cs.phaseCommitmentsMessages {
+		commitmentsSenders = append(commitmentsSenders, message.SenderID())
+	}
+
+	return cs.member.isReceivedFromAllOperatingMembers(sharesSenders) &&
+		cs.member.isReceivedFromAllOperatingMembers(commitmentsSenders)
+}
+
 func (cs *commitmentState) Next() keyGenerationState {
 	return &commitmentsVerificationState{
 		channel: cs.channel,
@@ -225,11 +228,11 @@ type commitmentsVerificationState struct {
 }
 
 func (cvs *commitmentsVerificationState) DelayBlocks() uint64 {
-	return commitmentVerificationStateDelayBlocks
+	return cvs.member.timing.CommitmentVerification.DelayBlocks
 }
 
 func (cvs *commitmentsVerificationState) ActiveBlocks() uint64 {
-	return commitmentVerificationStateActiveBlocks
+	return cvs.member.timing.CommitmentVerification.ActiveBlocks
 }
 
 func (cvs *commitmentsVerificationState) Initiate(ctx context.Context) error {
@@ -268,6 +271,15 @@ func (cvs *commitmentsVerificationState) Receive(msg net.Message) error {
 	return nil
 }
 
+func (cvs *commitmentsVerificationState) IsComplete() bool {
+	phaseSenders := make([]group.MemberIndex, 0)
+	for _, message := range cvs.phaseAccusationsMessages {
+		phaseSenders = append(phaseSenders, message.SenderID())
+	}
+
+	return cvs.member.isReceivedFromAllOperatingMembers(phaseSenders)
+}
+
 func (cvs *commitmentsVerificationState) Next() keyGenerationState {
 	return &sharesJustificationState{
 		channel: cvs.channel,
@@ -380,11 +392,11 @@ type pointsShareState struct {
 }
 
 func (pss *pointsShareState) DelayBlocks() uint64 {
-	return pointsShareStateDelayBlocks
+	return pss.member.timing.PointsShare.DelayBlocks
 }
 
 func (pss *pointsShareState) ActiveBlocks() uint64 {
-	return pointsShareStateActiveBlocks
+	return pss.member.timing.PointsShare.ActiveBlocks
 }
 
 func (pss *pointsShareState) Initiate(ctx context.Context) error {
@@ -409,6 +421,15 @@ func (pss *pointsShareState) Receive(msg net.Message) error {
 	return nil
 }
 
+func (pss *pointsShareState) IsComplete() bool {
+	phaseSenders := make([]group.MemberIndex, 0)
+	for _, message := range pss.phaseMessages {
+		phaseSenders = append(phaseSenders, message.SenderID())
+	}
+
+	return pss.member.isReceivedFromAllOperatingMembers(phaseSenders)
+}
+
 func (pss *pointsShareState) Next() keyGenerationState {
 	return &pointsValidationState{
 		channel: pss.channel,
@@ -437,11 +458,11 @@ type pointsValidationState struct {
 }
 
 func (pvs *pointsValidationState) DelayBlocks() uint64 {
-	return pointsValidationStateDelayBlocks
+	return pvs.member.timing.PointsValidation.DelayBlocks
 }
 
 func (pvs *pointsValidationState) ActiveBlocks() uint64 {
-	return pointsValidationStateActiveBlocks
+	return pvs.member.timing.PointsValidation.ActiveBlocks
 }
 
 func (pvs *pointsValidationState) Initiate(ctx context.Context) error {
@@ -473,6 +494,15 @@ func (pvs *pointsValidationState) Receive(msg net.Message) error {
 	return nil
 }
 
+func (pvs *pointsValidationState) IsComplete() bool {
+	phaseSenders := make([]group.MemberIndex, 0)
+	for _, message := range pvs.phaseMessages {
+		phaseSenders = append(phaseSenders, message.SenderID())
+	}
+
+	return pvs.member.isReceivedFromAllOperatingMembers(phaseSenders)
+}
+
 func (pvs *pointsValidationState) Next() keyGenerationState {
 	return &pointsJustificationState{
 		channel: pvs.channel,
@@ -547,11 +577,11 @@ type keyRevealState struct {
 }
 
 func (rs *keyRevealState) DelayBlocks() uint64 {
-	return keyRevealStateDelayBlocks
+	return rs.member.timing.KeyReveal.DelayBlocks
 }
 
 func (rs *keyRevealState) ActiveBlocks() uint64 {
-	return keyRevealStateActiveBlocks
+	return rs.member.timing.KeyReveal.ActiveBlocks
 }
 
 func (rs *keyRevealState) Initiate(ctx context.Context) error {
@@ -580,6 +610,15 @@ func (rs *keyRevealState) Receive(msg net.Message) error {
 	return nil
 }
 
+func (rs *keyRevealState) IsComplete() bool {
+	phaseSenders := make([]group.MemberIndex, 0)
+	for _, message := range rs.phaseMessages {
+		phaseSenders = append(phaseSenders, message.SenderID())
+	}
+
+	return rs.member.isReceivedFromAllOperatingMembers(phaseSenders)
+}
+
 func (rs *keyRevealState) Next() keyGenerationState {
 	return &reconstructionState{
 		channel:               rs.channel,
@@ -649,11 +688,11 @@ type combinationState struct {
 }
 
 func (cs *combinationState) DelayBlocks() uint64 {
-	return combinationStateDelayBlocks
+	return cs.member.timing.Combination.DelayBlocks
 }
 
 func (cs *combinationState) ActiveBlocks() uint64 {
-	return combinationStateActiveBlocks
+	return cs.member.timing.Combination.ActiveBlocks
 }
 
 func (cs *combinationState) Initiate(ctx context.Context) error {
@@ -666,6 +705,12 @@ func (cs *combinationState) Receive(msg net.Message) error {
 	return nil
 }
 
+func (cs *combinationState) IsComplete() bool {
+	// The group public key is combined when the state is initiated; there are
+	// no messages to wait for.
+	return true
+}
+
 func (cs *combinationState) Next() keyGenerationState {
 	return &finalizationState{
 		channel: cs.channel,
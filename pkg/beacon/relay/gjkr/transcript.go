@@ -0,0 +1,60 @@
+package gjkr
+
+import (
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+)
+
+// Transcript is the record of the protocol execution by one member. Along
+// with all messages the member received and sent, it contains member's
+// secrets so that the verification performed by the member can be repeated.
+// Transcript must be persisted only in an encrypted form.
+type Transcript struct {
+	secrets *memberSecrets
+	machine *state.Transcript
+}
+
+// Machine returns the transcript of the protocol state machine.
+func (t *Transcript) Machine() *state.Transcript {
+	return t.machine
+}
+
+// TranscriptRecorder records the transcript of the protocol execution.
+type TranscriptRecorder struct {
+	machine *state.TranscriptRecorder
+
+	mutex   sync.Mutex
+	secrets *memberSecrets
+}
+
+// NewTranscriptRecorder returns a recorder reading block heights of recorded
+// messages from the given clock.
+func NewTranscriptRecorder(clock state.Clock) *TranscriptRecorder {
+	return &TranscriptRecorder{
+		machine: state.NewTranscriptRecorder(clock),
+	}
+}
+
+// Transcript returns the transcript recorded so far or nil if the protocol
+// execution has not started yet.
+func (tr *TranscriptRecorder) Transcript() *Transcript {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	if tr.secrets == nil {
+		return nil
+	}
+
+	return &Transcript{
+		secrets: tr.secrets,
+		machine: tr.machine.Transcript(),
+	}
+}
+
+func (tr *TranscriptRecorder) setSecrets(secrets *memberSecrets) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.secrets = secrets
+}
@@ -0,0 +1,128 @@
+package gjkr
+
+import (
+	"crypto/sha256"
+	"hash"
+	"sync"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+// VerificationCache lets several members of one group controlled by the same
+// node share the verification of protocol messages.
+//
+// Shares are verified against commitments and public key share points
+// evaluated for the member the shares were calculated for. Members evaluate
+// the same points when they resolve accusations, reconstruct keys of
+// misbehaving members and compute group public key shares, so members sharing
+// the cache evaluate each points only once instead of once per member.
+//
+// Evaluations are identified by the evaluated points, not by the member who
+// sent them, so a member never uses an evaluation of points it has not
+// received itself.
+type VerificationCache struct {
+	mutex       sync.Mutex
+	evaluations map[evaluationKey]*evaluation
+}
+
+type evaluationKey struct {
+	memberID group.MemberIndex
+	points   [sha256.Size]byte
+}
+
+type evaluation struct {
+	once  sync.Once
+	point interface{}
+}
+
+// NewVerificationCache returns an empty cache to be shared by members of one
+// group.
+func NewVerificationCache() *VerificationCache {
+	return &VerificationCache{
+		evaluations: make(map[evaluationKey]*evaluation),
+	}
+}
+
+// evaluateCommitments returns `Σ (C_j[k] * (i^k))` for `k` in `[0..T]`
+// evaluated with the given function, or the same evaluation performed
+// earlier by another member. If the cache is nil, commitments are evaluated
+// every time.
+func (vc *VerificationCache) evaluateCommitments(
+	commitments []*bn256.G1, // C_j
+	memberID group.MemberIndex, // i
+	evaluate func() *bn256.G1,
+) *bn256.G1 {
+	if vc == nil {
+		return evaluate()
+	}
+
+	digest := sha256.New()
+	for _, commitment := range commitments {
+		digest.Write(commitment.Marshal())
+	}
+
+	point := vc.evaluation(memberID, digest, func() interface{} {
+		return evaluate()
+	})
+
+	if point.(*bn256.G1) == nil {
+		return nil
+	}
+	return new(bn256.G1).Set(point.(*bn256.G1))
+}
+
+// evaluatePublicKeySharePoints returns `Σ (A_j[k] * (i^k))` for `k` in
+// `[0..T]` evaluated with the given function, or the same evaluation
+// performed earlier by another member. If the cache is nil, public key share
+// points are evaluated every time.
+func (vc *VerificationCache) evaluatePublicKeySharePoints(
+	publicKeySharePoints []*bn256.G2, // A_j
+	memberID group.MemberIndex, // i
+	evaluate func() *bn256.G2,
+) *bn256.G2 {
+	if vc == nil {
+		return evaluate()
+	}
+
+	digest := sha256.New()
+	for _, point := range publicKeySharePoints {
+		digest.Write(point.Marshal())
+	}
+
+	point := vc.evaluation(memberID, digest, func() interface{} {
+		return evaluate()
+	})
+
+	if point.(*bn256.G2) == nil {
+		return nil
+	}
+	return new(bn256.G2).Set(point.(*bn256.G2))
+}
+
+// evaluation returns the evaluation of points with the given digest for the
+// given member. Points are evaluated once even if several members ask for
+// the evaluation at the same time; the others wait for the result. Evaluated
+// points are never modified, callers get their copies.
+func (vc *VerificationCache) evaluation(
+	memberID group.MemberIndex,
+	digest hash.Hash,
+	evaluate func() interface{},
+) interface{} {
+	key := evaluationKey{memberID: memberID}
+	copy(key.points[:], digest.Sum(nil))
+
+	vc.mutex.Lock()
+	cached, ok := vc.evaluations[key]
+	if !ok {
+		cached = &evaluation{}
+		vc.evaluations[key] = cached
+	}
+	vc.mutex.Unlock()
+
+	cached.once.Do(func() {
+		cached.point = evaluate()
+	})
+
+	return cached.point
+}
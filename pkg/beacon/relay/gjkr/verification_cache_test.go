@@ -0,0 +1,186 @@
+package gjkr
+
+import (
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+func TestVerificationCacheEvaluatesCommitmentsOnce(t *testing.T) {
+	commitments := []*bn256.G1{
+		new(bn256.G1).ScalarBaseMult(big.NewInt(10)),
+		new(bn256.G1).ScalarBaseMult(big.NewInt(11)),
+	}
+	otherCommitments := []*bn256.G1{
+		new(bn256.G1).ScalarBaseMult(big.NewInt(20)),
+		new(bn256.G1).ScalarBaseMult(big.NewInt(21)),
+	}
+
+	var tests = map[string]struct {
+		cache               *VerificationCache
+		secondCommitments   []*bn256.G1
+		secondMemberID      group.MemberIndex
+		expectedEvaluations int
+	}{
+		"same commitments evaluated for the same member": {
+			cache:               NewVerificationCache(),
+			secondCommitments:   commitments,
+			secondMemberID:      1,
+			expectedEvaluations: 1,
+		},
+		"same commitments evaluated for another member": {
+			cache:               NewVerificationCache(),
+			secondCommitments:   commitments,
+			secondMemberID:      2,
+			expectedEvaluations: 2,
+		},
+		"other commitments evaluated for the same member": {
+			cache:               NewVerificationCache(),
+			secondCommitments:   otherCommitments,
+			secondMemberID:      1,
+			expectedEvaluations: 2,
+		},
+		"no cache": {
+			cache:               nil,
+			secondCommitments:   commitments,
+			secondMemberID:      1,
+			expectedEvaluations: 2,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			evaluations := 0
+			evaluate := func(value int64) func() *bn256.G1 {
+				return func() *bn256.G1 {
+					evaluations++
+					return new(bn256.G1).ScalarBaseMult(big.NewInt(value))
+				}
+			}
+
+			first := test.cache.evaluateCommitments(commitments, 1, evaluate(1))
+			second := test.cache.evaluateCommitments(
+				test.secondCommitments,
+				test.secondMemberID,
+				evaluate(2),
+			)
+
+			if evaluations != test.expectedEvaluations {
+				t.Errorf(
+					"unexpected number of evaluations\nexpected: [%v]\nactual:   [%v]",
+					test.expectedEvaluations,
+					evaluations,
+				)
+			}
+
+			expectedSecond := new(bn256.G1).ScalarBaseMult(
+				big.NewInt(int64(test.expectedEvaluations)),
+			)
+			if second.String() != expectedSecond.String() {
+				t.Errorf(
+					"unexpected evaluation\nexpected: [%v]\nactual:   [%v]",
+					expectedSecond,
+					second,
+				)
+			}
+
+			if first == second {
+				t.Errorf("members got the same instance of the evaluation")
+			}
+		})
+	}
+}
+
+func TestVerificationCacheEvaluatesPublicKeySharePointsOnce(t *testing.T) {
+	cache := NewVerificationCache()
+
+	publicKeySharePoints := []*bn256.G2{
+		new(bn256.G2).ScalarBaseMult(big.NewInt(10)),
+		new(bn256.G2).ScalarBaseMult(big.NewInt(11)),
+	}
+
+	evaluations := 0
+	evaluate := func() *bn256.G2 {
+		evaluations++
+		return new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+	}
+
+	first := cache.evaluatePublicKeySharePoints(publicKeySharePoints, 1, evaluate)
+	// The evaluation returned is a copy; modifying it does not affect the
+	// evaluation other members get.
+	first.Add(first, first)
+
+	second := cache.evaluatePublicKeySharePoints(publicKeySharePoints, 1, evaluate)
+
+	if evaluations != 1 {
+		t.Errorf(
+			"unexpected number of evaluations\nexpected: [%v]\nactual:   [%v]",
+			1,
+			evaluations,
+		)
+	}
+
+	expectedSecond := new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+	if second.String() != expectedSecond.String() {
+		t.Errorf(
+			"unexpected evaluation\nexpected: [%v]\nactual:   [%v]",
+			expectedSecond,
+			second,
+		)
+	}
+}
+
+func TestMembersSharingVerificationCacheVerifyShares(t *testing.T) {
+	members, err := initializeCommittingMembersGroup(1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache := NewVerificationCache()
+	for _, member := range members {
+		member.verificationCache = cache
+	}
+
+	sender := members[0]
+	receiver := members[1]
+
+	sharesMessage, commitmentsMessage, err :=
+		sender.CalculateMembersSharesAndCommitments()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shareS, shareT, err := sharesMessage.decryptShares(
+		receiver.ID,
+		receiver.symmetricKeys[sender.ID],
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitments := commitmentsMessage.commitments
+	receiverID := receiver.ID
+
+	invalidShareS := new(big.Int).Add(shareS, big.NewInt(1))
+
+	for _, member := range members[1:] {
+		if !member.areSharesValidAgainstCommitments(
+			shareS,
+			shareT,
+			commitments,
+			receiverID,
+		) {
+			t.Errorf("member [%v] rejected valid shares", member.ID)
+		}
+
+		if member.areSharesValidAgainstCommitments(
+			invalidShareS,
+			shareT,
+			commitments,
+			receiverID,
+		) {
+			t.Errorf("member [%v] accepted invalid shares", member.ID)
+		}
+	}
+}
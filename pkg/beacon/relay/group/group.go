@@ -73,6 +73,27 @@ func (g *Group) OperatingMemberIDs() []MemberIndex {
 	return operatingMembers
 }
 
+// IsReceivedFromAllOperatingMembers returns true if the given senders of
+// messages include all members operating in the group except the receiver of
+// those messages.
+func (g *Group) IsReceivedFromAllOperatingMembers(
+	receiverID MemberIndex,
+	senderIDs []MemberIndex,
+) bool {
+	senders := make(map[MemberIndex]bool)
+	for _, senderID := range senderIDs {
+		senders[senderID] = true
+	}
+
+	for _, operatingMemberID := range g.OperatingMemberIDs() {
+		if operatingMemberID != receiverID && !senders[operatingMemberID] {
+			return false
+		}
+	}
+
+	return true
+}
+
 // MarkMemberAsDisqualified adds the member with the given ID to the list of
 // disqualified members. If the member is not a part of the group, is already
 // disqualified or marked as inactive, method does nothing.
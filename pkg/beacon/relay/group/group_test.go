@@ -228,3 +228,68 @@ func TestOperatingMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestIsReceivedFromAllOperatingMembers(t *testing.T) {
+	var tests = map[string]struct {
+		updateFunc     func(g *Group)
+		senderIDs      []MemberIndex
+		expectedResult bool
+	}{
+		"received from all other members": {
+			senderIDs:      []MemberIndex{1, 2, 4},
+			expectedResult: true,
+		},
+		"received from all other members and the receiver": {
+			senderIDs:      []MemberIndex{4, 3, 2, 1},
+			expectedResult: true,
+		},
+		"received from all other members more than once": {
+			senderIDs:      []MemberIndex{1, 2, 2, 4, 1},
+			expectedResult: true,
+		},
+		"one member missing": {
+			senderIDs:      []MemberIndex{1, 4},
+			expectedResult: false,
+		},
+		"one member missing but messages duplicated": {
+			senderIDs:      []MemberIndex{1, 1, 4},
+			expectedResult: false,
+		},
+		"disqualified member missing": {
+			updateFunc: func(g *Group) {
+				g.MarkMemberAsDisqualified(2)
+			},
+			senderIDs:      []MemberIndex{1, 4},
+			expectedResult: true,
+		},
+		"inactive member missing": {
+			updateFunc: func(g *Group) {
+				g.MarkMemberAsInactive(4)
+			},
+			senderIDs:      []MemberIndex{1, 2},
+			expectedResult: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			group := &Group{
+				memberIDs: []MemberIndex{1, 2, 3, 4},
+			}
+
+			if test.updateFunc != nil {
+				test.updateFunc(group)
+			}
+
+			result := group.IsReceivedFromAllOperatingMembers(3, test.senderIDs)
+
+			if test.expectedResult != result {
+				t.Errorf(
+					"unexpected result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedResult,
+					result,
+				)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package groupselection
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"math/big"
 	"sort"
@@ -17,19 +19,6 @@ import (
 
 var logger = log.Logger("keep-groupselection")
 
-// Recommended parameters all clients should use to minimize their expenses.
-// It is not a must to obey but it is nice and polite. And being nice to others
-// helps in reducing own costs because other clients should respect the same
-// protocol.
-const (
-	// The number of blocks one round takes.
-	roundDuration = uint64(3)
-
-	// The delay in blocks after all rounds complete to ensure all transactions
-	// are mined
-	miningLag = uint64(6)
-)
-
 // Result represents the result of group selection protocol. It contains the
 // list of all stakers selected to the candidate group as well as the number of
 // block at which the group selection protocol completed.
@@ -43,7 +32,8 @@ type Result struct {
 //
 // To minimize the submitter's cost by minimizing the number of redundant
 // tickets that are not selected into the group, tickets are submitted in
-// N rounds, each round taking 3 blocks.
+// N rounds, each round taking the number of blocks configured in the group
+// selection timing of the chain.
 // As the basic principle, the number of leading zeros in the ticket
 // value is subtracted from the number of rounds to determine the round
 // the ticket should be submitted in:
@@ -57,14 +47,24 @@ type Result struct {
 // the candidate not yet submitted to determine if continuing with
 // ticket submission still makes sense.
 //
-// After the last round, there is a 6 blocks mining lag allowing all
-// outstanding ticket submissions to have a higher chance of being
-// mined before the deadline.
+// After the last round, there is a mining lag of
+// GroupSelectionTiming.MiningLagBlocks blocks allowing all outstanding
+// ticket submissions to have a higher chance of being mined before the
+// deadline.
+//
+// Ticket submission is abandoned and onGroupSelected is never called when the
+// context is done before the group selection completes.
+//
+// Ticket submission is also stopped when the staker starts undelegating or its
+// stake drops below the minimum stake. Tickets submitted so far may still
+// qualify the staker to the group so onGroupSelected is called in that case.
 func CandidateToNewGroup(
+	ctx context.Context,
 	relayChain relaychain.Interface,
 	blockCounter chain.BlockCounter,
 	chainConfig *config.Chain,
 	staker chain.Staker,
+	stakeMonitor chain.StakeMonitor,
 	newEntry *big.Int,
 	startBlockHeight uint64,
 	onGroupSelected func(*Result),
@@ -84,9 +84,21 @@ func CandidateToNewGroup(
 		return err
 	}
 
+	ticketsGenerated.Add(float64(len(tickets)))
+
+	submissionCtx, cancelSubmission := context.WithCancel(ctx)
+	defer cancelSubmission()
+
+	unsubscribe, err := watchEligibility(stakeMonitor, staker, cancelSubmission)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
 	logger.Infof("starting ticket submission with [%v] tickets", len(tickets))
 
 	err = submitTickets(
+		submissionCtx,
 		tickets,
 		relayChain,
 		blockCounter,
@@ -106,7 +118,12 @@ func CandidateToNewGroup(
 		return err
 	}
 
-	ticketSubmissionEndBlockHeight := <-ticketSubmissionTimeoutChannel
+	var ticketSubmissionEndBlockHeight uint64
+	select {
+	case ticketSubmissionEndBlockHeight = <-ticketSubmissionTimeoutChannel:
+	case <-ctx.Done():
+		return fmt.Errorf("group selection cancelled: [%v]", ctx.Err())
+	}
 
 	logger.Infof(
 		"ticket submission ended at block [%v]",
@@ -130,20 +147,98 @@ func CandidateToNewGroup(
 	return nil
 }
 
+// watchEligibility calls the stop function when the staker starts
+// undelegating or its stake drops below the minimum stake, which makes it no
+// longer eligible for work selection. It returns a function which stops
+// watching.
+func watchEligibility(
+	stakeMonitor chain.StakeMonitor,
+	staker chain.Staker,
+	stop func(),
+) (func(), error) {
+	isStaker := func(operator string) bool {
+		operatorStaker, err := stakeMonitor.StakerFor(operator)
+		if err != nil {
+			return false
+		}
+
+		return bytes.Equal(operatorStaker.Address(), staker.Address())
+	}
+
+	stakeSubscription, err := stakeMonitor.OnStakeChanged(
+		func(change *chain.StakeChange) {
+			if !isStaker(change.Operator) {
+				return
+			}
+
+			hasMinimumStake, err := stakeMonitor.HasMinimumStake(change.Operator)
+			if err != nil {
+				logger.Errorf(
+					"could not check the stake after it changed: [%v]",
+					err,
+				)
+				return
+			}
+
+			if !hasMinimumStake {
+				logger.Warningf(
+					"stake dropped below the minimum stake at block [%v]; "+
+						"stopping ticket submission",
+					change.BlockNumber,
+				)
+				stop()
+			}
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not watch stake changes: [%v]", err)
+	}
+
+	undelegationSubscription, err := stakeMonitor.OnUndelegation(
+		func(undelegation *chain.Undelegation) {
+			if !isStaker(undelegation.Operator) {
+				return
+			}
+
+			logger.Warningf(
+				"stake undelegation started at block [%v]; "+
+					"stopping ticket submission",
+				undelegation.BlockNumber,
+			)
+			stop()
+		},
+	)
+	if err != nil {
+		stakeSubscription.Unsubscribe()
+		return nil, fmt.Errorf("could not watch stake undelegation: [%v]", err)
+	}
+
+	return func() {
+		stakeSubscription.Unsubscribe()
+		undelegationSubscription.Unsubscribe()
+	}, nil
+}
+
 func submitTickets(
+	ctx context.Context,
 	tickets []*ticket,
 	relayChain relaychain.GroupSelectionInterface,
 	blockCounter chain.BlockCounter,
 	chainConfig *config.Chain,
 	startBlockHeight uint64,
 ) error {
-	rounds, err := calculateRoundsCount(chainConfig.TicketSubmissionTimeout)
+	timing := chainConfig.GroupSelectionTiming
+
+	rounds, err := calculateRoundsCount(
+		chainConfig.TicketSubmissionTimeout,
+		timing,
+	)
 	if err != nil {
 		return err
 	}
 
 	for roundIndex := uint64(0); roundIndex <= rounds; roundIndex++ {
-		roundStartDelay := roundIndex * roundDuration
+		roundStartDelay := roundIndex * timing.RoundBlocks
 		roundStartBlock := startBlockHeight + roundStartDelay
 		roundLeadingZeros := rounds - roundIndex
 
@@ -160,6 +255,10 @@ func submitTickets(
 			return err
 		}
 
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("ticket submission cancelled: [%v]", err)
+		}
+
 		candidateTickets, err := roundCandidateTickets(
 			relayChain,
 			tickets,
@@ -171,6 +270,20 @@ func submitTickets(
 			return err
 		}
 
+		if len(candidateTickets) > 0 && !isSubmissionProfitable(
+			relayChain,
+			tickets,
+			candidateTickets,
+			chainConfig.GroupSize,
+		) {
+			logger.Warningf(
+				"skipping ticket submission round [%v]; "+
+					"submitting tickets does not pay off",
+				roundIndex,
+			)
+			continue
+		}
+
 		logger.Infof(
 			"ticket submission round [%v] submitting "+
 				"[%v] tickets",
@@ -184,16 +297,117 @@ func submitTickets(
 	return nil
 }
 
+// isSubmissionProfitable tells whether the reward expected for the seats the
+// staker gains in the group covers the cost of submitting all the candidate
+// tickets of the round. If the economics of the submission could not be
+// determined, tickets are submitted anyway so that the client does not miss
+// the group selection.
+func isSubmissionProfitable(
+	relayChain relaychain.GroupSelectionInterface,
+	memberTickets []*ticket,
+	candidateTickets []*ticket,
+	groupSize int,
+) bool {
+	economics, err := relayChain.GetTicketSubmissionEconomics()
+	if err != nil {
+		logger.Warningf(
+			"could not check whether ticket submission pays off; "+
+				"submitting tickets anyway: [%v]",
+			err,
+		)
+		return true
+	}
+
+	submittedTickets, err := relayChain.GetSubmittedTickets()
+	if err != nil {
+		logger.Warningf(
+			"could not check whether ticket submission pays off; "+
+				"submitting tickets anyway: [%v]",
+			err,
+		)
+		return true
+	}
+
+	seats := expectedSeats(
+		submittedTickets,
+		memberTickets,
+		candidateTickets,
+		groupSize,
+	)
+
+	if !economics.IsProfitable(len(candidateTickets), seats) {
+		logger.Warningf(
+			"cost of submitting [%v] tickets at [%v] each exceeds "+
+				"the expected reward for [%v] seats at [%v] each",
+			len(candidateTickets),
+			economics.SubmissionCost,
+			seats,
+			economics.ExpectedReward,
+		)
+		return false
+	}
+
+	return true
+}
+
+// expectedSeats returns the number of seats in the group the staker gains by
+// submitting the given candidate tickets. Candidate tickets which would only
+// displace tickets of the staker submitted before, or which would not make it
+// to the group, do not gain any seat.
+func expectedSeats(
+	submittedTickets []uint64,
+	memberTickets []*ticket,
+	candidateTickets []*ticket,
+	groupSize int,
+) int {
+	isMemberTicket := make(map[uint64]bool, len(memberTickets))
+	for _, memberTicket := range memberTickets {
+		isMemberTicket[memberTicket.intValue().Uint64()] = true
+	}
+
+	seats := func(tickets []uint64) int {
+		sorted := append([]uint64{}, tickets...)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i] < sorted[j]
+		})
+		if len(sorted) > groupSize {
+			sorted = sorted[:groupSize]
+		}
+
+		count := 0
+		for _, value := range sorted {
+			if isMemberTicket[value] {
+				count++
+			}
+		}
+		return count
+	}
+
+	withCandidates := append([]uint64{}, submittedTickets...)
+	for _, candidateTicket := range candidateTickets {
+		withCandidates = append(
+			withCandidates,
+			candidateTicket.intValue().Uint64(),
+		)
+	}
+
+	return seats(withCandidates) - seats(submittedTickets)
+}
+
 // calculateRoundsCount takes the on-chain ticket submission timeout
 // and calculates the number of rounds for ticket submission. If it is not
-// possible to use the recommended round duration and mining lag because the
+// possible to use the configured round duration and mining lag because the
 // supplied timeout is too short, function returns an error.
-func calculateRoundsCount(submissionTimeout uint64) (uint64, error) {
-	if submissionTimeout-miningLag <= roundDuration {
+func calculateRoundsCount(
+	submissionTimeout uint64,
+	timing config.GroupSelectionTiming,
+) (uint64, error) {
+	if timing.RoundBlocks == 0 ||
+		submissionTimeout <= timing.MiningLagBlocks+timing.RoundBlocks {
 		return 0, fmt.Errorf("submission timeout is too short")
 	}
 
-	return (submissionTimeout - miningLag) / roundDuration, nil
+	return (submissionTimeout - timing.MiningLagBlocks) / timing.RoundBlocks, nil
 }
 
 // roundCandidateTickets returns tickets which should be submitted in
@@ -1,11 +1,13 @@
 package groupselection
 
 import (
+	"context"
 	"encoding/binary"
 	"math/big"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
@@ -19,6 +21,7 @@ func TestSubmitTickets(t *testing.T) {
 	var tests = map[string]struct {
 		groupSize                int
 		tickets                  []*ticket
+		economics                *chain.TicketSubmissionEconomics
 		expectedSubmittedTickets []uint64
 	}{
 		// Client has the same number of tickets as the group size.
@@ -55,6 +58,34 @@ func TestSubmitTickets(t *testing.T) {
 			},
 			expectedSubmittedTickets: []uint64{1001, 1002},
 		},
+		// Submitting tickets costs more than the expected reward.
+		// No tickets should be submitted to the chain.
+		"submission cost exceeds the expected reward": {
+			groupSize: 4,
+			tickets: []*ticket{
+				newTestTicket(1, 1001),
+				newTestTicket(2, 1002),
+			},
+			economics: &chain.TicketSubmissionEconomics{
+				SubmissionCost: big.NewInt(1000),
+				ExpectedReward: big.NewInt(999),
+			},
+			expectedSubmittedTickets: []uint64{},
+		},
+		// Submitting tickets costs as much as the expected reward.
+		// All tickets should be submitted to the chain.
+		"submission cost equal to the expected reward": {
+			groupSize: 4,
+			tickets: []*ticket{
+				newTestTicket(1, 1001),
+				newTestTicket(2, 1002),
+			},
+			economics: &chain.TicketSubmissionEconomics{
+				SubmissionCost: big.NewInt(1000),
+				ExpectedReward: big.NewInt(1000),
+			},
+			expectedSubmittedTickets: []uint64{1001, 1002},
+		},
 	}
 
 	for testName, test := range tests {
@@ -62,10 +93,12 @@ func TestSubmitTickets(t *testing.T) {
 			chainConfig := &config.Chain{
 				GroupSize:               test.groupSize,
 				TicketSubmissionTimeout: 12,
+				GroupSelectionTiming:    config.DefaultGroupSelectionTiming(),
 			}
 
 			chain := &stubGroupInterface{
 				groupSize: test.groupSize,
+				economics: test.economics,
 			}
 
 			blockCounter, err := local.BlockCounter()
@@ -74,6 +107,7 @@ func TestSubmitTickets(t *testing.T) {
 			}
 
 			err = submitTickets(
+				context.Background(),
 				test.tickets,
 				chain,
 				blockCounter,
@@ -316,8 +350,146 @@ func TestRoundCandidateTickets(t *testing.T) {
 	}
 }
 
+func TestWatchEligibility(t *testing.T) {
+	operator := "0x524f2e0176350d950fa630d9a5a59a0a190daf48"
+	otherOperator := "0x65ea55c1f10491038425725dc00dffeab2a1e28a"
+
+	var tests = map[string]struct {
+		change       func(monitor *local.StakeMonitor) error
+		expectedStop bool
+	}{
+		"stake dropped below the minimum stake": {
+			change: func(monitor *local.StakeMonitor) error {
+				return monitor.UnstakeTokens(operator)
+			},
+			expectedStop: true,
+		},
+		"undelegation started": {
+			change: func(monitor *local.StakeMonitor) error {
+				return monitor.UndelegateTokens(operator)
+			},
+			expectedStop: true,
+		},
+		"stake changed above the minimum stake": {
+			change: func(monitor *local.StakeMonitor) error {
+				return monitor.StakeTokens(operator)
+			},
+			expectedStop: false,
+		},
+		"stake of another operator dropped below the minimum stake": {
+			change: func(monitor *local.StakeMonitor) error {
+				return monitor.UnstakeTokens(otherOperator)
+			},
+			expectedStop: false,
+		},
+		"another operator started undelegation": {
+			change: func(monitor *local.StakeMonitor) error {
+				return monitor.UndelegateTokens(otherOperator)
+			},
+			expectedStop: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			monitor := local.NewStakeMonitor(big.NewInt(200))
+			if err := monitor.StakeTokens(operator); err != nil {
+				t.Fatal(err)
+			}
+			if err := monitor.StakeTokens(otherOperator); err != nil {
+				t.Fatal(err)
+			}
+
+			staker, err := monitor.StakerFor(operator)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stopped := make(chan struct{}, 1)
+			unsubscribe, err := watchEligibility(monitor, staker, func() {
+				stopped <- struct{}{}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer unsubscribe()
+
+			if err := test.change(monitor); err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case <-stopped:
+				if !test.expectedStop {
+					t.Errorf("ticket submission should not be stopped")
+				}
+			case <-time.After(100 * time.Millisecond):
+				if test.expectedStop {
+					t.Errorf("ticket submission should be stopped")
+				}
+			}
+		})
+	}
+}
+
+func TestExpectedSeats(t *testing.T) {
+	groupSize := 3
+	memberTickets := []*ticket{
+		newTestTicket(1, 1001),
+		newTestTicket(2, 1002),
+		newTestTicket(3, 1003),
+	}
+
+	var tests = map[string]struct {
+		submittedTickets []uint64
+		candidateTickets []*ticket
+		expectedSeats    int
+	}{
+		"all candidate tickets gain seats": {
+			submittedTickets: []uint64{2001},
+			candidateTickets: memberTickets[:2],
+			expectedSeats:    2,
+		},
+		"candidate ticket displaces another staker's ticket": {
+			submittedTickets: []uint64{1001, 2001, 2002},
+			candidateTickets: memberTickets[1:2],
+			expectedSeats:    1,
+		},
+		"candidate ticket displaces the staker's own ticket": {
+			submittedTickets: []uint64{500, 501, 1003},
+			candidateTickets: memberTickets[:1],
+			expectedSeats:    0,
+		},
+		"candidate ticket does not make it to the group": {
+			submittedTickets: []uint64{500, 501, 502},
+			candidateTickets: memberTickets[:1],
+			expectedSeats:    0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			seats := expectedSeats(
+				test.submittedTickets,
+				memberTickets,
+				test.candidateTickets,
+				groupSize,
+			)
+
+			if seats != test.expectedSeats {
+				t.Errorf(
+					"unexpected number of seats\nexpected: [%v]\nactual:   [%v]",
+					test.expectedSeats,
+					seats,
+				)
+			}
+		})
+	}
+}
+
 type stubGroupInterface struct {
 	groupSize        int
+	economics        *chain.TicketSubmissionEconomics
 	submittedTickets []*chain.Ticket
 }
 
@@ -366,8 +538,35 @@ func (stg *stubGroupInterface) GetSelectedParticipants() ([]chain.StakerAddress,
 	return selected, nil
 }
 
+func (stg *stubGroupInterface) GetTicketSubmissionEconomics() (
+	*chain.TicketSubmissionEconomics,
+	error,
+) {
+	if stg.economics == nil {
+		return &chain.TicketSubmissionEconomics{
+			SubmissionCost: big.NewInt(0),
+			ExpectedReward: big.NewInt(0),
+		}, nil
+	}
+
+	return stg.economics, nil
+}
+
 func (stg *stubGroupInterface) OnGroupSelectionStarted(
 	func(groupSelectionStart *event.GroupSelectionStart),
 ) (subscription.EventSubscription, error) {
 	panic("not implemented")
 }
+
+func (stg *stubGroupInterface) OnGroupSelectionStartRemoved(
+	func(groupSelectionStart *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	panic("not implemented")
+}
+
+func (stg *stubGroupInterface) PastGroupSelectionsStarted(
+	ctx context.Context,
+	fromBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	panic("not implemented")
+}
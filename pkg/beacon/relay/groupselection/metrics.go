@@ -0,0 +1,44 @@
+package groupselection
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/keep-network/keep-core/pkg/metrics"
+)
+
+var (
+	ticketsGenerated = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "group_selection",
+			Name:      "tickets_generated_total",
+			Help:      "Tickets generated for group selections.",
+		},
+	)
+
+	ticketsSubmitted = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "group_selection",
+			Name:      "tickets_submitted_total",
+			Help:      "Tickets successfully submitted to the chain.",
+		},
+	)
+
+	ticketSubmissionFailures = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "group_selection",
+			Name:      "ticket_submission_failures_total",
+			Help:      "Tickets which could not be submitted to the chain.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		ticketsGenerated,
+		ticketsSubmitted,
+		ticketSubmissionFailures,
+	)
+}
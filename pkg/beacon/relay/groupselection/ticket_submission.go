@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 )
 
 // submitTicketsOnChain submits tickets to the chain.
@@ -21,8 +22,13 @@ func submitTicketsOnChain(
 			continue
 		}
 
-		relayChain.SubmitTicket(chainTicket).OnFailure(
+		relayChain.SubmitTicket(chainTicket).OnSuccess(
+			func(submission *event.GroupTicketSubmission) {
+				ticketsSubmitted.Inc()
+			},
+		).OnFailure(
 			func(err error) {
+				ticketSubmissionFailures.Inc()
 				logger.Errorf(
 					"ticket submission failed: [%v]",
 					err,
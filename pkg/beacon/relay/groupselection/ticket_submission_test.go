@@ -1,6 +1,7 @@
 package groupselection
 
 import (
+	"context"
 	"math/big"
 	"reflect"
 	"testing"
@@ -91,8 +92,28 @@ func (mgi *mockGroupInterface) GetSelectedParticipants() ([]chain.StakerAddress,
 	panic("unexpected")
 }
 
+func (mgi *mockGroupInterface) GetTicketSubmissionEconomics() (
+	*chain.TicketSubmissionEconomics,
+	error,
+) {
+	panic("not implemented")
+}
+
 func (mgi *mockGroupInterface) OnGroupSelectionStarted(
 	func(groupSelectionStart *event.GroupSelectionStart),
 ) (subscription.EventSubscription, error) {
 	panic("not implemented")
 }
+
+func (mgi *mockGroupInterface) OnGroupSelectionStartRemoved(
+	func(groupSelectionStart *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	panic("not implemented")
+}
+
+func (mgi *mockGroupInterface) PastGroupSelectionsStarted(
+	ctx context.Context,
+	fromBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	panic("not implemented")
+}
@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
@@ -96,6 +97,63 @@ func TestHonestThresholdMembersSigning(t *testing.T) {
 	}
 }
 
+// Success: group members are controlled by a few operators and each operator
+// signs on behalf of all its members at once.
+func TestOperatorsSigningForMultipleMembers(t *testing.T) {
+	t.Parallel()
+
+	interceptor := func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		return msg
+	}
+
+	dkgResult, err := dkgtest.RunTest(
+		groupSize,
+		honestThreshold,
+		dkgtest.RandomSeed(t),
+		interceptor,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgtest.AssertDkgResultPublished(t, dkgResult)
+	dkgtest.AssertSamePublicKey(t, dkgResult)
+
+	signers := dkgResult.GetSigners()
+	participants := [][]*dkg.ThresholdSigner{
+		signers[0:4],
+		signers[4:7],
+		signers[7:8],
+	}
+
+	signingResult, err := entrytest.RunCombinedTest(
+		participants,
+		honestThreshold,
+		interceptor,
+		previousEntry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entrytest.AssertEntryPublished(t, signingResult)
+	entrytest.AssertNoSignerFailures(t, signingResult)
+
+	groupPublicKey, err := getFirstGroupPublicKey(dkgResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newEntry, err := signingResult.EntryValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bls.VerifyG1(groupPublicKey, previousEntryG1(), newEntry) {
+		t.Errorf("threshold signature failed BLS verification")
+	}
+}
+
 // Failure: Less than honest threshold signing group members participate in
 // signing.
 func TestLessThanHonestThresholdMembersSigning(t *testing.T) {
@@ -2,6 +2,7 @@ package relay
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -15,10 +16,12 @@ import (
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/batch"
 )
 
 // Node represents the current state of a relay node.
@@ -35,6 +38,30 @@ type Node struct {
 	chainConfig  *config.Chain
 
 	groupRegistry *registry.Groups
+
+	// checkpoints persists the progress of DKG executed by the node so that
+	// DKG can be resumed after the client restarts. Progress is not persisted
+	// if not set.
+	checkpoints *dkg.CheckpointStorage
+
+	// transcripts persists records of DKG executed by the node so that DKG
+	// can be audited after it is over. Transcripts are not recorded if not
+	// set.
+	transcripts *dkg.TranscriptStorage
+
+	// batchMessages tells whether messages of several members of one group
+	// controlled by the node are combined into batches.
+	batchMessages bool
+
+	// inFlight tracks DKG executions and relay entry signing rounds started
+	// by the node which have not completed yet.
+	inFlight sync.WaitGroup
+}
+
+// WaitForInFlight blocks until all DKG executions and relay entry signing
+// rounds started by the node complete.
+func (n *Node) WaitForInFlight() {
+	n.inFlight.Wait()
 }
 
 // IsInGroup checks if this node is a member of the group which was selected to
@@ -51,12 +78,22 @@ func (n *Node) IsInGroup(groupPublicKey []byte) bool {
 //
 // Indirectly, the completion of the process is signaled by the formation of an
 // on-chain group containing at least one of this node's virtual stakers.
+//
+// The node does not join the group if the context is done and DKG in progress
+// is abandoned when the context is done. If the node joins the group,
+// JoinGroupIfEligible returns once DKG of all its members is over.
 func (n *Node) JoinGroupIfEligible(
+	ctx context.Context,
 	relayChain relaychain.Interface,
 	signing chain.Signing,
 	groupSelectionResult *groupselection.Result,
 	newEntry *big.Int,
 ) {
+	if err := ctx.Err(); err != nil {
+		logger.Infof("not joining the group; group selection cancelled")
+		return
+	}
+
 	dkgStartBlockHeight := groupSelectionResult.GroupSelectionEndBlock
 
 	if len(groupSelectionResult.SelectedStakers) > maxGroupSize {
@@ -75,9 +112,7 @@ func (n *Node) JoinGroupIfEligible(
 		}
 	}
 
-	// create temporary broadcast channel name for DKG using the
-	// group selection seed
-	channelName := newEntry.Text(16)
+	channelName := DKGChannelName(newEntry)
 
 	if len(indexes) > 0 {
 		broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
@@ -100,45 +135,30 @@ func (n *Node) JoinGroupIfEligible(
 			)
 		}
 
-		for _, index := range indexes {
-			// capture player index for goroutine
-			playerIndex := index
-
-			go func() {
-				signer, err := dkg.ExecuteDKG(
-					newEntry,
-					playerIndex,
-					n.chainConfig.GroupSize,
-					n.chainConfig.DishonestThreshold(),
-					membershipValidator,
-					dkgStartBlockHeight,
-					n.blockCounter,
-					relayChain,
-					signing,
-					broadcastChannel,
-				)
-				if err != nil {
-					logger.Errorf("failed to execute dkg: [%v]", err)
-					return
-				}
-
-				// final broadcast channel name for group is the compressed
-				// public key of the group
-				channelName := hex.EncodeToString(
-					signer.GroupPublicKeyBytesCompressed(),
-				)
-
-				err = n.groupRegistry.RegisterGroup(signer, channelName)
-				if err != nil {
-					logger.Errorf("failed to register a group: [%v]", err)
-				}
+		checkpoints := make([]*dkg.Checkpoint, len(indexes))
+		for i, index := range indexes {
+			checkpoints[i] = &dkg.Checkpoint{
+				Seed:             newEntry,
+				Index:            index,
+				SelectedStakers:  groupSelectionResult.SelectedStakers,
+				StartBlockHeight: dkgStartBlockHeight,
+			}
+		}
 
-				logger.Infof(
-					"[member:%v] ready to operate in the group",
-					signer.MemberID(),
-				)
-			}()
+		dkgDone, err := n.executeMembersDKG(
+			ctx,
+			relayChain,
+			signing,
+			membershipValidator,
+			broadcastChannel,
+			checkpoints,
+		)
+		if err != nil {
+			logger.Errorf("failed to execute DKG: [%v]", err)
+			return
 		}
+
+		<-dkgDone
 	} else {
 		go n.forwardDKGMessages(channelName)
 	}
@@ -146,6 +166,263 @@ func (n *Node) JoinGroupIfEligible(
 	return
 }
 
+// ResumeDKG resumes DKG captured in the given checkpoints, e.g. DKG which was
+// in progress when the client was stopped. All the checkpoints have to be
+// checkpoints of members of the same group. DKG in progress is abandoned when
+// the context is done.
+func (n *Node) ResumeDKG(
+	ctx context.Context,
+	relayChain relaychain.Interface,
+	signing chain.Signing,
+	checkpoints []*dkg.Checkpoint,
+) error {
+	if len(checkpoints) == 0 {
+		return nil
+	}
+
+	seed := checkpoints[0].Seed
+	selectedStakers := checkpoints[0].SelectedStakers
+
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Seed.Cmp(seed) != 0 {
+			return fmt.Errorf(
+				"checkpoints of groups selected with seeds [0x%x] and [0x%x] "+
+					"can not be resumed together",
+				seed,
+				checkpoint.Seed,
+			)
+		}
+	}
+
+	broadcastChannel, err := n.netProvider.BroadcastChannelFor(
+		DKGChannelName(seed),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get broadcast channel: [%v]", err)
+	}
+
+	membershipValidator := group.NewStakersMembershipValidator(
+		selectedStakers,
+		signing,
+	)
+
+	err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
+	if err != nil {
+		logger.Errorf(
+			"could not set filter for channel [%v]: [%v]",
+			broadcastChannel.Name(),
+			err,
+		)
+	}
+
+	for _, checkpoint := range checkpoints {
+		logger.Infof(
+			"[member:%v] resuming DKG for group selected with seed [0x%x] "+
+				"started at block [%v]",
+			checkpoint.Index+1,
+			checkpoint.Seed,
+			checkpoint.StartBlockHeight,
+		)
+	}
+
+	_, err = n.executeMembersDKG(
+		ctx,
+		relayChain,
+		signing,
+		membershipValidator,
+		broadcastChannel,
+		checkpoints,
+	)
+	return err
+}
+
+// executeMembersDKG executes DKG for all the given members of one group in
+// the background. Members share one channel which, if enabled, batches
+// messages they send in the same phase so that they reach other members as
+// a single network message. Members also share the verification of messages
+// they receive so that each message is verified once for all of them. The
+// returned channel is closed once DKG of all the members is over.
+func (n *Node) executeMembersDKG(
+	ctx context.Context,
+	relayChain relaychain.Interface,
+	signing chain.Signing,
+	membershipValidator group.MembershipValidator,
+	broadcastChannel net.BroadcastChannel,
+	checkpoints []*dkg.Checkpoint,
+) (<-chan struct{}, error) {
+	channel, err := n.membersChannel(broadcastChannel, len(checkpoints))
+	if err != nil {
+		return nil, err
+	}
+
+	verificationCache := gjkr.NewVerificationCache()
+
+	var members sync.WaitGroup
+	members.Add(len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		n.inFlight.Add(1)
+		go func(checkpoint *dkg.Checkpoint) {
+			defer members.Done()
+			n.executeDKG(
+				ctx,
+				relayChain,
+				signing,
+				membershipValidator,
+				channel,
+				verificationCache,
+				checkpoint,
+			)
+		}(checkpoint)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		members.Wait()
+		close(done)
+	}()
+
+	return done, nil
+}
+
+// membersChannel returns the channel shared by the given number of members
+// of one group controlled by the node. Messages of several members are
+// combined into batches only if batching is enabled; peers which do not
+// support batches would drop them. Messages of a single member are never
+// batched.
+func (n *Node) membersChannel(
+	broadcastChannel net.BroadcastChannel,
+	membersCount int,
+) (net.BroadcastChannel, error) {
+	if !n.batchMessages || membersCount < 2 {
+		return broadcastChannel, nil
+	}
+
+	channel, err := batch.NewChannel(broadcastChannel, membersCount)
+	if err != nil {
+		return nil, fmt.Errorf("could not create batching channel: [%v]", err)
+	}
+
+	return channel, nil
+}
+
+// executeDKG executes DKG from the given checkpoint and registers the group
+// if DKG succeeds. The progress is checkpointed as DKG proceeds and the
+// checkpoint is erased once DKG is over. The checkpoint is retained if DKG
+// is abandoned because the context is done, so that it can be resumed later.
+// The transcript of DKG is saved when DKG is over or abandoned, if the key
+// generation has started. It marks the in-flight work done when it returns.
+func (n *Node) executeDKG(
+	ctx context.Context,
+	relayChain relaychain.Interface,
+	signing chain.Signing,
+	membershipValidator group.MembershipValidator,
+	broadcastChannel net.BroadcastChannel,
+	verificationCache *gjkr.VerificationCache,
+	checkpoint *dkg.Checkpoint,
+) {
+	defer n.inFlight.Done()
+
+	var onCheckpoint func(*gjkr.Checkpoint)
+	if n.checkpoints != nil {
+		onCheckpoint = func(keyGeneration *gjkr.Checkpoint) {
+			checkpoint.KeyGeneration = keyGeneration
+			if err := n.checkpoints.Save(checkpoint); err != nil {
+				logger.Errorf(
+					"[member:%v] failed to save DKG checkpoint: [%v]",
+					checkpoint.Index+1,
+					err,
+				)
+			}
+		}
+
+		defer func() {
+			// Nothing has been saved if the key generation has not started.
+			if ctx.Err() != nil || checkpoint.KeyGeneration == nil {
+				return
+			}
+
+			err := n.checkpoints.Erase(checkpoint, "DKG is over")
+			if err != nil {
+				logger.Errorf(
+					"[member:%v] failed to erase DKG checkpoint: [%v]",
+					checkpoint.Index+1,
+					err,
+				)
+			}
+		}()
+	}
+
+	var transcriptRecorder *dkg.TranscriptRecorder
+	if n.transcripts != nil {
+		transcriptRecorder = dkg.NewTranscriptRecorder(n.blockCounter)
+
+		defer func() {
+			transcript := &dkg.Transcript{
+				Seed:               checkpoint.Seed,
+				Index:              checkpoint.Index,
+				SelectedStakers:    checkpoint.SelectedStakers,
+				GroupSize:          n.chainConfig.GroupSize,
+				DishonestThreshold: n.chainConfig.DishonestThreshold(),
+				StartBlockHeight:   checkpoint.StartBlockHeight,
+			}
+			transcriptRecorder.Record(transcript)
+
+			if transcript.KeyGeneration == nil {
+				return
+			}
+
+			if err := n.transcripts.Save(transcript); err != nil {
+				logger.Errorf(
+					"[member:%v] failed to save DKG transcript: [%v]",
+					checkpoint.Index+1,
+					err,
+				)
+			}
+		}()
+	}
+
+	signer, err := dkg.ExecuteDKG(
+		ctx,
+		n.blockCounter,
+		n.blockCounter,
+		relayChain,
+		signing,
+		broadcastChannel,
+		dkg.ExecutionConfig{
+			Seed:                    checkpoint.Seed,
+			Index:                   checkpoint.Index,
+			GroupSize:               n.chainConfig.GroupSize,
+			DishonestThreshold:      n.chainConfig.DishonestThreshold(),
+			MembershipValidator:     membershipValidator,
+			StartBlockHeight:        checkpoint.StartBlockHeight,
+			KeyGenerationCheckpoint: checkpoint.KeyGeneration,
+			OnCheckpoint:            onCheckpoint,
+			TranscriptRecorder:      transcriptRecorder,
+			VerificationCache:       verificationCache,
+		},
+	)
+	if err != nil {
+		logger.Errorf("failed to execute dkg: [%v]", err)
+		return
+	}
+
+	// final broadcast channel name for group is the compressed
+	// public key of the group
+	channelName := hex.EncodeToString(
+		signer.GroupPublicKeyBytesCompressed(),
+	)
+
+	err = n.groupRegistry.RegisterGroup(signer, channelName)
+	if err != nil {
+		logger.Errorf("failed to register a group: [%v]", err)
+	}
+
+	logger.Infof(
+		"[member:%v] ready to operate in the group",
+		signer.MemberID(),
+	)
+}
+
 // ForwardDKGMessages enables the ability to forward DKG messages
 // to other nodes even if this node has not been selected to the group.
 func (n *Node) forwardDKGMessages(name string) {
@@ -156,7 +433,7 @@ func (n *Node) forwardDKGMessages(name string) {
 // messages to other nodes even if this node is not a part of the group which
 // signs the relay entry.
 func (n *Node) ForwardSignatureShares(groupPublicKeyBytes []byte) {
-	name, err := channelNameForPublicKeyBytes(groupPublicKeyBytes)
+	name, err := ChannelNameForPublicKeyBytes(groupPublicKeyBytes)
 	if err != nil {
 		logger.Warningf("could not forward signature shares: [%v]", err)
 		return
@@ -165,11 +442,17 @@ func (n *Node) ForwardSignatureShares(groupPublicKeyBytes []byte) {
 	n.netProvider.BroadcastChannelForwarderFor(name)
 }
 
-// channelNameForPublicKey takes group public key represented by marshalled
-// G2 point and transforms it into a broadcast channel name.
+// DKGChannelName returns the name of the temporary broadcast channel used
+// by DKG of the group selected with the given group selection seed.
+func DKGChannelName(newEntry *big.Int) string {
+	return newEntry.Text(16)
+}
+
+// ChannelNameForPublicKeyBytes takes group public key represented by
+// marshalled G2 point and transforms it into a broadcast channel name.
 // Broadcast channel name for group is the hexadecimal representation of
 // compressed public key of the group.
-func channelNameForPublicKeyBytes(groupPublicKey []byte) (string, error) {
+func ChannelNameForPublicKeyBytes(groupPublicKey []byte) (string, error) {
 	g2 := new(bn256.G2)
 
 	if _, err := g2.Unmarshal(groupPublicKey); err != nil {
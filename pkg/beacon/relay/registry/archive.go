@@ -0,0 +1,410 @@
+package registry
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// archiveDirName is the name of the directory in which the persistence
+	// layer keeps archived data.
+	archiveDirName = "archive"
+
+	// AuditLogFileName is the name of the file in the storage data directory
+	// to which every erasure of archived key shares is recorded.
+	AuditLogFileName = "key_share_erasure.log"
+
+	// archivedAtFileName is the name of the file in the archived group
+	// directory holding the time at which memberships of the group were
+	// archived. Modification time of the directory can not be used instead;
+	// it changes whenever a file is added or removed and it is not preserved
+	// when the storage is copied or restored from a backup.
+	archivedAtFileName = ".archived_at"
+)
+
+// Statuses of erasure recorded in the audit log. An erasure is recorded as
+// started before any file is overwritten and as completed or failed once it
+// is over. An erasure recorded as started but never as completed or failed
+// has been interrupted and the group may be erased only partially.
+const (
+	ErasureStarted   = "started"
+	ErasureCompleted = "completed"
+	ErasureFailed    = "failed"
+)
+
+// ArchivedGroup describes memberships of a group archived in the storage.
+type ArchivedGroup struct {
+	// Name of the group directory in the archive; it is the hexadecimal
+	// representation of the compressed group public key.
+	Name string
+	// Time at which memberships of the group were archived.
+	ArchivedAt time.Time
+	// Names of files holding memberships of the group.
+	Files []string
+}
+
+// ErasureRecord is an entry of the audit log describing erasure of key shares
+// of one archived group.
+type ErasureRecord struct {
+	Time       time.Time `json:"time"`
+	Status     string    `json:"status"`
+	Group      string    `json:"group"`
+	ArchivedAt time.Time `json:"archivedAt"`
+	Files      []string  `json:"files"`
+	Reason     string    `json:"reason"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Archive gives access to memberships of stale groups archived in the
+// storage data directory. Key shares are erased from the archive by
+// overwriting the files holding them with random data before removing the
+// files. Note that the overwrite can not guarantee the data is unrecoverable
+// on file systems and drives which do not write data in place, e.g.
+// copy-on-write file systems and SSDs with wear levelling.
+type Archive struct {
+	dir          string
+	auditLogPath string
+}
+
+// NewArchive returns the archive of memberships kept in the given storage
+// data directory.
+func NewArchive(dataDir string) *Archive {
+	return &Archive{
+		dir:          filepath.Join(dataDir, archiveDirName),
+		auditLogPath: filepath.Join(dataDir, AuditLogFileName),
+	}
+}
+
+// Groups returns all groups with memberships in the archive, ordered by the
+// time they were archived.
+func (a *Archive) Groups() ([]*ArchivedGroup, error) {
+	entries, err := ioutil.ReadDir(a.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*ArchivedGroup{}, nil
+		}
+		return nil, fmt.Errorf("could not read archive directory: [%v]", err)
+	}
+
+	groups := make([]*ArchivedGroup, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		group, err := a.group(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ArchivedAt.Before(groups[j].ArchivedAt)
+	})
+
+	return groups, nil
+}
+
+func (a *Archive) group(name string) (*ArchivedGroup, error) {
+	groupDir := filepath.Join(a.dir, name)
+
+	files, err := ioutil.ReadDir(groupDir)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not read archived group [%v]: [%v]",
+			name,
+			err,
+		)
+	}
+
+	fileNames := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.Name() == archivedAtFileName {
+			continue
+		}
+		fileNames = append(fileNames, file.Name())
+	}
+
+	archivedAt, err := a.archivedAt(name)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not read archiving time of group [%v]: [%v]",
+			name,
+			err,
+		)
+	}
+
+	return &ArchivedGroup{
+		Name:       name,
+		ArchivedAt: archivedAt,
+		Files:      fileNames,
+	}, nil
+}
+
+// archivedAt returns the time memberships of the group with the given name
+// were archived. For groups archived before the time was recorded explicitly,
+// it returns the modification time of the group directory. Reading the time
+// never modifies the archive.
+func (a *Archive) archivedAt(name string) (time.Time, error) {
+	archivedAtPath := filepath.Join(a.dir, name, archivedAtFileName)
+
+	archivedAtText, err := ioutil.ReadFile(archivedAtPath)
+	if os.IsNotExist(err) {
+		info, err := os.Stat(filepath.Join(a.dir, name))
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		logger.Warningf(
+			"archiving time of group [0x%v] has not been recorded; "+
+				"using modification time of the group directory [%v]",
+			name,
+			info.ModTime(),
+		)
+
+		return info.ModTime(), nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var archivedAt time.Time
+	if err := archivedAt.UnmarshalText(archivedAtText); err != nil {
+		return time.Time{}, err
+	}
+
+	return archivedAt, nil
+}
+
+// MarkArchived records the current time as the time the directory with the
+// given name has been archived. The time is recorded only once.
+func (a *Archive) MarkArchived(name string) error {
+	return a.markArchived(name, time.Now())
+}
+
+// markArchived records the given time as the time memberships of the group
+// were archived. The time is recorded only once; if memberships are added to
+// the group already archived, the group keeps the original time.
+func (a *Archive) markArchived(name string, archivedAt time.Time) error {
+	archivedAtText, err := archivedAt.UTC().MarshalText()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(
+		filepath.Join(a.dir, name, archivedAtFileName),
+		os.O_CREATE|os.O_EXCL|os.O_WRONLY,
+		0600,
+	)
+	if os.IsExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(archivedAtText); err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
+
+// Erase securely erases memberships of the archived group with the given name.
+// The erasure, along with the given reason, is recorded in the audit log as
+// started before any file is overwritten and as completed or failed once it
+// is over. Nothing is erased if the start could not be recorded.
+func (a *Archive) Erase(name string, reason string) error {
+	if name == "" || filepath.Base(name) != name || name == "." || name == ".." {
+		return fmt.Errorf("invalid archived group name [%v]", name)
+	}
+
+	group, err := a.group(name)
+	if err != nil {
+		return err
+	}
+
+	record := func(status string, erasureErr error) error {
+		erasureRecord := &ErasureRecord{
+			Time:       time.Now().UTC(),
+			Status:     status,
+			Group:      name,
+			ArchivedAt: group.ArchivedAt.UTC(),
+			Files:      group.Files,
+			Reason:     reason,
+		}
+		if erasureErr != nil {
+			erasureRecord.Error = erasureErr.Error()
+		}
+
+		return a.audit(erasureRecord)
+	}
+
+	if err := record(ErasureStarted, nil); err != nil {
+		return fmt.Errorf(
+			"archived group [%v] not erased; the erasure could not be "+
+				"recorded in the audit log: [%v]",
+			name,
+			err,
+		)
+	}
+
+	if err := a.erase(group); err != nil {
+		if auditErr := record(ErasureFailed, err); auditErr != nil {
+			logger.Errorf(
+				"failed erasure of archived group [%v] could not be "+
+					"recorded in the audit log: [%v]",
+				name,
+				auditErr,
+			)
+		}
+		return err
+	}
+
+	if err := record(ErasureCompleted, nil); err != nil {
+		return fmt.Errorf(
+			"archived group [%v] erased but the completed erasure could not "+
+				"be recorded in the audit log: [%v]",
+			name,
+			err,
+		)
+	}
+
+	logger.Infof("erased key shares of archived group [0x%v]", name)
+
+	return nil
+}
+
+// erase overwrites and removes all the files of the archived group and then
+// removes the group directory.
+func (a *Archive) erase(group *ArchivedGroup) error {
+	name := group.Name
+	groupDir := filepath.Join(a.dir, name)
+	for _, file := range group.Files {
+		if err := overwriteAndRemove(filepath.Join(groupDir, file)); err != nil {
+			return fmt.Errorf(
+				"could not erase file [%v] of archived group [%v]: [%v]",
+				file,
+				name,
+				err,
+			)
+		}
+	}
+
+	err := os.Remove(filepath.Join(groupDir, archivedAtFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf(
+			"could not remove archiving time of group [%v]: [%v]",
+			name,
+			err,
+		)
+	}
+
+	if err := os.Remove(groupDir); err != nil {
+		return fmt.Errorf(
+			"could not remove directory of archived group [%v]: [%v]",
+			name,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// EraseArchivedBefore securely erases memberships of all groups archived
+// before the given time. It returns names of erased groups. Erasure continues
+// if one of the groups could not be erased; the first error is returned.
+func (a *Archive) EraseArchivedBefore(
+	deadline time.Time,
+	reason string,
+) ([]string, error) {
+	groups, err := a.Groups()
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	erased := make([]string, 0)
+	for _, group := range groups {
+		if !group.ArchivedAt.Before(deadline) {
+			continue
+		}
+
+		if err := a.Erase(group.Name, reason); err != nil {
+			logger.Errorf("%v", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		erased = append(erased, group.Name)
+	}
+
+	return erased, firstErr
+}
+
+// audit appends the record to the audit log.
+func (a *Archive) audit(record *ErasureRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	auditLog, err := os.OpenFile(
+		a.auditLogPath,
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+		0600,
+	)
+	if err != nil {
+		return err
+	}
+	defer auditLog.Close()
+
+	if _, err := auditLog.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return auditLog.Sync()
+}
+
+// overwriteAndRemove overwrites the content of the file with random data,
+// flushes it to the drive and removes the file.
+func overwriteAndRemove(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	if _, err := io.CopyN(file, rand.Reader, info.Size()); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
@@ -0,0 +1,361 @@
+package registry
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArchiveGroups(t *testing.T) {
+	dataDir := tempDataDir(t)
+	defer os.RemoveAll(dataDir)
+	archive := NewArchive(dataDir)
+
+	now := time.Now()
+	archiveGroup(t, archive, "group2", now.Add(-time.Hour), "membership_1")
+	archiveGroup(t, archive, "group1", now.Add(-2*time.Hour), "membership_2", "membership_3")
+
+	groups, err := archive.Groups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+
+	expectedNames := []string{"group1", "group2"}
+	if !reflect.DeepEqual(expectedNames, names) {
+		t.Errorf(
+			"unexpected archived groups\nexpected: [%v]\nactual:   [%v]",
+			expectedNames,
+			names,
+		)
+	}
+
+	expectedFiles := []string{"membership_2", "membership_3"}
+	if !reflect.DeepEqual(expectedFiles, groups[0].Files) {
+		t.Errorf(
+			"unexpected files of archived group\nexpected: [%v]\nactual:   [%v]",
+			expectedFiles,
+			groups[0].Files,
+		)
+	}
+}
+
+func TestArchiveGroupArchivingTime(t *testing.T) {
+	archivedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	var tests = map[string]struct {
+		archive func(archive *Archive)
+	}{
+		"recorded archiving time": {
+			archive: func(archive *Archive) {
+				archiveGroup(t, archive, "group1", archivedAt, "membership_1")
+			},
+		},
+		"archiving time recorded again": {
+			archive: func(archive *Archive) {
+				archiveGroup(t, archive, "group1", archivedAt, "membership_1")
+				archiveGroup(t, archive, "group1", time.Now(), "membership_2")
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			dataDir := tempDataDir(t)
+			defer os.RemoveAll(dataDir)
+			archive := NewArchive(dataDir)
+
+			test.archive(archive)
+
+			// The group directory is modified after it has been archived,
+			// for example when the storage is restored from a backup.
+			groupDir := filepath.Join(archive.dir, "group1")
+			err := ioutil.WriteFile(
+				filepath.Join(groupDir, "membership_3"),
+				[]byte("key share"),
+				0600,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			now := time.Now()
+			if err := os.Chtimes(groupDir, now, now); err != nil {
+				t.Fatal(err)
+			}
+
+			groups, err := archive.Groups()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(groups) != 1 {
+				t.Fatalf("unexpected groups in the archive: [%v]", groups)
+			}
+
+			if !groups[0].ArchivedAt.Equal(archivedAt) {
+				t.Errorf(
+					"unexpected archiving time\nexpected: [%v]\nactual:   [%v]",
+					archivedAt,
+					groups[0].ArchivedAt,
+				)
+			}
+		})
+	}
+}
+
+func TestArchiveGroupArchivingTimeNotRecorded(t *testing.T) {
+	dataDir := tempDataDir(t)
+	defer os.RemoveAll(dataDir)
+	archive := NewArchive(dataDir)
+
+	archivedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	groupDir := filepath.Join(archive.dir, "group1")
+	if err := os.MkdirAll(groupDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(groupDir, archivedAt, archivedAt); err != nil {
+		t.Fatal(err)
+	}
+
+	groups, err := archive.Groups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(groups) != 1 {
+		t.Fatalf("unexpected groups in the archive: [%v]", groups)
+	}
+
+	if !groups[0].ArchivedAt.Equal(archivedAt) {
+		t.Errorf(
+			"unexpected archiving time\nexpected: [%v]\nactual:   [%v]",
+			archivedAt,
+			groups[0].ArchivedAt,
+		)
+	}
+
+	// Reading the archive must not modify it.
+	_, err = os.Stat(filepath.Join(groupDir, archivedAtFileName))
+	if !os.IsNotExist(err) {
+		t.Errorf("archiving time has been recorded on read")
+	}
+}
+
+func TestArchiveErase(t *testing.T) {
+	var tests = map[string]struct {
+		groupName     string
+		expectedError bool
+	}{
+		"archived group": {
+			groupName: "group1",
+		},
+		"unknown group": {
+			groupName:     "group2",
+			expectedError: true,
+		},
+		"path outside of the archive": {
+			groupName:     "../current",
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			dataDir := tempDataDir(t)
+			defer os.RemoveAll(dataDir)
+			archive := NewArchive(dataDir)
+
+			archivedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+			archiveGroup(t, archive, "group1", archivedAt, "membership_1")
+
+			err := archive.Erase(test.groupName, "test")
+			if test.expectedError != (err != nil) {
+				t.Fatalf(
+					"unexpected error\nexpected error: [%v]\nactual:         [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			records := readAuditLog(t, dataDir)
+
+			if test.expectedError {
+				if len(records) != 0 {
+					t.Errorf("unexpected audit log records: [%v]", records)
+				}
+				return
+			}
+
+			_, err = os.Stat(filepath.Join(dataDir, archiveDirName, "group1"))
+			if !os.IsNotExist(err) {
+				t.Errorf("archived group directory has not been removed")
+			}
+
+			expectedStatuses := []string{ErasureStarted, ErasureCompleted}
+			if len(records) != len(expectedStatuses) {
+				t.Fatalf(
+					"unexpected number of audit log records\n"+
+						"expected: [%v]\nactual:   [%v]",
+					len(expectedStatuses),
+					len(records),
+				)
+			}
+
+			for i, status := range expectedStatuses {
+				expectedRecord := &ErasureRecord{
+					Time:       records[i].Time,
+					Status:     status,
+					Group:      "group1",
+					ArchivedAt: archivedAt.UTC(),
+					Files:      []string{"membership_1"},
+					Reason:     "test",
+				}
+				if !reflect.DeepEqual(expectedRecord, records[i]) {
+					t.Errorf(
+						"unexpected audit log record\nexpected: [%+v]\nactual:   [%+v]",
+						expectedRecord,
+						records[i],
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestArchiveEraseArchivedBefore(t *testing.T) {
+	dataDir := tempDataDir(t)
+	defer os.RemoveAll(dataDir)
+	archive := NewArchive(dataDir)
+
+	now := time.Now()
+	archiveGroup(t, archive, "group1", now.Add(-3*time.Hour), "membership_1")
+	archiveGroup(t, archive, "group2", now.Add(-2*time.Hour), "membership_1")
+	archiveGroup(t, archive, "group3", now.Add(-time.Hour), "membership_1")
+
+	erased, err := archive.EraseArchivedBefore(now.Add(-90*time.Minute), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedErased := []string{"group1", "group2"}
+	if !reflect.DeepEqual(expectedErased, erased) {
+		t.Errorf(
+			"unexpected erased groups\nexpected: [%v]\nactual:   [%v]",
+			expectedErased,
+			erased,
+		)
+	}
+
+	groups, err := archive.Groups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(groups) != 1 || groups[0].Name != "group3" {
+		t.Errorf("unexpected groups left in the archive: [%v]", groups)
+	}
+
+	if records := readAuditLog(t, dataDir); len(records) != 4 {
+		t.Errorf(
+			"unexpected number of audit log records\n"+
+				"expected: [4]\nactual:   [%v]",
+			len(records),
+		)
+	}
+}
+
+func TestArchiveEraseNotStartedWithoutAuditLog(t *testing.T) {
+	dataDir := tempDataDir(t)
+	defer os.RemoveAll(dataDir)
+	archive := NewArchive(dataDir)
+
+	archiveGroup(t, archive, "group1", time.Now(), "membership_1")
+
+	// The audit log can not be written when its path is a directory.
+	if err := os.MkdirAll(archive.auditLogPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := archive.Erase("group1", "test"); err == nil {
+		t.Fatal("expected erasure error")
+	}
+
+	membership := filepath.Join(archive.dir, "group1", "membership_1")
+	content, err := ioutil.ReadFile(membership)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "key share" {
+		t.Errorf("archived key share has been modified")
+	}
+}
+
+func tempDataDir(t *testing.T) string {
+	dataDir, err := ioutil.TempDir("", "registry-archive-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dataDir
+}
+
+func archiveGroup(
+	t *testing.T,
+	archive *Archive,
+	name string,
+	archivedAt time.Time,
+	files ...string,
+) {
+	groupDir := filepath.Join(archive.dir, name)
+	if err := os.MkdirAll(groupDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		err := ioutil.WriteFile(
+			filepath.Join(groupDir, file),
+			[]byte("key share"),
+			0600,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := archive.markArchived(name, archivedAt); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readAuditLog(t *testing.T, dataDir string) []*ErasureRecord {
+	auditLog, err := os.Open(filepath.Join(dataDir, AuditLogFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer auditLog.Close()
+
+	var records []*ErasureRecord
+	scanner := bufio.NewScanner(auditLog)
+	for scanner.Scan() {
+		record := &ErasureRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
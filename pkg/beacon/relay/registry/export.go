@@ -0,0 +1,276 @@
+package registry
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/keep-network/keep-common/pkg/encryption"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry/gen/pb"
+	"golang.org/x/crypto/scrypt"
+)
+
+// exportVersion is the version of the membership export format. Version 1
+// derives the encryption key from the passphrase with scrypt using
+// exportScrypt* parameters and encrypts marshalled memberships with
+// encryption.Box.
+const exportVersion = 1
+
+const (
+	exportSaltLength = 32
+	exportScryptN    = 1 << 15
+	exportScryptR    = 8
+	exportScryptP    = 1
+)
+
+// MembershipVerifier is the subset of the relay chain interface needed to
+// verify imported memberships.
+type MembershipVerifier interface {
+	// IsGroupRegistered checks if group with the given public key is
+	// registered on-chain.
+	IsGroupRegistered(groupPublicKey []byte) (bool, error)
+	// GetGroupMembers returns addresses of members of the group with the
+	// given public key.
+	GetGroupMembers(groupPublicKey []byte) ([]relaychain.StakerAddress, error)
+}
+
+// ReadMemberships reads all memberships kept in the given persistence. Unlike
+// LoadExistingGroups, it fails if any of the memberships could not be read.
+func ReadMemberships(persistence persistence.Handle) ([]*Membership, error) {
+	membershipsChannel, errorsChannel := newStorage(persistence).readAll()
+
+	var memberships []*Membership
+	var firstErr error
+
+	for membershipsChannel != nil || errorsChannel != nil {
+		select {
+		case membership, ok := <-membershipsChannel:
+			if !ok {
+				membershipsChannel = nil
+				continue
+			}
+			memberships = append(memberships, membership)
+		case err, ok := <-errorsChannel:
+			if !ok {
+				errorsChannel = nil
+				continue
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return memberships, nil
+}
+
+// ExportMemberships marshals the given memberships into a versioned archive
+// encrypted with a key derived from the given passphrase. The passphrase is
+// independent from the password of the operator key file, so the archive can
+// be moved to another machine without revealing the key file password.
+func ExportMemberships(
+	memberships []*Membership,
+	passphrase string,
+) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("export passphrase must not be empty")
+	}
+
+	pbMemberships := &pb.Memberships{
+		Memberships: make([][]byte, len(memberships)),
+	}
+	for i, membership := range memberships {
+		membershipBytes, err := membership.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not marshal membership: [%v]",
+				err,
+			)
+		}
+		pbMemberships.Memberships[i] = membershipBytes
+	}
+
+	plaintext, err := pbMemberships.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal memberships: [%v]", err)
+	}
+
+	salt := make([]byte, exportSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("could not generate salt: [%v]", err)
+	}
+
+	box, err := exportBox(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := box.Encrypt(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("could not encrypt memberships: [%v]", err)
+	}
+
+	return (&pb.MembershipExport{
+		Version:     exportVersion,
+		Salt:        salt,
+		Memberships: ciphertext,
+	}).Marshal()
+}
+
+// ImportMemberships decrypts memberships from an archive produced by
+// ExportMemberships with the given passphrase.
+func ImportMemberships(data []byte, passphrase string) ([]*Membership, error) {
+	pbExport := &pb.MembershipExport{}
+	if err := pbExport.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("could not unmarshal export: [%v]", err)
+	}
+
+	if pbExport.Version != exportVersion {
+		return nil, fmt.Errorf(
+			"unsupported export version [%v]; expected [%v]",
+			pbExport.Version,
+			exportVersion,
+		)
+	}
+
+	box, err := exportBox(passphrase, pbExport.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := box.Decrypt(pbExport.Memberships)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not decrypt memberships; is the passphrase correct? [%v]",
+			err,
+		)
+	}
+
+	pbMemberships := &pb.Memberships{}
+	if err := pbMemberships.Unmarshal(plaintext); err != nil {
+		return nil, fmt.Errorf("could not unmarshal memberships: [%v]", err)
+	}
+
+	memberships := make([]*Membership, len(pbMemberships.Memberships))
+	for i, membershipBytes := range pbMemberships.Memberships {
+		membership := &Membership{}
+		if err := membership.Unmarshal(membershipBytes); err != nil {
+			return nil, fmt.Errorf(
+				"could not unmarshal membership: [%v]",
+				err,
+			)
+		}
+		memberships[i] = membership
+	}
+
+	return memberships, nil
+}
+
+// VerifyMembership checks if the group of the given membership is registered
+// on-chain and if the operator with the given address has been selected to
+// that group at the position of the membership.
+func VerifyMembership(
+	membership *Membership,
+	verifier MembershipVerifier,
+	operatorAddress relaychain.StakerAddress,
+) error {
+	groupPublicKey := membership.Signer.GroupPublicKeyBytes()
+	memberID := membership.Signer.MemberID()
+
+	isRegistered, err := verifier.IsGroupRegistered(groupPublicKey)
+	if err != nil {
+		return fmt.Errorf(
+			"could not check if group [0x%v] is registered: [%v]",
+			groupKeyToString(groupPublicKey),
+			err,
+		)
+	}
+	if !isRegistered {
+		return fmt.Errorf(
+			"group [0x%v] is not registered on-chain",
+			groupKeyToString(groupPublicKey),
+		)
+	}
+
+	members, err := verifier.GetGroupMembers(groupPublicKey)
+	if err != nil {
+		return fmt.Errorf(
+			"could not get members of group [0x%v]: [%v]",
+			groupKeyToString(groupPublicKey),
+			err,
+		)
+	}
+
+	if int(memberID) < 1 || int(memberID) > len(members) {
+		return fmt.Errorf(
+			"member [%v] is out of range of group [0x%v] of size [%v]",
+			memberID,
+			groupKeyToString(groupPublicKey),
+			len(members),
+		)
+	}
+
+	if !bytes.Equal(members[memberID-1], operatorAddress) {
+		return fmt.Errorf(
+			"member [%v] of group [0x%v] does not belong to operator [0x%x]",
+			memberID,
+			groupKeyToString(groupPublicKey),
+			operatorAddress,
+		)
+	}
+
+	return nil
+}
+
+// SaveMemberships verifies all the given memberships and, only if all of them
+// are valid, saves them to the given persistence.
+func SaveMemberships(
+	memberships []*Membership,
+	verifier MembershipVerifier,
+	operatorAddress relaychain.StakerAddress,
+	persistence persistence.Handle,
+) error {
+	for _, membership := range memberships {
+		err := VerifyMembership(membership, verifier, operatorAddress)
+		if err != nil {
+			return err
+		}
+	}
+
+	storage := newStorage(persistence)
+	for _, membership := range memberships {
+		if err := storage.save(membership); err != nil {
+			return fmt.Errorf(
+				"could not persist membership to the storage: [%v]",
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func exportBox(passphrase string, salt []byte) (encryption.Box, error) {
+	key, err := scrypt.Key(
+		[]byte(passphrase),
+		salt,
+		exportScryptN,
+		exportScryptR,
+		exportScryptP,
+		encryption.KeyLength,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive export key: [%v]", err)
+	}
+
+	var boxKey [encryption.KeyLength]byte
+	copy(boxKey[:], key)
+
+	return encryption.NewBox(boxKey), nil
+}
@@ -0,0 +1,218 @@
+package registry
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry/gen/pb"
+)
+
+var (
+	operatorAddress      = chain.StakerAddress{0x01}
+	otherOperatorAddress = chain.StakerAddress{0x02}
+)
+
+func TestExportImportRoundtrip(t *testing.T) {
+	memberships := []*Membership{
+		{Signer: signer1, ChannelName: channelName1},
+		{Signer: signer2, ChannelName: channelName2},
+	}
+
+	exported, err := ExportMemberships(memberships, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := ImportMemberships(exported, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(memberships, imported) {
+		t.Errorf(
+			"unexpected imported memberships\nexpected: [%v]\nactual:   [%v]",
+			memberships,
+			imported,
+		)
+	}
+}
+
+func TestImportMembershipsFailure(t *testing.T) {
+	exported, err := ExportMemberships(
+		[]*Membership{{Signer: signer1, ChannelName: channelName1}},
+		"passphrase",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	futureVersion := &pb.MembershipExport{}
+	if err := futureVersion.Unmarshal(exported); err != nil {
+		t.Fatal(err)
+	}
+	futureVersion.Version = exportVersion + 1
+	futureVersionExported, err := futureVersion.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		data       []byte
+		passphrase string
+	}{
+		"wrong passphrase": {
+			data:       exported,
+			passphrase: "wrong passphrase",
+		},
+		"unsupported version": {
+			data:       futureVersionExported,
+			passphrase: "passphrase",
+		},
+		"corrupted data": {
+			data:       exported[:len(exported)-1],
+			passphrase: "passphrase",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := ImportMemberships(test.data, test.passphrase)
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestExportMembershipsEmptyPassphrase(t *testing.T) {
+	_, err := ExportMemberships(
+		[]*Membership{{Signer: signer1, ChannelName: channelName1}},
+		"",
+	)
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+func TestSaveMemberships(t *testing.T) {
+	membership1 := &Membership{Signer: signer1, ChannelName: channelName1}
+	membership2 := &Membership{Signer: signer2, ChannelName: channelName2}
+
+	var tests = map[string]struct {
+		verifier            *mockMembershipVerifier
+		expectedError       bool
+		expectedMemberships int
+	}{
+		"all memberships valid": {
+			verifier: newMockMembershipVerifier().
+				registerGroup(signer1.GroupPublicKeyBytes(), operatorAddress).
+				registerGroup(
+					signer2.GroupPublicKeyBytes(),
+					otherOperatorAddress,
+					operatorAddress,
+				),
+			expectedMemberships: 2,
+		},
+		"group not registered": {
+			verifier: newMockMembershipVerifier().
+				registerGroup(signer1.GroupPublicKeyBytes(), operatorAddress),
+			expectedError: true,
+		},
+		"member of other operator": {
+			verifier: newMockMembershipVerifier().
+				registerGroup(signer1.GroupPublicKeyBytes(), operatorAddress).
+				registerGroup(
+					signer2.GroupPublicKeyBytes(),
+					operatorAddress,
+					otherOperatorAddress,
+				),
+			expectedError: true,
+		},
+		"member out of group": {
+			verifier: newMockMembershipVerifier().
+				registerGroup(signer1.GroupPublicKeyBytes(), operatorAddress).
+				registerGroup(signer2.GroupPublicKeyBytes(), operatorAddress),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			dataDir := tempDataDir(t)
+			defer os.RemoveAll(dataDir)
+
+			handle, err := persistence.NewDiskHandle(dataDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = SaveMemberships(
+				[]*Membership{membership1, membership2},
+				test.verifier,
+				operatorAddress,
+				handle,
+			)
+			if test.expectedError != (err != nil) {
+				t.Fatalf(
+					"unexpected error\nexpected error: [%v]\nactual:         [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			saved, err := ReadMemberships(handle)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(saved) != test.expectedMemberships {
+				t.Errorf(
+					"unexpected number of saved memberships\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedMemberships,
+					len(saved),
+				)
+			}
+		})
+	}
+}
+
+type mockMembershipVerifier struct {
+	groups map[string][]chain.StakerAddress
+}
+
+func newMockMembershipVerifier() *mockMembershipVerifier {
+	return &mockMembershipVerifier{
+		groups: make(map[string][]chain.StakerAddress),
+	}
+}
+
+func (mmv *mockMembershipVerifier) registerGroup(
+	groupPublicKey []byte,
+	members ...chain.StakerAddress,
+) *mockMembershipVerifier {
+	mmv.groups[hex.EncodeToString(groupPublicKey)] = members
+	return mmv
+}
+
+func (mmv *mockMembershipVerifier) IsGroupRegistered(
+	groupPublicKey []byte,
+) (bool, error) {
+	_, ok := mmv.groups[hex.EncodeToString(groupPublicKey)]
+	return ok, nil
+}
+
+func (mmv *mockMembershipVerifier) GetGroupMembers(
+	groupPublicKey []byte,
+) ([]chain.StakerAddress, error) {
+	members, ok := mmv.groups[hex.EncodeToString(groupPublicKey)]
+	if !ok {
+		return nil, fmt.Errorf("group not registered")
+	}
+	return members, nil
+}
@@ -145,37 +145,144 @@ func (m *Membership) GetChannel() string {
 	return ""
 }
 
+type MembershipExport struct {
+	Version     uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Salt        []byte `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+	Memberships []byte `protobuf:"bytes,3,opt,name=memberships,proto3" json:"memberships,omitempty"`
+}
+
+func (m *MembershipExport) Reset()      { *m = MembershipExport{} }
+func (*MembershipExport) ProtoMessage() {}
+func (*MembershipExport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{2}
+}
+func (m *MembershipExport) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MembershipExport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MembershipExport.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MembershipExport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MembershipExport.Merge(m, src)
+}
+func (m *MembershipExport) XXX_Size() int {
+	return m.Size()
+}
+func (m *MembershipExport) XXX_DiscardUnknown() {
+	xxx_messageInfo_MembershipExport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MembershipExport proto.InternalMessageInfo
+
+func (m *MembershipExport) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *MembershipExport) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+func (m *MembershipExport) GetMemberships() []byte {
+	if m != nil {
+		return m.Memberships
+	}
+	return nil
+}
+
+type Memberships struct {
+	Memberships [][]byte `protobuf:"bytes,1,rep,name=memberships,proto3" json:"memberships,omitempty"`
+}
+
+func (m *Memberships) Reset()      { *m = Memberships{} }
+func (*Memberships) ProtoMessage() {}
+func (*Memberships) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{3}
+}
+func (m *Memberships) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Memberships) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Memberships.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Memberships) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Memberships.Merge(m, src)
+}
+func (m *Memberships) XXX_Size() int {
+	return m.Size()
+}
+func (m *Memberships) XXX_DiscardUnknown() {
+	xxx_messageInfo_Memberships.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Memberships proto.InternalMessageInfo
+
+func (m *Memberships) GetMemberships() [][]byte {
+	if m != nil {
+		return m.Memberships
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ThresholdSigner)(nil), "registry.ThresholdSigner")
 	proto.RegisterMapType((map[uint32][]byte)(nil), "registry.ThresholdSigner.GroupPublicKeySharesEntry")
 	proto.RegisterType((*Membership)(nil), "registry.Membership")
+	proto.RegisterType((*MembershipExport)(nil), "registry.MembershipExport")
+	proto.RegisterType((*Memberships)(nil), "registry.Memberships")
 }
 
 func init() { proto.RegisterFile("pb/message.proto", fileDescriptor_8447775385e7eb85) }
 
 var fileDescriptor_8447775385e7eb85 = []byte{
-	// 328 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x31, 0x4b, 0xc3, 0x40,
-	0x1c, 0xc5, 0x73, 0xa9, 0x56, 0x7b, 0xad, 0x5a, 0x8e, 0x22, 0xd1, 0xe1, 0x4f, 0xe8, 0x20, 0x99,
-	0x22, 0xb4, 0x4b, 0x71, 0x70, 0x10, 0xa4, 0x48, 0x11, 0x24, 0x75, 0x72, 0x4b, 0xda, 0x3f, 0x49,
-	0x30, 0x4d, 0xc2, 0x5d, 0x5a, 0xcc, 0xe6, 0x47, 0xf0, 0x63, 0xf8, 0x35, 0xdc, 0x1c, 0x3b, 0x76,
-	0xb4, 0xd7, 0xc5, 0xb1, 0x1f, 0x41, 0xbc, 0x36, 0x20, 0x25, 0x6e, 0xf7, 0xde, 0xf1, 0x7b, 0xbc,
-	0x77, 0x47, 0x9b, 0xa9, 0x77, 0x39, 0x41, 0x21, 0x5c, 0x1f, 0xed, 0x94, 0x27, 0x59, 0xc2, 0x0e,
-	0x39, 0xfa, 0xa1, 0xc8, 0x78, 0xde, 0xfe, 0xd0, 0xe9, 0xc9, 0x63, 0xc0, 0x51, 0x04, 0x49, 0x34,
-	0x1e, 0x86, 0x7e, 0x8c, 0x9c, 0x99, 0xb4, 0x3e, 0xc1, 0x89, 0x87, 0xfc, 0x2e, 0x1e, 0xe3, 0x8b,
-	0x41, 0x4c, 0x62, 0x1d, 0x39, 0x7f, 0x2d, 0x76, 0x41, 0x8f, 0x7d, 0x9e, 0x4c, 0xd3, 0x87, 0xa9,
-	0x17, 0x85, 0xa3, 0x01, 0xe6, 0x86, 0x6e, 0x12, 0xab, 0xe1, 0xec, 0xb8, 0xac, 0x43, 0x5b, 0x1b,
-	0x87, 0x87, 0x33, 0x37, 0xc3, 0x01, 0xe6, 0xc3, 0xc0, 0xe5, 0x68, 0x54, 0x4c, 0x62, 0xd5, 0x9c,
-	0xd2, 0x3b, 0xe6, 0x17, 0x4c, 0x91, 0xa2, 0x6c, 0x61, 0xec, 0x99, 0x15, 0xab, 0xde, 0xe9, 0xda,
-	0x45, 0x75, 0x7b, 0xa7, 0xb6, 0xdd, 0x2f, 0xa1, 0x6e, 0xe3, 0x8c, 0xe7, 0x4e, 0x69, 0xe0, 0x79,
-	0x9f, 0x9e, 0xfd, 0x8b, 0xb0, 0x26, 0xad, 0x3c, 0x63, 0xbe, 0xdd, 0xfe, 0x7b, 0x64, 0x2d, 0xba,
-	0x3f, 0x73, 0xa3, 0x29, 0x6e, 0xa7, 0x6e, 0xc4, 0x95, 0xde, 0x23, 0xed, 0x6b, 0x4a, 0xef, 0xd5,
-	0xe3, 0x88, 0x20, 0x4c, 0xd9, 0x29, 0xad, 0x0a, 0x55, 0x48, 0xc1, 0x0d, 0x67, 0xab, 0x98, 0x41,
-	0x0f, 0x46, 0x81, 0x1b, 0xc7, 0x18, 0xa9, 0x84, 0x9a, 0x53, 0xc8, 0x9b, 0xde, 0x7c, 0x09, 0xda,
-	0x62, 0x09, 0xda, 0x7a, 0x09, 0xe4, 0x55, 0x02, 0x79, 0x97, 0x40, 0x3e, 0x25, 0x90, 0xb9, 0x04,
-	0xf2, 0x25, 0x81, 0x7c, 0x4b, 0xd0, 0xd6, 0x12, 0xc8, 0xdb, 0x0a, 0xb4, 0xf9, 0x0a, 0xb4, 0xc5,
-	0x0a, 0xb4, 0x27, 0x3d, 0xf5, 0xbc, 0xaa, 0xfa, 0xce, 0xee, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xd0, 0xd8, 0x54, 0xe3, 0xe2, 0x01, 0x00, 0x00,
+	// 380 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0xb1, 0x8e, 0xda, 0x40,
+	0x10, 0xf5, 0xda, 0x84, 0x84, 0x81, 0x24, 0x68, 0x85, 0x22, 0x27, 0xc5, 0xca, 0x72, 0x11, 0xb9,
+	0x32, 0x12, 0x34, 0x28, 0x45, 0x8a, 0x48, 0x08, 0x45, 0x28, 0x52, 0x64, 0x52, 0xa5, 0xb3, 0x61,
+	0x64, 0x5b, 0x67, 0x6c, 0x6b, 0xd7, 0x20, 0xdc, 0xdd, 0x27, 0xdc, 0x67, 0xdc, 0x6f, 0x5c, 0x77,
+	0x25, 0x25, 0xe5, 0x61, 0x9a, 0x2b, 0xf9, 0x84, 0x13, 0x8b, 0x11, 0x9c, 0xc5, 0x75, 0xf3, 0xde,
+	0xee, 0x7b, 0xfb, 0x66, 0x76, 0xa0, 0x9d, 0x7a, 0xdd, 0x39, 0x0a, 0xe1, 0xfa, 0x68, 0xa7, 0x3c,
+	0xc9, 0x12, 0xfa, 0x81, 0xa3, 0x1f, 0x8a, 0x8c, 0xe7, 0xe6, 0x83, 0x0a, 0x9f, 0xff, 0x05, 0x1c,
+	0x45, 0x90, 0x44, 0xb3, 0x49, 0xe8, 0xc7, 0xc8, 0xa9, 0x01, 0xcd, 0x39, 0xce, 0x3d, 0xe4, 0xbf,
+	0xe3, 0x19, 0xae, 0x74, 0x62, 0x10, 0xeb, 0xa3, 0x73, 0x49, 0xd1, 0xef, 0xf0, 0xc9, 0xe7, 0xc9,
+	0x22, 0xfd, 0xbb, 0xf0, 0xa2, 0x70, 0x3a, 0xc6, 0x5c, 0x57, 0x0d, 0x62, 0xb5, 0x9c, 0x0a, 0x4b,
+	0x7b, 0xd0, 0x39, 0x32, 0x3c, 0x5c, 0xba, 0x19, 0x8e, 0x31, 0x9f, 0x04, 0x2e, 0x47, 0x5d, 0x33,
+	0x88, 0xd5, 0x70, 0xae, 0x9e, 0x51, 0x1f, 0x3a, 0xaf, 0x5d, 0x24, 0x2d, 0xf4, 0x9a, 0xa1, 0x59,
+	0xcd, 0x5e, 0xdf, 0x3e, 0x45, 0xb7, 0x2b, 0xb1, 0xed, 0xd1, 0x15, 0xd5, 0x30, 0xce, 0x78, 0xee,
+	0x5c, 0x35, 0xfc, 0x36, 0x82, 0xaf, 0x6f, 0x4a, 0x68, 0x1b, 0xb4, 0x1b, 0xcc, 0xcb, 0xde, 0x0f,
+	0x25, 0xed, 0xc0, 0xbb, 0xa5, 0x1b, 0x2d, 0xb0, 0x6c, 0xf5, 0x08, 0x7e, 0xa8, 0x03, 0x62, 0xfe,
+	0x04, 0xf8, 0x23, 0x87, 0x23, 0x82, 0x30, 0xa5, 0x5f, 0xa0, 0x2e, 0x64, 0x20, 0x29, 0x6e, 0x39,
+	0x25, 0xa2, 0x3a, 0xbc, 0x9f, 0x06, 0x6e, 0x1c, 0x63, 0x24, 0x1d, 0x1a, 0xce, 0x09, 0x9a, 0x1e,
+	0xb4, 0xcf, 0xfa, 0xe1, 0x2a, 0x4d, 0x78, 0x76, 0xb8, 0xbd, 0x44, 0x2e, 0xc2, 0x24, 0x2e, 0x33,
+	0x9c, 0x20, 0xa5, 0x50, 0x13, 0x6e, 0x94, 0x95, 0x31, 0x64, 0x7d, 0xfe, 0xb1, 0x83, 0x83, 0x90,
+	0xe3, 0x6d, 0x39, 0x97, 0x94, 0xd9, 0x85, 0xe6, 0xf9, 0x0d, 0x51, 0x15, 0x10, 0x43, 0xab, 0x08,
+	0x7e, 0x0d, 0xd6, 0x5b, 0xa6, 0x6c, 0xb6, 0x4c, 0xd9, 0x6f, 0x19, 0xb9, 0x2d, 0x18, 0xb9, 0x2f,
+	0x18, 0x79, 0x2c, 0x18, 0x59, 0x17, 0x8c, 0x3c, 0x15, 0x8c, 0x3c, 0x17, 0x4c, 0xd9, 0x17, 0x8c,
+	0xdc, 0xed, 0x98, 0xb2, 0xde, 0x31, 0x65, 0xb3, 0x63, 0xca, 0x7f, 0x35, 0xf5, 0xbc, 0xba, 0xdc,
+	0xb1, 0xfe, 0xcb, 0x00, 0xf2, 0x10, 0xb8, 0x90, 0x77, 0x02, 0x00, 0x00,
 }
 
 func (this *ThresholdSigner) Equal(that interface{}) bool {
@@ -243,6 +350,65 @@ func (this *Membership) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *MembershipExport) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MembershipExport)
+	if !ok {
+		that2, ok := that.(MembershipExport)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Version != that1.Version {
+		return false
+	}
+	if !bytes.Equal(this.Salt, that1.Salt) {
+		return false
+	}
+	if !bytes.Equal(this.Memberships, that1.Memberships) {
+		return false
+	}
+	return true
+}
+func (this *Memberships) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Memberships)
+	if !ok {
+		that2, ok := that.(Memberships)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Memberships) != len(that1.Memberships) {
+		return false
+	}
+	for i := range this.Memberships {
+		if !bytes.Equal(this.Memberships[i], that1.Memberships[i]) {
+			return false
+		}
+	}
+	return true
+}
 func (this *ThresholdSigner) GoString() string {
 	if this == nil {
 		return "nil"
@@ -279,6 +445,28 @@ func (this *Membership) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *MembershipExport) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.MembershipExport{")
+	s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	s = append(s, "Salt: "+fmt.Sprintf("%#v", this.Salt)+",\n")
+	s = append(s, "Memberships: "+fmt.Sprintf("%#v", this.Memberships)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Memberships) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.Memberships{")
+	s = append(s, "Memberships: "+fmt.Sprintf("%#v", this.Memberships)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringMessage(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -385,6 +573,80 @@ func (m *Membership) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *MembershipExport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MembershipExport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MembershipExport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Memberships) > 0 {
+		i -= len(m.Memberships)
+		copy(dAtA[i:], m.Memberships)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Memberships)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Salt) > 0 {
+		i -= len(m.Salt)
+		copy(dAtA[i:], m.Salt)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Salt)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Version != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Memberships) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Memberships) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Memberships) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Memberships) > 0 {
+		for iNdEx := len(m.Memberships) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Memberships[iNdEx])
+			copy(dAtA[i:], m.Memberships[iNdEx])
+			i = encodeVarintMessage(dAtA, i, uint64(len(m.Memberships[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
 	offset -= sovMessage(v)
 	base := offset
@@ -445,6 +707,41 @@ func (m *Membership) Size() (n int) {
 	return n
 }
 
+func (m *MembershipExport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovMessage(uint64(m.Version))
+	}
+	l = len(m.Salt)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.Memberships)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	return n
+}
+
+func (m *Memberships) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Memberships) > 0 {
+		for _, b := range m.Memberships {
+			l = len(b)
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovMessage(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -485,6 +782,28 @@ func (this *Membership) String() string {
 	}, "")
 	return s
 }
+func (this *MembershipExport) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MembershipExport{`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`Salt:` + fmt.Sprintf("%v", this.Salt) + `,`,
+		`Memberships:` + fmt.Sprintf("%v", this.Memberships) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Memberships) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Memberships{`,
+		`Memberships:` + fmt.Sprintf("%v", this.Memberships) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringMessage(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -864,6 +1183,231 @@ func (m *Membership) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MembershipExport) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MembershipExport: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MembershipExport: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Salt", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Salt = append(m.Salt[:0], dAtA[iNdEx:postIndex]...)
+			if m.Salt == nil {
+				m.Salt = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Memberships", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Memberships = append(m.Memberships[:0], dAtA[iNdEx:postIndex]...)
+			if m.Memberships == nil {
+				m.Memberships = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Memberships) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Memberships: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Memberships: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Memberships", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Memberships = append(m.Memberships, make([]byte, postIndex-iNdEx))
+			copy(m.Memberships[len(m.Memberships)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipMessage(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -21,6 +21,10 @@ type Groups struct {
 	relayChain relaychain.GroupRegistrationInterface
 
 	storage storage
+
+	// retention enforces the retention policy on memberships of stale groups;
+	// memberships are kept archived forever if not set.
+	retention *Retention
 }
 
 // Membership represents a member of a group
@@ -29,15 +33,19 @@ type Membership struct {
 	ChannelName string
 }
 
-// NewGroupRegistry returns an empty GroupRegistry.
+// NewGroupRegistry returns an empty GroupRegistry. Memberships of stale
+// groups are archived and then handled according to the given retention; they
+// are kept archived forever if the retention is nil.
 func NewGroupRegistry(
 	relayChain relaychain.GroupRegistrationInterface,
 	persistence persistence.Handle,
+	retention *Retention,
 ) *Groups {
 	return &Groups{
 		myGroups:   make(map[string][]*Membership),
 		relayChain: relayChain,
 		storage:    newStorage(persistence),
+		retention:  retention,
 		mutex:      sync.Mutex{},
 	}
 }
@@ -76,17 +84,32 @@ func (g *Groups) GetGroup(groupPublicKey []byte) []*Membership {
 	return g.myGroups[groupKeyToString(groupPublicKey)]
 }
 
+// GetGroups returns memberships of all groups the client is a member of,
+// keyed by the hexadecimal representation of the group public key.
+func (g *Groups) GetGroups() map[string][]*Membership {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	groups := make(map[string][]*Membership, len(g.myGroups))
+	for groupPublicKey, memberships := range g.myGroups {
+		groups[groupPublicKey] = append([]*Membership{}, memberships...)
+	}
+
+	return groups
+}
+
 // UnregisterStaleGroups lookup for groups that have been marked as stale
 // on-chain. A stale group is a group that has expired and a certain time passed
 // after the group expiration. This guarantees the group will not be selected to
 // a new operation and it cannot have an ongoing operation for which it could be
 // selected before it expired. Such a group can be safely removed from the registry
-// and archived in the underlying storage.
+// and archived in the underlying storage. Archived memberships are then handled
+// according to the retention policy.
 func (g *Groups) UnregisterStaleGroups() {
 	g.mutex.Lock()
 	defer g.mutex.Unlock()
 
-	for publicKey := range g.myGroups {
+	for publicKey, memberships := range g.myGroups {
 		publicKeyBytes, err := groupKeyFromString(publicKey)
 		if err != nil {
 			logger.Errorf(
@@ -101,9 +124,13 @@ func (g *Groups) UnregisterStaleGroups() {
 		}
 
 		if isStaleGroup {
-			err = g.storage.archive(publicKey)
+			groupDirectory := membershipDirectory(memberships[0])
+
+			err = g.storage.archive(groupDirectory)
 			if err != nil {
 				logger.Errorf("group archiving has failed: [%v]", err)
+			} else if g.retention != nil {
+				g.retention.onArchived(groupDirectory)
 			}
 
 			delete(g.myGroups, publicKey)
@@ -111,6 +138,16 @@ func (g *Groups) UnregisterStaleGroups() {
 	}
 }
 
+// EnforceRetention erases memberships of stale groups kept in the archive for
+// longer than the retention policy allows.
+func (g *Groups) EnforceRetention() {
+	if g.retention == nil {
+		return
+	}
+
+	g.retention.eraseExpired()
+}
+
 // LoadExistingGroups iterates over all stored memberships on disk and loads them
 // into memory
 func (g *Groups) LoadExistingGroups() {
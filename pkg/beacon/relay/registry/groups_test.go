@@ -54,7 +54,7 @@ var (
 func TestRegisterGroup(t *testing.T) {
 	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
 
-	gr := NewGroupRegistry(chain, persistenceMock)
+	gr := NewGroupRegistry(chain, persistenceMock, nil)
 
 	gr.RegisterGroup(signer1, channelName1)
 
@@ -75,9 +75,44 @@ func TestRegisterGroup(t *testing.T) {
 	}
 }
 
+func TestGetGroups(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+
+	gr := NewGroupRegistry(chain, persistenceMock, nil)
+
+	gr.RegisterGroup(signer1, channelName1)
+	gr.RegisterGroup(signer2, channelName2)
+	gr.RegisterGroup(signer4, channelName2)
+
+	groups := gr.GetGroups()
+
+	expectedMemberships := map[string]int{
+		hex.EncodeToString(signer1.GroupPublicKeyBytes()): 1,
+		hex.EncodeToString(signer2.GroupPublicKeyBytes()): 2,
+	}
+
+	if len(groups) != len(expectedMemberships) {
+		t.Fatalf(
+			"Unexpected number of groups \nExpected: [%+v]\nActual:   [%+v]",
+			len(expectedMemberships),
+			len(groups),
+		)
+	}
+
+	for groupPublicKey, expected := range expectedMemberships {
+		if len(groups[groupPublicKey]) != expected {
+			t.Errorf(
+				"Unexpected number of group memberships \nExpected: [%+v]\nActual:   [%+v]",
+				expected,
+				len(groups[groupPublicKey]),
+			)
+		}
+	}
+}
+
 func TestLoadGroup(t *testing.T) {
 	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
-	gr := NewGroupRegistry(chain, persistenceMock)
+	gr := NewGroupRegistry(chain, persistenceMock, nil)
 
 	if len(gr.myGroups) != 0 {
 		t.Fatalf(
@@ -121,7 +156,7 @@ func TestUnregisterStaleGroups(t *testing.T) {
 		groupsToRemove: [][]byte{},
 	}
 
-	gr := NewGroupRegistry(mockChain, persistenceMock)
+	gr := NewGroupRegistry(mockChain, persistenceMock, nil)
 
 	gr.RegisterGroup(signer1, channelName1)
 	gr.RegisterGroup(signer2, channelName1)
@@ -141,7 +176,7 @@ func TestUnregisterStaleGroups(t *testing.T) {
 		t.Fatalf("Group2 was expected to be unregistered, but is still present")
 	}
 	if len(persistenceMock.archivedGroups) != 1 ||
-		persistenceMock.archivedGroups[0] != hex.EncodeToString(signer2.GroupPublicKeyBytes()) {
+		persistenceMock.archivedGroups[0] != hex.EncodeToString(signer2.GroupPublicKeyBytesCompressed()) {
 		t.Fatalf("Group2 was expected to be archived")
 	}
 
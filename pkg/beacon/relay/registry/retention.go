@@ -0,0 +1,137 @@
+package registry
+
+import (
+	"fmt"
+	"time"
+)
+
+// Retention policies for key shares of stale groups.
+const (
+	// RetentionPolicyArchive keeps memberships of stale groups in the archive
+	// forever.
+	RetentionPolicyArchive = "archive"
+	// RetentionPolicyErase keeps memberships of stale groups in the archive
+	// for the configured number of days and then securely erases them.
+	RetentionPolicyErase = "erase"
+	// RetentionPolicyDelete securely erases memberships of stale groups
+	// right after they are archived.
+	RetentionPolicyDelete = "delete"
+)
+
+// RetentionConfig is the configuration of the retention of key shares of
+// stale groups.
+type RetentionConfig struct {
+	// Policy is one of RetentionPolicyArchive, RetentionPolicyErase or
+	// RetentionPolicyDelete. Defaults to RetentionPolicyArchive.
+	Policy string
+	// Days is the number of days memberships of stale groups are kept in the
+	// archive before they are erased. Used only by RetentionPolicyErase.
+	Days int
+}
+
+func (rc *RetentionConfig) policy() string {
+	if rc.Policy == "" {
+		return RetentionPolicyArchive
+	}
+
+	return rc.Policy
+}
+
+// Validate checks if the retention configuration is correct.
+func (rc *RetentionConfig) Validate() error {
+	switch rc.policy() {
+	case RetentionPolicyArchive, RetentionPolicyDelete:
+		return nil
+	case RetentionPolicyErase:
+		if rc.Days <= 0 {
+			return fmt.Errorf(
+				"retention days must be positive for the [%v] policy",
+				RetentionPolicyErase,
+			)
+		}
+		return nil
+	default:
+		return fmt.Errorf(
+			"unknown retention policy [%v]; expected one of [%v], [%v], [%v]",
+			rc.Policy,
+			RetentionPolicyArchive,
+			RetentionPolicyErase,
+			RetentionPolicyDelete,
+		)
+	}
+}
+
+// Retention enforces the retention policy on memberships archived in the
+// archive.
+type Retention struct {
+	config  RetentionConfig
+	archive *Archive
+}
+
+// NewRetention returns the retention enforcing the given configuration on
+// the given archive.
+func NewRetention(config RetentionConfig, archive *Archive) (*Retention, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Retention{
+		config:  config,
+		archive: archive,
+	}, nil
+}
+
+// onArchived is called when memberships of the group with the given name
+// have just been archived.
+func (r *Retention) onArchived(name string) {
+	if err := r.archive.MarkArchived(name); err != nil {
+		logger.Errorf(
+			"could not record archiving time of group [0x%v]: [%v]",
+			name,
+			err,
+		)
+	}
+
+	if r.config.policy() != RetentionPolicyDelete {
+		return
+	}
+
+	err := r.archive.Erase(
+		name,
+		fmt.Sprintf("group became stale; %v policy", RetentionPolicyDelete),
+	)
+	if err != nil {
+		logger.Errorf("could not erase key shares of stale group: [%v]", err)
+	}
+}
+
+// eraseExpired erases memberships kept in the archive for longer than the
+// policy allows.
+func (r *Retention) eraseExpired() {
+	var deadline time.Time
+
+	switch r.config.policy() {
+	case RetentionPolicyErase:
+		deadline = time.Now().AddDate(0, 0, -r.config.Days)
+	case RetentionPolicyDelete:
+		// Groups which could not be erased right after they were archived,
+		// or were archived before the policy was set, are erased now.
+		deadline = time.Now()
+	default:
+		return
+	}
+
+	_, err := r.archive.EraseArchivedBefore(
+		deadline,
+		fmt.Sprintf(
+			"retention period expired; %v policy",
+			r.config.policy(),
+		),
+	)
+	if err != nil {
+		logger.Errorf(
+			"could not erase key shares of archived groups: [%v]",
+			err,
+		)
+	}
+}
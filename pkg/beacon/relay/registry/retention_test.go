@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRetentionConfigValidate(t *testing.T) {
+	var tests = map[string]struct {
+		config        RetentionConfig
+		expectedError bool
+	}{
+		"default policy": {
+			config: RetentionConfig{},
+		},
+		"archive policy": {
+			config: RetentionConfig{Policy: RetentionPolicyArchive},
+		},
+		"erase policy": {
+			config: RetentionConfig{Policy: RetentionPolicyErase, Days: 30},
+		},
+		"erase policy without days": {
+			config:        RetentionConfig{Policy: RetentionPolicyErase},
+			expectedError: true,
+		},
+		"delete policy": {
+			config: RetentionConfig{Policy: RetentionPolicyDelete},
+		},
+		"unknown policy": {
+			config:        RetentionConfig{Policy: "shred"},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := test.config.Validate()
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected error: [%v]\nactual:         [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestRetention(t *testing.T) {
+	var tests = map[string]struct {
+		config RetentionConfig
+		// Groups expected to be left in the archive after one group has
+		// been archived 10 days ago, another one 2 days ago, and the last
+		// one has just been archived.
+		expectedGroups []string
+	}{
+		"archive policy": {
+			config:         RetentionConfig{Policy: RetentionPolicyArchive},
+			expectedGroups: []string{"group1", "group2", "group3"},
+		},
+		"erase policy": {
+			config:         RetentionConfig{Policy: RetentionPolicyErase, Days: 7},
+			expectedGroups: []string{"group2", "group3"},
+		},
+		"delete policy": {
+			config:         RetentionConfig{Policy: RetentionPolicyDelete},
+			expectedGroups: []string{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			dataDir := tempDataDir(t)
+			defer os.RemoveAll(dataDir)
+			archive := NewArchive(dataDir)
+
+			retention, err := NewRetention(test.config, archive)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			now := time.Now()
+			archiveGroup(t, archive, "group1", now.AddDate(0, 0, -10), "membership_1")
+			archiveGroup(t, archive, "group2", now.AddDate(0, 0, -2), "membership_1")
+			retention.eraseExpired()
+
+			archiveGroup(t, archive, "group3", now, "membership_1")
+			retention.onArchived("group3")
+
+			groups, err := archive.Groups()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := make([]string, 0)
+			for _, group := range groups {
+				names = append(names, group.Name)
+			}
+
+			if !reflect.DeepEqual(test.expectedGroups, names) {
+				t.Errorf(
+					"unexpected groups in the archive\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedGroups,
+					names,
+				)
+			}
+		})
+	}
+}
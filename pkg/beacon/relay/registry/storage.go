@@ -12,7 +12,7 @@ import (
 type storage interface {
 	save(membership *Membership) error
 	readAll() (<-chan *Membership, <-chan error)
-	archive(groupPublicKey string) error
+	archive(groupDirectory string) error
 }
 
 type persistentStorage struct {
@@ -31,9 +31,14 @@ func (ps *persistentStorage) save(membership *Membership) error {
 		return fmt.Errorf("marshalling of the membership failed: [%v]", err)
 	}
 
-	hexGroupPublicKey := hex.EncodeToString(membership.Signer.GroupPublicKeyBytesCompressed())
+	return ps.handle.Save(membershipBytes, membershipDirectory(membership), "/membership_"+fmt.Sprint(membership.Signer.MemberID()))
+}
 
-	return ps.handle.Save(membershipBytes, hexGroupPublicKey, "/membership_"+fmt.Sprint(membership.Signer.MemberID()))
+// membershipDirectory returns the name of the storage directory holding
+// memberships of the group the given membership belongs to. It is the
+// hexadecimal representation of the compressed group public key.
+func membershipDirectory(membership *Membership) string {
+	return hex.EncodeToString(membership.Signer.GroupPublicKeyBytesCompressed())
 }
 
 func (ps *persistentStorage) readAll() (<-chan *Membership, <-chan error) {
@@ -108,6 +113,6 @@ func (ps *persistentStorage) readAll() (<-chan *Membership, <-chan error) {
 	return outputMemberships, outputErrors
 }
 
-func (ps *persistentStorage) archive(groupName string) error {
-	return ps.handle.Archive(groupName)
+func (ps *persistentStorage) archive(groupDirectory string) error {
+	return ps.handle.Archive(groupDirectory)
 }
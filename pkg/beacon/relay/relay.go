@@ -1,6 +1,8 @@
 package relay
 
 import (
+	"context"
+
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
@@ -26,6 +29,9 @@ func NewNode(
 	blockCounter chain.BlockCounter,
 	chainConfig *config.Chain,
 	groupRegistry *registry.Groups,
+	checkpoints *dkg.CheckpointStorage,
+	transcripts *dkg.TranscriptStorage,
+	batchMessages bool,
 ) Node {
 	return Node{
 		Staker:        staker,
@@ -33,6 +39,9 @@ func NewNode(
 		blockCounter:  blockCounter,
 		chainConfig:   chainConfig,
 		groupRegistry: groupRegistry,
+		checkpoints:   checkpoints,
+		transcripts:   transcripts,
+		batchMessages: batchMessages,
 	}
 }
 
@@ -40,7 +49,9 @@ func NewNode(
 // When a processing group which is supposed to deliver a relay entry does not
 // fulfill its work, then this Node notifies the chain about it. In the case of
 // delivering a relay entry by a processing group, this Node does nothing.
+// Monitoring stops without reporting a timeout when the context is done.
 func (n *Node) MonitorRelayEntry(
+	ctx context.Context,
 	relayChain relayChain.Interface,
 	relayRequestBlockNumber uint64,
 	chainConfig *config.Chain,
@@ -52,24 +63,25 @@ func (n *Node) MonitorRelayEntry(
 		logger.Errorf("waiter for a relay entry timeout block failed: [%v]", err)
 	}
 
-	onEntrySubmittedChannel := make(chan *event.EntrySubmitted)
+	onEntrySubmittedChannel := make(chan *event.EntrySubmitted, 1)
 
 	subscription, err := relayChain.OnRelayEntrySubmitted(
 		func(event *event.EntrySubmitted) {
-			onEntrySubmittedChannel <- event
+			select {
+			case onEntrySubmittedChannel <- event:
+			default:
+			}
 		},
 	)
 	if err != nil {
-		close(onEntrySubmittedChannel)
 		logger.Errorf("could not watch for a signature submission: [%v]", err)
 		return
 	}
+	defer subscription.Unsubscribe()
 
 	for {
 		select {
 		case blockNumber := <-timeoutWaiterChannel:
-			subscription.Unsubscribe()
-			close(onEntrySubmittedChannel)
 			logger.Warningf(
 				"relay entry was not submitted on time, reporting timeout at block [%v]",
 				blockNumber,
@@ -85,6 +97,9 @@ func (n *Node) MonitorRelayEntry(
 				entry.BlockNumber,
 			)
 			return
+		case <-ctx.Done():
+			logger.Infof("relay entry monitoring cancelled")
+			return
 		}
 	}
 }
@@ -95,8 +110,10 @@ func (n *Node) MonitorRelayEntry(
 // upon successfully completing it, submits the signature as a new relay entry.
 // Note that this function returns immediately after determining whether the
 // node is or is not a member of the requested group, and signature creation
-// and submission is performed in a background goroutine.
+// and submission is performed in a background goroutine. Signature creation
+// and submission are abandoned when the context is done.
 func (n *Node) GenerateRelayEntry(
+	ctx context.Context,
 	previousEntry []byte,
 	relayChain relayChain.Interface,
 	signing chain.Signing,
@@ -109,14 +126,14 @@ func (n *Node) GenerateRelayEntry(
 		return
 	}
 
-	channel, err := n.netProvider.BroadcastChannelFor(memberships[0].ChannelName)
+	broadcastChannel, err := n.netProvider.BroadcastChannelFor(
+		memberships[0].ChannelName,
+	)
 	if err != nil {
 		logger.Errorf("could not create broadcast channel: [%v]", err)
 		return
 	}
 
-	entry.RegisterUnmarshallers(channel)
-
 	groupMembers, err := relayChain.GetGroupMembers(groupPublicKey)
 	if err != nil {
 		logger.Errorf("could not get group members: [%v]", err)
@@ -128,33 +145,52 @@ func (n *Node) GenerateRelayEntry(
 		signing,
 	)
 
-	err = channel.SetFilter(membershipValidator.IsInGroup)
+	err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
 	if err != nil {
 		logger.Errorf(
 			"could not set filter for channel [%v]: [%v]",
-			channel.Name(),
+			broadcastChannel.Name(),
 			err,
 		)
 	}
 
-	for _, member := range memberships {
-		go func(member *registry.Membership) {
-			err = entry.SignAndSubmit(
-				n.blockCounter,
-				channel,
-				relayChain,
-				previousEntry,
-				n.chainConfig.HonestThreshold,
-				member.Signer,
-				startBlockHeight,
-			)
-			if err != nil {
-				logger.Errorf(
-					"error creating threshold signature: [%v]",
-					err,
-				)
-				return
-			}
-		}(member)
+	// All the memberships of the node in the group sign the entry together.
+	// Their signature shares are sent through one channel which, if enabled,
+	// batches them so that they reach other members as a single network
+	// message.
+	channel, err := n.membersChannel(broadcastChannel, len(memberships))
+	if err != nil {
+		logger.Errorf("could not create members channel: [%v]", err)
+		return
+	}
+
+	entry.RegisterUnmarshallers(channel)
+
+	signers := make([]*dkg.ThresholdSigner, len(memberships))
+	for i, member := range memberships {
+		signers[i] = member.Signer
 	}
+
+	n.inFlight.Add(1)
+	go func() {
+		defer n.inFlight.Done()
+
+		err := entry.SignAndSubmit(
+			ctx,
+			n.blockCounter,
+			channel,
+			relayChain,
+			previousEntry,
+			n.chainConfig.HonestThreshold,
+			signers,
+			startBlockHeight,
+		)
+		if err != nil {
+			logger.Errorf(
+				"error creating threshold signature: [%v]",
+				err,
+			)
+			return
+		}
+	}()
 }
@@ -1,6 +1,7 @@
 package relay
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"testing"
@@ -33,6 +34,7 @@ func TestMonitorRelayEntryOnChain_EntrySubmitted(t *testing.T) {
 	}
 
 	go node.MonitorRelayEntry(
+		context.Background(),
 		relayChain,
 		startBlockHeight,
 		chainConfig,
@@ -91,6 +93,7 @@ func TestMonitorRelayEntryOnChain_EntryNotSubmitted(t *testing.T) {
 	}
 
 	go node.MonitorRelayEntry(
+		context.Background(),
 		relayChain,
 		startBlockHeight,
 		chainConfig,
@@ -0,0 +1,28 @@
+package relay_test
+
+import (
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/internal/scenario"
+)
+
+// TestScenarios executes adversarial scenarios defined in
+// testdata/scenarios. See the scenario package for the format.
+func TestScenarios(t *testing.T) {
+	scenarios, err := scenario.Load("testdata/scenarios/*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(scenarios) == 0 {
+		t.Fatal("no scenarios found")
+	}
+
+	for _, testScenario := range scenarios {
+		testScenario := testScenario
+		t.Run(testScenario.Name, func(t *testing.T) {
+			t.Parallel()
+			scenario.Run(t, testScenario)
+		})
+	}
+}
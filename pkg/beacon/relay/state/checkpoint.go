@@ -0,0 +1,173 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// Checkpoint captures the progress of a state machine execution so that the
+// execution can be resumed after the client restarts. It holds the index of
+// the state the machine was in, counted from the initial state, and all the
+// messages received by the machine so far.
+type Checkpoint struct {
+	StartBlockHeight uint64
+	StateIndex       int
+	// Initiated is true if the state with StateIndex has been initiated,
+	// i.e. its messages have already been sent.
+	Initiated bool
+	Messages  []*RecordedMessage
+}
+
+// RecordedMessage is a message received by the state machine, kept along
+// with the index of the state which received it.
+type RecordedMessage struct {
+	StateIndex        int
+	Type              string
+	SenderPublicKey   []byte
+	TransportSenderID string
+	Seqno             uint64
+	Payload           []byte
+}
+
+// copy returns a copy of the checkpoint not sharing the messages slice with
+// the original one.
+func (c *Checkpoint) copy() *Checkpoint {
+	messages := make([]*RecordedMessage, len(c.Messages))
+	copy(messages, c.Messages)
+
+	return &Checkpoint{
+		StartBlockHeight: c.StartBlockHeight,
+		StateIndex:       c.StateIndex,
+		Initiated:        c.Initiated,
+		Messages:         messages,
+	}
+}
+
+// recordMessage converts the received message to its recorded form. The
+// message payload has to be marshalable. The type of the payload is recorded,
+// rather than the type of the message assigned by the transport, so that the
+// payload can be unmarshaled when the message is replayed.
+func recordMessage(stateIndex int, msg net.Message) (*RecordedMessage, error) {
+	marshaler, ok := msg.Payload().(net.TaggedMarshaler)
+	if !ok {
+		return nil, fmt.Errorf(
+			"payload of type [%T] can not be marshaled",
+			msg.Payload(),
+		)
+	}
+
+	payload, err := marshaler.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal payload: [%v]", err)
+	}
+
+	return &RecordedMessage{
+		StateIndex:        stateIndex,
+		Type:              marshaler.Type(),
+		SenderPublicKey:   msg.SenderPublicKey(),
+		TransportSenderID: msg.TransportSenderID().String(),
+		Seqno:             msg.Seqno(),
+		Payload:           payload,
+	}, nil
+}
+
+// replayedMessage is a recorded message delivered again to a state being
+// replayed.
+type replayedMessage struct {
+	recorded *RecordedMessage
+	payload  interface{}
+}
+
+func (rm *replayedMessage) TransportSenderID() net.TransportIdentifier {
+	return transportIdentifier(rm.recorded.TransportSenderID)
+}
+
+func (rm *replayedMessage) SenderPublicKey() []byte {
+	return rm.recorded.SenderPublicKey
+}
+
+func (rm *replayedMessage) Payload() interface{} {
+	return rm.payload
+}
+
+func (rm *replayedMessage) Type() string {
+	return rm.recorded.Type
+}
+
+func (rm *replayedMessage) Seqno() uint64 {
+	return rm.recorded.Seqno
+}
+
+type transportIdentifier string
+
+func (ti transportIdentifier) String() string {
+	return string(ti)
+}
+
+// replayMessage unmarshals the payload of the recorded message with the
+// unmarshaler registered for the message type.
+func replayMessage(
+	recorded *RecordedMessage,
+	unmarshalers map[string]func() net.TaggedUnmarshaler,
+) (net.Message, error) {
+	unmarshaler, ok := unmarshalers[recorded.Type]
+	if !ok {
+		return nil, fmt.Errorf(
+			"no unmarshaler for message type [%v]",
+			recorded.Type,
+		)
+	}
+
+	payload := unmarshaler()
+	if err := payload.Unmarshal(recorded.Payload); err != nil {
+		return nil, fmt.Errorf(
+			"could not unmarshal message of type [%v]: [%v]",
+			recorded.Type,
+			err,
+		)
+	}
+
+	return &replayedMessage{recorded, payload}, nil
+}
+
+// NetMessage returns the recorded message with the payload unmarshaled with
+// the unmarshaler registered for the message type, so that the message can
+// be delivered again to a state.
+func (rm *RecordedMessage) NetMessage(
+	unmarshalers map[string]func() net.TaggedUnmarshaler,
+) (net.Message, error) {
+	return replayMessage(rm, unmarshalers)
+}
+
+// replayChannel is a broadcast channel handed to states of a resumed state
+// machine. Messages sent by states being replayed have already been sent
+// before the client restarted so the channel drops them until the machine
+// catches up with the chain and goes live.
+type replayChannel struct {
+	net.BroadcastChannel
+
+	mutex sync.Mutex
+	live  bool
+}
+
+func (rc *replayChannel) Send(ctx context.Context, m net.TaggedMarshaler) error {
+	rc.mutex.Lock()
+	live := rc.live
+	rc.mutex.Unlock()
+
+	if !live {
+		return nil
+	}
+
+	return rc.BroadcastChannel.Send(ctx, m)
+}
+
+func (rc *replayChannel) goLive() {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.live = true
+}
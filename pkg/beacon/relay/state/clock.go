@@ -0,0 +1,89 @@
+package state
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock measures the time of the state machine execution in blocks. Delay and
+// active blocks of states are counted in blocks of the clock the machine is
+// executed with.
+//
+// chain.BlockCounter is a clock producing a new block each time a block is
+// mined on chain and it is the clock states are executed with by default.
+// WallClock produces new blocks at a fixed time interval, independently of
+// the chain.
+type Clock interface {
+	// WaitForBlockHeight blocks at the caller until the given block height is
+	// reached. If the given block height has been already reached, it returns
+	// immediately.
+	WaitForBlockHeight(blockNumber uint64) error
+
+	// BlockHeightWaiter returns a channel that will emit the block number
+	// after the given block height is reached.
+	BlockHeightWaiter(blockNumber uint64) (<-chan uint64, error)
+
+	// CurrentBlock returns the current block height.
+	CurrentBlock() (uint64, error)
+}
+
+// WallClock is a clock producing a new block each time the block time
+// elapses, counting from the genesis time. Block height is zero before the
+// genesis. Members executing states with a wall clock need to agree on its
+// genesis and block time and their system clocks need to be synchronized.
+type WallClock struct {
+	genesis   time.Time
+	blockTime time.Duration
+}
+
+// NewWallClock returns a new wall clock with the given genesis and block time.
+func NewWallClock(genesis time.Time, blockTime time.Duration) (*WallClock, error) {
+	if blockTime <= 0 {
+		return nil, fmt.Errorf("block time must be positive: [%v]", blockTime)
+	}
+
+	return &WallClock{
+		genesis:   genesis,
+		blockTime: blockTime,
+	}, nil
+}
+
+// WaitForBlockHeight blocks at the caller until the given block height is
+// reached.
+func (wc *WallClock) WaitForBlockHeight(blockNumber uint64) error {
+	waiter, err := wc.BlockHeightWaiter(blockNumber)
+	if err != nil {
+		return err
+	}
+	<-waiter
+	return nil
+}
+
+// BlockHeightWaiter returns a channel that will emit the block number after
+// the given block height is reached and then immediately close.
+func (wc *WallClock) BlockHeightWaiter(
+	blockNumber uint64,
+) (<-chan uint64, error) {
+	waiter := make(chan uint64, 1)
+
+	time.AfterFunc(time.Until(wc.blockStart(blockNumber)), func() {
+		waiter <- blockNumber
+		close(waiter)
+	})
+
+	return waiter, nil
+}
+
+// CurrentBlock returns the current block height.
+func (wc *WallClock) CurrentBlock() (uint64, error) {
+	elapsed := time.Since(wc.genesis)
+	if elapsed < 0 {
+		return 0, nil
+	}
+
+	return uint64(elapsed / wc.blockTime), nil
+}
+
+func (wc *WallClock) blockStart(blockNumber uint64) time.Time {
+	return wc.genesis.Add(time.Duration(blockNumber) * wc.blockTime)
+}
@@ -0,0 +1,126 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewWallClock(t *testing.T) {
+	var tests = map[string]struct {
+		blockTime     time.Duration
+		expectedError bool
+	}{
+		"positive block time": {
+			blockTime:     time.Millisecond,
+			expectedError: false,
+		},
+		"zero block time": {
+			blockTime:     0,
+			expectedError: true,
+		},
+		"negative block time": {
+			blockTime:     -time.Millisecond,
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := NewWallClock(time.Now(), test.blockTime)
+
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected error: [%v]\nactual error:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestWallClockCurrentBlock(t *testing.T) {
+	var tests = map[string]struct {
+		genesisOffset time.Duration
+		expectedBlock uint64
+	}{
+		"before genesis": {
+			genesisOffset: time.Hour,
+			expectedBlock: 0,
+		},
+		"at genesis": {
+			genesisOffset: 0,
+			expectedBlock: 0,
+		},
+		"after genesis": {
+			genesisOffset: -25 * time.Minute,
+			expectedBlock: 2,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			clock, err := NewWallClock(
+				time.Now().Add(test.genesisOffset),
+				10*time.Minute,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			currentBlock, err := clock.CurrentBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if currentBlock != test.expectedBlock {
+				t.Errorf(
+					"unexpected current block\nexpected: [%v]\nactual:   [%v]",
+					test.expectedBlock,
+					currentBlock,
+				)
+			}
+		})
+	}
+}
+
+func TestWallClockBlockHeightWaiter(t *testing.T) {
+	blockTime := 20 * time.Millisecond
+
+	clock, err := NewWallClock(time.Now(), blockTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, blockNumber := range []uint64{0, 3} {
+		waiter, err := clock.BlockHeightWaiter(blockNumber)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case emitted := <-waiter:
+			if emitted != blockNumber {
+				t.Errorf(
+					"unexpected block emitted\nexpected: [%v]\nactual:   [%v]",
+					blockNumber,
+					emitted,
+				)
+			}
+		case <-time.After(time.Duration(blockNumber+5) * blockTime):
+			t.Fatalf("block [%v] has not been reached", blockNumber)
+		}
+
+		currentBlock, err := clock.CurrentBlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if currentBlock < blockNumber {
+			t.Errorf(
+				"waiter emitted block [%v] at block [%v]",
+				blockNumber,
+				currentBlock,
+			)
+		}
+	}
+}
@@ -0,0 +1,1982 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: pb/message.proto
+
+package pb
+
+import (
+	bytes "bytes"
+	fmt "fmt"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strings "strings"
+
+	proto "github.com/gogo/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type Checkpoint struct {
+	StartBlockHeight uint64                `protobuf:"varint,1,opt,name=startBlockHeight,proto3" json:"startBlockHeight,omitempty"`
+	StateIndex       uint32                `protobuf:"varint,2,opt,name=stateIndex,proto3" json:"stateIndex,omitempty"`
+	Initiated        bool                  `protobuf:"varint,3,opt,name=initiated,proto3" json:"initiated,omitempty"`
+	Messages         []*Checkpoint_Message `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (m *Checkpoint) Reset()      { *m = Checkpoint{} }
+func (*Checkpoint) ProtoMessage() {}
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{0}
+}
+func (m *Checkpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Checkpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint.Merge(m, src)
+}
+func (m *Checkpoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *Checkpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint proto.InternalMessageInfo
+
+func (m *Checkpoint) GetStartBlockHeight() uint64 {
+	if m != nil {
+		return m.StartBlockHeight
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetStateIndex() uint32 {
+	if m != nil {
+		return m.StateIndex
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetInitiated() bool {
+	if m != nil {
+		return m.Initiated
+	}
+	return false
+}
+
+func (m *Checkpoint) GetMessages() []*Checkpoint_Message {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+type Checkpoint_Message struct {
+	StateIndex        uint32 `protobuf:"varint,1,opt,name=stateIndex,proto3" json:"stateIndex,omitempty"`
+	Type              string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	SenderPublicKey   []byte `protobuf:"bytes,3,opt,name=senderPublicKey,proto3" json:"senderPublicKey,omitempty"`
+	TransportSenderID string `protobuf:"bytes,4,opt,name=transportSenderID,proto3" json:"transportSenderID,omitempty"`
+	Seqno             uint64 `protobuf:"varint,5,opt,name=seqno,proto3" json:"seqno,omitempty"`
+	Payload           []byte `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (m *Checkpoint_Message) Reset()      { *m = Checkpoint_Message{} }
+func (*Checkpoint_Message) ProtoMessage() {}
+func (*Checkpoint_Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{0, 0}
+}
+func (m *Checkpoint_Message) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint_Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint_Message.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Checkpoint_Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint_Message.Merge(m, src)
+}
+func (m *Checkpoint_Message) XXX_Size() int {
+	return m.Size()
+}
+func (m *Checkpoint_Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint_Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint_Message proto.InternalMessageInfo
+
+func (m *Checkpoint_Message) GetStateIndex() uint32 {
+	if m != nil {
+		return m.StateIndex
+	}
+	return 0
+}
+
+func (m *Checkpoint_Message) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Checkpoint_Message) GetSenderPublicKey() []byte {
+	if m != nil {
+		return m.SenderPublicKey
+	}
+	return nil
+}
+
+func (m *Checkpoint_Message) GetTransportSenderID() string {
+	if m != nil {
+		return m.TransportSenderID
+	}
+	return ""
+}
+
+func (m *Checkpoint_Message) GetSeqno() uint64 {
+	if m != nil {
+		return m.Seqno
+	}
+	return 0
+}
+
+func (m *Checkpoint_Message) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type Transcript struct {
+	StartBlockHeight uint64                `protobuf:"varint,1,opt,name=startBlockHeight,proto3" json:"startBlockHeight,omitempty"`
+	EndBlockHeight   uint64                `protobuf:"varint,2,opt,name=endBlockHeight,proto3" json:"endBlockHeight,omitempty"`
+	Phases           []*Transcript_Phase   `protobuf:"bytes,3,rep,name=phases,proto3" json:"phases,omitempty"`
+	Messages         []*Transcript_Message `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (m *Transcript) Reset()      { *m = Transcript{} }
+func (*Transcript) ProtoMessage() {}
+func (*Transcript) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{1}
+}
+func (m *Transcript) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Transcript) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Transcript.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Transcript) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transcript.Merge(m, src)
+}
+func (m *Transcript) XXX_Size() int {
+	return m.Size()
+}
+func (m *Transcript) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transcript.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transcript proto.InternalMessageInfo
+
+func (m *Transcript) GetStartBlockHeight() uint64 {
+	if m != nil {
+		return m.StartBlockHeight
+	}
+	return 0
+}
+
+func (m *Transcript) GetEndBlockHeight() uint64 {
+	if m != nil {
+		return m.EndBlockHeight
+	}
+	return 0
+}
+
+func (m *Transcript) GetPhases() []*Transcript_Phase {
+	if m != nil {
+		return m.Phases
+	}
+	return nil
+}
+
+func (m *Transcript) GetMessages() []*Transcript_Message {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+type Transcript_Phase struct {
+	State            string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	StartBlockHeight uint64 `protobuf:"varint,2,opt,name=startBlockHeight,proto3" json:"startBlockHeight,omitempty"`
+}
+
+func (m *Transcript_Phase) Reset()      { *m = Transcript_Phase{} }
+func (*Transcript_Phase) ProtoMessage() {}
+func (*Transcript_Phase) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{1, 0}
+}
+func (m *Transcript_Phase) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Transcript_Phase) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Transcript_Phase.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Transcript_Phase) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transcript_Phase.Merge(m, src)
+}
+func (m *Transcript_Phase) XXX_Size() int {
+	return m.Size()
+}
+func (m *Transcript_Phase) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transcript_Phase.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transcript_Phase proto.InternalMessageInfo
+
+func (m *Transcript_Phase) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *Transcript_Phase) GetStartBlockHeight() uint64 {
+	if m != nil {
+		return m.StartBlockHeight
+	}
+	return 0
+}
+
+type Transcript_Message struct {
+	Message     *Checkpoint_Message `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Sent        bool                `protobuf:"varint,2,opt,name=sent,proto3" json:"sent,omitempty"`
+	BlockHeight uint64              `protobuf:"varint,3,opt,name=blockHeight,proto3" json:"blockHeight,omitempty"`
+}
+
+func (m *Transcript_Message) Reset()      { *m = Transcript_Message{} }
+func (*Transcript_Message) ProtoMessage() {}
+func (*Transcript_Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8447775385e7eb85, []int{1, 1}
+}
+func (m *Transcript_Message) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Transcript_Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Transcript_Message.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Transcript_Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transcript_Message.Merge(m, src)
+}
+func (m *Transcript_Message) XXX_Size() int {
+	return m.Size()
+}
+func (m *Transcript_Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transcript_Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transcript_Message proto.InternalMessageInfo
+
+func (m *Transcript_Message) GetMessage() *Checkpoint_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *Transcript_Message) GetSent() bool {
+	if m != nil {
+		return m.Sent
+	}
+	return false
+}
+
+func (m *Transcript_Message) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Checkpoint)(nil), "state.Checkpoint")
+	proto.RegisterType((*Checkpoint_Message)(nil), "state.Checkpoint.Message")
+	proto.RegisterType((*Transcript)(nil), "state.Transcript")
+	proto.RegisterType((*Transcript_Phase)(nil), "state.Transcript.Phase")
+	proto.RegisterType((*Transcript_Message)(nil), "state.Transcript.Message")
+}
+
+func init() { proto.RegisterFile("pb/message.proto", fileDescriptor_8447775385e7eb85) }
+
+var fileDescriptor_8447775385e7eb85 = []byte{
+	// 439 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xbf, 0x8b, 0xd4, 0x40,
+	0x14, 0xce, 0x24, 0xfb, 0xf3, 0x9d, 0x3f, 0xce, 0x41, 0x70, 0x3c, 0x64, 0x08, 0x57, 0x48, 0x10,
+	0xc9, 0x81, 0x87, 0x60, 0x7d, 0x5a, 0xb8, 0x88, 0x70, 0x8c, 0x56, 0x76, 0x93, 0xcd, 0x70, 0x1b,
+	0x6e, 0x9d, 0x8c, 0x99, 0x11, 0xdc, 0xce, 0xd6, 0xce, 0x3f, 0xc3, 0x7f, 0x44, 0xb0, 0xdc, 0xf2,
+	0x4a, 0x37, 0xdb, 0x58, 0x5e, 0x63, 0x2f, 0xfb, 0x92, 0x33, 0x61, 0x73, 0x2c, 0x5c, 0x37, 0xef,
+	0x7b, 0xdf, 0x7c, 0xef, 0x9b, 0xef, 0x25, 0xb0, 0x6f, 0x92, 0xa3, 0x8f, 0xca, 0x5a, 0x79, 0xa6,
+	0x62, 0x53, 0xe4, 0x2e, 0xa7, 0x7d, 0xeb, 0xa4, 0x53, 0x87, 0x7f, 0x7d, 0x80, 0x97, 0x33, 0x35,
+	0x3d, 0x37, 0x79, 0xa6, 0x1d, 0x7d, 0x02, 0xfb, 0xd6, 0xc9, 0xc2, 0x9d, 0xcc, 0xf3, 0xe9, 0xf9,
+	0x6b, 0x95, 0x9d, 0xcd, 0x1c, 0x23, 0x21, 0x89, 0x7a, 0xa2, 0x83, 0x53, 0x0e, 0x80, 0x1a, 0x13,
+	0x9d, 0xaa, 0x2f, 0xcc, 0x0f, 0x49, 0x74, 0x5b, 0xb4, 0x10, 0xfa, 0x08, 0xc6, 0x99, 0xce, 0x5c,
+	0x26, 0x9d, 0x4a, 0x59, 0x10, 0x92, 0x68, 0x24, 0x1a, 0x80, 0x3e, 0x87, 0x51, 0x6d, 0xc8, 0xb2,
+	0x5e, 0x18, 0x44, 0x7b, 0xcf, 0x1e, 0xc6, 0x78, 0x39, 0x6e, 0xec, 0xc4, 0x6f, 0x2b, 0x86, 0xf8,
+	0x4f, 0x3d, 0xf8, 0x49, 0x60, 0x58, 0xa3, 0x5b, 0x06, 0x48, 0xc7, 0x00, 0x85, 0x9e, 0x5b, 0x18,
+	0x85, 0xd6, 0xc6, 0x02, 0xcf, 0x34, 0x82, 0xbb, 0x56, 0xe9, 0x54, 0x15, 0xa7, 0x9f, 0x93, 0x79,
+	0x36, 0x7d, 0xa3, 0x16, 0x68, 0xed, 0x96, 0xd8, 0x86, 0xe9, 0x53, 0xb8, 0xe7, 0x0a, 0xa9, 0xad,
+	0xc9, 0x0b, 0xf7, 0x0e, 0x7b, 0x93, 0x57, 0xac, 0x87, 0x52, 0xdd, 0x06, 0xbd, 0x0f, 0x7d, 0xab,
+	0x3e, 0xe9, 0x9c, 0xf5, 0x31, 0xad, 0xaa, 0xa0, 0x0c, 0x86, 0x46, 0x2e, 0xe6, 0xb9, 0x4c, 0xd9,
+	0x00, 0xa7, 0x5c, 0x95, 0x87, 0xdf, 0x02, 0x80, 0xf7, 0x1b, 0x95, 0x69, 0x91, 0x99, 0x9b, 0xe5,
+	0xfe, 0x18, 0xee, 0x28, 0x9d, 0xb6, 0x99, 0x3e, 0x32, 0xb7, 0x50, 0x7a, 0x04, 0x03, 0x33, 0x93,
+	0x56, 0x59, 0x16, 0x60, 0xbe, 0x0f, 0xea, 0x7c, 0x9b, 0xb1, 0xf1, 0xe9, 0xa6, 0x2f, 0x6a, 0xda,
+	0x8e, 0x95, 0xb4, 0xae, 0x74, 0x57, 0x32, 0x81, 0x3e, 0xea, 0x60, 0x06, 0x1b, 0x3a, 0x3a, 0x1f,
+	0x8b, 0xaa, 0xb8, 0xf6, 0x69, 0xfe, 0xf5, 0x4f, 0x3b, 0x70, 0xcd, 0x72, 0x8f, 0x61, 0x58, 0x4f,
+	0x40, 0xb9, 0x9d, 0x9f, 0xc7, 0x15, 0x73, 0xb3, 0x71, 0xab, 0x74, 0xa5, 0x3f, 0x12, 0x78, 0xa6,
+	0x21, 0xec, 0x25, 0xad, 0xd1, 0x01, 0x8e, 0x6e, 0x43, 0x27, 0x2f, 0x96, 0x2b, 0xee, 0x5d, 0xac,
+	0xb8, 0x77, 0xb9, 0xe2, 0xe4, 0x6b, 0xc9, 0xc9, 0x8f, 0x92, 0x93, 0x5f, 0x25, 0x27, 0xcb, 0x92,
+	0x93, 0xdf, 0x25, 0x27, 0x7f, 0x4a, 0xee, 0x5d, 0x96, 0x9c, 0x7c, 0x5f, 0x73, 0x6f, 0xb9, 0xe6,
+	0xde, 0xc5, 0x9a, 0x7b, 0x1f, 0x7c, 0x93, 0x24, 0x03, 0xfc, 0x97, 0x8e, 0xff, 0x0d, 0x00, 0x44,
+	0x16, 0x13, 0x2a, 0x5f, 0x03, 0x00, 0x00,
+}
+
+func (this *Checkpoint) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Checkpoint)
+	if !ok {
+		that2, ok := that.(Checkpoint)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.StartBlockHeight != that1.StartBlockHeight {
+		return false
+	}
+	if this.StateIndex != that1.StateIndex {
+		return false
+	}
+	if this.Initiated != that1.Initiated {
+		return false
+	}
+	if len(this.Messages) != len(that1.Messages) {
+		return false
+	}
+	for i := range this.Messages {
+		if !this.Messages[i].Equal(that1.Messages[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Checkpoint_Message) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Checkpoint_Message)
+	if !ok {
+		that2, ok := that.(Checkpoint_Message)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.StateIndex != that1.StateIndex {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if !bytes.Equal(this.SenderPublicKey, that1.SenderPublicKey) {
+		return false
+	}
+	if this.TransportSenderID != that1.TransportSenderID {
+		return false
+	}
+	if this.Seqno != that1.Seqno {
+		return false
+	}
+	if !bytes.Equal(this.Payload, that1.Payload) {
+		return false
+	}
+	return true
+}
+func (this *Transcript) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Transcript)
+	if !ok {
+		that2, ok := that.(Transcript)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.StartBlockHeight != that1.StartBlockHeight {
+		return false
+	}
+	if this.EndBlockHeight != that1.EndBlockHeight {
+		return false
+	}
+	if len(this.Phases) != len(that1.Phases) {
+		return false
+	}
+	for i := range this.Phases {
+		if !this.Phases[i].Equal(that1.Phases[i]) {
+			return false
+		}
+	}
+	if len(this.Messages) != len(that1.Messages) {
+		return false
+	}
+	for i := range this.Messages {
+		if !this.Messages[i].Equal(that1.Messages[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Transcript_Phase) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Transcript_Phase)
+	if !ok {
+		that2, ok := that.(Transcript_Phase)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.State != that1.State {
+		return false
+	}
+	if this.StartBlockHeight != that1.StartBlockHeight {
+		return false
+	}
+	return true
+}
+func (this *Transcript_Message) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Transcript_Message)
+	if !ok {
+		that2, ok := that.(Transcript_Message)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Message.Equal(that1.Message) {
+		return false
+	}
+	if this.Sent != that1.Sent {
+		return false
+	}
+	if this.BlockHeight != that1.BlockHeight {
+		return false
+	}
+	return true
+}
+func (this *Checkpoint) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.Checkpoint{")
+	s = append(s, "StartBlockHeight: "+fmt.Sprintf("%#v", this.StartBlockHeight)+",\n")
+	s = append(s, "StateIndex: "+fmt.Sprintf("%#v", this.StateIndex)+",\n")
+	s = append(s, "Initiated: "+fmt.Sprintf("%#v", this.Initiated)+",\n")
+	if this.Messages != nil {
+		s = append(s, "Messages: "+fmt.Sprintf("%#v", this.Messages)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Checkpoint_Message) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.Checkpoint_Message{")
+	s = append(s, "StateIndex: "+fmt.Sprintf("%#v", this.StateIndex)+",\n")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "SenderPublicKey: "+fmt.Sprintf("%#v", this.SenderPublicKey)+",\n")
+	s = append(s, "TransportSenderID: "+fmt.Sprintf("%#v", this.TransportSenderID)+",\n")
+	s = append(s, "Seqno: "+fmt.Sprintf("%#v", this.Seqno)+",\n")
+	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Transcript) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.Transcript{")
+	s = append(s, "StartBlockHeight: "+fmt.Sprintf("%#v", this.StartBlockHeight)+",\n")
+	s = append(s, "EndBlockHeight: "+fmt.Sprintf("%#v", this.EndBlockHeight)+",\n")
+	if this.Phases != nil {
+		s = append(s, "Phases: "+fmt.Sprintf("%#v", this.Phases)+",\n")
+	}
+	if this.Messages != nil {
+		s = append(s, "Messages: "+fmt.Sprintf("%#v", this.Messages)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Transcript_Phase) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.Transcript_Phase{")
+	s = append(s, "State: "+fmt.Sprintf("%#v", this.State)+",\n")
+	s = append(s, "StartBlockHeight: "+fmt.Sprintf("%#v", this.StartBlockHeight)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Transcript_Message) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.Transcript_Message{")
+	if this.Message != nil {
+		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	}
+	s = append(s, "Sent: "+fmt.Sprintf("%#v", this.Sent)+",\n")
+	s = append(s, "BlockHeight: "+fmt.Sprintf("%#v", this.BlockHeight)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringMessage(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *Checkpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Checkpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Messages) > 0 {
+		for iNdEx := len(m.Messages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Messages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintMessage(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Initiated {
+		i--
+		if m.Initiated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.StateIndex != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.StateIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartBlockHeight != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.StartBlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Checkpoint_Message) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint_Message) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Checkpoint_Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Seqno != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.Seqno))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.TransportSenderID) > 0 {
+		i -= len(m.TransportSenderID)
+		copy(dAtA[i:], m.TransportSenderID)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.TransportSenderID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.SenderPublicKey) > 0 {
+		i -= len(m.SenderPublicKey)
+		copy(dAtA[i:], m.SenderPublicKey)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.SenderPublicKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.StateIndex != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.StateIndex))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Transcript) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Transcript) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Transcript) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Messages) > 0 {
+		for iNdEx := len(m.Messages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Messages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintMessage(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Phases) > 0 {
+		for iNdEx := len(m.Phases) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Phases[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintMessage(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.EndBlockHeight != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.EndBlockHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartBlockHeight != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.StartBlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Transcript_Phase) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Transcript_Phase) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Transcript_Phase) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.StartBlockHeight != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.StartBlockHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.State)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Transcript_Message) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Transcript_Message) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Transcript_Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Sent {
+		i--
+		if m.Sent {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Message != nil {
+		{
+			size, err := m.Message.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintMessage(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
+	offset -= sovMessage(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Checkpoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartBlockHeight != 0 {
+		n += 1 + sovMessage(uint64(m.StartBlockHeight))
+	}
+	if m.StateIndex != 0 {
+		n += 1 + sovMessage(uint64(m.StateIndex))
+	}
+	if m.Initiated {
+		n += 2
+	}
+	if len(m.Messages) > 0 {
+		for _, e := range m.Messages {
+			l = e.Size()
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Checkpoint_Message) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StateIndex != 0 {
+		n += 1 + sovMessage(uint64(m.StateIndex))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.SenderPublicKey)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.TransportSenderID)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.Seqno != 0 {
+		n += 1 + sovMessage(uint64(m.Seqno))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	return n
+}
+
+func (m *Transcript) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartBlockHeight != 0 {
+		n += 1 + sovMessage(uint64(m.StartBlockHeight))
+	}
+	if m.EndBlockHeight != 0 {
+		n += 1 + sovMessage(uint64(m.EndBlockHeight))
+	}
+	if len(m.Phases) > 0 {
+		for _, e := range m.Phases {
+			l = e.Size()
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	if len(m.Messages) > 0 {
+		for _, e := range m.Messages {
+			l = e.Size()
+			n += 1 + l + sovMessage(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Transcript_Phase) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.StartBlockHeight != 0 {
+		n += 1 + sovMessage(uint64(m.StartBlockHeight))
+	}
+	return n
+}
+
+func (m *Transcript_Message) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Message != nil {
+		l = m.Message.Size()
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.Sent {
+		n += 2
+	}
+	if m.BlockHeight != 0 {
+		n += 1 + sovMessage(uint64(m.BlockHeight))
+	}
+	return n
+}
+
+func sovMessage(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozMessage(x uint64) (n int) {
+	return sovMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Checkpoint) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMessages := "[]*Checkpoint_Message{"
+	for _, f := range this.Messages {
+		repeatedStringForMessages += strings.Replace(fmt.Sprintf("%v", f), "Checkpoint_Message", "Checkpoint_Message", 1) + ","
+	}
+	repeatedStringForMessages += "}"
+	s := strings.Join([]string{`&Checkpoint{`,
+		`StartBlockHeight:` + fmt.Sprintf("%v", this.StartBlockHeight) + `,`,
+		`StateIndex:` + fmt.Sprintf("%v", this.StateIndex) + `,`,
+		`Initiated:` + fmt.Sprintf("%v", this.Initiated) + `,`,
+		`Messages:` + repeatedStringForMessages + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Checkpoint_Message) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Checkpoint_Message{`,
+		`StateIndex:` + fmt.Sprintf("%v", this.StateIndex) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`SenderPublicKey:` + fmt.Sprintf("%v", this.SenderPublicKey) + `,`,
+		`TransportSenderID:` + fmt.Sprintf("%v", this.TransportSenderID) + `,`,
+		`Seqno:` + fmt.Sprintf("%v", this.Seqno) + `,`,
+		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Transcript) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForPhases := "[]*Transcript_Phase{"
+	for _, f := range this.Phases {
+		repeatedStringForPhases += strings.Replace(fmt.Sprintf("%v", f), "Transcript_Phase", "Transcript_Phase", 1) + ","
+	}
+	repeatedStringForPhases += "}"
+	repeatedStringForMessages := "[]*Transcript_Message{"
+	for _, f := range this.Messages {
+		repeatedStringForMessages += strings.Replace(fmt.Sprintf("%v", f), "Transcript_Message", "Transcript_Message", 1) + ","
+	}
+	repeatedStringForMessages += "}"
+	s := strings.Join([]string{`&Transcript{`,
+		`StartBlockHeight:` + fmt.Sprintf("%v", this.StartBlockHeight) + `,`,
+		`EndBlockHeight:` + fmt.Sprintf("%v", this.EndBlockHeight) + `,`,
+		`Phases:` + repeatedStringForPhases + `,`,
+		`Messages:` + repeatedStringForMessages + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Transcript_Phase) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Transcript_Phase{`,
+		`State:` + fmt.Sprintf("%v", this.State) + `,`,
+		`StartBlockHeight:` + fmt.Sprintf("%v", this.StartBlockHeight) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Transcript_Message) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Transcript_Message{`,
+		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "Checkpoint_Message", "Checkpoint_Message", 1) + `,`,
+		`Sent:` + fmt.Sprintf("%v", this.Sent) + `,`,
+		`BlockHeight:` + fmt.Sprintf("%v", this.BlockHeight) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringMessage(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Checkpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Checkpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Checkpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartBlockHeight", wireType)
+			}
+			m.StartBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartBlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateIndex", wireType)
+			}
+			m.StateIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StateIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Initiated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Initiated = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Messages = append(m.Messages, &Checkpoint_Message{})
+			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Checkpoint_Message) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Message: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Message: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateIndex", wireType)
+			}
+			m.StateIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StateIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SenderPublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SenderPublicKey = append(m.SenderPublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.SenderPublicKey == nil {
+				m.SenderPublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransportSenderID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TransportSenderID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seqno", wireType)
+			}
+			m.Seqno = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seqno |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Transcript) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Transcript: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Transcript: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartBlockHeight", wireType)
+			}
+			m.StartBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartBlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlockHeight", wireType)
+			}
+			m.EndBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndBlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Phases", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Phases = append(m.Phases, &Transcript_Phase{})
+			if err := m.Phases[len(m.Phases)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Messages = append(m.Messages, &Transcript_Message{})
+			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Transcript_Phase) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Phase: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Phase: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartBlockHeight", wireType)
+			}
+			m.StartBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartBlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Transcript_Message) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Message: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Message: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Message == nil {
+				m.Message = &Checkpoint_Message{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sent", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Sent = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			}
+			m.BlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipMessage(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthMessage
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupMessage
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthMessage
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthMessage        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowMessage          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupMessage = fmt.Errorf("proto: unexpected end of group")
+)
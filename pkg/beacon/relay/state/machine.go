@@ -3,8 +3,8 @@ package state
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
@@ -17,64 +17,221 @@ const receiveBuffer = 64
 // interface.
 type Machine struct {
 	channel      net.BroadcastChannel
-	blockCounter chain.BlockCounter
+	clock        Clock
 	initialState State // first state from which execution starts
+
+	// Set only for machines resumed from a checkpoint.
+	resumedFrom  *Checkpoint
+	replay       *replayChannel
+	unmarshalers map[string]func() net.TaggedUnmarshaler
+
+	onCheckpoint func(*Checkpoint)
+	checkpoint   *Checkpoint
+	// Keys of replayed messages; retransmissions of those messages received
+	// after the machine goes live are ignored.
+	replayed map[string]bool
+
+	// Set only for machines recording the transcript of the execution.
+	recorder *TranscriptRecorder
+
+	// Set for machines which do not move on from complete states early.
+	waitForActiveBlocks bool
 }
 
 // NewMachine returns a new state machine. It requires a broadcast channel and
 // an initialization function for the channel to be able to perform interactions.
+// States are executed with the given clock, usually the chain block counter.
 func NewMachine(
 	channel net.BroadcastChannel,
-	blockCounter chain.BlockCounter,
+	clock Clock,
 	initialState State,
 ) *Machine {
 	return &Machine{
 		channel:      channel,
-		blockCounter: blockCounter,
+		clock:        clock,
 		initialState: initialState,
 	}
 }
 
+// ResumeMachine returns a state machine resuming the execution captured in
+// the given checkpoint. The initial state is created with the given function
+// and has to be the same as the one of the machine which produced the
+// checkpoint.
+//
+// When executed, states which were active before the checkpoint was taken are
+// initiated again and receive the recorded messages, so they need to produce
+// the same results as before. Messages sent by those states are dropped. The
+// machine goes live once it reaches a state which has not ended yet. Recorded
+// messages are unmarshaled with the given unmarshalers, keyed by the message
+// type.
+func ResumeMachine(
+	channel net.BroadcastChannel,
+	clock Clock,
+	checkpoint *Checkpoint,
+	unmarshalers map[string]func() net.TaggedUnmarshaler,
+	newInitialState func(channel net.BroadcastChannel) State,
+) *Machine {
+	replay := &replayChannel{BroadcastChannel: channel}
+
+	return &Machine{
+		channel:      channel,
+		clock:        clock,
+		initialState: newInitialState(replay),
+		resumedFrom:  checkpoint,
+		replay:       replay,
+		unmarshalers: unmarshalers,
+	}
+}
+
+// OnCheckpoint registers a handler called with a new checkpoint each time the
+// machine initiates a state and each time a state ends. Handler is called
+// synchronously, before the machine proceeds.
+func (m *Machine) OnCheckpoint(handler func(*Checkpoint)) {
+	m.onCheckpoint = handler
+}
+
+// RecordTranscript registers a recorder to which the machine records state
+// boundaries and received messages. States of a resumed machine which are
+// replayed are recorded as well, along with the replayed messages.
+func (m *Machine) RecordTranscript(recorder *TranscriptRecorder) {
+	m.recorder = recorder
+}
+
+// WaitForActiveBlocks makes the machine wait for the active blocks of each
+// state to be over even if the state reports it is complete. The machine
+// executes then as if none of the states implemented CompletableState and
+// stays compatible with machines moving on from complete states early.
+func (m *Machine) WaitForActiveBlocks() {
+	m.waitForActiveBlocks = true
+}
+
 // Execute state machine starting with initial state up to finalization. It
-// requires the broadcast channel to be pre-initialized.
-func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
+// requires the broadcast channel to be pre-initialized. The execution is
+// abandoned with an error when the given context is done.
+//
+// States implementing CompletableState may end before their active blocks
+// are over, so the block at which the final state is reached, returned along
+// with that state, may be lower than the sum of blocks of all the states.
+func (m *Machine) Execute(
+	parentCtx context.Context,
+	startBlockHeight uint64,
+) (State, uint64, error) {
 	recvChan := make(chan net.Message, receiveBuffer)
 	handler := func(msg net.Message) {
 		recvChan <- msg
 	}
 
 	currentState := m.initialState
-	ctx, cancelCtx := context.WithCancel(context.Background())
+	ctx, cancelCtx := context.WithCancel(parentCtx)
 	m.channel.Recv(ctx, handler)
 
+	inFlight.enter(m, currentState, startBlockHeight)
+	defer inFlight.finish(m)
+
 	logger.Infof(
 		"[member:%v,channel:%s] waiting for block %v to start execution",
 		currentState.MemberIndex(),
 		m.channel.Name()[:5],
 		startBlockHeight,
 	)
-	err := m.blockCounter.WaitForBlockHeight(startBlockHeight)
+	startBlockWaiter, err := m.clock.BlockHeightWaiter(startBlockHeight)
 	if err != nil {
+		cancelCtx()
 		return nil, 0, fmt.Errorf("failed to wait for the execution start block")
 	}
 
+	select {
+	case <-startBlockWaiter:
+	case <-parentCtx.Done():
+		cancelCtx()
+		return nil, 0, fmt.Errorf(
+			"execution cancelled before the start block: [%v]",
+			parentCtx.Err(),
+		)
+	}
+
+	currentStateIndex := 0
 	lastStateEndBlockHeight := startBlockHeight
+	initiate := true
+
+	m.recorder.start(startBlockHeight)
+
+	m.checkpoint = &Checkpoint{StartBlockHeight: startBlockHeight}
+	if m.resumedFrom != nil {
+		m.checkpoint = m.resumedFrom.copy()
+
+		var final bool
+		currentState, currentStateIndex, lastStateEndBlockHeight, initiate, final, err =
+			m.catchUp(ctx)
+		if err != nil {
+			cancelCtx()
+			return nil, 0, err
+		}
+
+		if final {
+			cancelCtx()
+			m.recorder.finish(lastStateEndBlockHeight)
+			logger.Infof(
+				"[member:%v,channel:%s,state:%T] reached final state "+
+					"while resuming at block: [%v]",
+				currentState.MemberIndex(),
+				m.channel.Name()[:5],
+				currentState,
+				lastStateEndBlockHeight,
+			)
+			return currentState, lastStateEndBlockHeight, nil
+		}
+
+		m.replay.goLive()
+		inFlight.enter(m, currentState, lastStateEndBlockHeight)
+	}
+
+	stateStartTime := time.Now()
+	m.recorder.enterState(
+		currentStateIndex,
+		currentState,
+		lastStateEndBlockHeight,
+	)
+
+	// Block at which the current state starts if all the previous states
+	// wait for their active blocks to be over. States end no later than that
+	// schedule says even if some of the previous states completed early.
+	stateStartBlockHeight := lastStateEndBlockHeight
 
 	blockWaiter, err := stateTransition(
 		ctx,
 		currentState,
-		lastStateEndBlockHeight,
-		m.blockCounter,
+		stateStartBlockHeight,
+		m.clock,
 		m.channel.Name()[:5],
+		initiate,
+		false,
 	)
 	if err != nil {
 		cancelCtx()
 		return nil, 0, err
 	}
+	m.saveCheckpoint(currentStateIndex, true)
+
+	completionWaiter, err := m.completionWaiter(currentState)
+	if err != nil {
+		cancelCtx()
+		return nil, 0, err
+	}
 
 	for {
+		var stateEndBlockHeight uint64
+
 		select {
 		case msg := <-recvChan:
+			if m.replayed[replayKey(currentStateIndex, msg)] {
+				continue
+			}
+
+			messagesReceived.WithLabelValues(stateName(currentState)).Inc()
+			m.recordMessage(currentStateIndex, msg)
+			m.recorder.received(msg)
+
 			err := currentState.Receive(msg)
 			if err != nil {
 				logger.Errorf(
@@ -86,77 +243,366 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 				)
 			}
 
-		case lastStateEndBlockHeight := <-blockWaiter:
-			cancelCtx()
-			nextState := currentState.Next()
-			if nextState == nil {
+			if completionWaiter == nil {
+				completionWaiter, err = m.completionWaiter(currentState)
+				if err != nil {
+					cancelCtx()
+					return nil, 0, err
+				}
+			}
+
+			continue
+
+		case stateEndBlockHeight = <-blockWaiter:
+
+		case stateEndBlockHeight = <-completionWaiter:
+			scheduledEndBlockHeight := stateStartBlockHeight +
+				currentState.DelayBlocks() +
+				currentState.ActiveBlocks()
+			if stateEndBlockHeight < scheduledEndBlockHeight {
 				logger.Infof(
-					"[member:%v,channel:%s,state:%T] reached final state at block: [%v]",
+					"[member:%v,channel:%s,state:%T] state completed "+
+						"at block [%v], before scheduled block [%v]",
 					currentState.MemberIndex(),
 					m.channel.Name()[:5],
 					currentState,
-					lastStateEndBlockHeight,
+					stateEndBlockHeight,
+					scheduledEndBlockHeight,
 				)
-				return currentState, lastStateEndBlockHeight, nil
 			}
 
-			currentState = nextState
-			ctx, cancelCtx = context.WithCancel(context.Background())
-			m.channel.Recv(ctx, handler)
+		case <-parentCtx.Done():
+			cancelCtx()
+			return nil, 0, fmt.Errorf(
+				"[member:%v,channel:%s,state:%T] execution cancelled: [%v]",
+				currentState.MemberIndex(),
+				m.channel.Name()[:5],
+				currentState,
+				parentCtx.Err(),
+			)
+		}
 
-			blockWaiter, err = stateTransition(
-				ctx,
+		cancelCtx()
+		stateDuration.WithLabelValues(stateName(currentState)).Observe(
+			time.Since(stateStartTime).Seconds(),
+		)
+
+		nextState := currentState.Next()
+		if nextState == nil {
+			m.recorder.finish(stateEndBlockHeight)
+			logger.Infof(
+				"[member:%v,channel:%s,state:%T] reached final state at block: [%v]",
+				currentState.MemberIndex(),
+				m.channel.Name()[:5],
 				currentState,
-				lastStateEndBlockHeight,
-				m.blockCounter,
+				stateEndBlockHeight,
+			)
+			return currentState, stateEndBlockHeight, nil
+		}
+
+		stateStartBlockHeight += currentState.DelayBlocks() +
+			currentState.ActiveBlocks()
+
+		currentState = nextState
+		currentStateIndex++
+		stateStartTime = time.Now()
+		inFlight.enter(m, currentState, stateEndBlockHeight)
+		m.saveCheckpoint(currentStateIndex, false)
+		m.recorder.enterState(
+			currentStateIndex,
+			currentState,
+			stateEndBlockHeight,
+		)
+
+		ctx, cancelCtx = context.WithCancel(parentCtx)
+		m.channel.Recv(ctx, handler)
+
+		blockWaiter, err = stateTransition(
+			ctx,
+			currentState,
+			stateStartBlockHeight,
+			m.clock,
+			m.channel.Name()[:5],
+			true,
+			stateEndBlockHeight < stateStartBlockHeight,
+		)
+		if err != nil {
+			cancelCtx()
+			return nil, 0, err
+		}
+		m.saveCheckpoint(currentStateIndex, true)
+
+		completionWaiter, err = m.completionWaiter(currentState)
+		if err != nil {
+			cancelCtx()
+			return nil, 0, err
+		}
+	}
+}
+
+// completionWaiter returns a channel receiving the block at which the machine
+// moves on from the current state because the state is complete. If the
+// state is not complete yet, nil channel is returned.
+func (m *Machine) completionWaiter(currentState State) (<-chan uint64, error) {
+	if m.waitForActiveBlocks {
+		return nil, nil
+	}
+
+	if currentState.ActiveBlocks() > 0 {
+		completable, ok := currentState.(CompletableState)
+		if !ok || !completable.IsComplete() {
+			return nil, nil
+		}
+	}
+
+	currentBlock, err := m.clock.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not read the current block: [%v]",
+			err,
+		)
+	}
+
+	// States with no active blocks do not wait for messages, so there is
+	// nothing to deliver before moving on.
+	if currentState.ActiveBlocks() == 0 {
+		waiter := make(chan uint64, 1)
+		waiter <- currentBlock
+		return waiter, nil
+	}
+
+	return m.clock.BlockHeightWaiter(currentBlock + 1)
+}
+
+// catchUp replays states of the resumed execution which are already over or
+// were initiated before the checkpoint was taken. It returns the state from
+// which the execution should continue live, its index, the block at which it
+// started and whether it still needs to be initiated. If all the states are
+// over, the final state is returned and the returned flag is set.
+func (m *Machine) catchUp(ctx context.Context) (
+	currentState State,
+	currentStateIndex int,
+	stateStartBlockHeight uint64,
+	initiate bool,
+	final bool,
+	err error,
+) {
+	currentBlock, err := m.clock.CurrentBlock()
+	if err != nil {
+		return nil, 0, 0, false, false, fmt.Errorf(
+			"could not read the current block: [%v]",
+			err,
+		)
+	}
+
+	currentState = m.initialState
+	stateStartBlockHeight = m.resumedFrom.StartBlockHeight
+	m.replayed = make(map[string]bool)
+
+	for {
+		initiated := currentStateIndex < m.resumedFrom.StateIndex ||
+			(currentStateIndex == m.resumedFrom.StateIndex &&
+				m.resumedFrom.Initiated)
+		stateEndBlockHeight := stateStartBlockHeight +
+			currentState.DelayBlocks() +
+			currentState.ActiveBlocks()
+
+		if currentBlock < stateEndBlockHeight && !initiated {
+			return currentState, currentStateIndex, stateStartBlockHeight,
+				true, false, nil
+		}
+
+		if !initiated {
+			logger.Warningf(
+				"[member:%v,channel:%s,state:%T] state ended before "+
+					"it was initiated; other members may consider this "+
+					"member inactive",
+				currentState.MemberIndex(),
 				m.channel.Name()[:5],
+				currentState,
 			)
-			if err != nil {
-				cancelCtx()
-				return nil, 0, err
-			}
+		}
+
+		m.recorder.enterState(
+			currentStateIndex,
+			currentState,
+			stateStartBlockHeight,
+		)
+
+		if err := m.replayState(
+			ctx,
+			currentState,
+			currentStateIndex,
+		); err != nil {
+			return nil, 0, 0, false, false, err
+		}
+
+		// States before the one captured in the checkpoint are over even if
+		// their blocks are not, because they completed early.
+		if currentBlock < stateEndBlockHeight &&
+			currentStateIndex >= m.resumedFrom.StateIndex {
+			return currentState, currentStateIndex, stateStartBlockHeight,
+				false, false, nil
+		}
+
+		nextState := currentState.Next()
+		if nextState == nil {
+			return currentState, currentStateIndex, stateEndBlockHeight,
+				false, true, nil
+		}
+
+		currentState = nextState
+		currentStateIndex++
+		stateStartBlockHeight = stateEndBlockHeight
+	}
+}
+
+// replayState initiates the given state with sending disabled and delivers
+// all messages recorded for that state.
+func (m *Machine) replayState(
+	ctx context.Context,
+	currentState State,
+	currentStateIndex int,
+) error {
+	logger.Infof(
+		"[member:%v,channel:%s,state:%T] replaying state",
+		currentState.MemberIndex(),
+		m.channel.Name()[:5],
+		currentState,
+	)
 
+	if err := currentState.Initiate(ctx); err != nil {
+		return fmt.Errorf(
+			"failed to replay initiation of state [%T]: [%v]",
+			currentState,
+			err,
+		)
+	}
+
+	for _, recorded := range m.resumedFrom.Messages {
+		if recorded.StateIndex != currentStateIndex {
 			continue
 		}
+
+		msg, err := replayMessage(recorded, m.unmarshalers)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to replay message in state [%T]: [%v]",
+				currentState,
+				err,
+			)
+		}
+
+		m.replayed[replayKey(currentStateIndex, msg)] = true
+		m.recorder.received(msg)
+
+		if err := currentState.Receive(msg); err != nil {
+			logger.Errorf(
+				"[member:%v,channel:%s, state: %T] failed to receive "+
+					"a replayed message: [%v]",
+				currentState.MemberIndex(),
+				m.channel.Name()[:5],
+				currentState,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// replayKey identifies the given message received in the state with the
+// given index. Retransmissions of a message share the same key.
+func replayKey(currentStateIndex int, msg net.Message) string {
+	return fmt.Sprintf(
+		"%v-%x-%v-%v",
+		currentStateIndex,
+		msg.SenderPublicKey(),
+		msg.Type(),
+		msg.Seqno(),
+	)
+}
+
+// recordMessage adds the received message to the current checkpoint. Messages
+// are not recorded if there is no checkpoint handler registered.
+func (m *Machine) recordMessage(currentStateIndex int, msg net.Message) {
+	if m.onCheckpoint == nil {
+		return
+	}
+
+	recorded, err := recordMessage(currentStateIndex, msg)
+	if err != nil {
+		logger.Warningf(
+			"[channel:%s] could not record message of type [%v]: [%v]",
+			m.channel.Name()[:5],
+			msg.Type(),
+			err,
+		)
+		return
+	}
+
+	m.checkpoint.Messages = append(m.checkpoint.Messages, recorded)
+}
+
+// saveCheckpoint passes the current checkpoint to the registered checkpoint
+// handler, if any.
+func (m *Machine) saveCheckpoint(currentStateIndex int, initiated bool) {
+	if m.onCheckpoint == nil {
+		return
 	}
+
+	m.checkpoint.StateIndex = currentStateIndex
+	m.checkpoint.Initiated = initiated
+
+	m.onCheckpoint(m.checkpoint.copy())
 }
 
 func stateTransition(
 	ctx context.Context,
 	currentState State,
-	lastStateEndBlockHeight uint64,
-	blockCounter chain.BlockCounter,
+	stateStartBlockHeight uint64,
+	clock Clock,
 	channelName string,
+	initiate bool,
+	early bool,
 ) (<-chan uint64, error) {
 	logger.Infof(
 		"[member:%v,channel:%s,state:%T] transitioning to a new state at block: [%v]",
 		currentState.MemberIndex(),
 		channelName,
 		currentState,
-		lastStateEndBlockHeight,
+		stateStartBlockHeight,
 	)
 
 	// We delay the initialization of the new state by one block to give all
 	// other coopearating state machines a chance to enter the new state.
 	// This is needed when, for example, during the initialization some
 	// state-specific messages are sent.
-	initiateDelay := lastStateEndBlockHeight + currentState.DelayBlocks()
-	err := blockCounter.WaitForBlockHeight(initiateDelay)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to wait [%v] blocks entering state [%T]: [%v]",
-			currentState.DelayBlocks(),
-			currentState,
-			err,
-		)
+	//
+	// A state entered early, because the previous state completed, is
+	// initiated right away. Messages it sends are retransmitted, so they
+	// reach members which enter the state later.
+	initiateDelay := stateStartBlockHeight + currentState.DelayBlocks()
+	if !early {
+		err := clock.WaitForBlockHeight(initiateDelay)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to wait [%v] blocks entering state [%T]: [%v]",
+				currentState.DelayBlocks(),
+				currentState,
+				err,
+			)
+		}
 	}
 
-	err = currentState.Initiate(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initiate new state [%v]", err)
+	if initiate {
+		err := currentState.Initiate(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initiate new state [%v]", err)
+		}
 	}
 
-	blockWaiter, err := blockCounter.BlockHeightWaiter(
+	blockWaiter, err := clock.BlockHeightWaiter(
 		initiateDelay + currentState.ActiveBlocks(),
 	)
 	if err != nil {
@@ -176,3 +622,8 @@ func stateTransition(
 
 	return blockWaiter, nil
 }
+
+// stateName returns the name of the given state used in logs and metrics.
+func stateName(state State) string {
+	return fmt.Sprintf("%T", state)
+}
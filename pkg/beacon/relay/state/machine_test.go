@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/chain"
 	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/batch"
 	netLocal "github.com/keep-network/keep-core/pkg/net/local"
 )
 
@@ -56,7 +60,7 @@ func TestExecute(t *testing.T) {
 
 	stateMachine := NewMachine(channel, blockCounter, initialState)
 
-	finalState, endBlockHeight, err := stateMachine.Execute(1)
+	finalState, endBlockHeight, err := stateMachine.Execute(context.Background(), 1)
 	if err != nil {
 		t.Errorf("unexpected error [%v]", err)
 	}
@@ -93,6 +97,642 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestExecuteCancelled(t *testing.T) {
+	testLog = make(map[uint64][]string)
+
+	localChain := chainLocal.Connect(10, 5, big.NewInt(200))
+	blockCounter, _ = localChain.BlockCounter()
+	provider := netLocal.Connect()
+	channel, err := provider.BroadcastChannelFor("cancellation_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initialState := testState1{
+		memberIndex: group.MemberIndex(1),
+		channel:     channel,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func(blockCounter chain.BlockCounter) {
+		blockCounter.WaitForBlockHeight(4)
+		cancel()
+	}(blockCounter)
+
+	stateMachine := NewMachine(channel, blockCounter, initialState)
+
+	finalState, _, err := stateMachine.Execute(ctx, 1)
+	if err == nil {
+		t.Fatalf("expected execution to be cancelled")
+	}
+
+	if finalState != nil {
+		t.Errorf("unexpected final state [%v]", finalState)
+	}
+
+	if _, ok := testLog[6]; ok {
+		t.Errorf("state initiated after cancellation: [%v]", testLog)
+	}
+}
+
+func TestResume(t *testing.T) {
+	checkpoint := &Checkpoint{
+		StartBlockHeight: 1,
+		StateIndex:       1,
+		Initiated:        true,
+		Messages: []*RecordedMessage{
+			{
+				StateIndex:        0,
+				Type:              "test_message",
+				TransportSenderID: "peer_1",
+				Seqno:             1,
+				Payload:           []byte("message_1"),
+			},
+			{
+				StateIndex:        1,
+				Type:              "test_message",
+				TransportSenderID: "peer_1",
+				Seqno:             2,
+				Payload:           []byte("message_2"),
+			},
+		},
+	}
+
+	var tests = map[string]struct {
+		resumeBlockHeight      uint64
+		expectedEndBlockHeight uint64
+		expectedTestLog        map[uint64][]string
+	}{
+		"resumed before the last state ends": {
+			resumeBlockHeight:      4,
+			expectedEndBlockHeight: 8,
+			expectedTestLog: map[uint64][]string{
+				4: []string{
+					"1-state.testState1-initiate",
+					"1-state.testState1-receive-message_1",
+					"1-state.testState2-initiate",
+					"1-state.testState2-receive-message_2",
+				},
+				6: []string{
+					"1-state.testState3-initiate",
+					"1-state.testState4-initiate",
+				},
+				8: []string{
+					"1-state.testState5-initiate",
+				},
+			},
+		},
+		"resumed after the last state ends": {
+			resumeBlockHeight:      9,
+			expectedEndBlockHeight: 8,
+			expectedTestLog: map[uint64][]string{
+				9: []string{
+					"1-state.testState1-initiate",
+					"1-state.testState1-receive-message_1",
+					"1-state.testState2-initiate",
+					"1-state.testState2-receive-message_2",
+					"1-state.testState3-initiate",
+					"1-state.testState4-initiate",
+					"1-state.testState5-initiate",
+				},
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testLog = make(map[uint64][]string)
+
+			localChain := chainLocal.Connect(10, 5, big.NewInt(200))
+			blockCounter, _ = localChain.BlockCounter()
+			provider := netLocal.Connect()
+			channel, err := provider.BroadcastChannelFor("resume_test")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = blockCounter.WaitForBlockHeight(test.resumeBlockHeight)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var lastCheckpoint *Checkpoint
+			stateMachine := ResumeMachine(
+				channel,
+				blockCounter,
+				checkpoint,
+				map[string]func() net.TaggedUnmarshaler{
+					"test_message": func() net.TaggedUnmarshaler {
+						return &TestMessage{}
+					},
+				},
+				func(channel net.BroadcastChannel) State {
+					return testState1{
+						memberIndex: group.MemberIndex(1),
+						channel:     channel,
+					}
+				},
+			)
+			stateMachine.OnCheckpoint(func(checkpoint *Checkpoint) {
+				lastCheckpoint = checkpoint
+			})
+
+			finalState, endBlockHeight, err := stateMachine.Execute(
+				context.Background(),
+				checkpoint.StartBlockHeight,
+			)
+			if err != nil {
+				t.Fatalf("unexpected error [%v]", err)
+			}
+
+			if _, ok := finalState.(*testState5); !ok {
+				t.Errorf("state is not final [%v]", finalState)
+			}
+
+			if endBlockHeight != test.expectedEndBlockHeight {
+				t.Errorf(
+					"unexpected end block\nexpected: [%v]\nactual:   [%v]",
+					test.expectedEndBlockHeight,
+					endBlockHeight,
+				)
+			}
+
+			if !reflect.DeepEqual(test.expectedTestLog, testLog) {
+				t.Errorf(
+					"\nexpected: %v\nactual:   %v\n",
+					test.expectedTestLog,
+					testLog,
+				)
+			}
+
+			if lastCheckpoint != nil &&
+				len(lastCheckpoint.Messages) != len(checkpoint.Messages) {
+				t.Errorf(
+					"unexpected number of checkpointed messages\n"+
+						"expected: [%v]\nactual:   [%v]",
+					len(checkpoint.Messages),
+					len(lastCheckpoint.Messages),
+				)
+			}
+		})
+	}
+}
+
+func TestRecordTranscript(t *testing.T) {
+	testLog = make(map[uint64][]string)
+
+	localChain := chainLocal.Connect(10, 5, big.NewInt(200))
+	blockCounter, _ = localChain.BlockCounter()
+	provider := netLocal.Connect()
+	channel, err := provider.BroadcastChannelFor("transcript_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := NewTranscriptRecorder(blockCounter)
+	recordingChannel := recorder.Channel(channel)
+
+	go func(blockCounter chain.BlockCounter) {
+		blockCounter.WaitForBlockHeight(4)
+		ctx, cancel := context.WithCancel(context.Background())
+		recordingChannel.Send(ctx, &TestMessage{"message_2"})
+		// retransmission of the message is recorded only once
+		channel.Send(ctx, &TestMessage{"message_2"})
+		cancel()
+	}(blockCounter)
+
+	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+		return &TestMessage{}
+	})
+
+	initialState := testState1{
+		memberIndex: group.MemberIndex(1),
+		channel:     recordingChannel,
+	}
+
+	stateMachine := NewMachine(recordingChannel, blockCounter, initialState)
+	stateMachine.RecordTranscript(recorder)
+
+	_, _, err = stateMachine.Execute(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error [%v]", err)
+	}
+
+	transcript := recorder.Transcript()
+
+	if transcript.StartBlockHeight != 1 || transcript.EndBlockHeight != 8 {
+		t.Errorf(
+			"unexpected transcript boundaries\n"+
+				"expected: [1-8]\nactual:   [%v-%v]",
+			transcript.StartBlockHeight,
+			transcript.EndBlockHeight,
+		)
+	}
+
+	expectedPhases := []*TranscriptPhase{
+		{State: "state.testState1", StartBlockHeight: 1},
+		{State: "*state.testState2", StartBlockHeight: 3},
+		{State: "*state.testState3", StartBlockHeight: 5},
+		{State: "*state.testState4", StartBlockHeight: 6},
+		{State: "*state.testState5", StartBlockHeight: 8},
+	}
+	if !reflect.DeepEqual(expectedPhases, transcript.Phases) {
+		t.Errorf(
+			"unexpected phases\nexpected: %v\nactual:   %v",
+			phasesString(expectedPhases),
+			phasesString(transcript.Phases),
+		)
+	}
+
+	if len(transcript.Messages) != 2 {
+		t.Fatalf(
+			"unexpected number of messages\nexpected: [2]\nactual:   [%v]",
+			len(transcript.Messages),
+		)
+	}
+
+	for i, sent := range []bool{true, false} {
+		message := transcript.Messages[i]
+		if message.Sent != sent ||
+			message.StateIndex != 1 ||
+			message.BlockHeight != 4 ||
+			string(message.Payload) != "message_2" {
+			t.Errorf("unexpected message [%v]: [%+v]", i, message)
+		}
+	}
+}
+
+func TestExecuteCompletedEarly(t *testing.T) {
+	const groupSize = 3
+
+	var tests = map[string]struct {
+		completable []bool
+		// Members waiting for active blocks are expected to end exactly at
+		// the scheduled end of the execution.
+		waitForActiveBlocks []bool
+		// If set, all members are expected to complete before the scheduled
+		// end of the execution.
+		expectedEarly bool
+	}{
+		"no member completes states early": {
+			completable:   []bool{false, false, false},
+			expectedEarly: false,
+		},
+		"some members complete states early": {
+			completable:   []bool{true, false, true},
+			expectedEarly: false,
+		},
+		"all members complete states early": {
+			completable:   []bool{true, true, true},
+			expectedEarly: true,
+		},
+		"some members wait for active blocks": {
+			completable:         []bool{true, true, true},
+			waitForActiveBlocks: []bool{false, true, false},
+			expectedEarly:       false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			localChain := chainLocal.Connect(10, 5, big.NewInt(200))
+			blockCounter, _ := localChain.BlockCounter()
+			provider := netLocal.Connect()
+			channel, err := provider.BroadcastChannelFor(
+				fmt.Sprintf("early_completion_test_%v", testName),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+				return &TestMessage{}
+			})
+
+			currentBlock, err := blockCounter.CurrentBlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+			startBlockHeight := currentBlock + 2
+			scheduledEndBlockHeight := startBlockHeight +
+				exchangeRounds*(exchangeStateDelayBlocks+exchangeStateActiveBlocks)
+
+			type memberResult struct {
+				received       []string
+				endBlockHeight uint64
+				err            error
+			}
+			results := make([]*memberResult, groupSize)
+
+			var wg sync.WaitGroup
+			wg.Add(groupSize)
+			for i := 0; i < groupSize; i++ {
+				i := i
+				go func() {
+					defer wg.Done()
+
+					initialState := &exchangeState{
+						memberIndex: group.MemberIndex(i + 1),
+						groupSize:   groupSize,
+						round:       1,
+						channel:     channel,
+						completable: test.completable[i],
+						received:    make(map[string]bool),
+					}
+
+					machine := NewMachine(channel, blockCounter, initialState)
+					if test.waitForActiveBlocks != nil &&
+						test.waitForActiveBlocks[i] {
+						machine.WaitForActiveBlocks()
+					}
+
+					finalState, endBlockHeight, err := machine.Execute(
+						context.Background(),
+						startBlockHeight,
+					)
+
+					result := &memberResult{endBlockHeight: endBlockHeight, err: err}
+					if finalState != nil {
+						result.received = finalState.(*exchangeState).receivedMessages()
+					}
+					results[i] = result
+				}()
+			}
+			wg.Wait()
+
+			for i, result := range results {
+				if result.err != nil {
+					t.Fatalf("member [%v] failed: [%v]", i+1, result.err)
+				}
+
+				var expectedReceived []string
+				for round := 1; round <= exchangeRounds; round++ {
+					for sender := 1; sender <= groupSize; sender++ {
+						if sender != i+1 {
+							expectedReceived = append(
+								expectedReceived,
+								exchangeMessageContent(round, group.MemberIndex(sender)),
+							)
+						}
+					}
+				}
+				sort.Strings(expectedReceived)
+
+				if !reflect.DeepEqual(expectedReceived, result.received) {
+					t.Errorf(
+						"unexpected messages received by member [%v]\n"+
+							"expected: %v\nactual:   %v",
+						i+1,
+						expectedReceived,
+						result.received,
+					)
+				}
+
+				if result.endBlockHeight > scheduledEndBlockHeight {
+					t.Errorf(
+						"member [%v] ended after the scheduled end\n"+
+							"scheduled end: [%v]\nactual end:    [%v]",
+						i+1,
+						scheduledEndBlockHeight,
+						result.endBlockHeight,
+					)
+				}
+
+				if test.waitForActiveBlocks != nil &&
+					test.waitForActiveBlocks[i] &&
+					result.endBlockHeight != scheduledEndBlockHeight {
+					t.Errorf(
+						"member [%v] did not wait for active blocks\n"+
+							"scheduled end: [%v]\nactual end:    [%v]",
+						i+1,
+						scheduledEndBlockHeight,
+						result.endBlockHeight,
+					)
+				}
+
+				if test.expectedEarly &&
+					result.endBlockHeight >= scheduledEndBlockHeight {
+					t.Errorf(
+						"member [%v] did not complete early\n"+
+							"scheduled end: [%v]\nactual end:    [%v]",
+						i+1,
+						scheduledEndBlockHeight,
+						result.endBlockHeight,
+					)
+				}
+			}
+		})
+	}
+}
+
+// Members completing states early send messages of the next state before
+// slower members enter it. The slower members receive them when they enter
+// the state, as the messages are retransmitted. Messages are exchanged here
+// through batching channels: one operator controls two early members sharing
+// one channel and another operator controls one slow member.
+func TestExecuteCompletedEarlyWithBatchChannels(t *testing.T) {
+	const groupSize = 3
+
+	localChain := chainLocal.Connect(10, 5, big.NewInt(200))
+	blockCounter, _ := localChain.BlockCounter()
+
+	newOperatorChannel := func() net.BroadcastChannel {
+		broadcastChannel, err := netLocal.Connect().BroadcastChannelFor(
+			"early_completion_batch_test",
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		channel, err := batch.NewChannel(broadcastChannel, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+			return &TestMessage{}
+		})
+
+		return channel
+	}
+
+	earlyChannel := newOperatorChannel()
+	slowChannel := newOperatorChannel()
+
+	members := []struct {
+		channel     net.BroadcastChannel
+		completable bool
+	}{
+		{earlyChannel, true},
+		{earlyChannel, true},
+		{slowChannel, false},
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	startBlockHeight := currentBlock + 2
+
+	type memberResult struct {
+		received []string
+		err      error
+	}
+	results := make([]*memberResult, groupSize)
+
+	var wg sync.WaitGroup
+	wg.Add(groupSize)
+	for i, member := range members {
+		i, member := i, member
+		go func() {
+			defer wg.Done()
+
+			initialState := &exchangeState{
+				memberIndex: group.MemberIndex(i + 1),
+				groupSize:   groupSize,
+				round:       1,
+				channel:     member.channel,
+				completable: member.completable,
+				received:    make(map[string]bool),
+			}
+
+			machine := NewMachine(member.channel, blockCounter, initialState)
+			finalState, _, err := machine.Execute(
+				context.Background(),
+				startBlockHeight,
+			)
+
+			result := &memberResult{err: err}
+			if finalState != nil {
+				result.received = finalState.(*exchangeState).receivedMessages()
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		if result.err != nil {
+			t.Fatalf("member [%v] failed: [%v]", i+1, result.err)
+		}
+
+		var expectedReceived []string
+		for round := 1; round <= exchangeRounds; round++ {
+			for sender := 1; sender <= groupSize; sender++ {
+				if sender != i+1 {
+					expectedReceived = append(
+						expectedReceived,
+						exchangeMessageContent(round, group.MemberIndex(sender)),
+					)
+				}
+			}
+		}
+		sort.Strings(expectedReceived)
+
+		if !reflect.DeepEqual(expectedReceived, result.received) {
+			t.Errorf(
+				"unexpected messages received by member [%v]\n"+
+					"expected: %v\nactual:   %v",
+				i+1,
+				expectedReceived,
+				result.received,
+			)
+		}
+	}
+}
+
+func TestExecuteWithWallClock(t *testing.T) {
+	const groupSize = 3
+
+	clock, err := NewWallClock(time.Now(), 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := netLocal.Connect()
+	channel, err := provider.BroadcastChannelFor("wall_clock_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+		return &TestMessage{}
+	})
+
+	currentBlock, err := clock.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	startBlockHeight := currentBlock + 2
+	expectedEndBlockHeight := startBlockHeight +
+		exchangeRounds*(exchangeStateDelayBlocks+exchangeStateActiveBlocks)
+
+	finalStates := make([]State, groupSize)
+	endBlockHeights := make([]uint64, groupSize)
+	memberErrors := make([]error, groupSize)
+
+	var wg sync.WaitGroup
+	wg.Add(groupSize)
+	for i := 0; i < groupSize; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+
+			initialState := &exchangeState{
+				memberIndex: group.MemberIndex(i + 1),
+				groupSize:   groupSize,
+				round:       1,
+				channel:     channel,
+				received:    make(map[string]bool),
+			}
+
+			finalStates[i], endBlockHeights[i], memberErrors[i] = NewMachine(
+				channel,
+				clock,
+				initialState,
+			).Execute(context.Background(), startBlockHeight)
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < groupSize; i++ {
+		if memberErrors[i] != nil {
+			t.Fatalf("member [%v] failed: [%v]", i+1, memberErrors[i])
+		}
+
+		if endBlockHeights[i] != expectedEndBlockHeight {
+			t.Errorf(
+				"unexpected end block of member [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i+1,
+				expectedEndBlockHeight,
+				endBlockHeights[i],
+			)
+		}
+
+		received := finalStates[i].(*exchangeState).receivedMessages()
+		expectedReceived := exchangeRounds * (groupSize - 1)
+		if len(received) != expectedReceived {
+			t.Errorf(
+				"unexpected number of messages received by member [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i+1,
+				expectedReceived,
+				len(received),
+			)
+		}
+	}
+}
+
+func phasesString(phases []*TranscriptPhase) string {
+	var result []string
+	for _, phase := range phases {
+		result = append(
+			result,
+			fmt.Sprintf("%v@%v", phase.State, phase.StartBlockHeight),
+		)
+	}
+	return fmt.Sprint(result)
+}
+
 func addToTestLog(testState State, functionName string) {
 	currentBlock, _ := blockCounter.CurrentBlock()
 	testLog[currentBlock] = append(
@@ -226,3 +866,81 @@ func (tm *TestMessage) Unmarshal(bytes []byte) error {
 func (tm *TestMessage) Type() string {
 	return "test_message"
 }
+
+const (
+	exchangeRounds            = 3
+	exchangeStateDelayBlocks  = 1
+	exchangeStateActiveBlocks = 3
+)
+
+// exchangeState is a state in which each member broadcasts a message and
+// expects messages from all the other members. There are exchangeRounds
+// such states in a row. If the state is completable, it is complete once
+// messages from all the other members have been received.
+type exchangeState struct {
+	memberIndex group.MemberIndex
+	groupSize   int
+	round       int
+	channel     net.BroadcastChannel
+	completable bool
+
+	// Messages received in all the rounds.
+	received map[string]bool
+	// Members from which messages have been received in the current round.
+	roundSenders int
+}
+
+func exchangeMessageContent(round int, sender group.MemberIndex) string {
+	return fmt.Sprintf("round_%v_member_%v", round, sender)
+}
+
+func (es *exchangeState) DelayBlocks() uint64  { return exchangeStateDelayBlocks }
+func (es *exchangeState) ActiveBlocks() uint64 { return exchangeStateActiveBlocks }
+func (es *exchangeState) Initiate(ctx context.Context) error {
+	return es.channel.Send(
+		ctx,
+		&TestMessage{exchangeMessageContent(es.round, es.memberIndex)},
+	)
+}
+func (es *exchangeState) Receive(msg net.Message) error {
+	content := msg.Payload().(*TestMessage).content
+
+	for sender := 1; sender <= es.groupSize; sender++ {
+		memberIndex := group.MemberIndex(sender)
+		if memberIndex != es.memberIndex &&
+			content == exchangeMessageContent(es.round, memberIndex) &&
+			!es.received[content] {
+			es.received[content] = true
+			es.roundSenders++
+		}
+	}
+
+	return nil
+}
+func (es *exchangeState) IsComplete() bool {
+	return es.completable && es.roundSenders == es.groupSize-1
+}
+func (es *exchangeState) Next() State {
+	if es.round == exchangeRounds {
+		return nil
+	}
+
+	return &exchangeState{
+		memberIndex: es.memberIndex,
+		groupSize:   es.groupSize,
+		round:       es.round + 1,
+		channel:     es.channel,
+		completable: es.completable,
+		received:    es.received,
+	}
+}
+func (es *exchangeState) MemberIndex() group.MemberIndex { return es.memberIndex }
+
+func (es *exchangeState) receivedMessages() []string {
+	messages := make([]string, 0)
+	for content := range es.received {
+		messages = append(messages, content)
+	}
+	sort.Strings(messages)
+	return messages
+}
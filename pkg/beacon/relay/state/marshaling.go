@@ -0,0 +1,124 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state/gen/pb"
+)
+
+// Marshal converts the Checkpoint to a byte array.
+func (c *Checkpoint) Marshal() ([]byte, error) {
+	messages := make([]*pb.Checkpoint_Message, 0, len(c.Messages))
+	for _, message := range c.Messages {
+		messages = append(messages, marshalRecordedMessage(message))
+	}
+
+	return (&pb.Checkpoint{
+		StartBlockHeight: c.StartBlockHeight,
+		StateIndex:       uint32(c.StateIndex),
+		Initiated:        c.Initiated,
+		Messages:         messages,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a Checkpoint.
+func (c *Checkpoint) Unmarshal(bytes []byte) error {
+	pbCheckpoint := pb.Checkpoint{}
+	if err := pbCheckpoint.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	c.StartBlockHeight = pbCheckpoint.StartBlockHeight
+	c.StateIndex = int(pbCheckpoint.StateIndex)
+	c.Initiated = pbCheckpoint.Initiated
+
+	c.Messages = make([]*RecordedMessage, 0, len(pbCheckpoint.Messages))
+	for _, message := range pbCheckpoint.Messages {
+		c.Messages = append(c.Messages, unmarshalRecordedMessage(message))
+	}
+
+	return nil
+}
+
+// Marshal converts the Transcript to a byte array.
+func (t *Transcript) Marshal() ([]byte, error) {
+	phases := make([]*pb.Transcript_Phase, 0, len(t.Phases))
+	for _, phase := range t.Phases {
+		phases = append(phases, &pb.Transcript_Phase{
+			State:            phase.State,
+			StartBlockHeight: phase.StartBlockHeight,
+		})
+	}
+
+	messages := make([]*pb.Transcript_Message, 0, len(t.Messages))
+	for _, message := range t.Messages {
+		messages = append(messages, &pb.Transcript_Message{
+			Message:     marshalRecordedMessage(&message.RecordedMessage),
+			Sent:        message.Sent,
+			BlockHeight: message.BlockHeight,
+		})
+	}
+
+	return (&pb.Transcript{
+		StartBlockHeight: t.StartBlockHeight,
+		EndBlockHeight:   t.EndBlockHeight,
+		Phases:           phases,
+		Messages:         messages,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a Transcript.
+func (t *Transcript) Unmarshal(bytes []byte) error {
+	pbTranscript := pb.Transcript{}
+	if err := pbTranscript.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	t.StartBlockHeight = pbTranscript.StartBlockHeight
+	t.EndBlockHeight = pbTranscript.EndBlockHeight
+
+	t.Phases = make([]*TranscriptPhase, 0, len(pbTranscript.Phases))
+	for _, phase := range pbTranscript.Phases {
+		t.Phases = append(t.Phases, &TranscriptPhase{
+			State:            phase.State,
+			StartBlockHeight: phase.StartBlockHeight,
+		})
+	}
+
+	t.Messages = make([]*TranscriptMessage, 0, len(pbTranscript.Messages))
+	for _, message := range pbTranscript.Messages {
+		if message.Message == nil {
+			return fmt.Errorf("transcript message has no content")
+		}
+
+		t.Messages = append(t.Messages, &TranscriptMessage{
+			RecordedMessage: *unmarshalRecordedMessage(message.Message),
+			Sent:            message.Sent,
+			BlockHeight:     message.BlockHeight,
+		})
+	}
+
+	return nil
+}
+
+func marshalRecordedMessage(message *RecordedMessage) *pb.Checkpoint_Message {
+	return &pb.Checkpoint_Message{
+		StateIndex:        uint32(message.StateIndex),
+		Type:              message.Type,
+		SenderPublicKey:   message.SenderPublicKey,
+		TransportSenderID: message.TransportSenderID,
+		Seqno:             message.Seqno,
+		Payload:           message.Payload,
+	}
+}
+
+func unmarshalRecordedMessage(message *pb.Checkpoint_Message) *RecordedMessage {
+	return &RecordedMessage{
+		StateIndex:        int(message.StateIndex),
+		Type:              message.Type,
+		SenderPublicKey:   message.SenderPublicKey,
+		TransportSenderID: message.TransportSenderID,
+		Seqno:             message.Seqno,
+		Payload:           message.Payload,
+	}
+}
@@ -0,0 +1,97 @@
+package state
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/internal/pbutils"
+)
+
+func TestCheckpointRoundtrip(t *testing.T) {
+	checkpoint := &Checkpoint{
+		StartBlockHeight: 1021,
+		StateIndex:       4,
+		Initiated:        true,
+		Messages: []*RecordedMessage{
+			{
+				StateIndex:        0,
+				Type:              "test_message",
+				SenderPublicKey:   []byte{0x01, 0x02},
+				TransportSenderID: "peer_1",
+				Seqno:             12,
+				Payload:           []byte("message_1"),
+			},
+			{
+				StateIndex:        3,
+				Type:              "test_message",
+				SenderPublicKey:   []byte{0x03, 0x04},
+				TransportSenderID: "peer_2",
+				Seqno:             7,
+				Payload:           []byte("message_2"),
+			},
+		},
+	}
+	unmarshaled := &Checkpoint{}
+
+	err := pbutils.RoundTrip(checkpoint, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(checkpoint, unmarshaled) {
+		t.Fatalf(
+			"unexpected content of unmarshaled checkpoint\n"+
+				"expected: [%+v]\nactual:   [%+v]",
+			checkpoint,
+			unmarshaled,
+		)
+	}
+}
+
+func TestTranscriptRoundtrip(t *testing.T) {
+	transcript := &Transcript{
+		StartBlockHeight: 1021,
+		EndBlockHeight:   1100,
+		Phases: []*TranscriptPhase{
+			{State: "*state.testState1", StartBlockHeight: 1021},
+			{State: "*state.testState2", StartBlockHeight: 1030},
+		},
+		Messages: []*TranscriptMessage{
+			{
+				RecordedMessage: RecordedMessage{
+					StateIndex: 0,
+					Type:       "test_message",
+					Payload:    []byte("message_1"),
+				},
+				Sent:        true,
+				BlockHeight: 1022,
+			},
+			{
+				RecordedMessage: RecordedMessage{
+					StateIndex:        1,
+					Type:              "test_message",
+					SenderPublicKey:   []byte{0x03, 0x04},
+					TransportSenderID: "peer_2",
+					Seqno:             7,
+					Payload:           []byte("message_2"),
+				},
+				BlockHeight: 1031,
+			},
+		},
+	}
+	unmarshaled := &Transcript{}
+
+	err := pbutils.RoundTrip(transcript, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(transcript, unmarshaled) {
+		t.Fatalf(
+			"unexpected content of unmarshaled transcript\n"+
+				"expected: [%+v]\nactual:   [%+v]",
+			transcript,
+			unmarshaled,
+		)
+	}
+}
@@ -0,0 +1,34 @@
+package state
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/keep-network/keep-core/pkg/metrics"
+)
+
+var (
+	stateDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "state_machine",
+			Name:      "state_duration_seconds",
+			Help:      "Time state machines spent in the given state.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+		},
+		[]string{"state"},
+	)
+
+	messagesReceived = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "state_machine",
+			Name:      "messages_received_total",
+			Help:      "Messages received by state machines in the given state.",
+		},
+		[]string{"state"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(stateDuration, messagesReceived)
+}
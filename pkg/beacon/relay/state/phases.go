@@ -0,0 +1,80 @@
+package state
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+// Phase describes the state a state machine being executed is currently in.
+type Phase struct {
+	// Channel is the name of the broadcast channel the state machine
+	// exchanges messages on.
+	Channel string `json:"channel"`
+	// MemberIndex is the index of the member executing the state machine.
+	MemberIndex group.MemberIndex `json:"memberIndex"`
+	// State is the type of the current state, e.g. `*gjkr.joinState`.
+	State string `json:"state"`
+	// StartBlock is the block at which the state machine transitioned to
+	// the current state.
+	StartBlock uint64 `json:"startBlock"`
+}
+
+var inFlight = &phases{
+	current: make(map[*Machine]*Phase),
+}
+
+// InFlightPhases returns current phases of all state machines being executed
+// in the client, ordered by the channel name and the member index.
+func InFlightPhases() []*Phase {
+	return inFlight.all()
+}
+
+type phases struct {
+	mutex   sync.Mutex
+	current map[*Machine]*Phase
+}
+
+func (p *phases) enter(
+	machine *Machine,
+	currentState State,
+	startBlockHeight uint64,
+) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.current[machine] = &Phase{
+		Channel:     machine.channel.Name(),
+		MemberIndex: currentState.MemberIndex(),
+		State:       stateName(currentState),
+		StartBlock:  startBlockHeight,
+	}
+}
+
+func (p *phases) finish(machine *Machine) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.current, machine)
+}
+
+func (p *phases) all() []*Phase {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	all := make([]*Phase, 0, len(p.current))
+	for _, phase := range p.current {
+		copied := *phase
+		all = append(all, &copied)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Channel != all[j].Channel {
+			return all[i].Channel < all[j].Channel
+		}
+		return all[i].MemberIndex < all[j].MemberIndex
+	})
+
+	return all
+}
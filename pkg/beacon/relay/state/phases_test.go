@@ -0,0 +1,73 @@
+package state
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/net"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestTracksInFlightPhases(t *testing.T) {
+	localChain := chainLocal.Connect(10, 5, big.NewInt(200))
+	localBlockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	channel, err := netLocal.Connect().BroadcastChannelFor("phases_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var phasesDuringExecution []*Phase
+	initialState := &phaseTestState{
+		memberIndex: group.MemberIndex(3),
+		onInitiate: func() {
+			phasesDuringExecution = InFlightPhases()
+		},
+	}
+
+	_, _, err = NewMachine(channel, localBlockCounter, initialState).Execute(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPhases := []*Phase{
+		{
+			Channel:     "phases_test",
+			MemberIndex: group.MemberIndex(3),
+			State:       "*state.phaseTestState",
+			StartBlock:  1,
+		},
+	}
+	if !reflect.DeepEqual(expectedPhases, phasesDuringExecution) {
+		t.Errorf(
+			"unexpected phases during execution\nexpected: %v\nactual:   %v",
+			expectedPhases,
+			phasesDuringExecution,
+		)
+	}
+
+	if phases := InFlightPhases(); len(phases) != 0 {
+		t.Errorf("unexpected phases after execution: %v", phases)
+	}
+}
+
+type phaseTestState struct {
+	memberIndex group.MemberIndex
+	onInitiate  func()
+}
+
+func (pts *phaseTestState) DelayBlocks() uint64  { return 0 }
+func (pts *phaseTestState) ActiveBlocks() uint64 { return 1 }
+func (pts *phaseTestState) Initiate(ctx context.Context) error {
+	pts.onInitiate()
+	return nil
+}
+func (pts *phaseTestState) Receive(msg net.Message) error  { return nil }
+func (pts *phaseTestState) Next() State                    { return nil }
+func (pts *phaseTestState) MemberIndex() group.MemberIndex { return pts.memberIndex }
@@ -12,6 +12,8 @@ import (
 var logger = log.Logger("keep-relay-state")
 
 // State is and interface against which relay states should be implemented.
+// Delay and active blocks of the state are counted in blocks of the Clock
+// the state machine is executed with.
 type State interface {
 	// DelayBlocks returns the number of blocks for which the current state
 	// initialization is delayed. We delay the initialization to give all other
@@ -46,6 +48,30 @@ type State interface {
 	MemberIndex() group.MemberIndex
 }
 
+// CompletableState is a State which can tell it has received all the messages
+// it expects, so there is no need to wait until its active blocks are over.
+//
+// The machine moves from a complete state to the next one at the next block,
+// giving the network a chance to deliver messages the state has sent to
+// other members. A state entered early is initiated right away; its delay
+// blocks are skipped. The state still ends no later than it would if all the
+// previous states waited for their active blocks to be over, so members which
+// do not complete states early, for example members running an older client,
+// remain compatible with members which do. Messages sent early are
+// retransmitted until the sending state ends, so they reach members which
+// enter the state later.
+//
+// Any state with no active blocks, completable or not, is considered complete
+// once initiated.
+type CompletableState interface {
+	State
+
+	// IsComplete returns true if the state has received all the messages it
+	// expects in the current execution. It is called after the state has been
+	// initiated and each time the state receives a message.
+	IsComplete() bool
+}
+
 // SilentStateDelayBlocks is a delay in blocks for a state that do not
 // exchange any network messages as a part of its execution.
 //
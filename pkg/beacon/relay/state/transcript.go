@@ -0,0 +1,244 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// Transcript is the record of a state machine execution. It holds the
+// boundaries of all states the machine went through and all messages the
+// machine received and sent, so that the execution can be audited later.
+type Transcript struct {
+	StartBlockHeight uint64
+	// EndBlockHeight is the block at which the final state ended; zero if
+	// the execution has not reached the final state.
+	EndBlockHeight uint64
+	// Phases are states the machine went through, in the order of execution;
+	// index of the phase is the index of the state.
+	Phases   []*TranscriptPhase
+	Messages []*TranscriptMessage
+}
+
+// TranscriptPhase describes one state the machine went through.
+type TranscriptPhase struct {
+	// State is the name of the state, e.g. `*gjkr.commitmentState`.
+	State string
+	// StartBlockHeight is the block at which the previous state ended and
+	// the machine entered the state.
+	StartBlockHeight uint64
+}
+
+// TranscriptMessage is a message received or sent by the machine, along with
+// the block at which it happened. The StateIndex of the message is the index
+// of the phase in which the message was received or sent. Sender public key
+// and transport sender of sent messages are not known and left empty.
+type TranscriptMessage struct {
+	RecordedMessage
+	Sent        bool
+	BlockHeight uint64
+}
+
+// TranscriptRecorder records the transcript of a state machine execution.
+// The recorder is attached to the machine with Machine.RecordTranscript.
+// Messages sent by states are recorded only if states send them through
+// the channel returned by Channel. Retransmissions of received messages are
+// recorded once. Unexported recording methods do nothing if called on a nil
+// recorder.
+type TranscriptRecorder struct {
+	clock Clock
+
+	mutex      sync.Mutex
+	transcript *Transcript
+	stateIndex int
+	// Keys of received messages recorded so far.
+	receivedKeys map[string]bool
+}
+
+// NewTranscriptRecorder returns a recorder with an empty transcript. Block
+// heights of recorded messages are read from the given clock.
+func NewTranscriptRecorder(clock Clock) *TranscriptRecorder {
+	return &TranscriptRecorder{
+		clock:        clock,
+		transcript:   &Transcript{},
+		receivedKeys: make(map[string]bool),
+	}
+}
+
+// Channel returns a broadcast channel recording all messages sent through it
+// before passing them to the given channel.
+func (tr *TranscriptRecorder) Channel(
+	channel net.BroadcastChannel,
+) net.BroadcastChannel {
+	return &recordingChannel{channel, tr}
+}
+
+// Transcript returns a copy of the transcript recorded so far.
+func (tr *TranscriptRecorder) Transcript() *Transcript {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	phases := make([]*TranscriptPhase, len(tr.transcript.Phases))
+	copy(phases, tr.transcript.Phases)
+
+	messages := make([]*TranscriptMessage, len(tr.transcript.Messages))
+	copy(messages, tr.transcript.Messages)
+
+	return &Transcript{
+		StartBlockHeight: tr.transcript.StartBlockHeight,
+		EndBlockHeight:   tr.transcript.EndBlockHeight,
+		Phases:           phases,
+		Messages:         messages,
+	}
+}
+
+func (tr *TranscriptRecorder) start(startBlockHeight uint64) {
+	if tr == nil {
+		return
+	}
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.transcript.StartBlockHeight = startBlockHeight
+}
+
+// enterState records the boundary of the state with the given index. States
+// entered again, e.g. when a resumed machine catches up, are not recorded
+// twice.
+func (tr *TranscriptRecorder) enterState(
+	stateIndex int,
+	state State,
+	startBlockHeight uint64,
+) {
+	if tr == nil {
+		return
+	}
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.stateIndex = stateIndex
+
+	if stateIndex < len(tr.transcript.Phases) {
+		return
+	}
+
+	tr.transcript.Phases = append(tr.transcript.Phases, &TranscriptPhase{
+		State:            stateName(state),
+		StartBlockHeight: startBlockHeight,
+	})
+}
+
+func (tr *TranscriptRecorder) finish(endBlockHeight uint64) {
+	if tr == nil {
+		return
+	}
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.transcript.EndBlockHeight = endBlockHeight
+}
+
+// received records the message received in the current state.
+func (tr *TranscriptRecorder) received(msg net.Message) {
+	if tr == nil {
+		return
+	}
+
+	tr.mutex.Lock()
+	stateIndex := tr.stateIndex
+	tr.mutex.Unlock()
+
+	recorded, err := recordMessage(stateIndex, msg)
+	if err != nil {
+		logger.Warningf(
+			"could not record received message of type [%v] "+
+				"in the transcript: [%v]",
+			msg.Type(),
+			err,
+		)
+		return
+	}
+
+	// Retransmissions carry the same payload as the original message.
+	key := fmt.Sprintf(
+		"%v-%v-%x",
+		recorded.StateIndex,
+		recorded.Type,
+		recorded.Payload,
+	)
+
+	tr.mutex.Lock()
+	if tr.receivedKeys[key] {
+		tr.mutex.Unlock()
+		return
+	}
+	tr.receivedKeys[key] = true
+	tr.mutex.Unlock()
+
+	tr.add(recorded, false)
+}
+
+// sent records the message sent in the current state.
+func (tr *TranscriptRecorder) sent(message net.TaggedMarshaler) {
+	payload, err := message.Marshal()
+	if err != nil {
+		logger.Warningf(
+			"could not record sent message of type [%v] "+
+				"in the transcript: [%v]",
+			message.Type(),
+			err,
+		)
+		return
+	}
+
+	tr.mutex.Lock()
+	stateIndex := tr.stateIndex
+	tr.mutex.Unlock()
+
+	tr.add(&RecordedMessage{
+		StateIndex: stateIndex,
+		Type:       message.Type(),
+		Payload:    payload,
+	}, true)
+}
+
+func (tr *TranscriptRecorder) add(recorded *RecordedMessage, sent bool) {
+	blockHeight, err := tr.clock.CurrentBlock()
+	if err != nil {
+		logger.Warningf(
+			"could not read the current block for the transcript: [%v]",
+			err,
+		)
+	}
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	tr.transcript.Messages = append(tr.transcript.Messages, &TranscriptMessage{
+		RecordedMessage: *recorded,
+		Sent:            sent,
+		BlockHeight:     blockHeight,
+	})
+}
+
+// recordingChannel is a broadcast channel recording messages sent through it
+// in the transcript.
+type recordingChannel struct {
+	net.BroadcastChannel
+
+	recorder *TranscriptRecorder
+}
+
+func (rc *recordingChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+) error {
+	rc.recorder.sent(message)
+
+	return rc.BroadcastChannel.Send(ctx, message)
+}
@@ -0,0 +1,76 @@
+package beacon
+
+import (
+	"sort"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+)
+
+// Status is a read-only view of the work the random beacon client is
+// currently doing.
+type Status struct {
+	groupRegistry          *registry.Groups
+	pendingGroupSelections *event.GroupSelectionTrack
+	pendingRelayRequests   *event.RelayRequestTrack
+	stopped                chan struct{}
+}
+
+// GroupMemberships describes memberships the client holds in a group.
+type GroupMemberships struct {
+	GroupPublicKey string              `json:"groupPublicKey"`
+	ChannelName    string              `json:"channelName"`
+	MemberIndexes  []group.MemberIndex `json:"memberIndexes"`
+}
+
+// Groups returns memberships the client holds in groups, ordered by the
+// group public key.
+func (s *Status) Groups() []*GroupMemberships {
+	groups := make([]*GroupMemberships, 0)
+
+	for groupPublicKey, memberships := range s.groupRegistry.GetGroups() {
+		if len(memberships) == 0 {
+			continue
+		}
+
+		memberIndexes := make([]group.MemberIndex, 0, len(memberships))
+		for _, membership := range memberships {
+			memberIndexes = append(memberIndexes, membership.Signer.MemberID())
+		}
+		sort.Slice(memberIndexes, func(i, j int) bool {
+			return memberIndexes[i] < memberIndexes[j]
+		})
+
+		groups = append(groups, &GroupMemberships{
+			GroupPublicKey: groupPublicKey,
+			ChannelName:    memberships[0].ChannelName,
+			MemberIndexes:  memberIndexes,
+		})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].GroupPublicKey < groups[j].GroupPublicKey
+	})
+
+	return groups
+}
+
+// PendingGroupSelections returns seeds of group selections the client is
+// currently taking part in.
+func (s *Status) PendingGroupSelections() []string {
+	return s.pendingGroupSelections.Pending()
+}
+
+// PendingRelayRequests returns previous entries of relay requests the client
+// is currently generating a relay entry for.
+func (s *Status) PendingRelayRequests() []string {
+	return s.pendingRelayRequests.Pending()
+}
+
+// Stopped returns a channel which is closed once the random beacon has shut
+// down: chain events are no longer handled and DKG and relay entry signing
+// which were in progress have either completed or been abandoned.
+func (s *Status) Stopped() <-chan struct{} {
+	return s.stopped
+}
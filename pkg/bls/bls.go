@@ -1,6 +1,7 @@
 package bls
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"math/big"
@@ -75,6 +76,57 @@ func VerifyG1(publicKey *bn256.G2, message *bn256.G1, signature *bn256.G1) bool
 	return bn256.PairingCheck(a, b)
 }
 
+// batchVerificationScalarBits is the size of random scalars used to combine
+// signatures verified in a batch. A batch with an invalid signature passes
+// the verification with probability of at most 2^-128.
+const batchVerificationScalarBits = 128
+
+// BatchVerifyG1 checks if all the signatures are correct for the provided G1
+// point message and the corresponding public keys; the signature with index i
+// is verified against the public key with index i. Instead of a pairing check
+// for each signature, signatures and public keys are combined using random
+// scalars and a single multi-pairing check is performed for the combination.
+// The check fails if any of the signatures is not correct but it does not
+// tell which one; use VerifyG1 to find out.
+func BatchVerifyG1(
+	publicKeys []*bn256.G2,
+	message *bn256.G1,
+	signatures []*bn256.G1,
+) (bool, error) {
+	if len(publicKeys) != len(signatures) {
+		return false, fmt.Errorf(
+			"number of public keys [%v] does not match number of signatures [%v]",
+			len(publicKeys),
+			len(signatures),
+		)
+	}
+
+	scalarLimit := new(big.Int).Lsh(big.NewInt(1), batchVerificationScalarBits)
+
+	combinedPublicKey := new(bn256.G2).ScalarBaseMult(big.NewInt(0))
+	combinedSignature := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	for i := range signatures {
+		scalar, err := rand.Int(rand.Reader, scalarLimit)
+		if err != nil {
+			return false, fmt.Errorf(
+				"could not generate random scalar: [%v]",
+				err,
+			)
+		}
+
+		combinedPublicKey.Add(
+			combinedPublicKey,
+			new(bn256.G2).ScalarMult(publicKeys[i], scalar),
+		)
+		combinedSignature.Add(
+			combinedSignature,
+			new(bn256.G1).ScalarMult(signatures[i], scalar),
+		)
+	}
+
+	return VerifyG1(combinedPublicKey, message, combinedSignature), nil
+}
+
 // RecoverSignature reconstructs the full BLS signature from a threshold number of
 // signature shares using Lagrange interpolation.
 func RecoverSignature(shares []*SignatureShare, threshold int) (*bn256.G1, error) {
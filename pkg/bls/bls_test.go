@@ -184,3 +184,102 @@ func TestThresholdBLS(t *testing.T) {
 	}
 
 }
+
+func TestBatchVerifyG1(t *testing.T) {
+	pi, _ := new(big.Int).SetString("31415926535897932384626433832795028841971693993751058209749445923078164062862", 10)
+	message := new(bn256.G1).ScalarBaseMult(pi)
+
+	var publicKeys []*bn256.G2
+	var signatures []*bn256.G1
+
+	for i := 0; i < 10; i++ {
+		secretKey, publicKey, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publicKeys = append(publicKeys, publicKey)
+		signatures = append(signatures, SignG1(secretKey, message))
+	}
+
+	var tests = map[string]struct {
+		publicKeys     func() []*bn256.G2
+		signatures     func() []*bn256.G1
+		expectedResult bool
+		expectedError  bool
+	}{
+		"all signatures valid": {
+			publicKeys:     func() []*bn256.G2 { return publicKeys },
+			signatures:     func() []*bn256.G1 { return signatures },
+			expectedResult: true,
+		},
+		"single valid signature": {
+			publicKeys:     func() []*bn256.G2 { return publicKeys[:1] },
+			signatures:     func() []*bn256.G1 { return signatures[:1] },
+			expectedResult: true,
+		},
+		"one signature invalid": {
+			publicKeys: func() []*bn256.G2 { return publicKeys },
+			signatures: func() []*bn256.G1 {
+				invalid := make([]*bn256.G1, len(signatures))
+				copy(invalid, signatures)
+				invalid[4] = SignG1(big.NewInt(123), message)
+				return invalid
+			},
+			expectedResult: false,
+		},
+		"signatures swapped": {
+			publicKeys: func() []*bn256.G2 { return publicKeys[:2] },
+			signatures: func() []*bn256.G1 {
+				return []*bn256.G1{signatures[1], signatures[0]}
+			},
+			expectedResult: false,
+		},
+		"invalid signatures cancelling out": {
+			publicKeys: func() []*bn256.G2 { return publicKeys[:2] },
+			signatures: func() []*bn256.G1 {
+				// The sum of signatures is still the sum of valid signatures
+				// so the invalid signatures would pass if they were just
+				// aggregated.
+				offset := new(bn256.G1).ScalarBaseMult(big.NewInt(7))
+				return []*bn256.G1{
+					new(bn256.G1).Add(signatures[0], offset),
+					new(bn256.G1).Add(signatures[1], new(bn256.G1).Neg(offset)),
+				}
+			},
+			expectedResult: false,
+		},
+		"number of public keys and signatures not matching": {
+			publicKeys:     func() []*bn256.G2 { return publicKeys[:3] },
+			signatures:     func() []*bn256.G1 { return signatures[:2] },
+			expectedResult: false,
+			expectedError:  true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			result, err := BatchVerifyG1(
+				test.publicKeys(),
+				message,
+				test.signatures(),
+			)
+
+			if test.expectedError != (err != nil) {
+				t.Fatalf(
+					"unexpected error\nexpected error: [%v]\nactual:         [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if test.expectedResult != result {
+				t.Errorf(
+					"unexpected verification result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedResult,
+					result,
+				)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/gen/async"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 // BlockCounter is an interface that provides the ability to wait for a certain
@@ -49,6 +50,34 @@ type StakeMonitor interface {
 
 	// StakerFor returns a Staker for the given address.
 	StakerFor(address string) (Staker, error)
+
+	// OnStakeChanged registers a callback that is invoked when the stake
+	// of any operator changes: when tokens are delegated to the operator,
+	// slashed, seized or recovered by the owner. The callback is expected
+	// to check the current stake of the operator if it needs it.
+	OnStakeChanged(
+		handler func(change *StakeChange),
+	) (subscription.EventSubscription, error)
+
+	// OnUndelegation registers a callback that is invoked when any operator
+	// starts undelegating its stake. Undelegating operator is no longer
+	// eligible for work selection.
+	OnUndelegation(
+		handler func(undelegation *Undelegation),
+	) (subscription.EventSubscription, error)
+}
+
+// StakeChange represents a change of the stake delegated to an operator.
+type StakeChange struct {
+	Operator    string
+	BlockNumber uint64
+}
+
+// Undelegation represents the start of undelegation of the stake delegated
+// to an operator.
+type Undelegation struct {
+	Operator    string
+	BlockNumber uint64
 }
 
 // Signing is an interface that provides ability to sign and verify
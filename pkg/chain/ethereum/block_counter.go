@@ -0,0 +1,225 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// blockSubscribeTimeout is a timeout for a single new blocks
+	// subscription request.
+	blockSubscribeTimeout = 10 * time.Second
+
+	// blockResubscribeDelay is a delay which must be preserved before a new
+	// blocks resubscription attempt.
+	blockResubscribeDelay = 5 * time.Second
+)
+
+// headSource is the source of new block headers for the block counter.
+type headSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SubscribeNewHead(
+		ctx context.Context,
+		ch chan<- *types.Header,
+	) (goethereum.Subscription, error)
+}
+
+// blockCounter implements chain.BlockCounter for Ethereum. It follows the
+// headers of new blocks and resubscribes whenever the subscription fails,
+// for example when the failover client switches to another endpoint.
+//
+// The block height reported by the counter never decreases, even if the
+// endpoint the counter switched to stays a few blocks behind the previous one.
+type blockCounter struct {
+	source headSource
+
+	mutex             sync.Mutex
+	latestBlockHeight uint64
+	waiters           map[uint64][]chan uint64
+	watchers          []*blockWatcher
+}
+
+type blockWatcher struct {
+	ctx     context.Context
+	channel chan uint64
+}
+
+// newBlockCounter creates a block counter following blocks reported by the
+// given source until the context is done.
+func newBlockCounter(
+	ctx context.Context,
+	source headSource,
+) (*blockCounter, error) {
+	startupHeader, err := source.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get initial block from the chain: [%v]",
+			err,
+		)
+	}
+
+	counter := &blockCounter{
+		source:            source,
+		latestBlockHeight: startupHeader.Number.Uint64(),
+		waiters:           make(map[uint64][]chan uint64),
+	}
+
+	go counter.followBlocks(ctx)
+
+	return counter, nil
+}
+
+func (bc *blockCounter) WaitForBlockHeight(blockNumber uint64) error {
+	waiter, err := bc.BlockHeightWaiter(blockNumber)
+	if err != nil {
+		return err
+	}
+	<-waiter
+	return nil
+}
+
+func (bc *blockCounter) BlockHeightWaiter(
+	blockNumber uint64,
+) (<-chan uint64, error) {
+	newWaiter := make(chan uint64)
+
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	if blockNumber <= bc.latestBlockHeight {
+		go func() { newWaiter <- blockNumber }()
+	} else {
+		bc.waiters[blockNumber] = append(bc.waiters[blockNumber], newWaiter)
+	}
+
+	return newWaiter, nil
+}
+
+func (bc *blockCounter) CurrentBlock() (uint64, error) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	return bc.latestBlockHeight, nil
+}
+
+func (bc *blockCounter) WatchBlocks(ctx context.Context) <-chan uint64 {
+	watcher := &blockWatcher{
+		ctx:     ctx,
+		channel: make(chan uint64),
+	}
+
+	bc.mutex.Lock()
+	bc.watchers = append(bc.watchers, watcher)
+	bc.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		bc.mutex.Lock()
+		defer bc.mutex.Unlock()
+
+		for i, w := range bc.watchers {
+			if w == watcher {
+				bc.watchers = append(bc.watchers[:i], bc.watchers[i+1:]...)
+				break
+			}
+		}
+		close(watcher.channel)
+	}()
+
+	return watcher.channel
+}
+
+// followBlocks subscribes for new blocks and resubscribes after a delay
+// whenever the subscription fails, until the context is done.
+func (bc *blockCounter) followBlocks(ctx context.Context) {
+	for {
+		err := bc.receiveBlocks(ctx)
+		if err == nil {
+			return
+		}
+
+		logger.Warningf(
+			"subscription to new blocks interrupted: [%v]; "+
+				"resubscription attempt will be performed after the retry delay",
+			err,
+		)
+
+		select {
+		case <-time.After(blockResubscribeDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// receiveBlocks subscribes for new blocks, catches up with the latest block
+// and then observes new blocks until the subscription fails, in which case
+// the subscription error is returned, or until the context is done, in which
+// case nil is returned.
+func (bc *blockCounter) receiveBlocks(ctx context.Context) error {
+	headers := make(chan *types.Header)
+
+	subscribeCtx, cancel := context.WithTimeout(ctx, blockSubscribeTimeout)
+	defer cancel()
+
+	subscription, err := bc.source.SubscribeNewHead(subscribeCtx, headers)
+	if err != nil {
+		return fmt.Errorf("could not subscribe to new blocks: [%v]", err)
+	}
+	defer subscription.Unsubscribe()
+
+	latestHeader, err := bc.source.HeaderByNumber(subscribeCtx, nil)
+	if err != nil {
+		return fmt.Errorf("could not get the latest block: [%v]", err)
+	}
+	bc.observe(latestHeader.Number.Uint64())
+
+	for {
+		select {
+		case header := <-headers:
+			bc.observe(header.Number.Uint64())
+		case err := <-subscription.Err():
+			if err == nil {
+				err = fmt.Errorf("subscription closed")
+			}
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// observe notifies waiters and watchers about all the blocks up to the given
+// height which have not been observed yet. Heights lower than the latest
+// observed one are ignored so that the block height never decreases.
+func (bc *blockCounter) observe(blockHeight uint64) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	for height := bc.latestBlockHeight + 1; height <= blockHeight; height++ {
+		bc.latestBlockHeight = height
+
+		for _, waiter := range bc.waiters[height] {
+			go func(waiter chan uint64, height uint64) {
+				waiter <- height
+			}(waiter, height)
+		}
+		delete(bc.waiters, height)
+
+		for _, watcher := range bc.watchers {
+			select {
+			case watcher.channel <- height:
+			default:
+				// The watcher is not ready to receive; the block is
+				// dropped for it.
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+func TestBlockCounterDoesNotRegress(t *testing.T) {
+	source := &mockHeadSource{blockNumber: 100}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	counter, err := newBlockCounter(ctx, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waiter, err := counter.BlockHeightWaiter(102)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter.observe(101)
+
+	// The counter switched to an endpoint which is a few blocks behind.
+	counter.observe(98)
+
+	currentBlock, err := counter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if currentBlock != 101 {
+		t.Errorf(
+			"unexpected current block\nexpected: [%v]\nactual:   [%v]",
+			101,
+			currentBlock,
+		)
+	}
+
+	counter.observe(103)
+
+	select {
+	case block := <-waiter:
+		if block != 102 {
+			t.Errorf(
+				"unexpected waiter block\nexpected: [%v]\nactual:   [%v]",
+				102,
+				block,
+			)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("block height waiter has not been notified")
+	}
+}
+
+type mockHeadSource struct {
+	blockNumber uint64
+}
+
+func (mhs *mockHeadSource) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	return &types.Header{
+		Number: new(big.Int).SetUint64(mhs.blockNumber),
+	}, nil
+}
+
+func (mhs *mockHeadSource) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (goethereum.Subscription, error) {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil
+}
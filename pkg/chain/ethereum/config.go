@@ -0,0 +1,330 @@
+package ethereum
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	relayconfig "github.com/keep-network/keep-core/pkg/beacon/relay/config"
+)
+
+// Config contains the configuration needed to connect to an Ethereum node and
+// to operate the Ethereum chain adapter. It extends the common Ethereum
+// configuration with options specific to the Keep client.
+type Config struct {
+	ethereum.Config
+
+	// Endpoints is an ordered list of WebSocket URLs of additional Ethereum
+	// nodes the client fails over to when the node configured with URL is
+	// unavailable or lags behind. Endpoints listed earlier are preferred over
+	// the ones listed later. The node configured with URLRPC is the last
+	// endpoint to fail over to.
+	Endpoints []string
+
+	// Failover configures how the health of Ethereum endpoints is checked.
+	Failover FailoverConfig
+
+	// ConfirmationDepth is the number of blocks which must be mined on top of
+	// the block containing a chain event before the event is acted upon.
+	// Events removed from the chain before they are confirmed, for example
+	// as a result of a chain reorganization, are discarded. Past events read
+	// from the chain, e.g. when recovering work on startup, are confirmed the
+	// same way. Zero means events are acted upon as soon as they are received.
+	ConfirmationDepth uint64
+
+	// Transactions configures how the client tracks and resubmits the
+	// transactions it sends to the chain.
+	Transactions TransactionsConfig
+
+	// Gas configures how much the client is willing to pay for transactions.
+	Gas GasConfig
+
+	// Timing configures the timing of group selection and DKG phases. It has
+	// to be consistent with the operator contract deployed on the chain.
+	Timing TimingConfig
+
+	// Retry configures retries of failed read calls per call type. Call types
+	// are Config, Stake, SubmittedTickets, SelectedParticipants, Group,
+	// GasEstimate and RelayEntry. Call types which are not configured use
+	// default policies.
+	Retry map[string]RetryConfig
+
+	// Signer configures how transactions and messages are signed with the
+	// operator key.
+	Signer SignerConfig
+}
+
+// SignerConfig contains configuration of the operator signer.
+type SignerConfig struct {
+	// Type is the type of the operator signer. With "keystore", the default,
+	// the operator key is decrypted from Account.KeyFile. With "external",
+	// signing is delegated to an external signer, like Clef, over JSON-RPC
+	// and the operator key never has to be held in the client's memory.
+	// The operator account is then configured with Account.Address.
+	Type string
+
+	// URL is the HTTP URL or the IPC socket path of the external signer.
+	URL string
+}
+
+// IsExternal returns true if the operator key is held by an external signer.
+func (sc SignerConfig) IsExternal() bool {
+	return sc.Type == externalSignerType
+}
+
+// TimingConfig contains configuration of the timing of group selection and
+// DKG phases, in blocks. All values are optional; zero values are replaced
+// with defaults matching the operator contract deployed on Ethereum mainnet.
+// Chains with faster blocks use operator contracts deployed with longer
+// timeouts, and the timing of phases has to be adjusted accordingly.
+type TimingConfig struct {
+	// DKGTimeout is the DKG timeout of the operator contract. Phases of DKG
+	// together have to take exactly the DKG timeout.
+	DKGTimeout uint64
+
+	// GroupSelection configures the timing of ticket submission rounds.
+	GroupSelection relayconfig.GroupSelectionTiming
+
+	// DKG configures the timing of DKG phases.
+	DKG relayconfig.DKGTiming
+}
+
+// GasConfig contains configuration of the gas policy. All values are
+// optional; zero values are replaced with defaults.
+type GasConfig struct {
+	// MaxGasPrice is the maximum gas price, in wei, the client pays for
+	// a transaction. Transactions are not submitted when the gas price is
+	// higher and resubmissions do not raise the gas price above it. Zero
+	// means there is no maximum.
+	MaxGasPrice uint64
+
+	// EstimateMarginPercent is the percentage added to gas estimates to set
+	// gas limits of transactions.
+	EstimateMarginPercent uint64
+
+	// ExpectedGroupEntries is the number of relay entries a group is
+	// expected to sign during its lifetime. A seat in the group is expected
+	// to earn the group member base reward for each of them, which is
+	// weighed against the cost of submitting tickets. The default of one
+	// entry is conservative; groups usually sign more entries.
+	ExpectedGroupEntries uint64
+
+	// Budgets configures gas limits of transactions per operation.
+	Budgets GasBudgetsConfig
+}
+
+// GasBudgetsConfig contains gas budgets of operations. Transactions which
+// would need more gas than the budget of their operation are not submitted.
+type GasBudgetsConfig struct {
+	// TicketSubmission is the gas limit of a ticket submission.
+	TicketSubmission uint64
+
+	// RelayEntrySubmission is the gas budget of a relay entry submission.
+	// Zero means there is no budget.
+	RelayEntrySubmission uint64
+
+	// DKGResultSubmission is the gas budget of a DKG result submission.
+	// Zero means there is no budget.
+	DKGResultSubmission uint64
+}
+
+// RetryConfig contains configuration of the retry policy of one call type.
+// All values are optional; zero values are replaced with defaults of the
+// call type.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts of a call, including
+	// the first one.
+	MaxAttempts uint64
+
+	// InitialBackoffMilliseconds is the delay before the second attempt.
+	// The delay doubles with every following attempt.
+	InitialBackoffMilliseconds uint64
+
+	// MaxBackoffMilliseconds is the maximum delay between attempts.
+	MaxBackoffMilliseconds uint64
+
+	// TimeoutSeconds is the time after which no further attempts are made.
+	TimeoutSeconds uint64
+}
+
+// TransactionsConfig contains configuration of the transaction manager.
+// All values are optional; zero values are replaced with defaults.
+type TransactionsConfig struct {
+	// ResubmitAfterBlocks is the number of blocks the transaction manager
+	// waits for a pending transaction to be mined before it resubmits the
+	// transaction with a higher gas price.
+	ResubmitAfterBlocks uint64
+
+	// GasPriceBumpPercent is the percentage by which the gas price is
+	// increased on each resubmission. Ethereum clients reject replacement
+	// transactions whose gas price is not at least 10% higher than the
+	// original one, so values lower than 10 are raised to 10.
+	GasPriceBumpPercent uint64
+
+	// MaxResubmissions is the number of times a transaction is resubmitted
+	// with a higher gas price before it is abandoned.
+	MaxResubmissions uint64
+}
+
+// FailoverConfig contains configuration of Ethereum endpoints health checks.
+// All values are optional; zero values are replaced with defaults.
+type FailoverConfig struct {
+	// HealthCheckIntervalSeconds is the interval between subsequent health
+	// checks of all the configured endpoints.
+	HealthCheckIntervalSeconds uint64
+
+	// MaxBlockLag is the maximum number of blocks an endpoint can stay behind
+	// the endpoint reporting the highest block to be considered healthy.
+	MaxBlockLag uint64
+}
+
+const (
+	defaultHealthCheckInterval = 15 * time.Second
+	defaultMaxBlockLag         = 3
+)
+
+// endpoints returns URLs of all the configured Ethereum endpoints, in the
+// order of preference. The RPC endpoint configured with URLRPC does not
+// support subscriptions, so it is the least preferred one and it is used only
+// when none of the WebSocket endpoints is healthy.
+func (c Config) endpoints() []string {
+	endpoints := make([]string, 0, len(c.Endpoints)+2)
+	if c.URL != "" {
+		endpoints = append(endpoints, c.URL)
+	}
+	endpoints = append(endpoints, c.Endpoints...)
+
+	if c.URLRPC != "" {
+		endpoints = append(endpoints, c.URLRPC)
+	}
+
+	return endpoints
+}
+
+// healthCheckInterval returns the configured health check interval or the
+// default one if it has not been configured.
+func (fc FailoverConfig) healthCheckInterval() time.Duration {
+	if fc.HealthCheckIntervalSeconds == 0 {
+		return defaultHealthCheckInterval
+	}
+
+	return time.Duration(fc.HealthCheckIntervalSeconds) * time.Second
+}
+
+// maxBlockLag returns the configured maximum block lag or the default one if
+// it has not been configured.
+func (fc FailoverConfig) maxBlockLag() uint64 {
+	if fc.MaxBlockLag == 0 {
+		return defaultMaxBlockLag
+	}
+
+	return fc.MaxBlockLag
+}
+
+const (
+	defaultEstimateMarginPercent    = 20
+	defaultExpectedGroupEntries     = 1
+	defaultTicketSubmissionGasLimit = 250000
+)
+
+// maxGasPrice returns the configured maximum gas price or nil if there is no
+// maximum.
+func (gc GasConfig) maxGasPrice() *big.Int {
+	if gc.MaxGasPrice == 0 {
+		return nil
+	}
+
+	return new(big.Int).SetUint64(gc.MaxGasPrice)
+}
+
+// estimateMarginPercent returns the configured gas estimate margin or the
+// default one if it has not been configured.
+func (gc GasConfig) estimateMarginPercent() uint64 {
+	if gc.EstimateMarginPercent == 0 {
+		return defaultEstimateMarginPercent
+	}
+
+	return gc.EstimateMarginPercent
+}
+
+// expectedGroupEntries returns the configured number of relay entries a group
+// is expected to sign or the default one if it has not been configured.
+func (gc GasConfig) expectedGroupEntries() uint64 {
+	if gc.ExpectedGroupEntries == 0 {
+		return defaultExpectedGroupEntries
+	}
+
+	return gc.ExpectedGroupEntries
+}
+
+// ticketSubmissionEconomics returns the economics of submitting a ticket at
+// the given gas price when a group member earns the given base reward for
+// each relay entry signed by the group.
+func (gc GasConfig) ticketSubmissionEconomics(
+	gasPrice *big.Int,
+	groupMemberBaseReward *big.Int,
+) *relaychain.TicketSubmissionEconomics {
+	return &relaychain.TicketSubmissionEconomics{
+		SubmissionCost: new(big.Int).Mul(
+			gasPrice,
+			new(big.Int).SetUint64(gc.Budgets.ticketSubmission()),
+		),
+		ExpectedReward: new(big.Int).Mul(
+			groupMemberBaseReward,
+			new(big.Int).SetUint64(gc.expectedGroupEntries()),
+		),
+	}
+}
+
+// ticketSubmission returns the configured gas limit of a ticket submission
+// or the default one if it has not been configured.
+func (gbc GasBudgetsConfig) ticketSubmission() uint64 {
+	if gbc.TicketSubmission == 0 {
+		return defaultTicketSubmissionGasLimit
+	}
+
+	return gbc.TicketSubmission
+}
+
+const (
+	defaultResubmitAfterBlocks = 3
+	defaultGasPriceBumpPercent = 20
+	minimumGasPriceBumpPercent = 10
+	defaultMaxResubmissions    = 5
+)
+
+// resubmitAfterBlocks returns the configured resubmission interval or the
+// default one if it has not been configured.
+func (tc TransactionsConfig) resubmitAfterBlocks() uint64 {
+	if tc.ResubmitAfterBlocks == 0 {
+		return defaultResubmitAfterBlocks
+	}
+
+	return tc.ResubmitAfterBlocks
+}
+
+// gasPriceBumpPercent returns the configured gas price bump or the default
+// one if it has not been configured. The returned value is never lower than
+// the minimum bump accepted by Ethereum clients for replacement transactions.
+func (tc TransactionsConfig) gasPriceBumpPercent() uint64 {
+	if tc.GasPriceBumpPercent == 0 {
+		return defaultGasPriceBumpPercent
+	}
+
+	if tc.GasPriceBumpPercent < minimumGasPriceBumpPercent {
+		return minimumGasPriceBumpPercent
+	}
+
+	return tc.GasPriceBumpPercent
+}
+
+// maxResubmissions returns the configured maximum number of resubmissions or
+// the default one if it has not been configured.
+func (tc TransactionsConfig) maxResubmissions() uint64 {
+	if tc.MaxResubmissions == 0 {
+		return defaultMaxResubmissions
+	}
+
+	return tc.MaxResubmissions
+}
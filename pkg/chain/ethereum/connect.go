@@ -1,29 +1,42 @@
 package ethereum
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync"
 
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
-	"github.com/keep-network/keep-common/pkg/chain/ethereum/blockcounter"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
 	"github.com/keep-network/keep-core/pkg/chain/gen/contract"
 )
 
 type ethereumChain struct {
-	config                           ethereum.Config
+	config                           Config
 	client                           bind.ContractBackend
-	clientRPC                        *rpc.Client
-	clientWS                         *rpc.Client
 	keepRandomBeaconOperatorContract *contract.KeepRandomBeaconOperator
+	keepRandomBeaconOperatorCaller   *abi.KeepRandomBeaconOperatorCaller
+	keepRandomBeaconOperatorAddress  common.Address
+	keepRandomBeaconOperatorABI      *ethereumabi.ABI
+	keepRandomBeaconOperatorFilterer *abi.KeepRandomBeaconOperatorFilterer
 	stakingContract                  *contract.TokenStaking
-	accountKey                       *keystore.Key
-	blockCounter                     *blockcounter.EthereumBlockCounter
+	stakingCaller                    *abi.TokenStakingCaller
+	stakingAddress                   common.Address
+	stakingABI                       *ethereumabi.ABI
+	stakingFilterer                  *abi.TokenStakingFilterer
+	transactions                     transactionReader
+	signer                           operatorSigner
+	bindingKey                       *keystore.Key
+	blockCounter                     *blockCounter
+	transactionManager               *transactionManager
+	retryPolicies                    map[string]*retryPolicy
 
 	// transactionMutex allows interested parties to forcibly serialize
 	// transaction submission.
@@ -44,19 +57,46 @@ type ethereumUtilityChain struct {
 	ethereumChain
 
 	keepRandomBeaconServiceContract *contract.KeepRandomBeaconService
+	keepRandomBeaconServiceCaller   *abi.KeepRandomBeaconServiceImplV1Caller
 }
 
-func connect(config ethereum.Config) (*ethereumChain, error) {
-	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
+func connect(ctx context.Context, config Config) (*ethereumChain, error) {
+	signer, bindingKey, err := connectSigner(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return connectWithSigner(ctx, config, signer, bindingKey)
+}
+
+// connectWithSigner connects to the Ethereum network with the given operator
+// signer. Contract bindings sign transactions with the given binding key.
+// Endpoint health checks, block counting and tracking of submitted
+// transactions stop once the given context is done or connecting fails.
+func connectWithSigner(
+	parentCtx context.Context,
+	config Config,
+	signer operatorSigner,
+	bindingKey *keystore.Key,
+) (_ *ethereumChain, err error) {
+	ctx, cancelCtx := context.WithCancel(parentCtx)
+	defer func() {
+		if err != nil {
+			cancelCtx()
+		}
+	}()
+
+	client, err := dialFailoverClient(config.endpoints(), config.Failover)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"error connecting to Ethereum server: %s [%v]",
-			config.URL,
+			"error connecting to Ethereum servers: %v [%v]",
+			config.endpoints(),
 			err,
 		)
 	}
+	client.start(ctx, config.Failover.healthCheckInterval())
 
-	blockCounter, err := blockcounter.CreateBlockCounter(client)
+	blockCounter, err := newBlockCounter(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to create Ethereum blockcounter: [%v]",
@@ -66,29 +106,33 @@ func connect(config ethereum.Config) (*ethereumChain, error) {
 
 	pv := &ethereumChain{
 		config:           config,
-		client:           ethutil.WrapCallLogging(logger, client),
-		clientRPC:        clientRPC,
-		clientWS:         clientWS,
 		transactionMutex: &sync.Mutex{},
 		blockCounter:     blockCounter,
 	}
 
-	if pv.accountKey == nil {
-		key, err := ethutil.DecryptKeyFile(
-			config.Account.KeyFile,
-			config.Account.KeyFilePassword,
-		)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"failed to read KeyFile: %s: [%v]",
-				config.Account.KeyFile,
-				err,
-			)
-		}
-		pv.accountKey = key
+	pv.retryPolicies, err = newRetryPolicies(config.Retry)
+	if err != nil {
+		return nil, err
 	}
 
-	address, err := addressForContract(config, "KeepRandomBeaconOperator")
+	pv.transactions = client
+	pv.signer = signer
+	pv.bindingKey = bindingKey
+
+	pv.transactionManager = newTransactionManager(
+		client,
+		client,
+		pv.signer,
+		config.Transactions,
+		config.Gas.maxGasPrice(),
+	)
+	pv.transactionManager.start(ctx, blockCounter)
+	pv.client = pv.transactionManager.wrap(
+		ethutil.WrapCallLogging(logger, client),
+		pv.bindingKey.Address,
+	)
+
+	address, err := addressForContract(config.Config, "KeepRandomBeaconOperator")
 	if err != nil {
 		return nil, fmt.Errorf("error resolving KeepRandomBeaconOperator contract: [%v]", err)
 	}
@@ -96,7 +140,7 @@ func connect(config ethereum.Config) (*ethereumChain, error) {
 	keepRandomBeaconOperatorContract, err :=
 		contract.NewKeepRandomBeaconOperator(
 			*address,
-			pv.accountKey,
+			pv.bindingKey,
 			pv.client,
 			pv.transactionMutex,
 		)
@@ -104,8 +148,31 @@ func connect(config ethereum.Config) (*ethereumChain, error) {
 		return nil, fmt.Errorf("error attaching to KeepRandomBeaconOperator contract: [%v]", err)
 	}
 	pv.keepRandomBeaconOperatorContract = keepRandomBeaconOperatorContract
+	pv.keepRandomBeaconOperatorAddress = *address
+
+	keepRandomBeaconOperatorABI, err := ethereumabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing KeepRandomBeaconOperator ABI: [%v]", err)
+	}
+	pv.keepRandomBeaconOperatorABI = &keepRandomBeaconOperatorABI
+
+	keepRandomBeaconOperatorFilterer, err :=
+		abi.NewKeepRandomBeaconOperatorFilterer(*address, pv.client)
+	if err != nil {
+		return nil, fmt.Errorf("error attaching to KeepRandomBeaconOperator events: [%v]", err)
+	}
+	pv.keepRandomBeaconOperatorFilterer = keepRandomBeaconOperatorFilterer
 
-	address, err = addressForContract(config, "TokenStaking")
+	keepRandomBeaconOperatorCaller, err :=
+		abi.NewKeepRandomBeaconOperatorCaller(*address, pv.client)
+	if err != nil {
+		return nil, fmt.Errorf("error attaching to KeepRandomBeaconOperator calls: [%v]", err)
+	}
+	pv.keepRandomBeaconOperatorCaller = keepRandomBeaconOperatorCaller
+
+	address, err = addressForContract(config.Config, "TokenStaking")
 	if err != nil {
 		return nil, fmt.Errorf("error resolving TokenStaking contract: [%v]", err)
 	}
@@ -113,7 +180,7 @@ func connect(config ethereum.Config) (*ethereumChain, error) {
 	stakingContract, err :=
 		contract.NewTokenStaking(
 			*address,
-			pv.accountKey,
+			pv.bindingKey,
 			pv.client,
 			pv.transactionMutex,
 		)
@@ -121,22 +188,104 @@ func connect(config ethereum.Config) (*ethereumChain, error) {
 		return nil, fmt.Errorf("error attaching to TokenStaking contract: [%v]", err)
 	}
 	pv.stakingContract = stakingContract
+	pv.stakingAddress = *address
+
+	stakingABI, err := ethereumabi.JSON(strings.NewReader(abi.TokenStakingABI))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing TokenStaking ABI: [%v]", err)
+	}
+	pv.stakingABI = &stakingABI
+
+	stakingFilterer, err := abi.NewTokenStakingFilterer(*address, pv.client)
+	if err != nil {
+		return nil, fmt.Errorf("error attaching to TokenStaking events: [%v]", err)
+	}
+	pv.stakingFilterer = stakingFilterer
+
+	stakingCaller, err := abi.NewTokenStakingCaller(*address, pv.client)
+	if err != nil {
+		return nil, fmt.Errorf("error attaching to TokenStaking calls: [%v]", err)
+	}
+	pv.stakingCaller = stakingCaller
 
 	return pv, nil
 }
 
+// connectSigner creates the operator signer of the configured type. It also
+// returns the key contract bindings should sign transactions with.
+//
+// Contract bindings can sign transactions only with a key held in memory.
+// When the operator key is held by an external signer, contract bindings are
+// given an ephemeral key instead and the transactions they sign are re-signed
+// by the external signer before they are sent.
+func connectSigner(config Config) (operatorSigner, *keystore.Key, error) {
+	switch config.Signer.Type {
+	case "", keystoreSignerType:
+		key, err := ethutil.DecryptKeyFile(
+			config.Account.KeyFile,
+			config.Account.KeyFilePassword,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"failed to read KeyFile: %s: [%v]",
+				config.Account.KeyFile,
+				err,
+			)
+		}
+
+		return &keySigner{key}, key, nil
+	case externalSignerType:
+		if !common.IsHexAddress(config.Account.Address) {
+			return nil, nil, fmt.Errorf(
+				"configured account address [%v] is not valid hex address",
+				config.Account.Address,
+			)
+		}
+
+		signer, err := dialExternalSigner(
+			config.Signer.URL,
+			common.HexToAddress(config.Account.Address),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		bindingPrivateKey, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"could not generate contract bindings key: [%v]",
+				err,
+			)
+		}
+
+		bindingKey := &keystore.Key{
+			Address:    crypto.PubkeyToAddress(bindingPrivateKey.PublicKey),
+			PrivateKey: bindingPrivateKey,
+		}
+
+		return signer, bindingKey, nil
+	default:
+		return nil, nil, fmt.Errorf(
+			"unknown signer type [%v]; expected [%v] or [%v]",
+			config.Signer.Type,
+			keystoreSignerType,
+			externalSignerType,
+		)
+	}
+}
+
 // ConnectUtility makes the network connection to the Ethereum network and
 // returns a utility handle to the chain interface with additional methods for
 // non- standard client interactions. Note: for other things to work correctly
 // the configuration will need to reference a websocket, "ws://", or local IPC
-// connection.
-func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
-	base, err := connect(config)
+// connection. The connection is maintained until the given context is done.
+func ConnectUtility(ctx context.Context, config Config) (chain.Utility, error) {
+	base, err := connect(ctx, config)
 	if err != nil {
 		return nil, err
 	}
 
-	address, err := addressForContract(config, "KeepRandomBeaconService")
+	address, err := addressForContract(config.Config, "KeepRandomBeaconService")
 	if err != nil {
 		return nil, fmt.Errorf("error resolving KeepRandomBeaconService contract: [%v]", err)
 	}
@@ -144,7 +293,7 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 	keepRandomBeaconServiceContract, err :=
 		contract.NewKeepRandomBeaconService(
 			*address,
-			base.accountKey,
+			base.bindingKey,
 			base.client,
 			base.transactionMutex,
 		)
@@ -152,18 +301,37 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 		return nil, fmt.Errorf("error attaching to KeepRandomBeaconService contract: [%v]", err)
 	}
 
+	keepRandomBeaconServiceCaller, err :=
+		abi.NewKeepRandomBeaconServiceImplV1Caller(*address, base.client)
+	if err != nil {
+		return nil, fmt.Errorf("error attaching to KeepRandomBeaconService calls: [%v]", err)
+	}
+
 	return &ethereumUtilityChain{
 		*base,
 		keepRandomBeaconServiceContract,
+		keepRandomBeaconServiceCaller,
 	}, nil
 }
 
 // Connect makes the network connection to the Ethereum network and returns a
 // standard handle to the chain interface. Note: for other things to work
 // correctly the configuration will need to reference a websocket, "ws://", or
-// local IPC connection.
-func Connect(config ethereum.Config) (chain.Handle, error) {
-	return connect(config)
+// local IPC connection. The connection is maintained until the given context
+// is done.
+func Connect(ctx context.Context, config Config) (chain.Handle, error) {
+	return connect(ctx, config)
+}
+
+// ConnectWithKey makes the network connection to the Ethereum network the
+// same way as Connect does but it uses the given account key instead of the
+// key from the configured key file, which is not read at all.
+func ConnectWithKey(
+	ctx context.Context,
+	config Config,
+	accountKey *keystore.Key,
+) (chain.Handle, error) {
+	return connectWithSigner(ctx, config, &keySigner{accountKey}, accountKey)
 }
 
 func addressForContract(config ethereum.Config, contractName string) (*common.Address, error) {
@@ -78,3 +78,47 @@ func TestResolveContractByName(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigEndpoints(t *testing.T) {
+	var tests = map[string]struct {
+		config            Config
+		expectedEndpoints []string
+	}{
+		"only WebSocket endpoints": {
+			config: Config{
+				Config:    ethereum.Config{URL: "ws://first"},
+				Endpoints: []string{"ws://second"},
+			},
+			expectedEndpoints: []string{"ws://first", "ws://second"},
+		},
+		"RPC endpoint is the least preferred": {
+			config: Config{
+				Config: ethereum.Config{
+					URL:    "ws://first",
+					URLRPC: "http://rpc",
+				},
+				Endpoints: []string{"ws://second"},
+			},
+			expectedEndpoints: []string{"ws://first", "ws://second", "http://rpc"},
+		},
+		"only RPC endpoint": {
+			config: Config{
+				Config: ethereum.Config{URLRPC: "http://rpc"},
+			},
+			expectedEndpoints: []string{"http://rpc"},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			endpoints := test.config.endpoints()
+			if !reflect.DeepEqual(test.expectedEndpoints, endpoints) {
+				t.Errorf(
+					"unexpected endpoints\nexpected: [%v]\nactual:   [%v]",
+					test.expectedEndpoints,
+					endpoints,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+)
+
+// PastRelayEntryRequests returns confirmed relay entry requests emitted
+// starting from the given block, in the chain order.
+func (ec *ethereumChain) PastRelayEntryRequests(
+	ctx context.Context,
+	fromBlock uint64,
+) ([]*event.Request, error) {
+	confirmedBlock, ok, err := ec.confirmedBlock()
+	if err != nil || !ok || fromBlock > confirmedBlock {
+		return nil, err
+	}
+
+	var requests []*event.Request
+	err = ec.withRetry(
+		ctx,
+		relayEntryCallType,
+		func(ctx context.Context) error {
+			iterator, err :=
+				ec.keepRandomBeaconOperatorFilterer.FilterRelayEntryRequested(
+					&bind.FilterOpts{
+						Start:   fromBlock,
+						End:     &confirmedBlock,
+						Context: ctx,
+					},
+				)
+			if err != nil {
+				return err
+			}
+			defer iterator.Close()
+
+			requests = nil
+			for iterator.Next() {
+				requests = append(requests, &event.Request{
+					PreviousEntry:  iterator.Event.PreviousEntry,
+					GroupPublicKey: iterator.Event.GroupPublicKey,
+					BlockNumber:    iterator.Event.Raw.BlockNumber,
+				})
+			}
+
+			return iterator.Error()
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not filter relay requests: [%v]", err)
+	}
+
+	return requests, nil
+}
+
+// PastRelayEntriesSubmitted returns confirmed relay entries submitted
+// starting from the given block, in the chain order. Submission events do
+// not carry the entry so it is read from the transaction which submitted it.
+func (ec *ethereumChain) PastRelayEntriesSubmitted(
+	ctx context.Context,
+	fromBlock uint64,
+) ([]*event.EntrySubmitted, error) {
+	confirmedBlock, ok, err := ec.confirmedBlock()
+	if err != nil || !ok || fromBlock > confirmedBlock {
+		return nil, err
+	}
+
+	var logs []types.Log
+	err = ec.withRetry(
+		ctx,
+		relayEntryCallType,
+		func(ctx context.Context) error {
+			iterator, err :=
+				ec.keepRandomBeaconOperatorFilterer.FilterRelayEntrySubmitted(
+					&bind.FilterOpts{
+						Start:   fromBlock,
+						End:     &confirmedBlock,
+						Context: ctx,
+					},
+				)
+			if err != nil {
+				return err
+			}
+			defer iterator.Close()
+
+			logs = nil
+			for iterator.Next() {
+				logs = append(logs, iterator.Event.Raw)
+			}
+
+			return iterator.Error()
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not filter relay entries: [%v]", err)
+	}
+
+	var submissions []*event.EntrySubmitted
+	for _, log := range logs {
+		submissions = append(submissions, ec.relayEntrySubmission(ctx, log))
+	}
+
+	return submissions, nil
+}
+
+// transactionReader reads transactions sent to the chain.
+type transactionReader interface {
+	TransactionByHash(
+		ctx context.Context,
+		hash common.Hash,
+	) (*types.Transaction, bool, error)
+}
+
+// relayEntrySubmission returns the submission of the relay entry emitted in
+// the given log. The entry is read from the call data of the transaction
+// which submitted it; if it could not be read, the entry is left nil.
+func (ec *ethereumChain) relayEntrySubmission(
+	ctx context.Context,
+	log types.Log,
+) *event.EntrySubmitted {
+	var entry []byte
+	err := ec.withRetry(
+		ctx,
+		relayEntryCallType,
+		func(ctx context.Context) (err error) {
+			entry, err = ec.relayEntryFromTransaction(ctx, log.TxHash)
+			return
+		},
+	)
+	if err != nil {
+		logger.Warningf(
+			"could not read relay entry submitted at block [%v] "+
+				"in transaction [%v]: [%v]",
+			log.BlockNumber,
+			log.TxHash.Hex(),
+			err,
+		)
+	}
+
+	return &event.EntrySubmitted{
+		Entry:       entry,
+		BlockNumber: log.BlockNumber,
+	}
+}
+
+// relayEntryFromTransaction reads the relay entry from the call data of the
+// transaction with the given hash. The transaction is expected to call
+// relayEntry of the operator contract directly.
+func (ec *ethereumChain) relayEntryFromTransaction(
+	ctx context.Context,
+	transactionHash common.Hash,
+) ([]byte, error) {
+	transaction, _, err := ec.transactions.TransactionByHash(
+		ctx,
+		transactionHash,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not get transaction: [%v]", err)
+	}
+
+	if transaction.To() == nil ||
+		*transaction.To() != ec.keepRandomBeaconOperatorAddress {
+		return nil, fmt.Errorf("transaction is not a call to the operator contract")
+	}
+
+	data := transaction.Data()
+	if len(data) < 4 {
+		return nil, fmt.Errorf("transaction is not a contract method call")
+	}
+
+	method, err := ec.keepRandomBeaconOperatorABI.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	if method.Name != "relayEntry" {
+		return nil, fmt.Errorf(
+			"transaction calls [%v] instead of [relayEntry]",
+			method.Name,
+		)
+	}
+
+	arguments, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("could not unpack call data: [%v]", err)
+	}
+
+	entry, ok := arguments[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected relay entry type [%T]", arguments[0])
+	}
+
+	return entry, nil
+}
+
+// PastGroupSelectionsStarted returns confirmed group selections started
+// starting from the given block, in the chain order.
+func (ec *ethereumChain) PastGroupSelectionsStarted(
+	ctx context.Context,
+	fromBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	confirmedBlock, ok, err := ec.confirmedBlock()
+	if err != nil || !ok || fromBlock > confirmedBlock {
+		return nil, err
+	}
+
+	var groupSelections []*event.GroupSelectionStart
+	err = ec.withRetry(
+		ctx,
+		groupSelectionCallType,
+		func(ctx context.Context) error {
+			iterator, err :=
+				ec.keepRandomBeaconOperatorFilterer.FilterGroupSelectionStarted(
+					&bind.FilterOpts{
+						Start:   fromBlock,
+						End:     &confirmedBlock,
+						Context: ctx,
+					},
+				)
+			if err != nil {
+				return err
+			}
+			defer iterator.Close()
+
+			groupSelections = nil
+			for iterator.Next() {
+				groupSelections = append(groupSelections, &event.GroupSelectionStart{
+					NewEntry:    iterator.Event.NewEntry,
+					BlockNumber: iterator.Event.Raw.BlockNumber,
+				})
+			}
+
+			return iterator.Error()
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not filter group selections: [%v]", err)
+	}
+
+	return groupSelections, nil
+}
+
+// confirmedBlock returns the last block confirmed by the configured number of
+// blocks mined on top of it. Events up to that block are acted upon. It
+// returns false if no block has been confirmed yet.
+func (ec *ethereumChain) confirmedBlock() (uint64, bool, error) {
+	currentBlock, err := ec.blockCounter.CurrentBlock()
+	if err != nil {
+		return 0, false, fmt.Errorf("could not get current block: [%v]", err)
+	}
+
+	if currentBlock < ec.config.ConfirmationDepth {
+		return 0, false, nil
+	}
+
+	return currentBlock - ec.config.ConfirmationDepth, true, nil
+}
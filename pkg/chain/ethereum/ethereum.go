@@ -1,13 +1,17 @@
 package ethereum
 
 import (
+	"context"
 	"fmt"
 	"math/big"
-	"time"
 
 	"github.com/ipfs/go-log"
 
+	goethereum "github.com/ethereum/go-ethereum"
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/chain"
@@ -21,68 +25,122 @@ import (
 
 var logger = log.Logger("keep-chain-ethereum")
 
+// submittedEventMarginBlocks is the number of blocks a submission waits for the
+// event emitted by its successfully mined transaction on top of the blocks
+// needed to confirm the event. If the event is not delivered by then, for
+// example because the block has been reorganized or the event watcher failed,
+// the submission fails.
+const submittedEventMarginBlocks = 20
+
 // ThresholdRelay converts from ethereumChain to beacon.ChainInterface.
 func (ec *ethereumChain) ThresholdRelay() relaychain.Interface {
 	return ec
 }
 
 func (ec *ethereumChain) GetKeys() (*operator.PrivateKey, *operator.PublicKey) {
-	return operator.EthereumKeyToOperatorKey(ec.accountKey)
+	if signer, ok := ec.signer.(*keySigner); ok {
+		return operator.EthereumKeyToOperatorKey(signer.key)
+	}
+
+	// The operator key is held by an external signer.
+	return nil, ec.signer.publicKey()
 }
 
 func (ec *ethereumChain) GetConfig() (*relayconfig.Chain, error) {
-	groupSize, err := ec.keepRandomBeaconOperatorContract.GroupSize()
-	if err != nil {
-		return nil, fmt.Errorf("error calling GroupSize: [%v]", err)
-	}
+	var config *relayconfig.Chain
 
-	threshold, err := ec.keepRandomBeaconOperatorContract.GroupThreshold()
-	if err != nil {
-		return nil, fmt.Errorf("error calling GroupThreshold: [%v]", err)
-	}
+	err := ec.withRetry(
+		context.Background(),
+		configCallType,
+		func(ctx context.Context) error {
+			callOpts := callOptions(ctx)
 
-	ticketSubmissionTimeout, err :=
-		ec.keepRandomBeaconOperatorContract.TicketSubmissionTimeout()
-	if err != nil {
-		return nil, fmt.Errorf(
-			"error calling TicketSubmissionTimeout: [%v]",
-			err,
-		)
-	}
+			groupSize, err := ec.keepRandomBeaconOperatorCaller.GroupSize(callOpts)
+			if err != nil {
+				return fmt.Errorf("error calling GroupSize: [%v]", err)
+			}
 
-	resultPublicationBlockStep, err := ec.keepRandomBeaconOperatorContract.ResultPublicationBlockStep()
-	if err != nil {
-		return nil, fmt.Errorf(
-			"error calling ResultPublicationBlockStep: [%v]",
-			err,
-		)
-	}
+			threshold, err := ec.keepRandomBeaconOperatorCaller.GroupThreshold(callOpts)
+			if err != nil {
+				return fmt.Errorf("error calling GroupThreshold: [%v]", err)
+			}
 
-	minimumStake, err := ec.stakingContract.MinimumStake()
-	if err != nil {
-		return nil, fmt.Errorf("error calling MinimumStake: [%v]", err)
-	}
+			ticketSubmissionTimeout, err :=
+				ec.keepRandomBeaconOperatorCaller.TicketSubmissionTimeout(callOpts)
+			if err != nil {
+				return fmt.Errorf(
+					"error calling TicketSubmissionTimeout: [%v]",
+					err,
+				)
+			}
+
+			resultPublicationBlockStep, err := ec.keepRandomBeaconOperatorCaller.ResultPublicationBlockStep(callOpts)
+			if err != nil {
+				return fmt.Errorf(
+					"error calling ResultPublicationBlockStep: [%v]",
+					err,
+				)
+			}
 
-	relayEntryTimeout, err := ec.keepRandomBeaconOperatorContract.RelayEntryTimeout()
+			minimumStake, err := ec.stakingCaller.MinimumStake(callOpts)
+			if err != nil {
+				return fmt.Errorf("error calling MinimumStake: [%v]", err)
+			}
+
+			relayEntryTimeout, err := ec.keepRandomBeaconOperatorCaller.RelayEntryTimeout(callOpts)
+			if err != nil {
+				return fmt.Errorf("error calling RelayEntryTimeout: [%v]", err)
+			}
+
+			// The DKG timeout is not exposed by the operator contract so it is
+			// taken from the client config.
+			dkgTimeout := ec.config.Timing.DKGTimeout
+			if dkgTimeout == 0 {
+				dkgTimeout = relayconfig.DefaultDKGTimeout
+			}
+
+			config = &relayconfig.Chain{
+				GroupSize:                  int(groupSize.Int64()),
+				HonestThreshold:            int(threshold.Int64()),
+				TicketSubmissionTimeout:    ticketSubmissionTimeout.Uint64(),
+				ResultPublicationBlockStep: resultPublicationBlockStep.Uint64(),
+				MinimumStake:               minimumStake,
+				RelayEntryTimeout:          relayEntryTimeout.Uint64(),
+				DKGTimeout:                 dkgTimeout,
+				GroupSelectionTiming:       ec.config.Timing.GroupSelection.WithDefaults(),
+				DKGTiming:                  ec.config.Timing.DKG.WithDefaults(),
+				EventConfirmationDepth:     ec.config.ConfirmationDepth,
+			}
+
+			return nil
+		},
+	)
 	if err != nil {
-		return nil, fmt.Errorf("error calling RelayEntryTimeout: [%v]", err)
+		return nil, err
 	}
 
-	return &relayconfig.Chain{
-		GroupSize:                  int(groupSize.Int64()),
-		HonestThreshold:            int(threshold.Int64()),
-		TicketSubmissionTimeout:    ticketSubmissionTimeout.Uint64(),
-		ResultPublicationBlockStep: resultPublicationBlockStep.Uint64(),
-		MinimumStake:               minimumStake,
-		RelayEntryTimeout:          relayEntryTimeout.Uint64(),
-	}, nil
+	return config, nil
 }
 
 // HasMinimumStake returns true if the specified address is staked.  False will
 // be returned if not staked.  If err != nil then it was not possible to determine
 // if the address is staked or not.
 func (ec *ethereumChain) HasMinimumStake(address common.Address) (bool, error) {
-	return ec.keepRandomBeaconOperatorContract.HasMinimumStake(address)
+	var hasMinimumStake bool
+	err := ec.withRetry(
+		context.Background(),
+		stakeCallType,
+		func(ctx context.Context) (err error) {
+			hasMinimumStake, err =
+				ec.keepRandomBeaconOperatorCaller.HasMinimumStake(
+					callOptions(ctx),
+					address,
+				)
+			return err
+		},
+	)
+
+	return hasMinimumStake, err
 }
 
 func (ec *ethereumChain) SubmitTicket(ticket *chain.Ticket) *async.EventGroupTicketSubmissionPromise {
@@ -101,17 +159,47 @@ func (ec *ethereumChain) SubmitTicket(ticket *chain.Ticket) *async.EventGroupTic
 
 	ticketBytes := ec.packTicket(ticket)
 
-	_, err := ec.keepRandomBeaconOperatorContract.SubmitTicket(
-		ticketBytes,
-		ethutil.TransactionOptions{
-			GasLimit: 250000,
+	gasPrice, err := ec.gasPrice()
+	if err != nil {
+		logger.Warningf("skipping ticket submission: [%v]", err)
+		failPromise(err)
+		return submittedTicketPromise
+	}
+
+	transactionOutcome, err := ec.submitTransaction(
+		func() (*types.Transaction, error) {
+			return ec.keepRandomBeaconOperatorContract.SubmitTicket(
+				ticketBytes,
+				ethutil.TransactionOptions{
+					GasLimit: ec.config.Gas.Budgets.ticketSubmission(),
+					GasPrice: gasPrice,
+				},
+			)
 		},
 	)
 	if err != nil {
 		failPromise(err)
+		return submittedTicketPromise
 	}
 
-	// TODO: fulfill when submitted
+	go func() {
+		outcome := <-transactionOutcome
+		if outcome.err != nil {
+			failPromise(outcome.err)
+			return
+		}
+
+		err := submittedTicketPromise.Fulfill(&event.GroupTicketSubmission{
+			TicketValue: new(big.Int).SetBytes(ticket.Value[:]),
+			BlockNumber: outcome.receipt.BlockNumber.Uint64(),
+		})
+		if err != nil {
+			logger.Errorf(
+				"failed to fulfill promise: [%v]",
+				err,
+			)
+		}
+	}()
 
 	return submittedTicketPromise
 }
@@ -129,13 +217,27 @@ func (ec *ethereumChain) packTicket(ticket *relaychain.Ticket) [32]uint8 {
 }
 
 func (ec *ethereumChain) GetSubmittedTickets() ([]uint64, error) {
-	return ec.keepRandomBeaconOperatorContract.SubmittedTickets()
+	var tickets []uint64
+	err := ec.withRetry(
+		context.Background(),
+		ticketsCallType,
+		func(ctx context.Context) (err error) {
+			tickets, err = ec.keepRandomBeaconOperatorCaller.SubmittedTickets(
+				callOptions(ctx),
+			)
+			return err
+		},
+	)
+
+	return tickets, err
 }
 
 func (ec *ethereumChain) GetSelectedParticipants() ([]chain.StakerAddress, error) {
 	var stakerAddresses []chain.StakerAddress
-	fetchParticipants := func() error {
-		participants, err := ec.keepRandomBeaconOperatorContract.SelectedParticipants()
+	fetchParticipants := func(ctx context.Context) error {
+		participants, err := ec.keepRandomBeaconOperatorCaller.SelectedParticipants(
+			callOptions(ctx),
+		)
 		if err != nil {
 			return err
 		}
@@ -148,38 +250,113 @@ func (ec *ethereumChain) GetSelectedParticipants() ([]chain.StakerAddress, error
 		return nil
 	}
 
-	// The reason behind a retry functionality is Infura's load balancer synchronization
-	// problem. Whenever a Keep client is connected to Infura, it might experience
-	// a slight delay with block updates between ethereum clients. One or more
-	// clients might stay behind and report a block number 'n-1', whereas the
-	// actual block number is already 'n'. This delay results in error triggering
-	// a new group selection. To mitigate Infura's sync issue, a Keep client will
-	// retry calling for selected participants up to 4 times.
-	// Synchronization issue can occur on any setup where we have more than one
-	// Ethereum clients behind a load balancer.
-	if err := ec.withRetry(fetchParticipants); err != nil {
+	// Reverts of this call are retried; see the default retry policy of
+	// the selected participants call type.
+	err := ec.withRetry(
+		context.Background(),
+		selectedParticipantsCallType,
+		fetchParticipants,
+	)
+	if err != nil {
 		return nil, err
 	}
 
 	return stakerAddresses, nil
 }
 
-func (ec *ethereumChain) withRetry(fn func() error) error {
-	const numberOfRetries = 10
-	const delay = time.Second
+// GetTicketSubmissionEconomics returns the cost of submitting a single ticket
+// at the current gas price and with the configured ticket submission gas
+// limit, and the reward expected for a single seat in the group. The base
+// reward is what a member earns for a single relay entry signed by the group;
+// the expected reward is the base reward for the configured number of entries
+// the group is expected to sign, one by default.
+func (ec *ethereumChain) GetTicketSubmissionEconomics() (
+	*relaychain.TicketSubmissionEconomics,
+	error,
+) {
+	gasPrice, err := ec.gasPrice()
+	if err != nil {
+		return nil, err
+	}
 
-	for i := 1; ; i++ {
-		err := fn()
-		if err != nil {
-			logger.Errorf("Error occurred [%v]; on [%v] retry", err, i)
-			if i == numberOfRetries {
-				return err
-			}
-			time.Sleep(delay)
-		} else {
-			return nil
-		}
+	var baseReward *big.Int
+	err = ec.withRetry(
+		context.Background(),
+		configCallType,
+		func(ctx context.Context) (err error) {
+			baseReward, err =
+				ec.keepRandomBeaconOperatorCaller.GroupMemberBaseReward(
+					callOptions(ctx),
+				)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get group member base reward: [%v]",
+			err,
+		)
 	}
+
+	return ec.config.Gas.ticketSubmissionEconomics(gasPrice, baseReward), nil
+}
+
+// withRetry executes the given read call and retries it according to the
+// retry policy of the given call type. The call is given a context derived
+// from the given one, done when the timeout of the policy is over, and should
+// bind its requests to it.
+func (ec *ethereumChain) withRetry(
+	ctx context.Context,
+	callType string,
+	call func(ctx context.Context) error,
+) error {
+	policy := ec.retryPolicies[callType]
+
+	ctx, cancel := context.WithTimeout(ctx, policy.timeout)
+	defer cancel()
+
+	return policy.do(ctx, callType, call)
+}
+
+// callOptions returns options of read calls bound to the given context.
+func callOptions(ctx context.Context) *bind.CallOpts {
+	return &bind.CallOpts{Context: ctx}
+}
+
+// estimateOperatorGas estimates the gas of a transaction calling the given
+// method of the operator contract with the given parameters. The transaction
+// is estimated as sent from the operator account because the operator
+// contract checks the sender, e.g. the DKG result submitter has to be
+// a member of the group.
+func (ec *ethereumChain) estimateOperatorGas(
+	ctx context.Context,
+	method string,
+	parameters ...interface{},
+) (uint64, error) {
+	input, err := ec.keepRandomBeaconOperatorABI.Pack(method, parameters...)
+	if err != nil {
+		return 0, err
+	}
+
+	return ec.client.EstimateGas(ctx, goethereum.CallMsg{
+		From: ec.signer.address(),
+		To:   &ec.keepRandomBeaconOperatorAddress,
+		Data: input,
+	})
+}
+
+// submitTransaction submits a transaction using the provided function and
+// hands it over to the transaction manager. The returned channel receives
+// the transaction outcome once the transaction is mined or abandoned.
+func (ec *ethereumChain) submitTransaction(
+	submitFn func() (*types.Transaction, error),
+) (<-chan transactionOutcome, error) {
+	currentBlock, err := ec.blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("could not get current block: [%v]", err)
+	}
+
+	return ec.transactionManager.submit(currentBlock, submitFn)
 }
 
 func (ec *ethereumChain) SubmitRelayEntry(
@@ -198,32 +375,78 @@ func (ec *ethereumChain) SubmitRelayEntry(
 		}
 	}
 
-	generatedEntry := make(chan *event.EntrySubmitted)
+	// Do not pay for a transaction which is going to revert. If other member
+	// has already submitted the entry, the promise is resolved with the
+	// existing submission.
+	existingEntry, err := ec.checkRelayEntrySubmission(entry)
+	if err != nil {
+		failPromise(err)
+		return relayEntryPromise
+	}
+	if existingEntry != nil {
+		logger.Infof(
+			"relay entry has already been submitted at block [%v]; "+
+				"skipping submission",
+			existingEntry.BlockNumber,
+		)
+		if err := relayEntryPromise.Fulfill(existingEntry); err != nil {
+			logger.Errorf(
+				"failed to fulfill promise: [%v]",
+				err,
+			)
+		}
+		return relayEntryPromise
+	}
+
+	transactionOptions, err := ec.relayEntryTransactionOptions(entry)
+	if err != nil {
+		logger.Warningf("skipping relay entry submission: [%v]", err)
+		failPromise(err)
+		return relayEntryPromise
+	}
+
+	// The channel is buffered and the handler never blocks so that
+	// unsubscribing is always possible, even if an event arrives after the
+	// promise has been completed.
+	generatedEntry := make(chan *event.EntrySubmitted, 1)
 
 	subscription, err := ec.OnRelayEntrySubmitted(
 		func(onChainEvent *event.EntrySubmitted) {
-			generatedEntry <- onChainEvent
+			select {
+			case generatedEntry <- onChainEvent:
+			default:
+			}
 		},
 	)
 	if err != nil {
-		close(generatedEntry)
+		failPromise(err)
+		return relayEntryPromise
+	}
+
+	transactionOutcome, err := ec.submitTransaction(
+		func() (*types.Transaction, error) {
+			return ec.keepRandomBeaconOperatorContract.RelayEntry(
+				entry,
+				transactionOptions,
+			)
+		},
+	)
+	if err != nil {
+		subscription.Unsubscribe()
 		failPromise(err)
 		return relayEntryPromise
 	}
 
 	go func() {
-		for {
-			select {
-			case event, success := <-generatedEntry:
-				// Channel is closed when SubmitRelayEntry failed.
-				// When this happens, event is nil.
-				if !success {
-					return
-				}
+		defer subscription.Unsubscribe()
 
-				subscription.Unsubscribe()
-				close(generatedEntry)
+		var minedTransaction common.Hash
+		var deadlineBlock uint64
+		var eventTimeout <-chan uint64
 
+		for {
+			select {
+			case event := <-generatedEntry:
 				err := relayEntryPromise.Fulfill(event)
 				if err != nil {
 					logger.Errorf(
@@ -232,131 +455,294 @@ func (ec *ethereumChain) SubmitRelayEntry(
 					)
 				}
 
+				return
+			case outcome := <-transactionOutcome:
+				// Successfully mined transaction emits the event the promise
+				// is waiting for. Only a failed or abandoned transaction
+				// completes the promise here.
+				if outcome.err == nil {
+					transactionOutcome = nil
+					minedTransaction = outcome.receipt.TxHash
+					deadline, waiter, err := ec.submittedEventDeadline(
+						outcome.receipt,
+					)
+					if err != nil {
+						failPromise(err)
+						return
+					}
+					deadlineBlock, eventTimeout = deadline, waiter
+					continue
+				}
+
+				failPromise(outcome.err)
+				return
+			case <-eventTimeout:
+				failPromise(fmt.Errorf(
+					"relay entry submitted event of mined transaction [%v] "+
+						"not received until block [%v]",
+					minedTransaction.Hex(),
+					deadlineBlock,
+				))
 				return
 			}
 		}
 	}()
 
-	gasEstimate, err := ec.keepRandomBeaconOperatorContract.RelayEntryGasEstimate(entry)
-	if err != nil {
-		logger.Errorf("failed to estimate gas [%v]", err)
-	}
-
-	gasEstimateWithMargin := float64(gasEstimate) * float64(1.2) // 20% more than original
-	_, err = ec.keepRandomBeaconOperatorContract.RelayEntry(
-		entry,
-		ethutil.TransactionOptions{
-			GasLimit: uint64(gasEstimateWithMargin),
-		},
-	)
-	if err != nil {
-		subscription.Unsubscribe()
-		close(generatedEntry)
-		failPromise(err)
-	}
-
 	return relayEntryPromise
 }
 
 func (ec *ethereumChain) OnRelayEntrySubmitted(
 	handle func(entry *event.EntrySubmitted),
 ) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchRelayEntrySubmitted(
-		func(blockNumber uint64) {
-			handle(&event.EntrySubmitted{
-				BlockNumber: blockNumber,
-			})
-		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch relay entry generated failed with [%v]",
-				err,
-			)
+	return ec.watchOperatorEvent(
+		"RelayEntrySubmitted",
+		func(log types.Log) error {
+			_, err := ec.keepRandomBeaconOperatorFilterer.ParseRelayEntrySubmitted(log)
+			if err != nil {
+				return err
+			}
+
+			handle(ec.relayEntrySubmission(context.Background(), log))
+			return nil
 		},
+		nil,
 	)
 }
 
 func (ec *ethereumChain) OnRelayEntryRequested(
 	handle func(request *event.Request),
 ) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchRelayEntryRequested(
-		func(
-			previousEntry []byte,
-			groupPublicKey []byte,
-			blockNumber uint64,
-		) {
-			handle(&event.Request{
-				PreviousEntry:  previousEntry,
-				GroupPublicKey: groupPublicKey,
-				BlockNumber:    blockNumber,
-			})
-		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch relay entry requested failed with [%v]",
-				err,
-			)
-		},
+	return ec.watchOperatorEvent(
+		"RelayEntryRequested",
+		ec.relayEntryRequestedHandler(handle),
+		nil,
+	)
+}
+
+func (ec *ethereumChain) OnRelayEntryRequestRemoved(
+	handle func(request *event.Request),
+) (subscription.EventSubscription, error) {
+	return ec.watchOperatorEvent(
+		"RelayEntryRequested",
+		ignoreLog,
+		ec.relayEntryRequestedHandler(handle),
 	)
 }
 
+func (ec *ethereumChain) relayEntryRequestedHandler(
+	handle func(request *event.Request),
+) func(log types.Log) error {
+	return func(log types.Log) error {
+		parsed, err := ec.keepRandomBeaconOperatorFilterer.ParseRelayEntryRequested(log)
+		if err != nil {
+			return err
+		}
+
+		handle(&event.Request{
+			PreviousEntry:  parsed.PreviousEntry,
+			GroupPublicKey: parsed.GroupPublicKey,
+			BlockNumber:    log.BlockNumber,
+		})
+		return nil
+	}
+}
+
 func (ec *ethereumChain) OnGroupSelectionStarted(
 	handle func(groupSelectionStart *event.GroupSelectionStart),
 ) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchGroupSelectionStarted(
-		func(
-			newEntry *big.Int,
-			blockNumber uint64,
-		) {
-			handle(&event.GroupSelectionStart{
-				NewEntry:    newEntry,
-				BlockNumber: blockNumber,
-			})
-		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch group selection started failed with [%v]",
-				err,
-			)
-		},
+	return ec.watchOperatorEvent(
+		"GroupSelectionStarted",
+		ec.groupSelectionStartedHandler(handle),
+		nil,
+	)
+}
+
+func (ec *ethereumChain) OnGroupSelectionStartRemoved(
+	handle func(groupSelectionStart *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	return ec.watchOperatorEvent(
+		"GroupSelectionStarted",
+		ignoreLog,
+		ec.groupSelectionStartedHandler(handle),
 	)
 }
 
+func (ec *ethereumChain) groupSelectionStartedHandler(
+	handle func(groupSelectionStart *event.GroupSelectionStart),
+) func(log types.Log) error {
+	return func(log types.Log) error {
+		parsed, err := ec.keepRandomBeaconOperatorFilterer.ParseGroupSelectionStarted(log)
+		if err != nil {
+			return err
+		}
+
+		handle(&event.GroupSelectionStart{
+			NewEntry:    parsed.NewEntry,
+			BlockNumber: log.BlockNumber,
+		})
+		return nil
+	}
+}
+
 func (ec *ethereumChain) OnGroupRegistered(
 	handle func(groupRegistration *event.GroupRegistration),
 ) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchDkgResultSubmittedEvent(
-		func(
-			memberIndex *big.Int,
-			groupPublicKey []byte,
-			misbehaved []byte,
-			blockNumber uint64,
-		) {
+	return ec.watchOperatorEvent(
+		"DkgResultSubmittedEvent",
+		func(log types.Log) error {
+			parsed, err := ec.keepRandomBeaconOperatorFilterer.ParseDkgResultSubmittedEvent(log)
+			if err != nil {
+				return err
+			}
+
 			handle(&event.GroupRegistration{
-				GroupPublicKey: groupPublicKey,
-				BlockNumber:    blockNumber,
+				GroupPublicKey: parsed.GroupPubKey,
+				BlockNumber:    log.BlockNumber,
 			})
+			return nil
 		},
-		func(err error) error {
-			return fmt.Errorf("entry of group key failed with: [%v]", err)
+		nil,
+	)
+}
+
+// watchOperatorEvent watches the KeepRandomBeaconOperator contract for the
+// event with the given name, starting from the current block. Each log of
+// the event is passed to the handler exactly once, after it is confirmed by
+// the configured number of blocks, including logs emitted while the
+// subscription was down, which are backfilled after resubscribing. Confirmed
+// logs later removed from the chain are passed to the removal handler, if it
+// is not nil.
+func (ec *ethereumChain) watchOperatorEvent(
+	eventName string,
+	handle func(log types.Log) error,
+	handleRemoved func(log types.Log) error,
+) (subscription.EventSubscription, error) {
+	return ec.watchContractEvent(
+		ec.keepRandomBeaconOperatorABI,
+		ec.keepRandomBeaconOperatorAddress,
+		eventName,
+		handle,
+		handleRemoved,
+	)
+}
+
+// watchStakingEvent watches the TokenStaking contract for the event with the
+// given name the same way watchOperatorEvent watches the operator contract.
+func (ec *ethereumChain) watchStakingEvent(
+	eventName string,
+	handle func(log types.Log) error,
+	handleRemoved func(log types.Log) error,
+) (subscription.EventSubscription, error) {
+	return ec.watchContractEvent(
+		ec.stakingABI,
+		ec.stakingAddress,
+		eventName,
+		handle,
+		handleRemoved,
+	)
+}
+
+func (ec *ethereumChain) watchContractEvent(
+	contractABI *ethereumabi.ABI,
+	contractAddress common.Address,
+	eventName string,
+	handle func(log types.Log) error,
+	handleRemoved func(log types.Log) error,
+) (subscription.EventSubscription, error) {
+	contractEvent, ok := contractABI.Events[eventName]
+	if !ok {
+		return nil, fmt.Errorf("unknown event [%v]", eventName)
+	}
+
+	logParseError := func(handle func(log types.Log) error) func(types.Log) {
+		return func(log types.Log) {
+			if err := handle(log); err != nil {
+				logger.Errorf(
+					"could not parse %v event from block [%v]: [%v]",
+					eventName,
+					log.BlockNumber,
+					err,
+				)
+			}
+		}
+	}
+
+	var removedHandler func(types.Log)
+	if handleRemoved != nil {
+		removedHandler = logParseError(handleRemoved)
+	}
+
+	return watchEvent(
+		eventName,
+		ec.client,
+		goethereum.FilterQuery{
+			Addresses: []common.Address{contractAddress},
+			Topics:    [][]common.Hash{{contractEvent.ID()}},
 		},
+		ec.blockCounter,
+		ec.config.ConfirmationDepth,
+		logParseError(handle),
+		removedHandler,
 	)
 }
 
+// ignoreLog is a log handler which does nothing. It is used for watchers
+// interested only in logs removed from the chain.
+func ignoreLog(log types.Log) error {
+	return nil
+}
+
 func (ec *ethereumChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
-	return ec.keepRandomBeaconOperatorContract.IsGroupRegistered(groupPublicKey)
+	var isRegistered bool
+	err := ec.withRetry(
+		context.Background(),
+		groupCallType,
+		func(ctx context.Context) (err error) {
+			isRegistered, err =
+				ec.keepRandomBeaconOperatorCaller.IsGroupRegistered(
+					callOptions(ctx),
+					groupPublicKey,
+				)
+			return err
+		},
+	)
+
+	return isRegistered, err
 }
 
 func (ec *ethereumChain) IsStaleGroup(groupPublicKey []byte) (bool, error) {
-	return ec.keepRandomBeaconOperatorContract.IsStaleGroup(groupPublicKey)
+	var isStale bool
+	err := ec.withRetry(
+		context.Background(),
+		groupCallType,
+		func(ctx context.Context) (err error) {
+			isStale, err =
+				ec.keepRandomBeaconOperatorCaller.IsStaleGroup(
+					callOptions(ctx),
+					groupPublicKey,
+				)
+			return err
+		},
+	)
+
+	return isStale, err
 }
 
 func (ec *ethereumChain) GetGroupMembers(groupPublicKey []byte) (
 	[]chain.StakerAddress,
 	error,
 ) {
-	members, err := ec.keepRandomBeaconOperatorContract.GetGroupMembers(
-		groupPublicKey,
+	var members []common.Address
+	err := ec.withRetry(
+		context.Background(),
+		groupCallType,
+		func(ctx context.Context) (err error) {
+			members, err = ec.keepRandomBeaconOperatorCaller.GetGroupMembers(
+				callOptions(ctx),
+				groupPublicKey,
+			)
+			return err
+		},
 	)
 	if err != nil {
 		return nil, err
@@ -373,26 +759,23 @@ func (ec *ethereumChain) GetGroupMembers(groupPublicKey []byte) (
 func (ec *ethereumChain) OnDKGResultSubmitted(
 	handler func(dkgResultPublication *event.DKGResultSubmission),
 ) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchDkgResultSubmittedEvent(
-		func(
-			memberIndex *big.Int,
-			groupPublicKey []byte,
-			misbehaved []byte,
-			blockNumber uint64,
-		) {
+	return ec.watchOperatorEvent(
+		"DkgResultSubmittedEvent",
+		func(log types.Log) error {
+			parsed, err := ec.keepRandomBeaconOperatorFilterer.ParseDkgResultSubmittedEvent(log)
+			if err != nil {
+				return err
+			}
+
 			handler(&event.DKGResultSubmission{
-				MemberIndex:    uint32(memberIndex.Uint64()),
-				GroupPublicKey: groupPublicKey,
-				Misbehaved:     misbehaved,
-				BlockNumber:    blockNumber,
+				MemberIndex:    uint32(parsed.MemberIndex.Uint64()),
+				GroupPublicKey: parsed.GroupPubKey,
+				Misbehaved:     parsed.Misbehaved,
+				BlockNumber:    log.BlockNumber,
 			})
+			return nil
 		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch DKG result published failed with: [%v]",
-				err,
-			)
-		},
+		nil,
 	)
 }
 
@@ -423,32 +806,100 @@ func (ec *ethereumChain) SubmitDKGResult(
 		}
 	}
 
-	publishedResult := make(chan *event.DKGResultSubmission)
+	membersIndicesOnChainFormat, signaturesOnChainFormat, err :=
+		convertSignaturesToChainFormat(signatures)
+	if err != nil {
+		failPromise(fmt.Errorf("converting signatures failed [%v]", err))
+		return resultPublicationPromise
+	}
+
+	// Do not pay for a transaction which is going to revert. If a result
+	// has already been submitted, the promise is resolved with the existing
+	// submission.
+	existingResult, err := ec.checkDKGResultSubmission(
+		participantIndex,
+		result,
+		signaturesOnChainFormat,
+		membersIndicesOnChainFormat,
+	)
+	if err != nil {
+		failPromise(err)
+		return resultPublicationPromise
+	}
+	if existingResult != nil {
+		logger.Infof(
+			"DKG result with public key [0x%x] has already been submitted "+
+				"at block [%v]; skipping submission",
+			existingResult.GroupPublicKey,
+			existingResult.BlockNumber,
+		)
+		if err := resultPublicationPromise.Fulfill(existingResult); err != nil {
+			logger.Errorf(
+				"failed to fulfill promise: [%v]",
+				err,
+			)
+		}
+		return resultPublicationPromise
+	}
+
+	transactionOptions, err := ec.dkgResultTransactionOptions(
+		participantIndex,
+		result,
+		signaturesOnChainFormat,
+		membersIndicesOnChainFormat,
+	)
+	if err != nil {
+		logger.Warningf("skipping DKG result submission: [%v]", err)
+		failPromise(err)
+		return resultPublicationPromise
+	}
+
+	// The channel is buffered and the handler never blocks so that
+	// unsubscribing is always possible, even if an event arrives after the
+	// promise has been completed.
+	publishedResult := make(chan *event.DKGResultSubmission, 1)
 
 	subscription, err := ec.OnDKGResultSubmitted(
 		func(onChainEvent *event.DKGResultSubmission) {
-			publishedResult <- onChainEvent
+			select {
+			case publishedResult <- onChainEvent:
+			default:
+			}
 		},
 	)
 	if err != nil {
-		close(publishedResult)
+		failPromise(err)
+		return resultPublicationPromise
+	}
+
+	transactionOutcome, err := ec.submitTransaction(
+		func() (*types.Transaction, error) {
+			return ec.keepRandomBeaconOperatorContract.SubmitDkgResult(
+				big.NewInt(int64(participantIndex)),
+				result.GroupPublicKey,
+				result.Misbehaved,
+				signaturesOnChainFormat,
+				membersIndicesOnChainFormat,
+				transactionOptions,
+			)
+		},
+	)
+	if err != nil {
+		subscription.Unsubscribe()
 		failPromise(err)
 		return resultPublicationPromise
 	}
 
 	go func() {
-		for {
-			select {
-			case event, success := <-publishedResult:
-				// Channel is closed when SubmitDKGResult failed.
-				// When this happens, event is nil.
-				if !success {
-					return
-				}
+		defer subscription.Unsubscribe()
 
-				subscription.Unsubscribe()
-				close(publishedResult)
+		var minedTransaction common.Hash
+		var deadlineBlock uint64
+		var eventTimeout <-chan uint64
 
+		for {
+			select {
+			case event := <-publishedResult:
 				err := resultPublicationPromise.Fulfill(event)
 				if err != nil {
 					logger.Errorf(
@@ -457,32 +908,64 @@ func (ec *ethereumChain) SubmitDKGResult(
 					)
 				}
 
+				return
+			case outcome := <-transactionOutcome:
+				// Successfully mined transaction emits the event the promise
+				// is waiting for. Only a failed or abandoned transaction
+				// completes the promise here.
+				if outcome.err == nil {
+					transactionOutcome = nil
+					minedTransaction = outcome.receipt.TxHash
+					deadline, waiter, err := ec.submittedEventDeadline(
+						outcome.receipt,
+					)
+					if err != nil {
+						failPromise(err)
+						return
+					}
+					deadlineBlock, eventTimeout = deadline, waiter
+					continue
+				}
+
+				failPromise(outcome.err)
+				return
+			case <-eventTimeout:
+				failPromise(fmt.Errorf(
+					"DKG result submitted event of mined transaction [%v] "+
+						"not received until block [%v]",
+					minedTransaction.Hex(),
+					deadlineBlock,
+				))
 				return
 			}
 		}
 	}()
 
-	membersIndicesOnChainFormat, signaturesOnChainFormat, err :=
-		convertSignaturesToChainFormat(signatures)
-	if err != nil {
-		close(publishedResult)
-		failPromise(fmt.Errorf("converting signatures failed [%v]", err))
-		return resultPublicationPromise
-	}
+	return resultPublicationPromise
+}
 
-	if _, err = ec.keepRandomBeaconOperatorContract.SubmitDkgResult(
-		big.NewInt(int64(participantIndex)),
-		result.GroupPublicKey,
-		result.Misbehaved,
-		signaturesOnChainFormat,
-		membersIndicesOnChainFormat,
-	); err != nil {
-		subscription.Unsubscribe()
-		close(publishedResult)
-		failPromise(err)
+// submittedEventDeadline returns the block until which a submission waits for
+// the event emitted by its transaction mined in the block of the given
+// receipt, along with a waiter notified once that block is reached. The event
+// is delivered only after it is confirmed so the deadline covers the
+// configured confirmation depth and a margin of submittedEventMarginBlocks.
+func (ec *ethereumChain) submittedEventDeadline(
+	receipt *types.Receipt,
+) (uint64, <-chan uint64, error) {
+	deadlineBlock := receipt.BlockNumber.Uint64() +
+		ec.config.ConfirmationDepth +
+		submittedEventMarginBlocks
+
+	waiter, err := ec.blockCounter.BlockHeightWaiter(deadlineBlock)
+	if err != nil {
+		return 0, nil, fmt.Errorf(
+			"could not wait for submitted event deadline block [%v]: [%v]",
+			deadlineBlock,
+			err,
+		)
 	}
 
-	return resultPublicationPromise
+	return deadlineBlock, waiter, nil
 }
 
 // convertSignaturesToChainFormat converts signatures map to two slices. First
@@ -2,13 +2,16 @@ package ethereum
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 )
@@ -211,3 +214,52 @@ func TestPackTicket(t *testing.T) {
 		})
 	}
 }
+
+func TestSubmittedEventDeadlineCoversConfirmationDepth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	counter, err := newBlockCounter(ctx, &mockHeadSource{blockNumber: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ec := &ethereumChain{
+		config:       Config{ConfirmationDepth: 30},
+		blockCounter: counter,
+	}
+
+	deadlineBlock, waiter, err := ec.submittedEventDeadline(
+		&types.Receipt{BlockNumber: big.NewInt(100)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDeadlineBlock := uint64(100 + 30 + submittedEventMarginBlocks)
+	if deadlineBlock != expectedDeadlineBlock {
+		t.Errorf(
+			"unexpected deadline block\nexpected: [%v]\nactual:   [%v]",
+			expectedDeadlineBlock,
+			deadlineBlock,
+		)
+	}
+
+	// The event of the mined transaction is delivered once it is confirmed,
+	// so the submission must still be waiting for it.
+	counter.observe(130)
+
+	select {
+	case block := <-waiter:
+		t.Fatalf("deadline reached at confirmation block [%v]", block)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	counter.observe(expectedDeadlineBlock)
+
+	select {
+	case <-waiter:
+	case <-time.After(time.Second):
+		t.Fatal("deadline has not been reached")
+	}
+}
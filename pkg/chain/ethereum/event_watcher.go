@@ -0,0 +1,455 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+const (
+	// eventResubscribeDelay is a delay which must be preserved before a new
+	// resubscription attempt. There is no sense to resubscribe immediately
+	// after the fail of current subscription because the publisher must have
+	// some time to recover.
+	eventResubscribeDelay = 5 * time.Second
+
+	// eventSubscribeTimeout is a timeout for a single subscription or
+	// backfill request.
+	eventSubscribeTimeout = 30 * time.Second
+
+	// eventLogsBuffer is the size of the buffer for logs received from a live
+	// subscription. Logs are buffered while the gap in events is backfilled.
+	eventLogsBuffer = 64
+
+	// seenLogsRetentionBlocks is the number of blocks, counting back from the
+	// current block, for which identifiers of already handled logs are kept
+	// to guarantee they are not handled again. Identifiers of logs from blocks
+	// a backfill may still fetch are kept for longer.
+	seenLogsRetentionBlocks = 128
+)
+
+// logID uniquely identifies a log in the chain.
+type logID struct {
+	blockHash common.Hash
+	index     uint
+}
+
+// eventWatcher watches the chain for logs matching the given query and
+// passes each of them to the handler exactly once. When the underlying
+// subscription fails, the watcher resubscribes and backfills the logs
+// emitted in the meantime using FilterLogs, starting from the last block it
+// processed or from the block of the earliest log it holds, whichever is
+// lower.
+//
+// Logs are held by the watcher until the configured number of blocks is
+// mined on top of the block containing them. Held logs removed from the
+// chain as a result of a chain reorganization are discarded. If a log is
+// removed after it has been already passed to the handler, it is passed to
+// the removal handler so that the work started from it can be cancelled.
+type eventWatcher struct {
+	name              string
+	filterer          bind.ContractFilterer
+	query             goethereum.FilterQuery
+	blockCounter      chain.BlockCounter
+	confirmationDepth uint64
+	handle            func(types.Log)
+	handleRemoved     func(types.Log)
+
+	// resubscribeDelay is the delay between subsequent resubscription
+	// attempts; it is a field so that tests can shorten it.
+	resubscribeDelay time.Duration
+
+	// Fields below are accessed only by the watcher goroutine.
+	lastProcessedBlock uint64
+	heldLogs           map[logID]types.Log
+	deliveredLogs      map[logID]uint64
+}
+
+// watchEvent starts watching logs matching the given query and returns a
+// subscription which stops the watcher. Logs emitted in blocks not confirmed
+// yet at the current block, or later, are passed to the handler once they are
+// confirmed by the given number of blocks. Confirmed logs which are later
+// removed from the chain are passed to the removal handler, if it is not nil.
+func watchEvent(
+	name string,
+	filterer bind.ContractFilterer,
+	query goethereum.FilterQuery,
+	blockCounter chain.BlockCounter,
+	confirmationDepth uint64,
+	handle func(types.Log),
+	handleRemoved func(types.Log),
+) (subscription.EventSubscription, error) {
+	startBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("could not get current block: [%v]", err)
+	}
+
+	// Logs from blocks which are not confirmed yet are backfilled when the
+	// watcher starts; logs from confirmed blocks are not passed to the
+	// handler.
+	var firstUnconfirmedBlock uint64
+	if startBlock >= confirmationDepth {
+		firstUnconfirmedBlock = startBlock - confirmationDepth + 1
+	}
+
+	watcher := &eventWatcher{
+		name:               name,
+		filterer:           filterer,
+		query:              query,
+		blockCounter:       blockCounter,
+		confirmationDepth:  confirmationDepth,
+		handle:             handle,
+		handleRemoved:      handleRemoved,
+		resubscribeDelay:   eventResubscribeDelay,
+		lastProcessedBlock: firstUnconfirmedBlock,
+		heldLogs:           make(map[logID]types.Log),
+		deliveredLogs:      make(map[logID]uint64),
+	}
+
+	return watcher.start()
+}
+
+func (ew *eventWatcher) start() (subscription.EventSubscription, error) {
+	logs, liveSubscription, err := ew.subscribe()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error creating watch for %v events: [%v]",
+			ew.name,
+			err,
+		)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	blocks := ew.blockCounter.WatchBlocks(ctx)
+
+	go ew.loop(ctx, blocks, logs, liveSubscription)
+
+	return subscription.NewEventSubscription(cancel), nil
+}
+
+// loop handles live logs and, whenever the live subscription fails,
+// resubscribes and backfills the missed logs. It runs until the context
+// is done.
+func (ew *eventWatcher) loop(
+	ctx context.Context,
+	blocks <-chan uint64,
+	logs <-chan types.Log,
+	liveSubscription goethereum.Subscription,
+) {
+	// Logs emitted since the last processed block, before the live
+	// subscription was created, are backfilled first.
+	if err := ew.backfill(); err != nil {
+		logger.Warningf(
+			"could not backfill %v events emitted before the watch "+
+				"started: [%v]",
+			ew.name,
+			err,
+		)
+	}
+
+	for {
+		err := ew.receive(ctx, blocks, logs, liveSubscription)
+		liveSubscription.Unsubscribe()
+		if err == nil {
+			return
+		}
+
+		logger.Warningf(
+			"subscription to %v events terminated with error [%v]; "+
+				"resubscription attempt will be performed after the retry delay",
+			ew.name,
+			err,
+		)
+
+		for {
+			select {
+			case <-time.After(ew.resubscribeDelay):
+			case <-ctx.Done():
+				return
+			}
+
+			logs, liveSubscription, err = ew.resubscribe()
+			if err == nil {
+				break
+			}
+
+			logger.Warningf(
+				"could not resubscribe to %v events: [%v]",
+				ew.name,
+				err,
+			)
+		}
+	}
+}
+
+// receive processes live logs and new blocks until the context is done, in
+// which case nil is returned, or until the subscription fails, in which case
+// the subscription error is returned.
+func (ew *eventWatcher) receive(
+	ctx context.Context,
+	blocks <-chan uint64,
+	logs <-chan types.Log,
+	liveSubscription goethereum.Subscription,
+) error {
+	for {
+		select {
+		case log := <-logs:
+			ew.process(log)
+		case blockNumber, ok := <-blocks:
+			if !ok {
+				// Block updates are no longer delivered once the context
+				// is done.
+				blocks = nil
+				continue
+			}
+			// Logs of blocks confirmed at this block have been received
+			// from the live subscription, so a backfill does not have to
+			// reach behind them even if no log has been emitted for long.
+			if blockNumber >= ew.confirmationDepth {
+				ew.markProcessed(blockNumber - ew.confirmationDepth)
+			}
+			ew.releaseConfirmed(blockNumber)
+		case err := <-liveSubscription.Err():
+			if err == nil {
+				err = fmt.Errorf("subscription closed")
+			}
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// resubscribe creates a new live subscription and then backfills all the
+// logs emitted since the last processed block. The live subscription is
+// created first so that no log is lost between the backfill and the moment
+// the subscription becomes active; logs returned by both are deduplicated.
+func (ew *eventWatcher) resubscribe() (
+	<-chan types.Log,
+	goethereum.Subscription,
+	error,
+) {
+	logs, liveSubscription, err := ew.subscribe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ew.backfill(); err != nil {
+		liveSubscription.Unsubscribe()
+		return nil, nil, fmt.Errorf("could not backfill events: [%v]", err)
+	}
+
+	return logs, liveSubscription, nil
+}
+
+func (ew *eventWatcher) subscribe() (
+	<-chan types.Log,
+	goethereum.Subscription,
+	error,
+) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		eventSubscribeTimeout,
+	)
+	defer cancel()
+
+	logs := make(chan types.Log, eventLogsBuffer)
+	liveSubscription, err := ew.filterer.SubscribeFilterLogs(
+		ctx,
+		ew.query,
+		logs,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return logs, liveSubscription, nil
+}
+
+// backfill fetches all the logs emitted between the first block to
+// backfill and the current block and processes the ones not seen yet. Held
+// logs which are no longer returned by the chain have been removed while the
+// subscription was down and are discarded.
+func (ew *eventWatcher) backfill() error {
+	currentBlock, err := ew.blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("could not get current block: [%v]", err)
+	}
+
+	fromBlock := ew.firstBlockToBackfill()
+	if currentBlock < fromBlock {
+		return nil
+	}
+
+	query := ew.query
+	query.FromBlock = new(big.Int).SetUint64(fromBlock)
+	query.ToBlock = new(big.Int).SetUint64(currentBlock)
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		eventSubscribeTimeout,
+	)
+	defer cancel()
+
+	logs, err := ew.filterer.FilterLogs(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(
+		"backfilled [%v] %v events from blocks [%v-%v]",
+		len(logs),
+		ew.name,
+		fromBlock,
+		currentBlock,
+	)
+
+	canonical := make(map[logID]bool)
+	for _, log := range logs {
+		canonical[logID{log.BlockHash, log.Index}] = true
+	}
+	for id, log := range ew.heldLogs {
+		if !canonical[id] {
+			log.Removed = true
+			ew.process(log)
+		}
+	}
+
+	for _, log := range logs {
+		ew.process(log)
+	}
+
+	ew.markProcessed(currentBlock)
+	ew.releaseConfirmed(currentBlock)
+
+	return nil
+}
+
+// process holds a new log until it is confirmed or, if the log has been
+// removed from the chain, discards it.
+func (ew *eventWatcher) process(log types.Log) {
+	id := logID{log.BlockHash, log.Index}
+
+	if log.Removed {
+		ew.remove(id, log)
+		return
+	}
+
+	if _, held := ew.heldLogs[id]; held {
+		return
+	}
+	if _, delivered := ew.deliveredLogs[id]; delivered {
+		return
+	}
+
+	ew.heldLogs[id] = log
+	ew.markProcessed(log.BlockNumber)
+
+	currentBlock, err := ew.blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Warningf("could not get current block: [%v]", err)
+		return
+	}
+	ew.releaseConfirmed(currentBlock)
+}
+
+// remove discards a log removed from the chain. If the log has been already
+// passed to the handler, it is passed to the removal handler.
+func (ew *eventWatcher) remove(id logID, log types.Log) {
+	if _, held := ew.heldLogs[id]; held {
+		delete(ew.heldLogs, id)
+
+		logger.Infof(
+			"discarding %v event from block [%v] removed from the chain "+
+				"before it has been confirmed",
+			ew.name,
+			log.BlockNumber,
+		)
+		return
+	}
+
+	if _, delivered := ew.deliveredLogs[id]; delivered {
+		delete(ew.deliveredLogs, id)
+
+		logger.Warningf(
+			"%v event from block [%v] removed from the chain "+
+				"after it has been confirmed",
+			ew.name,
+			log.BlockNumber,
+		)
+
+		if ew.handleRemoved != nil {
+			ew.handleRemoved(log)
+		}
+	}
+}
+
+// releaseConfirmed passes all the held logs confirmed at the given block to
+// the handler, in the order they were emitted.
+func (ew *eventWatcher) releaseConfirmed(currentBlock uint64) {
+	confirmed := make([]types.Log, 0)
+	for _, log := range ew.heldLogs {
+		if log.BlockNumber+ew.confirmationDepth <= currentBlock {
+			confirmed = append(confirmed, log)
+		}
+	}
+
+	sort.Slice(confirmed, func(i, j int) bool {
+		if confirmed[i].BlockNumber != confirmed[j].BlockNumber {
+			return confirmed[i].BlockNumber < confirmed[j].BlockNumber
+		}
+		return confirmed[i].Index < confirmed[j].Index
+	})
+
+	for _, log := range confirmed {
+		id := logID{log.BlockHash, log.Index}
+		delete(ew.heldLogs, id)
+		ew.deliveredLogs[id] = log.BlockNumber
+
+		ew.handle(log)
+	}
+
+	if currentBlock < seenLogsRetentionBlocks {
+		return
+	}
+	firstBlockToBackfill := ew.firstBlockToBackfill()
+	for id, logBlock := range ew.deliveredLogs {
+		if logBlock < currentBlock-seenLogsRetentionBlocks &&
+			logBlock < firstBlockToBackfill {
+			delete(ew.deliveredLogs, id)
+		}
+	}
+}
+
+// firstBlockToBackfill returns the block from which a backfill fetches logs.
+// It is the last processed block or, if it is lower, the block of the
+// earliest held log. Held logs are not confirmed yet and have to be checked
+// again as they may have been removed from the chain while the subscription
+// was down.
+func (ew *eventWatcher) firstBlockToBackfill() uint64 {
+	firstBlock := ew.lastProcessedBlock
+	for _, log := range ew.heldLogs {
+		if log.BlockNumber < firstBlock {
+			firstBlock = log.BlockNumber
+		}
+	}
+	return firstBlock
+}
+
+// markProcessed records the given block as processed if it is higher than
+// the last processed block.
+func (ew *eventWatcher) markProcessed(blockNumber uint64) {
+	if blockNumber > ew.lastProcessedBlock {
+		ew.lastProcessedBlock = blockNumber
+	}
+}
@@ -0,0 +1,694 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+var testEventTopic = crypto.Keccak256Hash([]byte("TestEvent(bytes)"))
+
+func TestEventWatcherBackfillsEventsMissedWhileDisconnected(t *testing.T) {
+	emitter := newTestEventEmitter(t)
+	filterer := &droppingFilterer{ContractFilterer: emitter.backend}
+
+	handled := &handledEvents{}
+	watcher := &eventWatcher{
+		name:     "TestEvent",
+		filterer: filterer,
+		query: goethereum.FilterQuery{
+			Addresses: []common.Address{emitter.contract},
+			Topics:    [][]common.Hash{{testEventTopic}},
+		},
+		blockCounter:       emitter,
+		handle:             handled.add,
+		resubscribeDelay:   10 * time.Millisecond,
+		lastProcessedBlock: emitter.currentBlockNumber(),
+		heldLogs:           make(map[logID]types.Log),
+		deliveredLogs:      make(map[logID]uint64),
+	}
+
+	eventSubscription, err := watcher.start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eventSubscription.Unsubscribe()
+
+	emitter.emit(t, 1)
+	handled.waitFor(t, 1)
+
+	// Connection drops and the client can not resubscribe for a while;
+	// events emitted in the meantime are not delivered by any subscription.
+	filterer.disconnect()
+	emitter.emit(t, 2)
+	emitter.emit(t, 3)
+	time.Sleep(50 * time.Millisecond)
+	filterer.reconnect()
+
+	handled.waitFor(t, 3)
+
+	emitter.emit(t, 4)
+	handled.waitFor(t, 4)
+
+	// Give the watcher a chance to deliver any duplicates.
+	time.Sleep(50 * time.Millisecond)
+
+	expected := []byte{1, 2, 3, 4}
+	if !reflect.DeepEqual(expected, handled.values()) {
+		t.Fatalf(
+			"unexpected handled events\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			handled.values(),
+		)
+	}
+}
+
+func TestEventWatcherBackfillsAfterQuietPeriod(t *testing.T) {
+	emitter := newTestEventEmitter(t)
+	filterer := &droppingFilterer{ContractFilterer: emitter.backend}
+
+	handled := &handledEvents{}
+	watcher := &eventWatcher{
+		name:     "TestEvent",
+		filterer: filterer,
+		query: goethereum.FilterQuery{
+			Addresses: []common.Address{emitter.contract},
+			Topics:    [][]common.Hash{{testEventTopic}},
+		},
+		blockCounter:       emitter,
+		confirmationDepth:  1,
+		handle:             handled.add,
+		resubscribeDelay:   10 * time.Millisecond,
+		lastProcessedBlock: emitter.currentBlockNumber(),
+		heldLogs:           make(map[logID]types.Log),
+		deliveredLogs:      make(map[logID]uint64),
+	}
+
+	eventSubscription, err := watcher.start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eventSubscription.Unsubscribe()
+
+	emitter.emit(t, 1)
+	emitter.mine()
+	handled.waitFor(t, 1)
+
+	// No events are emitted for longer than handled events are remembered.
+	for i := 0; i < seenLogsRetentionBlocks+10; i++ {
+		emitter.mine()
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	// Connection drops and the watcher resubscribes.
+	filterer.disconnect()
+	time.Sleep(50 * time.Millisecond)
+	filterer.reconnect()
+
+	emitter.emit(t, 2)
+	emitter.mine()
+	handled.waitFor(t, 2)
+
+	// Give the watcher a chance to deliver any duplicates.
+	time.Sleep(50 * time.Millisecond)
+
+	expected := []byte{1, 2}
+	if !reflect.DeepEqual(expected, handled.values()) {
+		t.Fatalf(
+			"unexpected handled events\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			handled.values(),
+		)
+	}
+}
+
+func TestEventWatcherDeliversUnconfirmedEventsEmittedBeforeStart(t *testing.T) {
+	emitter := newTestEventEmitter(t)
+
+	// The first event is confirmed before the watch starts and it is not
+	// delivered. The second one is not confirmed yet.
+	emitter.emit(t, 1)
+	emitter.mine()
+	emitter.mine()
+	emitter.emit(t, 2)
+
+	handled := &handledEvents{}
+	eventSubscription, err := watchEvent(
+		"TestEvent",
+		emitter.backend,
+		goethereum.FilterQuery{
+			Addresses: []common.Address{emitter.contract},
+			Topics:    [][]common.Hash{{testEventTopic}},
+		},
+		emitter,
+		2,
+		handled.add,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eventSubscription.Unsubscribe()
+
+	emitter.mine()
+	emitter.mine()
+	handled.waitFor(t, 1)
+
+	// Give the watcher a chance to deliver any other events.
+	time.Sleep(50 * time.Millisecond)
+
+	expected := []byte{2}
+	if !reflect.DeepEqual(expected, handled.values()) {
+		t.Fatalf(
+			"unexpected handled events\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			handled.values(),
+		)
+	}
+}
+
+func TestEventWatcherUnsubscribe(t *testing.T) {
+	emitter := newTestEventEmitter(t)
+
+	handled := &handledEvents{}
+	eventSubscription, err := watchEvent(
+		"TestEvent",
+		emitter.backend,
+		goethereum.FilterQuery{
+			Addresses: []common.Address{emitter.contract},
+			Topics:    [][]common.Hash{{testEventTopic}},
+		},
+		emitter,
+		0,
+		handled.add,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emitter.emit(t, 1)
+	handled.waitFor(t, 1)
+
+	eventSubscription.Unsubscribe()
+	time.Sleep(50 * time.Millisecond)
+
+	emitter.emit(t, 2)
+	time.Sleep(50 * time.Millisecond)
+
+	expected := []byte{1}
+	if !reflect.DeepEqual(expected, handled.values()) {
+		t.Fatalf(
+			"unexpected handled events\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			handled.values(),
+		)
+	}
+}
+
+func TestEventWatcherWaitsForConfirmations(t *testing.T) {
+	emitter := newTestEventEmitter(t)
+
+	handled := &handledEvents{}
+	eventSubscription, err := watchEvent(
+		"TestEvent",
+		emitter.backend,
+		goethereum.FilterQuery{
+			Addresses: []common.Address{emitter.contract},
+			Topics:    [][]common.Hash{{testEventTopic}},
+		},
+		emitter,
+		2,
+		handled.add,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eventSubscription.Unsubscribe()
+
+	emitter.emit(t, 1)
+	emitter.mine()
+	time.Sleep(50 * time.Millisecond)
+
+	if len(handled.values()) != 0 {
+		t.Fatalf(
+			"event handled before it has been confirmed: [%v]",
+			handled.values(),
+		)
+	}
+
+	emitter.mine()
+	handled.waitFor(t, 1)
+
+	expected := []byte{1}
+	if !reflect.DeepEqual(expected, handled.values()) {
+		t.Fatalf(
+			"unexpected handled events\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			handled.values(),
+		)
+	}
+}
+
+func TestEventWatcherRemovedEvents(t *testing.T) {
+	var tests = map[string]struct {
+		removedAtBlock  uint64
+		expectedHandled []byte
+		expectedRemoved []byte
+	}{
+		"removed before confirmation": {
+			removedAtBlock:  11,
+			expectedHandled: []byte{},
+			expectedRemoved: []byte{},
+		},
+		"removed after confirmation": {
+			removedAtBlock:  13,
+			expectedHandled: []byte{1},
+			expectedRemoved: []byte{1},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			blockCounter := &fixedBlockCounter{block: 10}
+			handled := &handledEvents{events: []byte{}}
+			removed := &handledEvents{events: []byte{}}
+
+			watcher := &eventWatcher{
+				name:              "TestEvent",
+				blockCounter:      blockCounter,
+				confirmationDepth: 2,
+				handle:            handled.add,
+				handleRemoved:     removed.add,
+				heldLogs:          make(map[logID]types.Log),
+				deliveredLogs:     make(map[logID]uint64),
+			}
+
+			log := types.Log{
+				BlockNumber: 10,
+				BlockHash:   common.HexToHash("0x01"),
+				Data:        []byte{1},
+			}
+			watcher.process(log)
+
+			for block := uint64(11); block <= test.removedAtBlock; block++ {
+				blockCounter.block = block
+				watcher.releaseConfirmed(block)
+			}
+
+			log.Removed = true
+			watcher.process(log)
+
+			if !reflect.DeepEqual(test.expectedHandled, handled.values()) {
+				t.Errorf(
+					"unexpected handled events\nexpected: [%v]\nactual:   [%v]",
+					test.expectedHandled,
+					handled.values(),
+				)
+			}
+			if !reflect.DeepEqual(test.expectedRemoved, removed.values()) {
+				t.Errorf(
+					"unexpected removed events\nexpected: [%v]\nactual:   [%v]",
+					test.expectedRemoved,
+					removed.values(),
+				)
+			}
+		})
+	}
+}
+
+func TestEventWatcherDiscardsEventsRemovedWhileDisconnected(t *testing.T) {
+	blockCounter := &fixedBlockCounter{block: 11}
+	filterer := &staticFilterer{}
+	handled := &handledEvents{events: []byte{}}
+	removed := &handledEvents{events: []byte{}}
+
+	watcher := &eventWatcher{
+		name:               "TestEvent",
+		filterer:           filterer,
+		blockCounter:       blockCounter,
+		confirmationDepth:  3,
+		handle:             handled.add,
+		handleRemoved:      removed.add,
+		lastProcessedBlock: 10,
+		heldLogs:           make(map[logID]types.Log),
+		deliveredLogs:      make(map[logID]uint64),
+	}
+
+	removedLog := types.Log{
+		BlockNumber: 10,
+		BlockHash:   common.HexToHash("0x0a"),
+		Data:        []byte{1},
+	}
+	remainingLog := types.Log{
+		BlockNumber: 11,
+		BlockHash:   common.HexToHash("0x0b"),
+		Data:        []byte{2},
+	}
+
+	// Both logs are received from the live subscription and held until they
+	// are confirmed.
+	watcher.process(removedLog)
+	watcher.process(remainingLog)
+
+	// The subscription is down while the block of the first log is reorged
+	// out of the chain and the block of the first log gets confirmed.
+	filterer.logs = []types.Log{remainingLog}
+	blockCounter.block = 13
+
+	if err := watcher.backfill(); err != nil {
+		t.Fatal(err)
+	}
+
+	blockCounter.block = 14
+	watcher.releaseConfirmed(14)
+
+	expectedHandled := []byte{2}
+	if !reflect.DeepEqual(expectedHandled, handled.values()) {
+		t.Errorf(
+			"unexpected handled events\nexpected: [%v]\nactual:   [%v]",
+			expectedHandled,
+			handled.values(),
+		)
+	}
+	expectedRemoved := []byte{}
+	if !reflect.DeepEqual(expectedRemoved, removed.values()) {
+		t.Errorf(
+			"unexpected removed events\nexpected: [%v]\nactual:   [%v]",
+			expectedRemoved,
+			removed.values(),
+		)
+	}
+}
+
+type handledEvents struct {
+	mutex  sync.Mutex
+	events []byte
+}
+
+func (he *handledEvents) add(log types.Log) {
+	he.mutex.Lock()
+	defer he.mutex.Unlock()
+
+	he.events = append(he.events, log.Data...)
+}
+
+func (he *handledEvents) values() []byte {
+	he.mutex.Lock()
+	defer he.mutex.Unlock()
+
+	return append([]byte{}, he.events...)
+}
+
+func (he *handledEvents) waitFor(t *testing.T, count int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if len(he.values()) >= count {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf(
+		"timed out waiting for [%v] events; handled: [%v]",
+		count,
+		he.values(),
+	)
+}
+
+// testEventEmitter is a simulated chain with a deployed contract which emits
+// a TestEvent log with the call data as the log data on every call. It also
+// serves as a block counter of the simulated chain.
+type testEventEmitter struct {
+	backend  *backends.SimulatedBackend
+	key      *ecdsa.PrivateKey
+	contract common.Address
+
+	mutex         sync.Mutex
+	blockWatchers []chan uint64
+}
+
+func newTestEventEmitter(t *testing.T) *testEventEmitter {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := backends.NewSimulatedBackend(
+		core.GenesisAlloc{
+			address: {Balance: big.NewInt(1000000000000000000)},
+		},
+		8000000,
+	)
+
+	// CALLDATACOPY the call data to memory and LOG1 it with the test topic.
+	runtime := []byte{0x36, 0x60, 0x00, 0x60, 0x00, 0x37, 0x7f}
+	runtime = append(runtime, testEventTopic.Bytes()...)
+	runtime = append(runtime, 0x36, 0x60, 0x00, 0xa1, 0x00)
+
+	// CODECOPY the runtime code placed after the init code and RETURN it.
+	initCode := []byte{
+		0x60, byte(len(runtime)), 0x80, 0x60, 0x0b, 0x60, 0x00, 0x39,
+		0x60, 0x00, 0xf3,
+	}
+
+	deployment, err := types.SignTx(
+		types.NewContractCreation(
+			0,
+			big.NewInt(0),
+			1000000,
+			big.NewInt(1),
+			append(initCode, runtime...),
+		),
+		types.HomesteadSigner{},
+		key,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := backend.SendTransaction(context.Background(), deployment); err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+
+	receipt, err := backend.TransactionReceipt(
+		context.Background(),
+		deployment.Hash(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testEventEmitter{
+		backend:  backend,
+		key:      key,
+		contract: receipt.ContractAddress,
+	}
+}
+
+func (tee *testEventEmitter) emit(t *testing.T, value byte) {
+	from := crypto.PubkeyToAddress(tee.key.PublicKey)
+	nonce, err := tee.backend.PendingNonceAt(context.Background(), from)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transaction, err := types.SignTx(
+		types.NewTransaction(
+			nonce,
+			tee.contract,
+			big.NewInt(0),
+			100000,
+			big.NewInt(1),
+			[]byte{value},
+		),
+		types.HomesteadSigner{},
+		tee.key,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tee.backend.SendTransaction(context.Background(), transaction); err != nil {
+		t.Fatal(err)
+	}
+	tee.mine()
+}
+
+// mine commits the pending block and notifies block watchers about it.
+func (tee *testEventEmitter) mine() {
+	tee.backend.Commit()
+
+	tee.mutex.Lock()
+	defer tee.mutex.Unlock()
+
+	for _, watcher := range tee.blockWatchers {
+		select {
+		case watcher <- tee.currentBlockNumber():
+		default:
+		}
+	}
+}
+
+func (tee *testEventEmitter) currentBlockNumber() uint64 {
+	return tee.backend.Blockchain().CurrentBlock().NumberU64()
+}
+
+func (tee *testEventEmitter) CurrentBlock() (uint64, error) {
+	return tee.currentBlockNumber(), nil
+}
+
+func (tee *testEventEmitter) WatchBlocks(ctx context.Context) <-chan uint64 {
+	tee.mutex.Lock()
+	defer tee.mutex.Unlock()
+
+	watcher := make(chan uint64, eventLogsBuffer)
+	tee.blockWatchers = append(tee.blockWatchers, watcher)
+
+	return watcher
+}
+
+func (tee *testEventEmitter) WaitForBlockHeight(blockNumber uint64) error {
+	panic("not implemented")
+}
+
+func (tee *testEventEmitter) BlockHeightWaiter(
+	blockNumber uint64,
+) (<-chan uint64, error) {
+	panic("not implemented")
+}
+
+// fixedBlockCounter is a block counter reporting the block set by the test.
+type fixedBlockCounter struct {
+	chain.BlockCounter
+
+	block uint64
+}
+
+func (fbc *fixedBlockCounter) CurrentBlock() (uint64, error) {
+	return fbc.block, nil
+}
+
+// staticFilterer is a filterer returning the logs set by the test.
+type staticFilterer struct {
+	bind.ContractFilterer
+
+	logs []types.Log
+}
+
+func (sf *staticFilterer) FilterLogs(
+	ctx context.Context,
+	query goethereum.FilterQuery,
+) ([]types.Log, error) {
+	logs := make([]types.Log, 0)
+	for _, log := range sf.logs {
+		if log.BlockNumber >= query.FromBlock.Uint64() &&
+			log.BlockNumber <= query.ToBlock.Uint64() {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+// droppingFilterer wraps a filterer and allows to simulate a connection
+// drop. When disconnected, all active subscriptions fail and no new
+// subscriptions can be created until reconnected.
+type droppingFilterer struct {
+	bind.ContractFilterer
+
+	mutex         sync.Mutex
+	disconnected  bool
+	subscriptions []*droppableSubscription
+}
+
+func (df *droppingFilterer) SubscribeFilterLogs(
+	ctx context.Context,
+	query goethereum.FilterQuery,
+	ch chan<- types.Log,
+) (goethereum.Subscription, error) {
+	df.mutex.Lock()
+	defer df.mutex.Unlock()
+
+	if df.disconnected {
+		return nil, fmt.Errorf("connection refused")
+	}
+
+	delegate, err := df.ContractFilterer.SubscribeFilterLogs(ctx, query, ch)
+	if err != nil {
+		return nil, err
+	}
+
+	droppable := &droppableSubscription{
+		Subscription: delegate,
+		errChan:      make(chan error, 1),
+	}
+	df.subscriptions = append(df.subscriptions, droppable)
+
+	return droppable, nil
+}
+
+func (df *droppingFilterer) FilterLogs(
+	ctx context.Context,
+	query goethereum.FilterQuery,
+) ([]types.Log, error) {
+	df.mutex.Lock()
+	disconnected := df.disconnected
+	df.mutex.Unlock()
+
+	if disconnected {
+		return nil, fmt.Errorf("connection refused")
+	}
+
+	return df.ContractFilterer.FilterLogs(ctx, query)
+}
+
+func (df *droppingFilterer) disconnect() {
+	df.mutex.Lock()
+	defer df.mutex.Unlock()
+
+	df.disconnected = true
+	for _, subscription := range df.subscriptions {
+		subscription.drop()
+	}
+	df.subscriptions = nil
+}
+
+func (df *droppingFilterer) reconnect() {
+	df.mutex.Lock()
+	defer df.mutex.Unlock()
+
+	df.disconnected = false
+}
+
+type droppableSubscription struct {
+	goethereum.Subscription
+
+	errChan chan error
+}
+
+func (ds *droppableSubscription) drop() {
+	ds.Subscription.Unsubscribe()
+	ds.errChan <- fmt.Errorf("connection lost")
+}
+
+func (ds *droppableSubscription) Err() <-chan error {
+	return ds.errChan
+}
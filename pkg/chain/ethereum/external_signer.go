@@ -0,0 +1,242 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// externalSignerTimeout is a timeout for a single request to the external
+// signer. External signers may be configured to ask for a manual approval of
+// requests so the timeout is generous.
+const externalSignerTimeout = 1 * time.Minute
+
+// publicKeyRecoveryMessage is the message signed by the external signer to
+// recover the operator public key.
+var publicKeyRecoveryMessage = []byte("keep-client operator public key")
+
+// signTransactionArgs are arguments of the account_signTransaction
+// JSON-RPC call.
+type signTransactionArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice hexutil.Big     `json:"gasPrice"`
+	Value    hexutil.Big     `json:"value"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	Data     hexutil.Bytes   `json:"data"`
+}
+
+// signTransactionResult is the result of the account_signTransaction
+// JSON-RPC call.
+type signTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// externalSigner is an operator signer delegating all signing operations to
+// an external signer, like Clef, over JSON-RPC, so that the operator key is
+// never held in the client's memory.
+type externalSigner struct {
+	client            *rpc.Client
+	account           common.Address
+	operatorPublicKey *ecdsa.PublicKey
+}
+
+// dialExternalSigner connects to the external signer available at the given
+// URL, which can be an HTTP URL or a path to an IPC socket, and makes sure
+// the signer signs with the key of the given account.
+func dialExternalSigner(
+	url string,
+	account common.Address,
+) (*externalSigner, error) {
+	client, err := rpc.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not connect to external signer [%v]: [%v]",
+			url,
+			err,
+		)
+	}
+
+	signer, err := newExternalSigner(client, account)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+func newExternalSigner(
+	client *rpc.Client,
+	account common.Address,
+) (*externalSigner, error) {
+	signer := &externalSigner{
+		client:  client,
+		account: account,
+	}
+
+	// The external signer protocol does not expose public keys. The operator
+	// public key is recovered from a signature of a fixed message instead.
+	signature, err := signer.signMessage(publicKeyRecoveryMessage)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not determine operator public key: [%v]",
+			err,
+		)
+	}
+
+	recoverySignature := make([]byte, SignatureSize)
+	copy(recoverySignature, signature)
+	recoverySignature[SignatureSize-1] -= 27
+
+	publicKey, err := crypto.SigToPub(
+		prefixedHash(publicKeyRecoveryMessage),
+		recoverySignature,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not recover operator public key: [%v]",
+			err,
+		)
+	}
+
+	if recovered := crypto.PubkeyToAddress(*publicKey); recovered != account {
+		return nil, fmt.Errorf(
+			"external signer signed with key of account [%v] "+
+				"instead of operator account [%v]",
+			recovered.Hex(),
+			account.Hex(),
+		)
+	}
+
+	signer.operatorPublicKey = publicKey
+
+	return signer, nil
+}
+
+func (es *externalSigner) address() common.Address {
+	return es.account
+}
+
+func (es *externalSigner) publicKey() *ecdsa.PublicKey {
+	return es.operatorPublicKey
+}
+
+func (es *externalSigner) signTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) (*types.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, externalSignerTimeout)
+	defer cancel()
+
+	args := signTransactionArgs{
+		From:     es.account,
+		To:       transaction.To(),
+		Gas:      hexutil.Uint64(transaction.Gas()),
+		GasPrice: hexutil.Big(*transaction.GasPrice()),
+		Value:    hexutil.Big(*transaction.Value()),
+		Nonce:    hexutil.Uint64(transaction.Nonce()),
+		Data:     transaction.Data(),
+	}
+
+	var result signTransactionResult
+	err := es.client.CallContext(ctx, &result, "account_signTransaction", args)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"external signer could not sign transaction: [%v]",
+			err,
+		)
+	}
+
+	signed := new(types.Transaction)
+	if err := rlp.DecodeBytes(result.Raw, signed); err != nil {
+		return nil, fmt.Errorf(
+			"could not decode transaction signed by external signer: [%v]",
+			err,
+		)
+	}
+
+	// The external signer is not trusted to sign exactly what it was asked
+	// to; a transaction which differs from the requested one is rejected.
+	sender, err := transactionSender(signed)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not determine sender of transaction signed by "+
+				"external signer: [%v]",
+			err,
+		)
+	}
+	if sender != es.account || !sameTransactionContent(transaction, signed) {
+		return nil, fmt.Errorf(
+			"transaction signed by external signer does not match " +
+				"the requested transaction",
+		)
+	}
+
+	return signed, nil
+}
+
+func (es *externalSigner) signMessage(message []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		externalSignerTimeout,
+	)
+	defer cancel()
+
+	var signature hexutil.Bytes
+	err := es.client.CallContext(
+		ctx,
+		&signature,
+		"account_signData",
+		"text/plain",
+		es.account,
+		hexutil.Bytes(message),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"external signer could not sign message: [%v]",
+			err,
+		)
+	}
+
+	if len(signature) != SignatureSize {
+		return nil, fmt.Errorf(
+			"external signer returned signature of [%v] bytes; "+
+				"expected [%v] bytes",
+			len(signature),
+			SignatureSize,
+		)
+	}
+
+	// Some signers return v={0, 1}; on-chain signature validation accepts
+	// only v={27, 28}.
+	if signature[SignatureSize-1] < 27 {
+		signature[SignatureSize-1] += 27
+	}
+
+	return signature, nil
+}
+
+// sameTransactionContent checks whether both transactions have the same
+// content, regardless of their signatures.
+func sameTransactionContent(a, b *types.Transaction) bool {
+	sameRecipient := (a.To() == nil && b.To() == nil) ||
+		(a.To() != nil && b.To() != nil && *a.To() == *b.To())
+
+	return sameRecipient &&
+		a.Nonce() == b.Nonce() &&
+		a.Gas() == b.Gas() &&
+		a.GasPrice().Cmp(b.GasPrice()) == 0 &&
+		a.Value().Cmp(b.Value()) == 0 &&
+		bytes.Equal(a.Data(), b.Data())
+}
@@ -0,0 +1,331 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestExternalSignerSignsMessage(t *testing.T) {
+	key := newTestAccountKey(t)
+
+	signer := newTestExternalSigner(t, &fakeExternalSigner{key: key.PrivateKey})
+	signing := &ethereumSigning{signer}
+
+	if !bytes.Equal(
+		signing.PublicKey(),
+		crypto.FromECDSAPub(&key.PrivateKey.PublicKey),
+	) {
+		t.Errorf("unexpected operator public key")
+	}
+
+	message := []byte("It is a capital mistake to theorize before one has data.")
+
+	signature, err := signing.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Signatures are deterministic so the external signer must produce the
+	// same signature as the one calculated with the key held in memory.
+	expectedSignature, err := (&keySigner{key}).signMessage(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(expectedSignature, signature) {
+		t.Errorf(
+			"unexpected signature\nexpected: [%x]\nactual:   [%x]",
+			expectedSignature,
+			signature,
+		)
+	}
+
+	ok, err := signing.Verify(message, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("expected valid signature but verification failed")
+	}
+}
+
+func TestExternalSignerRejectsKeyOfOtherAccount(t *testing.T) {
+	key := newTestAccountKey(t)
+	otherKey := newTestAccountKey(t)
+
+	_, err := newExternalSigner(
+		newFakeExternalSignerClient(
+			t,
+			&fakeExternalSigner{key: otherKey.PrivateKey},
+		),
+		key.Address,
+	)
+	if err == nil {
+		t.Fatal("expected error for key of other account")
+	}
+}
+
+func TestExternalSignerSignsTransaction(t *testing.T) {
+	var tests = map[string]struct {
+		tamper        func(args *signTransactionArgs)
+		expectedError bool
+	}{
+		"transaction signed as requested": {
+			tamper:        func(args *signTransactionArgs) {},
+			expectedError: false,
+		},
+		"transaction signed with other gas price": {
+			tamper: func(args *signTransactionArgs) {
+				args.GasPrice = hexutil.Big(*big.NewInt(1000))
+			},
+			expectedError: true,
+		},
+		"transaction signed with other recipient": {
+			tamper: func(args *signTransactionArgs) {
+				to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+				args.To = &to
+			},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			key := newTestAccountKey(t)
+
+			signer := newTestExternalSigner(
+				t,
+				&fakeExternalSigner{key: key.PrivateKey, tamper: test.tamper},
+			)
+
+			transaction := types.NewTransaction(
+				7,
+				common.HexToAddress("0x0b185C37E1C9D01437c800a8B60fA0845742c271"),
+				big.NewInt(0),
+				21000,
+				big.NewInt(10),
+				[]byte{0x01},
+			)
+
+			signed, err := signer.signTransaction(
+				context.Background(),
+				transaction,
+			)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected error for tampered transaction")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sender, err := transactionSender(signed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sender != key.Address {
+				t.Errorf(
+					"unexpected sender\nexpected: [%v]\nactual:   [%v]",
+					key.Address.Hex(),
+					sender.Hex(),
+				)
+			}
+			if !sameTransactionContent(transaction, signed) {
+				t.Errorf("signed transaction differs from the requested one")
+			}
+		})
+	}
+}
+
+func TestManagedBackendResignsBindingTransactions(t *testing.T) {
+	operatorKey := newTestAccountKey(t)
+	bindingKey := newTestAccountKey(t)
+
+	signer := newTestExternalSigner(
+		t,
+		&fakeExternalSigner{key: operatorKey.PrivateKey},
+	)
+
+	backend := newMockTransactionBackend()
+	manager := newTransactionManager(
+		backend,
+		backend,
+		signer,
+		TransactionsConfig{},
+		nil,
+	)
+	managedBackend := manager.wrap(
+		&mockContractBackend{mockTransactionBackend: backend},
+		bindingKey.Address,
+	)
+
+	bindingTransaction := signTestTransaction(t, bindingKey, 3, big.NewInt(10))
+
+	outcome, err := manager.submit(0, func() (*types.Transaction, error) {
+		err := managedBackend.SendTransaction(
+			context.Background(),
+			bindingTransaction,
+		)
+		return bindingTransaction, err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent := backend.sentTransactions()
+	if len(sent) != 1 {
+		t.Fatalf(
+			"unexpected number of sent transactions\n"+
+				"expected: [%v]\nactual:   [%v]",
+			1,
+			len(sent),
+		)
+	}
+
+	sender, err := transactionSender(sent[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != operatorKey.Address {
+		t.Errorf(
+			"unexpected sender\nexpected: [%v]\nactual:   [%v]",
+			operatorKey.Address.Hex(),
+			sender.Hex(),
+		)
+	}
+
+	// The transaction actually sent is tracked, not the one signed by
+	// the contract binding.
+	backend.mine(sent[0].Hash(), 1, types.ReceiptStatusSuccessful)
+	manager.checkPending(1)
+
+	select {
+	case result := <-outcome:
+		if result.err != nil {
+			t.Fatal(result.err)
+		}
+	default:
+		t.Fatal("expected transaction outcome")
+	}
+
+	nonce, err := managedBackend.PendingNonceAt(
+		context.Background(),
+		bindingKey.Address,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 4 {
+		t.Errorf(
+			"unexpected nonce\nexpected: [%v]\nactual:   [%v]",
+			4,
+			nonce,
+		)
+	}
+}
+
+func newTestExternalSigner(
+	t *testing.T,
+	fake *fakeExternalSigner,
+) *externalSigner {
+	signer, err := newExternalSigner(
+		newFakeExternalSignerClient(t, fake),
+		crypto.PubkeyToAddress(fake.key.PublicKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signer
+}
+
+func newFakeExternalSignerClient(
+	t *testing.T,
+	fake *fakeExternalSigner,
+) *rpc.Client {
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", fake); err != nil {
+		t.Fatal(err)
+	}
+
+	return rpc.DialInProc(server)
+}
+
+// fakeExternalSigner is an in-process external signer serving the
+// account_signData and account_signTransaction JSON-RPC methods the same way
+// as Clef does. Transactions are signed with replay protection. If tamper is
+// set, transactions are modified with it before they are signed.
+type fakeExternalSigner struct {
+	key    *ecdsa.PrivateKey
+	tamper func(args *signTransactionArgs)
+}
+
+func (fes *fakeExternalSigner) SignData(
+	contentType string,
+	account common.Address,
+	data hexutil.Bytes,
+) (hexutil.Bytes, error) {
+	if contentType != "text/plain" {
+		return nil, fmt.Errorf("unsupported content type [%v]", contentType)
+	}
+
+	signature, err := crypto.Sign(prefixedHash(data), fes.key)
+	if err != nil {
+		return nil, err
+	}
+	signature[len(signature)-1] += 27
+
+	return signature, nil
+}
+
+func (fes *fakeExternalSigner) SignTransaction(
+	args signTransactionArgs,
+) (*signTransactionResult, error) {
+	if fes.tamper != nil {
+		fes.tamper(&args)
+	}
+
+	transaction := types.NewTransaction(
+		uint64(args.Nonce),
+		*args.To,
+		args.Value.ToInt(),
+		uint64(args.Gas),
+		args.GasPrice.ToInt(),
+		args.Data,
+	)
+
+	signed, err := types.SignTx(
+		transaction,
+		types.NewEIP155Signer(big.NewInt(1101)),
+		fes.key,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := rlp.EncodeToBytes(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signTransactionResult{Raw: raw}, nil
+}
+
+type mockContractBackend struct {
+	bind.ContractCaller
+	bind.ContractFilterer
+	*mockTransactionBackend
+}
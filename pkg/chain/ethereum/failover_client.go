@@ -0,0 +1,600 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// healthCheckTimeout is a timeout for a single endpoint health check.
+const healthCheckTimeout = 5 * time.Second
+
+// ethereumClient is the subset of the Ethereum client API used by the chain
+// adapter.
+type ethereumClient interface {
+	CodeAt(
+		ctx context.Context,
+		contract common.Address,
+		blockNumber *big.Int,
+	) ([]byte, error)
+	CallContract(
+		ctx context.Context,
+		call goethereum.CallMsg,
+		blockNumber *big.Int,
+	) ([]byte, error)
+	PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, call goethereum.CallMsg) (uint64, error)
+	SendTransaction(ctx context.Context, transaction *types.Transaction) error
+	FilterLogs(
+		ctx context.Context,
+		query goethereum.FilterQuery,
+	) ([]types.Log, error)
+	SubscribeFilterLogs(
+		ctx context.Context,
+		query goethereum.FilterQuery,
+		ch chan<- types.Log,
+	) (goethereum.Subscription, error)
+	TransactionReceipt(
+		ctx context.Context,
+		hash common.Hash,
+	) (*types.Receipt, error)
+	TransactionByHash(
+		ctx context.Context,
+		hash common.Hash,
+	) (*types.Transaction, bool, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SubscribeNewHead(
+		ctx context.Context,
+		ch chan<- *types.Header,
+	) (goethereum.Subscription, error)
+}
+
+// failoverEndpoint is a single Ethereum endpoint known to the failover client.
+// The client is nil if the endpoint has not been connected to yet.
+type failoverEndpoint struct {
+	url     string
+	client  ethereumClient
+	healthy bool
+}
+
+// failoverClient is an Ethereum client which sends all calls and
+// subscriptions to the most preferred healthy endpoint out of an ordered list
+// of endpoints.
+//
+// Endpoints are health-checked periodically. Endpoints which could not be
+// connected to are redialed on every health check. An endpoint is healthy if it
+// responds and does not lag behind the endpoint reporting the highest block by
+// more than the configured number of blocks. An endpoint is also considered
+// unhealthy as soon as a call to it fails because of a connection problem; the
+// call is then retried on the next healthy endpoint.
+//
+// When the client switches to another endpoint, all the subscriptions created
+// on the previous one are terminated with an error so that their owners
+// resubscribe to the new endpoint.
+type failoverClient struct {
+	maxBlockLag uint64
+	dial        func(url string) (ethereumClient, error)
+
+	mutex         sync.RWMutex
+	endpoints     []*failoverEndpoint
+	active        int
+	subscriptions map[*failoverSubscription]bool
+}
+
+// dialFailoverClient connects to all the given endpoints and returns a
+// failover client using them. Endpoints which can not be connected to are
+// kept as unhealthy and redialed on the following health checks. It fails if
+// none of the endpoints is healthy.
+func dialFailoverClient(
+	urls []string,
+	config FailoverConfig,
+) (*failoverClient, error) {
+	endpoints := make([]*failoverEndpoint, len(urls))
+	for i, url := range urls {
+		endpoints[i] = &failoverEndpoint{url: url}
+	}
+
+	client := newFailoverClient(endpoints, config.maxBlockLag())
+	if !client.checkHealth() {
+		return nil, fmt.Errorf("none of the Ethereum endpoints is healthy")
+	}
+
+	return client, nil
+}
+
+func dialEthereumClient(url string) (ethereumClient, error) {
+	return ethclient.Dial(url)
+}
+
+func newFailoverClient(
+	endpoints []*failoverEndpoint,
+	maxBlockLag uint64,
+) *failoverClient {
+	return &failoverClient{
+		maxBlockLag:   maxBlockLag,
+		dial:          dialEthereumClient,
+		endpoints:     endpoints,
+		subscriptions: make(map[*failoverSubscription]bool),
+	}
+}
+
+// start periodically checks the health of all endpoints until the context is
+// done.
+func (fc *failoverClient) start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				fc.checkHealth()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// checkHealth connects to endpoints which have not been connected to yet,
+// checks the block height of all the endpoints, updates their health, and
+// switches to the most preferred healthy endpoint. It returns false if none of
+// the endpoints is healthy.
+func (fc *failoverClient) checkHealth() bool {
+	blockNumbers := make([]uint64, len(fc.endpoints))
+	responded := make([]bool, len(fc.endpoints))
+	dialed := make([]ethereumClient, len(fc.endpoints))
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(fc.endpoints))
+	for i, endpoint := range fc.endpoints {
+		go func(i int, endpoint *failoverEndpoint) {
+			defer wg.Done()
+
+			client := endpoint.client
+			if client == nil {
+				var err error
+				client, err = fc.dial(endpoint.url)
+				if err != nil {
+					logger.Warningf(
+						"could not connect to Ethereum endpoint [%v]: [%v]",
+						endpoint.url,
+						err,
+					)
+					return
+				}
+
+				dialed[i] = client
+			}
+
+			ctx, cancel := context.WithTimeout(
+				context.Background(),
+				healthCheckTimeout,
+			)
+			defer cancel()
+
+			header, err := client.HeaderByNumber(ctx, nil)
+			if err != nil {
+				logger.Warningf(
+					"health check of Ethereum endpoint [%v] failed: [%v]",
+					endpoint.url,
+					err,
+				)
+				return
+			}
+
+			blockNumbers[i] = header.Number.Uint64()
+			responded[i] = true
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	highestBlock := uint64(0)
+	for i := range fc.endpoints {
+		if responded[i] && blockNumbers[i] > highestBlock {
+			highestBlock = blockNumbers[i]
+		}
+	}
+
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	for i, endpoint := range fc.endpoints {
+		if dialed[i] != nil {
+			endpoint.client = dialed[i]
+		}
+
+		endpoint.healthy = responded[i] &&
+			highestBlock-blockNumbers[i] <= fc.maxBlockLag
+
+		if responded[i] && !endpoint.healthy {
+			logger.Warningf(
+				"Ethereum endpoint [%v] lags behind; "+
+					"it reported block [%v] while the highest block is [%v]",
+				endpoint.url,
+				blockNumbers[i],
+				highestBlock,
+			)
+		}
+	}
+
+	return fc.switchToPreferredHealthy()
+}
+
+// markUnhealthy marks the endpoint with the given index as unhealthy and
+// switches to the most preferred healthy endpoint. It returns false if there
+// is no healthy endpoint left. Must be called with the mutex held.
+func (fc *failoverClient) markUnhealthy(index int) bool {
+	fc.endpoints[index].healthy = false
+	return fc.switchToPreferredHealthy()
+}
+
+// switchToPreferredHealthy makes the most preferred healthy endpoint active.
+// If there is no healthy endpoint, the active endpoint is left unchanged and
+// false is returned. Must be called with the mutex held.
+func (fc *failoverClient) switchToPreferredHealthy() bool {
+	for i, endpoint := range fc.endpoints {
+		if !endpoint.healthy {
+			continue
+		}
+
+		if i != fc.active {
+			logger.Warningf(
+				"switching from Ethereum endpoint [%v] to [%v]",
+				fc.endpoints[fc.active].url,
+				endpoint.url,
+			)
+
+			fc.active = i
+
+			for subscription := range fc.subscriptions {
+				go subscription.terminate(fmt.Errorf(
+					"switched to Ethereum endpoint [%v]",
+					endpoint.url,
+				))
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// activeEndpoint returns the index of the active endpoint and a copy of the
+// endpoint.
+func (fc *failoverClient) activeEndpoint() (int, failoverEndpoint) {
+	fc.mutex.RLock()
+	defer fc.mutex.RUnlock()
+
+	return fc.active, *fc.endpoints[fc.active]
+}
+
+// call executes the given function using the client of the active endpoint.
+// If the function fails because of a connection problem, the endpoint is
+// marked as unhealthy and the function is retried on the next healthy one.
+// Latency and errors of every attempt are recorded under the given method
+// name.
+func (fc *failoverClient) call(
+	ctx context.Context,
+	method string,
+	fn func(client ethereumClient) error,
+) error {
+	var err error
+	for attempt := 0; attempt < len(fc.endpoints); attempt++ {
+		index, endpoint := fc.activeEndpoint()
+		if endpoint.client == nil {
+			err = fmt.Errorf("not connected to [%v]", endpoint.url)
+		} else {
+			startTime := time.Now()
+			err = fn(endpoint.client)
+			observeRPCCall(method, time.Since(startTime), err)
+
+			if !isEndpointFailure(ctx, err) {
+				return err
+			}
+		}
+
+		logger.Warningf(
+			"call to Ethereum endpoint [%v] failed: [%v]",
+			endpoint.url,
+			err,
+		)
+
+		fc.mutex.Lock()
+		switched := fc.active != index || fc.markUnhealthy(index)
+		fc.mutex.Unlock()
+
+		if !switched {
+			return err
+		}
+	}
+
+	return err
+}
+
+// isEndpointFailure returns true if the error indicates a problem with the
+// endpoint rather than an error returned by the Ethereum node for the
+// particular call, like a reverted call or a transaction rejected by the node.
+// Errors of calls whose context is done, for example because the caller's
+// timeout expired, are not endpoint failures.
+func isEndpointFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	if _, ok := err.(rpc.Error); ok {
+		return false
+	}
+
+	switch err {
+	case goethereum.NotFound, context.Canceled:
+		return false
+	}
+
+	return true
+}
+
+// subscribe creates a subscription using the given function and the client of
+// the active endpoint. The subscription is terminated with an error when the
+// client switches to another endpoint.
+func (fc *failoverClient) subscribe(
+	ctx context.Context,
+	method string,
+	subscribeFn func(client ethereumClient) (goethereum.Subscription, error),
+) (goethereum.Subscription, error) {
+	var subscription *failoverSubscription
+
+	err := fc.call(ctx, method, func(client ethereumClient) error {
+		delegate, err := subscribeFn(client)
+		if err != nil {
+			return err
+		}
+
+		subscription = newFailoverSubscription(fc, delegate)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fc.mutex.Lock()
+	fc.subscriptions[subscription] = true
+	fc.mutex.Unlock()
+
+	go subscription.forward()
+
+	return subscription, nil
+}
+
+func (fc *failoverClient) removeSubscription(subscription *failoverSubscription) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	delete(fc.subscriptions, subscription)
+}
+
+func (fc *failoverClient) CodeAt(
+	ctx context.Context,
+	contract common.Address,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	var code []byte
+	err := fc.call(ctx, "CodeAt", func(client ethereumClient) (err error) {
+		code, err = client.CodeAt(ctx, contract, blockNumber)
+		return
+	})
+	return code, err
+}
+
+func (fc *failoverClient) CallContract(
+	ctx context.Context,
+	call goethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	var result []byte
+	err := fc.call(ctx, "CallContract", func(client ethereumClient) (err error) {
+		result, err = client.CallContract(ctx, call, blockNumber)
+		return
+	})
+	return result, err
+}
+
+func (fc *failoverClient) PendingCodeAt(
+	ctx context.Context,
+	account common.Address,
+) ([]byte, error) {
+	var code []byte
+	err := fc.call(ctx, "PendingCodeAt", func(client ethereumClient) (err error) {
+		code, err = client.PendingCodeAt(ctx, account)
+		return
+	})
+	return code, err
+}
+
+func (fc *failoverClient) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	var nonce uint64
+	err := fc.call(ctx, "PendingNonceAt", func(client ethereumClient) (err error) {
+		nonce, err = client.PendingNonceAt(ctx, account)
+		return
+	})
+	return nonce, err
+}
+
+func (fc *failoverClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var gasPrice *big.Int
+	err := fc.call(ctx, "SuggestGasPrice", func(client ethereumClient) (err error) {
+		gasPrice, err = client.SuggestGasPrice(ctx)
+		return
+	})
+	return gasPrice, err
+}
+
+func (fc *failoverClient) EstimateGas(
+	ctx context.Context,
+	call goethereum.CallMsg,
+) (uint64, error) {
+	var gas uint64
+	err := fc.call(ctx, "EstimateGas", func(client ethereumClient) (err error) {
+		gas, err = client.EstimateGas(ctx, call)
+		return
+	})
+	return gas, err
+}
+
+func (fc *failoverClient) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	return fc.call(ctx, "SendTransaction", func(client ethereumClient) error {
+		return client.SendTransaction(ctx, transaction)
+	})
+}
+
+func (fc *failoverClient) FilterLogs(
+	ctx context.Context,
+	query goethereum.FilterQuery,
+) ([]types.Log, error) {
+	var logs []types.Log
+	err := fc.call(ctx, "FilterLogs", func(client ethereumClient) (err error) {
+		logs, err = client.FilterLogs(ctx, query)
+		return
+	})
+	return logs, err
+}
+
+func (fc *failoverClient) SubscribeFilterLogs(
+	ctx context.Context,
+	query goethereum.FilterQuery,
+	ch chan<- types.Log,
+) (goethereum.Subscription, error) {
+	return fc.subscribe(
+		ctx,
+		"SubscribeFilterLogs",
+		func(client ethereumClient) (goethereum.Subscription, error) {
+			return client.SubscribeFilterLogs(ctx, query, ch)
+		},
+	)
+}
+
+func (fc *failoverClient) TransactionReceipt(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := fc.call(ctx, "TransactionReceipt", func(client ethereumClient) (err error) {
+		receipt, err = client.TransactionReceipt(ctx, hash)
+		return
+	})
+	return receipt, err
+}
+
+func (fc *failoverClient) TransactionByHash(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Transaction, bool, error) {
+	var transaction *types.Transaction
+	var isPending bool
+	err := fc.call(ctx, "TransactionByHash", func(client ethereumClient) (err error) {
+		transaction, isPending, err = client.TransactionByHash(ctx, hash)
+		return
+	})
+	return transaction, isPending, err
+}
+
+func (fc *failoverClient) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	var header *types.Header
+	err := fc.call(ctx, "HeaderByNumber", func(client ethereumClient) (err error) {
+		header, err = client.HeaderByNumber(ctx, number)
+		return
+	})
+	return header, err
+}
+
+func (fc *failoverClient) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (goethereum.Subscription, error) {
+	return fc.subscribe(
+		ctx,
+		"SubscribeNewHead",
+		func(client ethereumClient) (goethereum.Subscription, error) {
+			return client.SubscribeNewHead(ctx, ch)
+		},
+	)
+}
+
+// failoverSubscription is a subscription created on one of the failover
+// client endpoints. It is terminated with an error when the underlying
+// subscription fails or when the failover client switches to another
+// endpoint.
+type failoverSubscription struct {
+	client   *failoverClient
+	delegate goethereum.Subscription
+
+	errChan       chan error
+	quit          chan struct{}
+	terminateOnce sync.Once
+}
+
+func newFailoverSubscription(
+	client *failoverClient,
+	delegate goethereum.Subscription,
+) *failoverSubscription {
+	return &failoverSubscription{
+		client:   client,
+		delegate: delegate,
+		errChan:  make(chan error, 1),
+		quit:     make(chan struct{}),
+	}
+}
+
+func (fs *failoverSubscription) forward() {
+	select {
+	case err := <-fs.delegate.Err():
+		if err == nil {
+			err = fmt.Errorf("subscription closed")
+		}
+		fs.terminate(err)
+	case <-fs.quit:
+	}
+}
+
+// terminate unsubscribes the underlying subscription and, if the error is not
+// nil, delivers it to the subscriber.
+func (fs *failoverSubscription) terminate(err error) {
+	fs.terminateOnce.Do(func() {
+		close(fs.quit)
+		fs.delegate.Unsubscribe()
+		fs.client.removeSubscription(fs)
+
+		if err != nil {
+			fs.errChan <- err
+		}
+		close(fs.errChan)
+	})
+}
+
+func (fs *failoverSubscription) Unsubscribe() {
+	fs.terminate(nil)
+}
+
+func (fs *failoverSubscription) Err() <-chan error {
+	return fs.errChan
+}
@@ -0,0 +1,336 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+func TestFailoverClientSelectsPreferredHealthyEndpoint(t *testing.T) {
+	var tests = map[string]struct {
+		blockNumbers   []uint64
+		unavailable    []bool
+		expectedActive int
+		expectedHealth bool
+	}{
+		"all endpoints healthy": {
+			blockNumbers:   []uint64{100, 100, 100},
+			unavailable:    []bool{false, false, false},
+			expectedActive: 0,
+			expectedHealth: true,
+		},
+		"first endpoint lags behind": {
+			blockNumbers:   []uint64{96, 100, 99},
+			unavailable:    []bool{false, false, false},
+			expectedActive: 1,
+			expectedHealth: true,
+		},
+		"first endpoint lags within the limit": {
+			blockNumbers:   []uint64{97, 100, 100},
+			unavailable:    []bool{false, false, false},
+			expectedActive: 0,
+			expectedHealth: true,
+		},
+		"first endpoint unavailable": {
+			blockNumbers:   []uint64{100, 100, 100},
+			unavailable:    []bool{true, false, false},
+			expectedActive: 1,
+			expectedHealth: true,
+		},
+		"all endpoints unavailable": {
+			blockNumbers:   []uint64{100, 100, 100},
+			unavailable:    []bool{true, true, true},
+			expectedActive: 0,
+			expectedHealth: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			endpoints := make([]*failoverEndpoint, len(test.blockNumbers))
+			for i := range endpoints {
+				endpoints[i] = &failoverEndpoint{
+					url: fmt.Sprintf("ws://endpoint-%v", i),
+					client: &mockEthereumClient{
+						blockNumber: test.blockNumbers[i],
+						unavailable: test.unavailable[i],
+					},
+				}
+			}
+
+			client := newFailoverClient(endpoints, 3)
+
+			healthy := client.checkHealth()
+			if healthy != test.expectedHealth {
+				t.Errorf(
+					"unexpected health\nexpected: [%v]\nactual:   [%v]",
+					test.expectedHealth,
+					healthy,
+				)
+			}
+
+			active, _ := client.activeEndpoint()
+			if active != test.expectedActive {
+				t.Errorf(
+					"unexpected active endpoint\nexpected: [%v]\nactual:   [%v]",
+					test.expectedActive,
+					active,
+				)
+			}
+		})
+	}
+}
+
+func TestFailoverClientSwitchesBackToPreferredEndpoint(t *testing.T) {
+	first := &mockEthereumClient{blockNumber: 90}
+	second := &mockEthereumClient{blockNumber: 100}
+
+	client := newFailoverClient(
+		[]*failoverEndpoint{
+			{url: "ws://first", client: first},
+			{url: "ws://second", client: second},
+		},
+		3,
+	)
+
+	client.checkHealth()
+	if active, _ := client.activeEndpoint(); active != 1 {
+		t.Fatalf("expected failover to the second endpoint")
+	}
+
+	first.setBlockNumber(100)
+
+	client.checkHealth()
+	if active, _ := client.activeEndpoint(); active != 0 {
+		t.Fatalf("expected switch back to the first endpoint")
+	}
+}
+
+func TestFailoverClientRedialsUnconnectedEndpoint(t *testing.T) {
+	first := &mockEthereumClient{blockNumber: 100}
+	second := &mockEthereumClient{blockNumber: 100}
+
+	client := newFailoverClient(
+		[]*failoverEndpoint{
+			{url: "ws://first"},
+			{url: "ws://second", client: second},
+		},
+		3,
+	)
+
+	firstReachable := false
+	client.dial = func(url string) (ethereumClient, error) {
+		if url != "ws://first" || !firstReachable {
+			return nil, fmt.Errorf("connection refused")
+		}
+		return first, nil
+	}
+
+	client.checkHealth()
+	if active, _ := client.activeEndpoint(); active != 1 {
+		t.Fatalf("expected failover to the second endpoint")
+	}
+
+	firstReachable = true
+
+	client.checkHealth()
+	if active, _ := client.activeEndpoint(); active != 0 {
+		t.Fatalf("expected switch to the redialed first endpoint")
+	}
+}
+
+func TestFailoverClientRetriesFailedCallOnNextEndpoint(t *testing.T) {
+	first := &mockEthereumClient{blockNumber: 100}
+	second := &mockEthereumClient{blockNumber: 100}
+
+	client := newFailoverClient(
+		[]*failoverEndpoint{
+			{url: "ws://first", client: first},
+			{url: "ws://second", client: second},
+		},
+		3,
+	)
+	client.checkHealth()
+
+	first.setUnavailable(true)
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Number.Uint64() != 100 {
+		t.Errorf(
+			"unexpected block\nexpected: [%v]\nactual:   [%v]",
+			100,
+			header.Number,
+		)
+	}
+
+	if active, _ := client.activeEndpoint(); active != 1 {
+		t.Errorf("expected failover to the second endpoint")
+	}
+}
+
+func TestFailoverClientDoesNotFailOverOnNodeError(t *testing.T) {
+	first := &mockEthereumClient{blockNumber: 100}
+	second := &mockEthereumClient{blockNumber: 100}
+
+	client := newFailoverClient(
+		[]*failoverEndpoint{
+			{url: "ws://first", client: first},
+			{url: "ws://second", client: second},
+		},
+		3,
+	)
+	client.checkHealth()
+
+	_, err := client.TransactionReceipt(
+		context.Background(),
+		common.Hash{},
+	)
+	if err != goethereum.NotFound {
+		t.Fatalf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			goethereum.NotFound,
+			err,
+		)
+	}
+
+	if active, _ := client.activeEndpoint(); active != 0 {
+		t.Errorf("unexpected failover to the second endpoint")
+	}
+}
+
+func TestFailoverClientDoesNotFailOverWhenCallerContextExpires(t *testing.T) {
+	first := &mockEthereumClient{blockNumber: 100}
+	second := &mockEthereumClient{blockNumber: 100}
+
+	client := newFailoverClient(
+		[]*failoverEndpoint{
+			{url: "ws://first", client: first},
+			{url: "ws://second", client: second},
+		},
+		3,
+	)
+	client.checkHealth()
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancelCtx()
+	<-ctx.Done()
+
+	_, err := client.HeaderByNumber(ctx, nil)
+	if err != context.DeadlineExceeded {
+		t.Fatalf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			context.DeadlineExceeded,
+			err,
+		)
+	}
+
+	if active, _ := client.activeEndpoint(); active != 0 {
+		t.Errorf("unexpected failover to the second endpoint")
+	}
+}
+
+func TestFailoverClientTerminatesSubscriptionsOnSwitch(t *testing.T) {
+	first := &mockEthereumClient{blockNumber: 100}
+	second := &mockEthereumClient{blockNumber: 100}
+
+	client := newFailoverClient(
+		[]*failoverEndpoint{
+			{url: "ws://first", client: first},
+			{url: "ws://second", client: second},
+		},
+		3,
+	)
+	client.checkHealth()
+
+	subscription, err := client.SubscribeNewHead(
+		context.Background(),
+		make(chan *types.Header),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first.setBlockNumber(90)
+	client.checkHealth()
+
+	select {
+	case err := <-subscription.Err():
+		if err == nil {
+			t.Fatal("expected subscription error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscription has not been terminated")
+	}
+}
+
+// mockEthereumClient is an Ethereum client reporting the configured block
+// number and failing all calls with a connection error when unavailable.
+type mockEthereumClient struct {
+	ethereumClient
+
+	mutex       sync.Mutex
+	blockNumber uint64
+	unavailable bool
+}
+
+func (mec *mockEthereumClient) setBlockNumber(blockNumber uint64) {
+	mec.mutex.Lock()
+	defer mec.mutex.Unlock()
+
+	mec.blockNumber = blockNumber
+}
+
+func (mec *mockEthereumClient) setUnavailable(unavailable bool) {
+	mec.mutex.Lock()
+	defer mec.mutex.Unlock()
+
+	mec.unavailable = unavailable
+}
+
+func (mec *mockEthereumClient) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	mec.mutex.Lock()
+	defer mec.mutex.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if mec.unavailable {
+		return nil, fmt.Errorf("connection refused")
+	}
+
+	return &types.Header{
+		Number: new(big.Int).SetUint64(mec.blockNumber),
+	}, nil
+}
+
+func (mec *mockEthereumClient) TransactionReceipt(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Receipt, error) {
+	return nil, goethereum.NotFound
+}
+
+func (mec *mockEthereumClient) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (goethereum.Subscription, error) {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil
+}
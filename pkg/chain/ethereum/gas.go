@@ -0,0 +1,161 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+)
+
+// gasPrice returns the gas price suggested by the Ethereum client. An error
+// is returned if the suggested gas price is higher than the maximum gas price
+// so that the transaction is not submitted.
+func (ec *ethereumChain) gasPrice() (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		transactionCallTimeout,
+	)
+	defer cancel()
+
+	gasPrice, err := ec.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get gas price: [%v]", err)
+	}
+
+	if err := checkGasPrice(gasPrice, ec.config.Gas.maxGasPrice()); err != nil {
+		return nil, err
+	}
+
+	return gasPrice, nil
+}
+
+// checkGasPrice returns an error if the given gas price is higher than the
+// given maximum gas price. Nil maximum means there is no maximum.
+func checkGasPrice(gasPrice *big.Int, maxGasPrice *big.Int) error {
+	if maxGasPrice != nil && gasPrice.Cmp(maxGasPrice) > 0 {
+		return fmt.Errorf(
+			"gas price [%v] is higher than the maximum gas price [%v]",
+			gasPrice,
+			maxGasPrice,
+		)
+	}
+
+	return nil
+}
+
+// gasLimit returns the gas limit of a transaction with the given gas
+// estimate. The limit is the estimate increased by the given margin and
+// capped at the given gas budget. An error is returned if the estimate itself
+// exceeds the budget. Zero budget means there is no budget.
+func gasLimit(estimate uint64, marginPercent uint64, budget uint64) (uint64, error) {
+	if budget != 0 && estimate > budget {
+		return 0, fmt.Errorf(
+			"gas estimate [%v] exceeds the gas budget [%v]",
+			estimate,
+			budget,
+		)
+	}
+
+	limit := estimate + estimate*marginPercent/100
+	if budget != 0 && limit > budget {
+		limit = budget
+	}
+
+	return limit, nil
+}
+
+// relayEntryTransactionOptions returns the gas price and gas limit of the
+// relay entry submission according to the gas policy.
+func (ec *ethereumChain) relayEntryTransactionOptions(
+	entry []byte,
+) (ethutil.TransactionOptions, error) {
+	var gasEstimate uint64
+	estimateErr := ec.withRetry(
+		context.Background(),
+		gasEstimateCallType,
+		func(ctx context.Context) (err error) {
+			gasEstimate, err = ec.estimateOperatorGas(ctx, "relayEntry", entry)
+			return err
+		},
+	)
+
+	return ec.transactionOptions(
+		gasEstimate,
+		estimateErr,
+		ec.config.Gas.Budgets.RelayEntrySubmission,
+	)
+}
+
+// dkgResultTransactionOptions returns the gas price and gas limit of the DKG
+// result submission according to the gas policy.
+func (ec *ethereumChain) dkgResultTransactionOptions(
+	participantIndex relaychain.GroupMemberIndex,
+	result *relaychain.DKGResult,
+	signaturesOnChainFormat []byte,
+	membersIndicesOnChainFormat []*big.Int,
+) (ethutil.TransactionOptions, error) {
+	var gasEstimate uint64
+	estimateErr := ec.withRetry(
+		context.Background(),
+		gasEstimateCallType,
+		func(ctx context.Context) (err error) {
+			gasEstimate, err =
+				ec.estimateOperatorGas(
+					ctx,
+					"submitDkgResult",
+					big.NewInt(int64(participantIndex)),
+					result.GroupPublicKey,
+					result.Misbehaved,
+					signaturesOnChainFormat,
+					membersIndicesOnChainFormat,
+				)
+			return err
+		},
+	)
+
+	return ec.transactionOptions(
+		gasEstimate,
+		estimateErr,
+		ec.config.Gas.Budgets.DKGResultSubmission,
+	)
+}
+
+// transactionOptions returns the gas price and gas limit of a transaction
+// with the given gas estimate and budget. If the gas could not be estimated,
+// the gas limit is the budget, so that the transaction never uses more gas
+// than budgeted, or, if there is no budget, it is left for the contract
+// binding to estimate.
+func (ec *ethereumChain) transactionOptions(
+	gasEstimate uint64,
+	estimateErr error,
+	budget uint64,
+) (ethutil.TransactionOptions, error) {
+	gasPrice, err := ec.gasPrice()
+	if err != nil {
+		return ethutil.TransactionOptions{}, err
+	}
+
+	if estimateErr != nil {
+		logger.Errorf("failed to estimate gas [%v]", estimateErr)
+		return ethutil.TransactionOptions{
+			GasLimit: budget,
+			GasPrice: gasPrice,
+		}, nil
+	}
+
+	limit, err := gasLimit(
+		gasEstimate,
+		ec.config.Gas.estimateMarginPercent(),
+		budget,
+	)
+	if err != nil {
+		return ethutil.TransactionOptions{}, err
+	}
+
+	return ethutil.TransactionOptions{
+		GasLimit: limit,
+		GasPrice: gasPrice,
+	}, nil
+}
@@ -0,0 +1,278 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
+)
+
+func TestCheckGasPrice(t *testing.T) {
+	var tests = map[string]struct {
+		gasPrice      int64
+		maxGasPrice   *big.Int
+		expectedError bool
+	}{
+		"no maximum": {
+			gasPrice:      100,
+			maxGasPrice:   nil,
+			expectedError: false,
+		},
+		"below the maximum": {
+			gasPrice:      90,
+			maxGasPrice:   big.NewInt(100),
+			expectedError: false,
+		},
+		"equal to the maximum": {
+			gasPrice:      100,
+			maxGasPrice:   big.NewInt(100),
+			expectedError: false,
+		},
+		"above the maximum": {
+			gasPrice:      101,
+			maxGasPrice:   big.NewInt(100),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := checkGasPrice(big.NewInt(test.gasPrice), test.maxGasPrice)
+			if (err != nil) != test.expectedError {
+				t.Errorf(
+					"unexpected error\nexpected error: [%v]\nactual:         [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestGasLimit(t *testing.T) {
+	var tests = map[string]struct {
+		estimate      uint64
+		budget        uint64
+		expectedLimit uint64
+		expectedError bool
+	}{
+		"no budget": {
+			estimate:      100000,
+			budget:        0,
+			expectedLimit: 120000,
+		},
+		"margin within the budget": {
+			estimate:      100000,
+			budget:        150000,
+			expectedLimit: 120000,
+		},
+		"margin capped at the budget": {
+			estimate:      100000,
+			budget:        110000,
+			expectedLimit: 110000,
+		},
+		"estimate exceeds the budget": {
+			estimate:      200000,
+			budget:        150000,
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			limit, err := gasLimit(test.estimate, 20, test.budget)
+			if (err != nil) != test.expectedError {
+				t.Fatalf(
+					"unexpected error\nexpected error: [%v]\nactual:         [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+			if limit != test.expectedLimit {
+				t.Errorf(
+					"unexpected gas limit\nexpected: [%v]\nactual:   [%v]",
+					test.expectedLimit,
+					limit,
+				)
+			}
+		})
+	}
+}
+
+func TestTicketSubmissionEconomics(t *testing.T) {
+	var tests = map[string]struct {
+		config                 GasConfig
+		expectedSubmissionCost int64
+		expectedReward         int64
+	}{
+		"defaults": {
+			config:                 GasConfig{},
+			expectedSubmissionCost: 2 * defaultTicketSubmissionGasLimit,
+			expectedReward:         1000,
+		},
+		"configured ticket submission gas limit": {
+			config: GasConfig{
+				Budgets: GasBudgetsConfig{TicketSubmission: 300000},
+			},
+			expectedSubmissionCost: 600000,
+			expectedReward:         1000,
+		},
+		"configured expected group entries": {
+			config:                 GasConfig{ExpectedGroupEntries: 5},
+			expectedSubmissionCost: 2 * defaultTicketSubmissionGasLimit,
+			expectedReward:         5000,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			economics := test.config.ticketSubmissionEconomics(
+				big.NewInt(2),
+				big.NewInt(1000),
+			)
+
+			if economics.SubmissionCost.Cmp(
+				big.NewInt(test.expectedSubmissionCost),
+			) != 0 {
+				t.Errorf(
+					"unexpected submission cost\nexpected: [%v]\nactual:   [%v]",
+					test.expectedSubmissionCost,
+					economics.SubmissionCost,
+				)
+			}
+			if economics.ExpectedReward.Cmp(
+				big.NewInt(test.expectedReward),
+			) != 0 {
+				t.Errorf(
+					"unexpected expected reward\nexpected: [%v]\nactual:   [%v]",
+					test.expectedReward,
+					economics.ExpectedReward,
+				)
+			}
+		})
+	}
+}
+
+func TestEstimateOperatorGasFromOperatorAccount(t *testing.T) {
+	operatorKey := newTestAccountKey(t)
+	backend := &estimatingContractBackend{estimate: 100000}
+	chain := newGasTestChain(t, operatorKey, backend)
+
+	_, err := chain.estimateOperatorGas(
+		context.Background(),
+		"relayEntry",
+		[]byte{0x01},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(backend.calls) != 1 {
+		t.Fatalf(
+			"unexpected number of estimate calls\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(backend.calls),
+		)
+	}
+
+	if backend.calls[0].From != operatorKey.Address {
+		t.Errorf(
+			"unexpected estimate call sender\nexpected: [%v]\nactual:   [%v]",
+			operatorKey.Address.Hex(),
+			backend.calls[0].From.Hex(),
+		)
+	}
+}
+
+func TestTransactionOptionsWhenEstimateFails(t *testing.T) {
+	var tests = map[string]struct {
+		budget           uint64
+		expectedGasLimit uint64
+	}{
+		"no budget": {
+			budget:           0,
+			expectedGasLimit: 0,
+		},
+		"budget": {
+			budget:           2000000,
+			expectedGasLimit: 2000000,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			backend := &estimatingContractBackend{}
+			chain := newGasTestChain(t, newTestAccountKey(t), backend)
+
+			options, err := chain.transactionOptions(
+				0,
+				fmt.Errorf("execution reverted"),
+				test.budget,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if options.GasLimit != test.expectedGasLimit {
+				t.Errorf(
+					"unexpected gas limit\nexpected: [%v]\nactual:   [%v]",
+					test.expectedGasLimit,
+					options.GasLimit,
+				)
+			}
+		})
+	}
+}
+
+func newGasTestChain(
+	t *testing.T,
+	operatorKey *keystore.Key,
+	backend bind.ContractBackend,
+) *ethereumChain {
+	operatorABI, err := ethereumabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ethereumChain{
+		client: backend,
+		keepRandomBeaconOperatorAddress: common.HexToAddress(
+			"0xcf64c2a367341170cb4e09cf8c0ed137d8473ceb",
+		),
+		keepRandomBeaconOperatorABI: &operatorABI,
+		signer:                      &keySigner{operatorKey},
+	}
+}
+
+// estimatingContractBackend records gas estimate calls and returns the
+// configured gas estimate and gas price.
+type estimatingContractBackend struct {
+	bind.ContractBackend
+
+	estimate uint64
+	calls    []goethereum.CallMsg
+}
+
+func (ecb *estimatingContractBackend) EstimateGas(
+	ctx context.Context,
+	call goethereum.CallMsg,
+) (uint64, error) {
+	ecb.calls = append(ecb.calls, call)
+	return ecb.estimate, nil
+}
+
+func (ecb *estimatingContractBackend) SuggestGasPrice(
+	ctx context.Context,
+) (*big.Int, error) {
+	return big.NewInt(10), nil
+}
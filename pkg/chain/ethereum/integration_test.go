@@ -0,0 +1,235 @@
+//go:build integration
+// +build integration
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/internal/ethereumtest"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+)
+
+const (
+	integrationGroupSize      = 3
+	integrationGroupThreshold = 2
+	integrationBlockTime      = 250 * time.Millisecond
+	integrationTimeout        = 5 * time.Minute
+	integrationPassword       = "password"
+)
+
+// TestGroupCreationAndRelayEntryOnSimulatedChain deploys the contracts to
+// a simulated chain, starts a client for every member of a group connected
+// to the chain through Connect, and checks that the group is created in
+// genesis and then signs a requested relay entry.
+//
+// Run with `go test -tags integration`, as the CI does. Compiled contracts
+// are expected in the directory pointed by the KEEP_CONTRACT_ARTIFACTS
+// environment variable or, by default, in solidity/build/truffle, where
+// `npm run compile` puts them. The test fails if the artifacts are not there.
+func TestGroupCreationAndRelayEntryOnSimulatedChain(t *testing.T) {
+	artifacts, err := ethereumtest.LoadArtifacts(
+		ethereumtest.ArtifactsDir(),
+		ethereumtest.Contracts...,
+	)
+	if err != nil {
+		t.Fatalf(
+			"contract artifacts not available; run `npm run compile` "+
+				"in the solidity directory: [%v]",
+			err,
+		)
+	}
+
+	dir, err := ioutil.TempDir("", "integration")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	deployerKey, _, err := ethereumtest.NewAccount(dir, integrationPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operators := make([]Config, integrationGroupSize)
+	operatorAccounts := make([]ethereum.Account, integrationGroupSize)
+	fundedAccounts := []common.Address{deployerKey.Address}
+	for i := range operatorAccounts {
+		operatorKey, keyFile, err := ethereumtest.NewAccount(
+			dir,
+			integrationPassword,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		operatorAccounts[i] = ethereum.Account{
+			Address:         operatorKey.Address.Hex(),
+			KeyFile:         keyFile,
+			KeyFilePassword: integrationPassword,
+		}
+		fundedAccounts = append(fundedAccounts, operatorKey.Address)
+	}
+
+	backend, err := ethereumtest.NewBackend(fundedAccounts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+
+	deployment, err := ethereumtest.Deploy(
+		backend,
+		artifacts,
+		deployerKey,
+		integrationGroupSize,
+		integrationGroupThreshold,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer deployment.Close()
+
+	for i, account := range operatorAccounts {
+		if err := deployment.Stake(fundedAccounts[i+1]); err != nil {
+			t.Fatal(err)
+		}
+
+		operators[i] = Config{
+			Config: ethereum.Config{
+				URL:               backend.URL(),
+				ContractAddresses: deployment.ContractAddresses(),
+				Account:           account,
+			},
+		}
+	}
+	// Stakes are initialized with the next block.
+	backend.Mine(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), integrationTimeout)
+	defer cancel()
+
+	backend.AutoMine(ctx, integrationBlockTime)
+
+	var observer *ethereumChain
+	for i, config := range operators {
+		chain, err := connect(ctx, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if observer == nil {
+			observer = chain
+		}
+
+		operatorPrivateKey, operatorPublicKey := chain.GetKeys()
+		_, networkPublicKey := key.OperatorKeyToNetworkKey(
+			operatorPrivateKey,
+			operatorPublicKey,
+		)
+
+		storageDir := filepath.Join(dir, fmt.Sprintf("storage-%v", i))
+		if err := os.Mkdir(storageDir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		handle, err := persistence.NewDiskHandle(storageDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		checkpointDir := filepath.Join(storageDir, "checkpoints")
+		if err := os.Mkdir(checkpointDir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		checkpointHandle, err := persistence.NewDiskHandle(checkpointDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = beacon.Initialize(
+			ctx,
+			config.Account.Address,
+			chain,
+			netLocal.ConnectWithKey(networkPublicKey),
+			beacon.Config{
+				Persistence: persistence.NewEncryptedPersistence(
+					handle,
+					integrationPassword,
+				),
+				CheckpointPersistence: persistence.NewEncryptedPersistence(
+					checkpointHandle,
+					integrationPassword,
+				),
+				CheckpointArchive: registry.NewArchive(checkpointDir),
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	groupRegistered := make(chan *event.GroupRegistration, 1)
+	groupSubscription, err := observer.OnGroupRegistered(
+		func(registration *event.GroupRegistration) {
+			select {
+			case groupRegistered <- registration:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer groupSubscription.Unsubscribe()
+
+	entrySubmitted := make(chan *event.EntrySubmitted, 1)
+	entrySubscription, err := observer.OnRelayEntrySubmitted(
+		func(entry *event.EntrySubmitted) {
+			select {
+			case entrySubmitted <- entry:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer entrySubscription.Unsubscribe()
+
+	if err := deployment.Genesis(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case registration := <-groupRegistered:
+		t.Logf(
+			"group [0x%x] registered at block [%v]",
+			registration.GroupPublicKey,
+			registration.BlockNumber,
+		)
+	case <-ctx.Done():
+		t.Fatal("group has not been registered")
+	}
+
+	if err := deployment.RequestRelayEntry(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case entry := <-entrySubmitted:
+		t.Logf("relay entry submitted at block [%v]", entry.BlockNumber)
+	case <-ctx.Done():
+		t.Fatal("relay entry has not been submitted")
+	}
+}
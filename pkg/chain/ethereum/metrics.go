@@ -0,0 +1,78 @@
+package ethereum
+
+import (
+	"math/big"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/keep-network/keep-core/pkg/metrics"
+)
+
+var (
+	rpcLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "ethereum",
+			Name:      "rpc_duration_seconds",
+			Help:      "Latency of calls to the Ethereum node.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+		[]string{"method"},
+	)
+
+	rpcErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "ethereum",
+			Name:      "rpc_errors_total",
+			Help:      "Calls to the Ethereum node which failed.",
+		},
+		[]string{"method"},
+	)
+
+	gasUsed = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "ethereum",
+			Name:      "gas_used_total",
+			Help:      "Gas used by mined transactions sent by the client.",
+		},
+	)
+
+	gasSpent = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "ethereum",
+			Name:      "gas_spent_wei_total",
+			Help:      "Wei paid for gas of mined transactions sent by the client.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(rpcLatency, rpcErrors, gasUsed, gasSpent)
+}
+
+// observeRPCCall records the latency and the outcome of the call to the
+// Ethereum node. Lookups of data which is not there are not counted as
+// errors.
+func observeRPCCall(method string, latency time.Duration, err error) {
+	rpcLatency.WithLabelValues(method).Observe(latency.Seconds())
+
+	if err != nil && err != goethereum.NotFound {
+		rpcErrors.WithLabelValues(method).Inc()
+	}
+}
+
+// observeGasSpent records gas used by the mined transaction with the given
+// receipt and submitted with the given gas price.
+func observeGasSpent(receipt *types.Receipt, gasPrice *big.Int) {
+	gasUsed.Add(float64(receipt.GasUsed))
+
+	spent := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice)
+	spentFloat, _ := new(big.Float).SetInt(spent).Float64()
+	gasSpent.Add(spentFloat)
+}
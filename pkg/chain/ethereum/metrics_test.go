@@ -0,0 +1,71 @@
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserveRPCCall(t *testing.T) {
+	var tests = map[string]struct {
+		err            error
+		expectedErrors float64
+	}{
+		"successful call": {
+			err:            nil,
+			expectedErrors: 0,
+		},
+		"data not found": {
+			err:            goethereum.NotFound,
+			expectedErrors: 0,
+		},
+		"failed call": {
+			err:            fmt.Errorf("connection refused"),
+			expectedErrors: 1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			method := "Test" + testName
+
+			observeRPCCall(method, 10*time.Millisecond, test.err)
+
+			errors := testutil.ToFloat64(rpcErrors.WithLabelValues(method))
+			if errors != test.expectedErrors {
+				t.Errorf(
+					"unexpected errors\nexpected: [%v]\nactual:   [%v]",
+					test.expectedErrors,
+					errors,
+				)
+			}
+		})
+	}
+}
+
+func TestObserveGasSpent(t *testing.T) {
+	usedBefore := testutil.ToFloat64(gasUsed)
+	spentBefore := testutil.ToFloat64(gasSpent)
+
+	observeGasSpent(&types.Receipt{GasUsed: 21000}, big.NewInt(20))
+
+	if used := testutil.ToFloat64(gasUsed) - usedBefore; used != 21000 {
+		t.Errorf(
+			"unexpected gas used\nexpected: [%v]\nactual:   [%v]",
+			21000,
+			used,
+		)
+	}
+	if spent := testutil.ToFloat64(gasSpent) - spentBefore; spent != 420000 {
+		t.Errorf(
+			"unexpected gas spent\nexpected: [%v]\nactual:   [%v]",
+			420000,
+			spent,
+		)
+	}
+}
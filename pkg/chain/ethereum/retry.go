@@ -0,0 +1,325 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Types of read calls made to the chain. Each call type has its own retry
+// policy.
+const (
+	// configCallType covers calls reading the relay configuration.
+	configCallType = "Config"
+	// stakeCallType covers calls reading stakes of operators.
+	stakeCallType = "Stake"
+	// ticketsCallType covers calls reading tickets submitted in the group
+	// selection.
+	ticketsCallType = "SubmittedTickets"
+	// selectedParticipantsCallType covers calls reading participants
+	// selected in the group selection.
+	selectedParticipantsCallType = "SelectedParticipants"
+	// groupSelectionCallType covers calls reading past group selection
+	// events.
+	groupSelectionCallType = "GroupSelection"
+	// groupCallType covers calls reading information about groups.
+	groupCallType = "Group"
+	// gasEstimateCallType covers gas estimations of transactions.
+	gasEstimateCallType = "GasEstimate"
+	// relayEntryCallType covers calls reading past relay entry events.
+	relayEntryCallType = "RelayEntry"
+)
+
+// callTypes lists all the known call types.
+var callTypes = []string{
+	configCallType,
+	stakeCallType,
+	ticketsCallType,
+	selectedParticipantsCallType,
+	groupSelectionCallType,
+	groupCallType,
+	gasEstimateCallType,
+	relayEntryCallType,
+}
+
+// errorClass tells whether a failed call is worth retrying.
+type errorClass int
+
+const (
+	// transientError is an error which may not occur when the call is
+	// retried, like a network failure or rate limiting.
+	transientError errorClass = iota
+	// revertError is an error caused by the contract reverting the call.
+	revertError
+	// permanentError is an error which is going to occur every time the
+	// call is retried, like a malformed request.
+	permanentError
+)
+
+func (ec errorClass) String() string {
+	switch ec {
+	case transientError:
+		return "transient"
+	case revertError:
+		return "revert"
+	default:
+		return "permanent"
+	}
+}
+
+// rateLimitErrorCode is the JSON-RPC error code used by Ethereum providers
+// to signal that the request rate limit has been exceeded.
+const rateLimitErrorCode = -32005
+
+// revertErrorFragments are fragments of messages of errors returned when
+// a call has been reverted by the contract. A reverted call may also yield
+// an empty output which can't be unpacked.
+var revertErrorFragments = []string{
+	"execution reverted",
+	"vm execution error",
+	"contract failed with",
+	"invalid opcode",
+	"always failing transaction",
+	"unmarshalling empty output",
+	"unmarshall an empty string",
+}
+
+// transientErrorFragments are fragments of messages of errors which are
+// likely not to occur again if the call is retried.
+var transientErrorFragments = []string{
+	"connection refused",
+	"connection reset",
+	"broken pipe",
+	"timeout",
+	"too many requests",
+	"rate limit",
+	"bad gateway",
+	"service unavailable",
+	"header not found",
+	"missing trie node",
+	"eof",
+	"websocket: close",
+}
+
+// classifyError tells the class of the given error returned by a call.
+func classifyError(err error) errorClass {
+	if err == io.EOF || err == io.ErrUnexpectedEOF ||
+		err == context.DeadlineExceeded {
+		return transientError
+	}
+
+	// A client lagging behind may not see the contract yet.
+	if err == bind.ErrNoCode {
+		return transientError
+	}
+
+	if _, ok := err.(net.Error); ok {
+		return transientError
+	}
+
+	if rpcErr, ok := err.(rpc.Error); ok &&
+		rpcErr.ErrorCode() == rateLimitErrorCode {
+		return transientError
+	}
+
+	message := strings.ToLower(err.Error())
+
+	for _, fragment := range revertErrorFragments {
+		if strings.Contains(message, fragment) {
+			return revertError
+		}
+	}
+
+	for _, fragment := range transientErrorFragments {
+		if strings.Contains(message, fragment) {
+			return transientError
+		}
+	}
+
+	return permanentError
+}
+
+// retryPolicy tells how a failed call is retried. Transient errors are
+// always retried, reverts are retried only if the policy says so and
+// permanent errors are never retried. Attempts are separated with an
+// exponential backoff with jitter.
+type retryPolicy struct {
+	maxAttempts    uint64
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	timeout        time.Duration
+	retryReverts   bool
+}
+
+// defaultRetryPolicy is the retry policy of call types not having a specific
+// default policy.
+var defaultRetryPolicy = retryPolicy{
+	maxAttempts:    5,
+	initialBackoff: 250 * time.Millisecond,
+	maxBackoff:     4 * time.Second,
+	timeout:        30 * time.Second,
+	retryReverts:   false,
+}
+
+// defaultRetryPolicies are the default retry policies of call types which
+// differ from the default retry policy.
+var defaultRetryPolicies = map[string]retryPolicy{
+	// When the client is connected to multiple Ethereum clients behind a load
+	// balancer, like Infura, some of them may stay a block behind the others.
+	// The call for selected participants reverts when made against a client
+	// which has not seen the block closing the group selection yet, so
+	// reverts are retried.
+	selectedParticipantsCallType: {
+		maxAttempts:    10,
+		initialBackoff: 500 * time.Millisecond,
+		maxBackoff:     2 * time.Second,
+		timeout:        30 * time.Second,
+		retryReverts:   true,
+	},
+}
+
+// newRetryPolicies creates retry policies for all call types. Default
+// policies are overridden with the configured values.
+func newRetryPolicies(
+	config map[string]RetryConfig,
+) (map[string]*retryPolicy, error) {
+	policies := make(map[string]*retryPolicy, len(callTypes))
+	for _, callType := range callTypes {
+		policy, ok := defaultRetryPolicies[callType]
+		if !ok {
+			policy = defaultRetryPolicy
+		}
+		policies[callType] = &policy
+	}
+
+	for callType, callConfig := range config {
+		policy, ok := policies[callType]
+		if !ok {
+			return nil, fmt.Errorf(
+				"unknown call type [%v] in retry configuration; "+
+					"expected one of %v",
+				callType,
+				callTypes,
+			)
+		}
+
+		if callConfig.MaxAttempts != 0 {
+			policy.maxAttempts = callConfig.MaxAttempts
+		}
+		if callConfig.InitialBackoffMilliseconds != 0 {
+			policy.initialBackoff = time.Duration(
+				callConfig.InitialBackoffMilliseconds,
+			) * time.Millisecond
+		}
+		if callConfig.MaxBackoffMilliseconds != 0 {
+			policy.maxBackoff = time.Duration(
+				callConfig.MaxBackoffMilliseconds,
+			) * time.Millisecond
+		}
+		if callConfig.TimeoutSeconds != 0 {
+			policy.timeout = time.Duration(
+				callConfig.TimeoutSeconds,
+			) * time.Second
+		}
+	}
+
+	return policies, nil
+}
+
+// do executes the given call and retries it according to the policy until
+// it succeeds, fails with an error which should not be retried, runs out of
+// attempts or until the context is done. The returned error is the error
+// of the last attempt. The context is passed to every attempt so that
+// a single attempt does not outlive it.
+func (rp *retryPolicy) do(
+	ctx context.Context,
+	callType string,
+	call func(ctx context.Context) error,
+) error {
+	for attempt := uint64(1); ; attempt++ {
+		err := call(ctx)
+		if err == nil {
+			return nil
+		}
+
+		class := classifyError(err)
+		retryable := class == transientError ||
+			(class == revertError && rp.retryReverts)
+
+		if !retryable || attempt >= rp.maxAttempts {
+			logger.Warningf(
+				"call failed; call type: [%v], attempt: [%v/%v], "+
+					"error class: [%v], error: [%v]",
+				callType,
+				attempt,
+				rp.maxAttempts,
+				class,
+				err,
+			)
+			return err
+		}
+
+		backoff := rp.backoff(attempt)
+
+		if deadline, ok := ctx.Deadline(); ok &&
+			time.Now().Add(backoff).After(deadline) {
+			logger.Warningf(
+				"call failed and deadline does not allow another attempt; "+
+					"call type: [%v], attempt: [%v/%v], "+
+					"error class: [%v], error: [%v]",
+				callType,
+				attempt,
+				rp.maxAttempts,
+				class,
+				err,
+			)
+			return err
+		}
+
+		logger.Infof(
+			"call failed and will be retried; call type: [%v], "+
+				"attempt: [%v/%v], error class: [%v], backoff: [%v], "+
+				"error: [%v]",
+			callType,
+			attempt,
+			rp.maxAttempts,
+			class,
+			backoff,
+			err,
+		)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// backoff returns the delay before the attempt following the given one.
+// The delay doubles with every attempt up to the maximum backoff and is
+// randomized between a half and the whole of the calculated value so that
+// clients retrying at the same time spread their attempts.
+func (rp *retryPolicy) backoff(attempt uint64) time.Duration {
+	backoff := rp.initialBackoff
+	for i := uint64(1); i < attempt && backoff < rp.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > rp.maxBackoff {
+		backoff = rp.maxBackoff
+	}
+
+	half := backoff / 2
+	if half <= 0 {
+		return backoff
+	}
+
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
@@ -0,0 +1,314 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+func TestClassifyError(t *testing.T) {
+	var tests = map[string]struct {
+		err           error
+		expectedClass errorClass
+	}{
+		"connection refused": {
+			err:           fmt.Errorf("dial tcp 127.0.0.1:8546: connect: connection refused"),
+			expectedClass: transientError,
+		},
+		"unexpected EOF": {
+			err:           io.ErrUnexpectedEOF,
+			expectedClass: transientError,
+		},
+		"rate limit": {
+			err:           &rpcError{code: rateLimitErrorCode, message: "daily request count exceeded"},
+			expectedClass: transientError,
+		},
+		"too many requests": {
+			err:           fmt.Errorf("429 Too Many Requests"),
+			expectedClass: transientError,
+		},
+		"lagging client": {
+			err:           bind.ErrNoCode,
+			expectedClass: transientError,
+		},
+		"execution reverted": {
+			err:           fmt.Errorf("execution reverted"),
+			expectedClass: revertError,
+		},
+		"revert with reason": {
+			err:           fmt.Errorf("contract failed with: [[Group selection in progress]] (original error [always failing transaction])"),
+			expectedClass: revertError,
+		},
+		"empty output": {
+			err:           fmt.Errorf("abi: attempting to unmarshall an empty string while arguments are expected"),
+			expectedClass: revertError,
+		},
+		"invalid argument": {
+			err:           &rpcError{code: -32602, message: "invalid argument 0: hex string has length 3"},
+			expectedClass: permanentError,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			class := classifyError(test.err)
+			if class != test.expectedClass {
+				t.Errorf(
+					"unexpected error class\nexpected: [%v]\nactual:   [%v]",
+					test.expectedClass,
+					class,
+				)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	transient := fmt.Errorf("connection reset by peer")
+	revert := fmt.Errorf("execution reverted")
+	permanent := fmt.Errorf("invalid argument")
+
+	var tests = map[string]struct {
+		errors           []error
+		retryReverts     bool
+		expectedError    error
+		expectedAttempts int
+	}{
+		"succeeds at first attempt": {
+			errors:           []error{nil},
+			expectedError:    nil,
+			expectedAttempts: 1,
+		},
+		"transient errors retried": {
+			errors:           []error{transient, transient, nil},
+			expectedError:    nil,
+			expectedAttempts: 3,
+		},
+		"transient errors retried until out of attempts": {
+			errors:           []error{transient, transient, transient, transient},
+			expectedError:    transient,
+			expectedAttempts: 3,
+		},
+		"permanent error not retried": {
+			errors:           []error{permanent, nil},
+			expectedError:    permanent,
+			expectedAttempts: 1,
+		},
+		"revert not retried": {
+			errors:           []error{revert, nil},
+			expectedError:    revert,
+			expectedAttempts: 1,
+		},
+		"revert retried when configured": {
+			errors:           []error{revert, nil},
+			retryReverts:     true,
+			expectedError:    nil,
+			expectedAttempts: 2,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			policy := &retryPolicy{
+				maxAttempts:    3,
+				initialBackoff: time.Millisecond,
+				maxBackoff:     2 * time.Millisecond,
+				timeout:        time.Second,
+				retryReverts:   test.retryReverts,
+			}
+
+			attempts := 0
+			err := policy.do(context.Background(), "Test", func(
+				ctx context.Context,
+			) error {
+				err := test.errors[attempts]
+				attempts++
+				return err
+			})
+
+			if err != test.expectedError {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+			if attempts != test.expectedAttempts {
+				t.Errorf(
+					"unexpected number of attempts\nexpected: [%v]\nactual:   [%v]",
+					test.expectedAttempts,
+					attempts,
+				)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyHonoursDeadline(t *testing.T) {
+	policy := &retryPolicy{
+		maxAttempts:    10,
+		initialBackoff: time.Second,
+		maxBackoff:     time.Second,
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		100*time.Millisecond,
+	)
+	defer cancel()
+
+	attempts := 0
+	start := time.Now()
+	err := policy.do(ctx, "Test", func(ctx context.Context) error {
+		attempts++
+		return io.EOF
+	})
+
+	if err != io.EOF {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			io.EOF,
+			err,
+		)
+	}
+	if attempts != 1 {
+		t.Errorf(
+			"unexpected number of attempts\nexpected: [%v]\nactual:   [%v]",
+			1,
+			attempts,
+		)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("policy waited [%v] despite the deadline", elapsed)
+	}
+}
+
+func TestRetryPolicyBoundsHungCall(t *testing.T) {
+	policy := &retryPolicy{
+		maxAttempts:    10,
+		initialBackoff: time.Second,
+		maxBackoff:     time.Second,
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		100*time.Millisecond,
+	)
+	defer cancel()
+
+	start := time.Now()
+	err := policy.do(ctx, "Test", func(ctx context.Context) error {
+		// A call hanging until its context is done, like a request to
+		// a non-responding endpoint.
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if err != context.DeadlineExceeded {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			context.DeadlineExceeded,
+			err,
+		)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("hung call was not abandoned after [%v]", elapsed)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &retryPolicy{
+		initialBackoff: 100 * time.Millisecond,
+		maxBackoff:     time.Second,
+	}
+
+	var tests = map[string]struct {
+		attempt     uint64
+		expectedMax time.Duration
+	}{
+		"first attempt": {
+			attempt:     1,
+			expectedMax: 100 * time.Millisecond,
+		},
+		"third attempt": {
+			attempt:     3,
+			expectedMax: 400 * time.Millisecond,
+		},
+		"tenth attempt": {
+			attempt:     10,
+			expectedMax: time.Second,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				backoff := policy.backoff(test.attempt)
+				if backoff < test.expectedMax/2 || backoff > test.expectedMax {
+					t.Fatalf(
+						"backoff [%v] out of range [%v, %v]",
+						backoff,
+						test.expectedMax/2,
+						test.expectedMax,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestNewRetryPolicies(t *testing.T) {
+	policies, err := newRetryPolicies(map[string]RetryConfig{
+		selectedParticipantsCallType: {MaxAttempts: 20},
+		stakeCallType:                {TimeoutSeconds: 5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSelectedParticipants := defaultRetryPolicies[selectedParticipantsCallType]
+	expectedSelectedParticipants.maxAttempts = 20
+	if !reflect.DeepEqual(
+		&expectedSelectedParticipants,
+		policies[selectedParticipantsCallType],
+	) {
+		t.Errorf(
+			"unexpected policy\nexpected: [%+v]\nactual:   [%+v]",
+			expectedSelectedParticipants,
+			policies[selectedParticipantsCallType],
+		)
+	}
+
+	expectedStake := defaultRetryPolicy
+	expectedStake.timeout = 5 * time.Second
+	if !reflect.DeepEqual(&expectedStake, policies[stakeCallType]) {
+		t.Errorf(
+			"unexpected policy\nexpected: [%+v]\nactual:   [%+v]",
+			expectedStake,
+			policies[stakeCallType],
+		)
+	}
+
+	_, err = newRetryPolicies(map[string]RetryConfig{"Unknown": {}})
+	if err == nil {
+		t.Errorf("expected error for unknown call type")
+	}
+}
+
+type rpcError struct {
+	code    int
+	message string
+}
+
+func (re *rpcError) Error() string {
+	return re.message
+}
+
+func (re *rpcError) ErrorCode() int {
+	return re.code
+}
@@ -0,0 +1,102 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	keystoreSignerType = "keystore"
+	externalSignerType = "external"
+)
+
+// operatorSigner signs transactions and messages on behalf of the operator
+// account.
+type operatorSigner interface {
+	// address returns the address of the operator account.
+	address() common.Address
+
+	// publicKey returns the public key of the operator account.
+	publicKey() *ecdsa.PublicKey
+
+	// signTransaction signs the given transaction with the operator key.
+	// The returned transaction may be protected against replay on other
+	// chains.
+	signTransaction(
+		ctx context.Context,
+		transaction *types.Transaction,
+	) (*types.Transaction, error)
+
+	// signMessage calculates an Ethereum signature of the given message
+	// prefixed with "\x19Ethereum Signed Message:\n" and the message length.
+	// The signature is returned in the [R || S || V] format, with V being
+	// 27 or 28.
+	signMessage(message []byte) ([]byte, error)
+}
+
+// keySigner is an operator signer using the operator key held in memory.
+type keySigner struct {
+	key *keystore.Key
+}
+
+func (ks *keySigner) address() common.Address {
+	return ks.key.Address
+}
+
+func (ks *keySigner) publicKey() *ecdsa.PublicKey {
+	return &ks.key.PrivateKey.PublicKey
+}
+
+func (ks *keySigner) signTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) (*types.Transaction, error) {
+	return types.SignTx(
+		transaction,
+		types.HomesteadSigner{},
+		ks.key.PrivateKey,
+	)
+}
+
+func (ks *keySigner) signMessage(message []byte) ([]byte, error) {
+	signature, err := crypto.Sign(prefixedHash(message), ks.key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(signature) == SignatureSize {
+		// go-ethereum/crypto produces signature with v={0, 1} and we need to add
+		// 27 to v-part (signature[64]) to conform wtih the on-chain signature
+		// validation code that accepts v={27, 28} as specified in the
+		// Appendix F of the Ethereum Yellow Paper
+		// https://ethereum.github.io/yellowpaper/paper.pdf
+		signature[len(signature)-1] = signature[len(signature)-1] + 27
+	}
+
+	return signature, nil
+}
+
+// prefixedHash returns the hash of the given message prefixed the same way
+// as by the eth_sign JSON-RPC method.
+func prefixedHash(message []byte) []byte {
+	return crypto.Keccak256(
+		[]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%v", len(message))),
+		message,
+	)
+}
+
+// transactionSender returns the address of the account which signed the
+// given transaction. Both transactions protected against replay on other
+// chains and unprotected ones are supported.
+func transactionSender(transaction *types.Transaction) (common.Address, error) {
+	return types.Sender(
+		types.NewEIP155Signer(transaction.ChainId()),
+		transaction,
+	)
+}
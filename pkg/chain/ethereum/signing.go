@@ -18,43 +18,24 @@ import (
 const SignatureSize = 65
 
 type ethereumSigning struct {
-	operatorKey *ecdsa.PrivateKey
+	signer operatorSigner
 }
 
 func (ec *ethereumChain) Signing() chain.Signing {
-	return &ethereumSigning{ec.accountKey.PrivateKey}
+	return &ethereumSigning{ec.signer}
 }
 
 func (es *ethereumSigning) PublicKey() []byte {
-	publicKey := es.operatorKey.PublicKey
+	publicKey := es.signer.publicKey()
 	return elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y)
 }
 
 func (es *ethereumSigning) Sign(message []byte) ([]byte, error) {
-	prefixedHash := crypto.Keccak256(
-		[]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%v", len(message))),
-		message,
-	)
-
-	signature, err := crypto.Sign(prefixedHash, es.operatorKey)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(signature) == SignatureSize {
-		// go-ethereum/crypto produces signature with v={0, 1} and we need to add
-		// 27 to v-part (signature[64]) to conform wtih the on-chain signature
-		// validation code that accepts v={27, 28} as specified in the
-		// Appendix F of the Ethereum Yellow Paper
-		// https://ethereum.github.io/yellowpaper/paper.pdf
-		signature[len(signature)-1] = signature[len(signature)-1] + 27
-	}
-
-	return signature, nil
+	return es.signer.signMessage(message)
 }
 
 func (es *ethereumSigning) Verify(message []byte, signature []byte) (bool, error) {
-	return verifySignature(message, signature, &es.operatorKey.PublicKey)
+	return verifySignature(message, signature, es.signer.publicKey())
 }
 
 func (es *ethereumSigning) VerifyWithPublicKey(
@@ -64,7 +45,7 @@ func (es *ethereumSigning) VerifyWithPublicKey(
 ) (bool, error) {
 	unmarshalledPubKey, err := unmarshalPublicKey(
 		publicKey,
-		es.operatorKey.Curve,
+		es.signer.publicKey().Curve,
 	)
 	if err != nil {
 		return false, err
@@ -95,14 +76,9 @@ func verifySignature(
 		)
 	}
 
-	prefixedHash := crypto.Keccak256(
-		[]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%v", len(message))),
-		message,
-	)
-
 	return crypto.VerifySignature(
 		uncompressedPubKey,
-		prefixedHash,
+		prefixedHash(message),
 		signature,
 	), nil
 }
@@ -129,3 +105,16 @@ func (es *ethereumSigning) PublicKeyBytesToAddress(publicKey []byte) []byte {
 	// Does the same as crypto.PubkeyToAddress but directly on public key bytes.
 	return crypto.Keccak256(publicKey[1:])[12:]
 }
+
+// NewSigning returns signing of the operator configured in the given config.
+// It does not connect to the Ethereum node so it can be used offline. When
+// the operator key is held by an external signer, the external signer has to
+// be reachable.
+func NewSigning(config Config) (chain.Signing, error) {
+	signer, _, err := connectSigner(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ethereumSigning{signer}, nil
+}
@@ -3,6 +3,7 @@ package ethereum
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -161,6 +162,13 @@ func newSigning() (*ethereumSigning, error) {
 		return nil, err
 	}
 
-	return &ethereumSigning{key}, nil
+	return &ethereumSigning{
+		&keySigner{
+			&keystore.Key{
+				Address:    crypto.PubkeyToAddress(key.PublicKey),
+				PrivateKey: key,
+			},
+		},
+	}, nil
 
 }
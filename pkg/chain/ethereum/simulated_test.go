@@ -0,0 +1,118 @@
+package ethereum
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/internal/ethereumtest"
+)
+
+func TestConnectToSimulatedChain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "simulated-chain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, keyFile, err := ethereumtest.NewAccount(dir, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend, err := ethereumtest.NewBackend(key.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+
+	// No contracts are deployed; only the connection, block counting and
+	// transaction tracking are exercised.
+	contractAddress := "0x0b185C37E1C9D01437c800a8B60fA0845742c271"
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	chain, err := connect(ctx, Config{
+		Config: ethereum.Config{
+			URL: backend.URL(),
+			ContractAddresses: map[string]string{
+				"KeepRandomBeaconOperator": contractAddress,
+				"TokenStaking":             contractAddress,
+			},
+			Account: ethereum.Account{
+				Address:         key.Address.Hex(),
+				KeyFile:         keyFile,
+				KeyFilePassword: "password",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend.Mine(2)
+
+	waiter, err := chain.blockCounter.BlockHeightWaiter(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-waiter:
+	case <-time.After(5 * time.Second):
+		t.Fatal("block counter did not follow the chain")
+	}
+
+	recipient := common.HexToAddress("0x524f2e0176350d950fa630d9a5a59a0a190daf48")
+	outcomeChannel, err := chain.submitTransaction(
+		func() (*types.Transaction, error) {
+			ctx := context.Background()
+
+			nonce, err := chain.client.PendingNonceAt(ctx, key.Address)
+			if err != nil {
+				return nil, err
+			}
+
+			transaction, err := chain.signer.signTransaction(
+				ctx,
+				types.NewTransaction(
+					nonce,
+					recipient,
+					big.NewInt(1000),
+					21000,
+					big.NewInt(1),
+					nil,
+				),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return transaction, chain.client.SendTransaction(ctx, transaction)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The transaction has been mined in its own block; the transaction
+	// manager checks pending transactions when it sees the next one.
+	backend.Mine(1)
+
+	select {
+	case outcome := <-outcomeChannel:
+		if outcome.err != nil {
+			t.Fatalf("unexpected error: [%v]", outcome.err)
+		}
+		if outcome.receipt.Status != types.ReceiptStatusSuccessful {
+			t.Errorf("unexpected receipt status: [%v]", outcome.receipt.Status)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected transaction outcome")
+	}
+}
@@ -1,12 +1,15 @@
 package ethereum
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 type ethereumStakeMonitor struct {
@@ -32,6 +35,108 @@ func (esm *ethereumStakeMonitor) StakerFor(address string) (chain.Staker, error)
 	}, nil
 }
 
+// OnStakeChanged watches TokenStaking events changing the stake of
+// an operator: Staked, TokensSlashed, TokensSeized and RecoveredStake.
+func (esm *ethereumStakeMonitor) OnStakeChanged(
+	handler func(change *chain.StakeChange),
+) (subscription.EventSubscription, error) {
+	filterer := esm.ethereum.stakingFilterer
+
+	stakeChangeHandler := func(
+		parse func(log types.Log) (common.Address, error),
+	) func(log types.Log) error {
+		return func(log types.Log) error {
+			operator, err := parse(log)
+			if err != nil {
+				return err
+			}
+
+			handler(&chain.StakeChange{
+				Operator:    operator.Hex(),
+				BlockNumber: log.BlockNumber,
+			})
+			return nil
+		}
+	}
+
+	events := map[string]func(log types.Log) (common.Address, error){
+		"Staked": func(log types.Log) (common.Address, error) {
+			parsed, err := filterer.ParseStaked(log)
+			if err != nil {
+				return common.Address{}, err
+			}
+			// Staked event is emitted with the operator the tokens
+			// are delegated to.
+			return parsed.From, nil
+		},
+		"TokensSlashed": func(log types.Log) (common.Address, error) {
+			parsed, err := filterer.ParseTokensSlashed(log)
+			if err != nil {
+				return common.Address{}, err
+			}
+			return parsed.Operator, nil
+		},
+		"TokensSeized": func(log types.Log) (common.Address, error) {
+			parsed, err := filterer.ParseTokensSeized(log)
+			if err != nil {
+				return common.Address{}, err
+			}
+			return parsed.Operator, nil
+		},
+		"RecoveredStake": func(log types.Log) (common.Address, error) {
+			parsed, err := filterer.ParseRecoveredStake(log)
+			if err != nil {
+				return common.Address{}, err
+			}
+			return parsed.Operator, nil
+		},
+	}
+
+	subscriptions := make([]subscription.EventSubscription, 0, len(events))
+	unsubscribeAll := func() {
+		for _, eventSubscription := range subscriptions {
+			eventSubscription.Unsubscribe()
+		}
+	}
+
+	for eventName, parse := range events {
+		eventSubscription, err := esm.ethereum.watchStakingEvent(
+			eventName,
+			stakeChangeHandler(parse),
+			nil,
+		)
+		if err != nil {
+			unsubscribeAll()
+			return nil, err
+		}
+		subscriptions = append(subscriptions, eventSubscription)
+	}
+
+	return subscription.NewEventSubscription(unsubscribeAll), nil
+}
+
+// OnUndelegation watches TokenStaking Undelegated events.
+func (esm *ethereumStakeMonitor) OnUndelegation(
+	handler func(undelegation *chain.Undelegation),
+) (subscription.EventSubscription, error) {
+	return esm.ethereum.watchStakingEvent(
+		"Undelegated",
+		func(log types.Log) error {
+			parsed, err := esm.ethereum.stakingFilterer.ParseUndelegated(log)
+			if err != nil {
+				return err
+			}
+
+			handler(&chain.Undelegation{
+				Operator:    parsed.Operator.Hex(),
+				BlockNumber: log.BlockNumber,
+			})
+			return nil
+		},
+		nil,
+	)
+}
+
 func (ec *ethereumChain) StakeMonitor() (chain.StakeMonitor, error) {
 	stakeMonitor := &ethereumStakeMonitor{
 		ethereum: ec,
@@ -50,5 +155,18 @@ func (es *ethereumStaker) Address() relaychain.StakerAddress {
 }
 
 func (es *ethereumStaker) Stake() (*big.Int, error) {
-	return es.ethereum.stakingContract.BalanceOf(common.HexToAddress(es.address))
+	var stake *big.Int
+	err := es.ethereum.withRetry(
+		context.Background(),
+		stakeCallType,
+		func(ctx context.Context) (err error) {
+			stake, err = es.ethereum.stakingCaller.BalanceOf(
+				callOptions(ctx),
+				common.HexToAddress(es.address),
+			)
+			return err
+		},
+	)
+
+	return stake, err
 }
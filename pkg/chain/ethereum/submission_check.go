@@ -0,0 +1,374 @@
+package ethereum
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
+)
+
+// checkRelayEntrySubmission simulates the submission of the given relay entry
+// with an eth_call before it is submitted in a transaction. If the simulated
+// submission succeeds, nil event and nil error are returned and the entry
+// should be submitted. If the simulated submission reverts because the entry
+// has already been submitted, the event of the existing submission is
+// returned. Otherwise, an error is returned and the entry should not be
+// submitted as the transaction would revert.
+//
+// If the simulation could not be completed, for example because of a network
+// failure, the entry should be submitted as if the simulation succeeded.
+func (ec *ethereumChain) checkRelayEntrySubmission(
+	entry []byte,
+) (*event.EntrySubmitted, error) {
+	simulationErr := ec.keepRandomBeaconOperatorContract.CallRelayEntry(
+		entry,
+		nil,
+	)
+	if simulationErr == nil {
+		return nil, nil
+	}
+
+	if classifyError(simulationErr) != revertError {
+		logger.Warningf(
+			"could not simulate relay entry submission: [%v]; "+
+				"submitting without simulation",
+			simulationErr,
+		)
+		return nil, nil
+	}
+
+	submission, err := ec.findRelayEntrySubmission()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"relay entry submission would revert: [%v]; could not check "+
+				"whether the entry has already been submitted: [%v]",
+			simulationErr,
+			err,
+		)
+	}
+	if submission == nil {
+		return nil, fmt.Errorf(
+			"relay entry submission would revert: [%v]",
+			simulationErr,
+		)
+	}
+
+	return &event.EntrySubmitted{
+		BlockNumber: submission.BlockNumber,
+	}, nil
+}
+
+// findRelayEntrySubmission looks for the submission of the entry for the
+// latest relay request. Only blocks within the relay entry timeout are
+// searched. Nil is returned if no entry has been submitted for the latest
+// request.
+func (ec *ethereumChain) findRelayEntrySubmission() (*types.Log, error) {
+	config, err := ec.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	filterOpts, err := ec.lookbackFilterOpts(config.RelayEntryTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var requests []types.Log
+	requestIterator, err :=
+		ec.keepRandomBeaconOperatorFilterer.FilterRelayEntryRequested(filterOpts)
+	if err != nil {
+		return nil, fmt.Errorf("could not filter relay requests: [%v]", err)
+	}
+	for requestIterator.Next() {
+		requests = append(requests, requestIterator.Event.Raw)
+	}
+	requestIterator.Close()
+	if err := requestIterator.Error(); err != nil {
+		return nil, fmt.Errorf("could not filter relay requests: [%v]", err)
+	}
+
+	var submissions []types.Log
+	submissionIterator, err :=
+		ec.keepRandomBeaconOperatorFilterer.FilterRelayEntrySubmitted(filterOpts)
+	if err != nil {
+		return nil, fmt.Errorf("could not filter relay entries: [%v]", err)
+	}
+	for submissionIterator.Next() {
+		submissions = append(submissions, submissionIterator.Event.Raw)
+	}
+	submissionIterator.Close()
+	if err := submissionIterator.Error(); err != nil {
+		return nil, fmt.Errorf("could not filter relay entries: [%v]", err)
+	}
+
+	return latestLogAfter(submissions, requests), nil
+}
+
+// checkDKGResultSubmission checks whether the given DKG result should be
+// submitted. If a result for the group has already been accepted, the event of
+// the existing submission is returned. Otherwise, the submission is simulated
+// with an eth_call. If the simulated submission succeeds, nil event and nil
+// error are returned and the result should be submitted. If the simulated
+// submission reverts because another result of the same group selection has
+// already been submitted, the event of that submission is returned.
+// Otherwise, an error is returned and the result should not be submitted as
+// the transaction would revert.
+//
+// If the simulation could not be completed, for example because of a network
+// failure, the result should be submitted as if the simulation succeeded.
+func (ec *ethereumChain) checkDKGResultSubmission(
+	participantIndex relaychain.GroupMemberIndex,
+	result *relaychain.DKGResult,
+	signaturesOnChainFormat []byte,
+	membersIndicesOnChainFormat []*big.Int,
+) (*event.DKGResultSubmission, error) {
+	isRegistered, err := ec.IsGroupRegistered(result.GroupPublicKey)
+	if err != nil {
+		logger.Warningf(
+			"could not check whether the group is already registered: [%v]",
+			err,
+		)
+	}
+	if isRegistered {
+		submission, err := ec.findDKGResultSubmission(result.GroupPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"DKG result has already been accepted; "+
+					"could not find its submission: [%v]",
+				err,
+			)
+		}
+		if submission == nil {
+			return nil, fmt.Errorf(
+				"DKG result has already been accepted; " +
+					"its submission is older than the result publication period",
+			)
+		}
+
+		return submission, nil
+	}
+
+	simulationErr := ec.keepRandomBeaconOperatorContract.CallSubmitDkgResult(
+		big.NewInt(int64(participantIndex)),
+		result.GroupPublicKey,
+		result.Misbehaved,
+		signaturesOnChainFormat,
+		membersIndicesOnChainFormat,
+		nil,
+	)
+	if simulationErr == nil {
+		return nil, nil
+	}
+
+	if classifyError(simulationErr) != revertError {
+		logger.Warningf(
+			"could not simulate DKG result submission: [%v]; "+
+				"submitting without simulation",
+			simulationErr,
+		)
+		return nil, nil
+	}
+
+	submission, err := ec.findCurrentDKGResultSubmission()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"DKG result submission would revert: [%v]; could not check "+
+				"whether a result has already been submitted: [%v]",
+			simulationErr,
+			err,
+		)
+	}
+	if submission == nil {
+		return nil, fmt.Errorf(
+			"DKG result submission would revert: [%v]",
+			simulationErr,
+		)
+	}
+
+	return submission, nil
+}
+
+// findDKGResultSubmission looks for the latest submission of a DKG result
+// with the given group public key. Only blocks within the result publication
+// period are searched. Nil is returned if no such result has been submitted.
+func (ec *ethereumChain) findDKGResultSubmission(
+	groupPublicKey []byte,
+) (*event.DKGResultSubmission, error) {
+	config, err := ec.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := ec.filterDKGResultSubmissions(
+		uint64(config.GroupSize) * config.ResultPublicationBlockStep,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *event.DKGResultSubmission
+	for _, submitted := range submissions {
+		if bytes.Equal(submitted.GroupPubKey, groupPublicKey) {
+			latest = toDKGResultSubmission(submitted)
+		}
+	}
+
+	return latest, nil
+}
+
+// findCurrentDKGResultSubmission looks for the latest submission of a DKG
+// result of the group being currently created, that is, a result submitted
+// after the latest group selection started. Results of earlier groups are
+// ignored. Only blocks within the group creation period are searched. Nil is
+// returned if no result has been submitted for the current group or if the
+// start of the current group selection could not be found.
+func (ec *ethereumChain) findCurrentDKGResultSubmission() (
+	*event.DKGResultSubmission,
+	error,
+) {
+	config, err := ec.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	lookbackBlocks := config.TicketSubmissionTimeout +
+		config.DKGTimeout +
+		uint64(config.GroupSize)*config.ResultPublicationBlockStep
+
+	filterOpts, err := ec.lookbackFilterOpts(lookbackBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	var selections []types.Log
+	selectionIterator, err :=
+		ec.keepRandomBeaconOperatorFilterer.FilterGroupSelectionStarted(filterOpts)
+	if err != nil {
+		return nil, fmt.Errorf("could not filter group selections: [%v]", err)
+	}
+	for selectionIterator.Next() {
+		selections = append(selections, selectionIterator.Event.Raw)
+	}
+	selectionIterator.Close()
+	if err := selectionIterator.Error(); err != nil {
+		return nil, fmt.Errorf("could not filter group selections: [%v]", err)
+	}
+
+	submissions, err := ec.filterDKGResultSubmissions(lookbackBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return latestDKGResultSubmissionAfter(submissions, selections), nil
+}
+
+// filterDKGResultSubmissions returns submissions of DKG results from the
+// given number of most recent blocks, in the chain order.
+func (ec *ethereumChain) filterDKGResultSubmissions(
+	lookbackBlocks uint64,
+) ([]*abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent, error) {
+	filterOpts, err := ec.lookbackFilterOpts(lookbackBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err :=
+		ec.keepRandomBeaconOperatorFilterer.FilterDkgResultSubmittedEvent(filterOpts)
+	if err != nil {
+		return nil, fmt.Errorf("could not filter DKG results: [%v]", err)
+	}
+	defer iterator.Close()
+
+	var submissions []*abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent
+	for iterator.Next() {
+		submissions = append(submissions, iterator.Event)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf("could not filter DKG results: [%v]", err)
+	}
+
+	return submissions, nil
+}
+
+// latestDKGResultSubmissionAfter returns the latest of the given DKG result
+// submissions if it has been emitted after the latest of the given group
+// selection starts. Nil is returned if there is no such submission or if
+// there are no group selection starts, as then it is not known which group
+// the submissions belong to. Logs are expected to be in the chain order.
+func latestDKGResultSubmissionAfter(
+	submissions []*abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent,
+	selections []types.Log,
+) *event.DKGResultSubmission {
+	if len(submissions) == 0 || len(selections) == 0 {
+		return nil
+	}
+
+	logs := make([]types.Log, len(submissions))
+	for i, submitted := range submissions {
+		logs[i] = submitted.Raw
+	}
+
+	if latestLogAfter(logs, selections) == nil {
+		return nil
+	}
+
+	return toDKGResultSubmission(submissions[len(submissions)-1])
+}
+
+func toDKGResultSubmission(
+	submitted *abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent,
+) *event.DKGResultSubmission {
+	return &event.DKGResultSubmission{
+		MemberIndex:    uint32(submitted.MemberIndex.Uint64()),
+		GroupPublicKey: submitted.GroupPubKey,
+		Misbehaved:     submitted.Misbehaved,
+		BlockNumber:    submitted.Raw.BlockNumber,
+	}
+}
+
+// lookbackFilterOpts returns options filtering logs from the given number of
+// most recent blocks.
+func (ec *ethereumChain) lookbackFilterOpts(
+	lookbackBlocks uint64,
+) (*bind.FilterOpts, error) {
+	currentBlock, err := ec.blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("could not get current block: [%v]", err)
+	}
+
+	startBlock := uint64(0)
+	if currentBlock > lookbackBlocks {
+		startBlock = currentBlock - lookbackBlocks
+	}
+
+	return &bind.FilterOpts{Start: startBlock}, nil
+}
+
+// latestLogAfter returns the latest of the given logs which has been emitted
+// after the latest of the given preceding logs. If there are no preceding
+// logs, the latest of the given logs is returned. Nil is returned if there is
+// no such log. Logs are expected to be in the chain order.
+func latestLogAfter(logs []types.Log, preceding []types.Log) *types.Log {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	latest := logs[len(logs)-1]
+	if len(preceding) == 0 {
+		return &latest
+	}
+
+	latestPreceding := preceding[len(preceding)-1]
+	if latest.BlockNumber > latestPreceding.BlockNumber ||
+		(latest.BlockNumber == latestPreceding.BlockNumber &&
+			latest.Index > latestPreceding.Index) {
+		return &latest
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package ethereum
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
+)
+
+func TestLatestLogAfter(t *testing.T) {
+	var tests = map[string]struct {
+		logs        []types.Log
+		preceding   []types.Log
+		expectedLog *types.Log
+	}{
+		"no logs": {
+			logs:        []types.Log{},
+			preceding:   []types.Log{{BlockNumber: 10}},
+			expectedLog: nil,
+		},
+		"no preceding logs": {
+			logs:        []types.Log{{BlockNumber: 5}, {BlockNumber: 8}},
+			preceding:   []types.Log{},
+			expectedLog: &types.Log{BlockNumber: 8},
+		},
+		"log after the latest preceding log": {
+			logs:        []types.Log{{BlockNumber: 5}, {BlockNumber: 12}},
+			preceding:   []types.Log{{BlockNumber: 3}, {BlockNumber: 10}},
+			expectedLog: &types.Log{BlockNumber: 12},
+		},
+		"log before the latest preceding log": {
+			logs:        []types.Log{{BlockNumber: 5}},
+			preceding:   []types.Log{{BlockNumber: 3}, {BlockNumber: 10}},
+			expectedLog: nil,
+		},
+		"log after the preceding log in the same block": {
+			logs:        []types.Log{{BlockNumber: 10, Index: 4}},
+			preceding:   []types.Log{{BlockNumber: 10, Index: 2}},
+			expectedLog: &types.Log{BlockNumber: 10, Index: 4},
+		},
+		"log before the preceding log in the same block": {
+			logs:        []types.Log{{BlockNumber: 10, Index: 1}},
+			preceding:   []types.Log{{BlockNumber: 10, Index: 2}},
+			expectedLog: nil,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			log := latestLogAfter(test.logs, test.preceding)
+			if !reflect.DeepEqual(test.expectedLog, log) {
+				t.Errorf(
+					"unexpected log\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedLog,
+					log,
+				)
+			}
+		})
+	}
+}
+
+func TestLatestDKGResultSubmissionAfter(t *testing.T) {
+	submission := func(
+		blockNumber uint64,
+		groupPublicKey byte,
+	) *abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent {
+		return &abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent{
+			MemberIndex: big.NewInt(1),
+			GroupPubKey: []byte{groupPublicKey},
+			Misbehaved:  []byte{},
+			Raw:         types.Log{BlockNumber: blockNumber},
+		}
+	}
+
+	var tests = map[string]struct {
+		submissions        []*abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent
+		selections         []types.Log
+		expectedSubmission *event.DKGResultSubmission
+	}{
+		"no submissions": {
+			submissions:        nil,
+			selections:         []types.Log{{BlockNumber: 10}},
+			expectedSubmission: nil,
+		},
+		"submission of the current group": {
+			submissions: []*abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent{
+				submission(5, 1),
+				submission(20, 2),
+			},
+			selections: []types.Log{{BlockNumber: 10}},
+			expectedSubmission: &event.DKGResultSubmission{
+				MemberIndex:    1,
+				GroupPublicKey: []byte{2},
+				Misbehaved:     []byte{},
+				BlockNumber:    20,
+			},
+		},
+		"submission of a previous group": {
+			submissions: []*abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent{
+				submission(5, 1),
+			},
+			selections:         []types.Log{{BlockNumber: 10}},
+			expectedSubmission: nil,
+		},
+		"no group selection": {
+			submissions: []*abi.KeepRandomBeaconOperatorDkgResultSubmittedEvent{
+				submission(5, 1),
+			},
+			selections:         nil,
+			expectedSubmission: nil,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			submission := latestDKGResultSubmissionAfter(
+				test.submissions,
+				test.selections,
+			)
+			if !reflect.DeepEqual(test.expectedSubmission, submission) {
+				t.Errorf(
+					"unexpected submission\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedSubmission,
+					submission,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,622 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// transactionCallTimeout is a timeout for a single call made to the Ethereum
+// client by the transaction manager.
+const transactionCallTimeout = 10 * time.Second
+
+// resignedRetentionNonces is the number of nonces after which the record of
+// a re-signed transaction is dropped if the transaction has not been
+// submitted through the transaction manager.
+const resignedRetentionNonces = 16
+
+// transactionOutcome is the final result of a transaction tracked by the
+// transaction manager. If the transaction has been mined, receipt is set.
+// If the transaction has been mined but reverted, or if it has been
+// abandoned, err is set.
+type transactionOutcome struct {
+	receipt *types.Receipt
+	err     error
+}
+
+// pendingTransaction is a transaction submitted to the chain but not yet
+// mined. All submission attempts share the same nonce and differ only in the
+// gas price; any of them can be eventually mined. Once registered, pending
+// transaction is modified only by the goroutine monitoring new blocks.
+type pendingTransaction struct {
+	nonce               uint64
+	attempts            []*types.Transaction
+	lastSubmissionBlock uint64
+	resubmissions       uint64
+	outcome             chan transactionOutcome
+}
+
+func (pt *pendingTransaction) latestAttempt() *types.Transaction {
+	return pt.attempts[len(pt.attempts)-1]
+}
+
+// attempt returns the submission attempt with the given hash or nil if there
+// is no such attempt.
+func (pt *pendingTransaction) attempt(hash common.Hash) *types.Transaction {
+	for _, attempt := range pt.attempts {
+		if attempt.Hash() == hash {
+			return attempt
+		}
+	}
+
+	return nil
+}
+
+// accountTransactions holds the state of transactions submitted from one
+// account.
+type accountTransactions struct {
+	// nextNonce is the nonce which should be used for the next transaction
+	// submitted from the account, as seen by the transaction manager. It is
+	// valid only if nonceKnown is true.
+	nextNonce  uint64
+	nonceKnown bool
+
+	pending map[uint64]*pendingTransaction
+}
+
+// transactionManager tracks transactions submitted to the chain by the
+// client. It makes sure subsequent transactions get consecutive nonces and
+// resubmits transactions which have not been mined within the configured
+// number of blocks with a higher gas price. Each tracked transaction has an
+// outcome delivered once the transaction is mined or finally abandoned.
+type transactionManager struct {
+	transactor bind.ContractTransactor
+	receipts   bind.DeployBackend
+	signer     operatorSigner
+	config     TransactionsConfig
+
+	// maxGasPrice is the gas price resubmissions never exceed; nil if there
+	// is no maximum.
+	maxGasPrice *big.Int
+
+	mutex    sync.Mutex
+	accounts map[common.Address]*accountTransactions
+
+	// resigned maps hashes of transactions signed by contract bindings with
+	// a key other than the operator key to the transactions re-signed by the
+	// operator signer which were actually sent.
+	resigned map[common.Hash]*types.Transaction
+}
+
+func newTransactionManager(
+	transactor bind.ContractTransactor,
+	receipts bind.DeployBackend,
+	signer operatorSigner,
+	config TransactionsConfig,
+	maxGasPrice *big.Int,
+) *transactionManager {
+	return &transactionManager{
+		transactor:  transactor,
+		receipts:    receipts,
+		signer:      signer,
+		config:      config,
+		maxGasPrice: maxGasPrice,
+		accounts:    make(map[common.Address]*accountTransactions),
+		resigned:    make(map[common.Hash]*types.Transaction),
+	}
+}
+
+// wrap returns a contract backend which delegates all the calls to the
+// given backend except for nonce resolution and transaction submission,
+// which are routed through the transaction manager. Contract bindings should
+// be created with the returned backend so that all transactions they submit
+// are tracked.
+//
+// Contract bindings sign transactions with the key of the given binding
+// account. If the binding account is not the operator account, as is the
+// case when the operator key is held by an external signer, the returned
+// backend treats calls made from the binding account as made from the
+// operator account and re-signs transactions of the binding account with
+// the operator signer before they are sent.
+func (tm *transactionManager) wrap(
+	backend bind.ContractBackend,
+	bindingAccount common.Address,
+) bind.ContractBackend {
+	return &managedBackend{backend, tm, bindingAccount}
+}
+
+// start starts monitoring pending transactions. Pending transactions are
+// checked with every new block until the context is done.
+func (tm *transactionManager) start(
+	ctx context.Context,
+	blockCounter chain.BlockCounter,
+) {
+	blocks := blockCounter.WatchBlocks(ctx)
+
+	go func() {
+		for {
+			select {
+			case blockNumber, ok := <-blocks:
+				if !ok {
+					return
+				}
+				tm.checkPending(blockNumber)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// submit executes the given submission function and starts tracking the
+// transaction it returned. The returned channel receives the transaction
+// outcome once the transaction is mined or abandoned.
+func (tm *transactionManager) submit(
+	currentBlock uint64,
+	submitFn func() (*types.Transaction, error),
+) (<-chan transactionOutcome, error) {
+	transaction, err := submitFn()
+	if err != nil {
+		return nil, err
+	}
+
+	transaction = tm.sentTransaction(transaction)
+
+	from, err := transactionSender(transaction)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not determine sender of transaction [%v]: [%v]",
+			transaction.Hash().Hex(),
+			err,
+		)
+	}
+
+	pending := &pendingTransaction{
+		nonce:               transaction.Nonce(),
+		attempts:            []*types.Transaction{transaction},
+		lastSubmissionBlock: currentBlock,
+		outcome:             make(chan transactionOutcome, 1),
+	}
+
+	tm.mutex.Lock()
+	tm.account(from).pending[pending.nonce] = pending
+	tm.mutex.Unlock()
+
+	logger.Debugf(
+		"tracking transaction [%v] with nonce [%v] submitted at block [%v]",
+		transaction.Hash().Hex(),
+		pending.nonce,
+		currentBlock,
+	)
+
+	return pending.outcome, nil
+}
+
+// sentTransaction returns the transaction which was actually sent in place
+// of the given one. It is the given transaction itself unless it has been
+// re-signed with the operator signer.
+func (tm *transactionManager) sentTransaction(
+	transaction *types.Transaction,
+) *types.Transaction {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	if resigned, ok := tm.resigned[transaction.Hash()]; ok {
+		delete(tm.resigned, transaction.Hash())
+		return resigned
+	}
+
+	return transaction
+}
+
+// recordResigned records that the given re-signed transaction was sent in
+// place of the original one. Records of transactions which have not been
+// submitted through the manager are dropped once the nonce moves further
+// than resignedRetentionNonces ahead of them.
+func (tm *transactionManager) recordResigned(
+	original *types.Transaction,
+	resigned *types.Transaction,
+) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	for hash, transaction := range tm.resigned {
+		if transaction.Nonce()+resignedRetentionNonces < resigned.Nonce() {
+			delete(tm.resigned, hash)
+		}
+	}
+
+	tm.resigned[original.Hash()] = resigned
+}
+
+// account returns the state of transactions for the given account. It must
+// be called with the manager's mutex held.
+func (tm *transactionManager) account(address common.Address) *accountTransactions {
+	account, ok := tm.accounts[address]
+	if !ok {
+		account = &accountTransactions{
+			pending: make(map[uint64]*pendingTransaction),
+		}
+		tm.accounts[address] = account
+	}
+
+	return account
+}
+
+// pendingNonceAt returns the nonce that should be used for the next
+// transaction submitted from the given account. It is the higher of the
+// pending nonce reported by the chain and the next nonce known by the
+// transaction manager, so that transactions submitted in short order never
+// share a nonce even if the client has not yet seen all of them.
+func (tm *transactionManager) pendingNonceAt(
+	ctx context.Context,
+	address common.Address,
+) (uint64, error) {
+	chainNonce, err := tm.transactor.PendingNonceAt(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	account := tm.account(address)
+	if account.nonceKnown && account.nextNonce > chainNonce {
+		return account.nextNonce, nil
+	}
+
+	return chainNonce, nil
+}
+
+// sendTransaction sends the given transaction and records its nonce as used.
+func (tm *transactionManager) sendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	if err := tm.transactor.SendTransaction(ctx, transaction); err != nil {
+		return err
+	}
+
+	from, err := transactionSender(transaction)
+	if err != nil {
+		logger.Warningf(
+			"could not determine sender of transaction [%v]: [%v]",
+			transaction.Hash().Hex(),
+			err,
+		)
+		return nil
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	account := tm.account(from)
+	if !account.nonceKnown || transaction.Nonce() >= account.nextNonce {
+		account.nextNonce = transaction.Nonce() + 1
+		account.nonceKnown = true
+	}
+
+	return nil
+}
+
+// checkPending checks all pending transactions at the given block. Mined
+// transactions are completed, stuck transactions are resubmitted with a
+// higher gas price or abandoned if they were resubmitted too many times.
+func (tm *transactionManager) checkPending(blockNumber uint64) {
+	tm.mutex.Lock()
+	pendingTransactions := make([]*pendingTransaction, 0)
+	for _, account := range tm.accounts {
+		for _, pending := range account.pending {
+			pendingTransactions = append(pendingTransactions, pending)
+		}
+	}
+	tm.mutex.Unlock()
+
+	for _, pending := range pendingTransactions {
+		tm.checkTransaction(pending, blockNumber)
+	}
+}
+
+func (tm *transactionManager) checkTransaction(
+	pending *pendingTransaction,
+	blockNumber uint64,
+) {
+	receipt, err := tm.findReceipt(pending)
+	if err != nil {
+		logger.Warningf(
+			"could not check receipt of transaction with nonce [%v]: [%v]",
+			pending.nonce,
+			err,
+		)
+		return
+	}
+
+	if receipt != nil {
+		var outcomeErr error
+		if receipt.Status == types.ReceiptStatusFailed {
+			outcomeErr = fmt.Errorf(
+				"transaction [%v] reverted at block [%v]",
+				receipt.TxHash.Hex(),
+				receipt.BlockNumber,
+			)
+		}
+
+		if minedAttempt := pending.attempt(receipt.TxHash); minedAttempt != nil {
+			observeGasSpent(receipt, minedAttempt.GasPrice())
+		}
+
+		logger.Debugf(
+			"transaction [%v] with nonce [%v] mined at block [%v]",
+			receipt.TxHash.Hex(),
+			pending.nonce,
+			receipt.BlockNumber,
+		)
+
+		tm.complete(pending, transactionOutcome{receipt, outcomeErr})
+		return
+	}
+
+	if blockNumber < pending.lastSubmissionBlock+tm.config.resubmitAfterBlocks() {
+		return
+	}
+
+	if pending.resubmissions >= tm.config.maxResubmissions() {
+		logger.Warningf(
+			"abandoning transaction [%v] with nonce [%v]; "+
+				"not mined after [%v] resubmissions",
+			pending.latestAttempt().Hash().Hex(),
+			pending.nonce,
+			pending.resubmissions,
+		)
+
+		tm.complete(pending, transactionOutcome{
+			err: fmt.Errorf(
+				"transaction [%v] not mined after [%v] resubmissions",
+				pending.latestAttempt().Hash().Hex(),
+				pending.resubmissions,
+			),
+		})
+		return
+	}
+
+	tm.resubmit(pending, blockNumber)
+}
+
+// findReceipt looks for a receipt of any of the submission attempts of the
+// given transaction. If none of them has been mined yet, nil is returned.
+func (tm *transactionManager) findReceipt(
+	pending *pendingTransaction,
+) (*types.Receipt, error) {
+	for i := len(pending.attempts) - 1; i >= 0; i-- {
+		ctx, cancel := context.WithTimeout(
+			context.Background(),
+			transactionCallTimeout,
+		)
+		receipt, err := tm.receipts.TransactionReceipt(
+			ctx,
+			pending.attempts[i].Hash(),
+		)
+		cancel()
+
+		if err == goethereum.NotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			return receipt, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// resubmit sends a replacement of the given pending transaction with the
+// same nonce and a gas price increased by the configured percentage. The gas
+// price is capped at the maximum gas price. If the capped gas price is not
+// high enough for the replacement to be accepted by Ethereum clients, the
+// transaction is not resubmitted.
+func (tm *transactionManager) resubmit(
+	pending *pendingTransaction,
+	blockNumber uint64,
+) {
+	previous := pending.latestAttempt()
+
+	gasPrice := bumpGasPrice(
+		previous.GasPrice(),
+		tm.config.gasPriceBumpPercent(),
+	)
+
+	// Resubmission is counted even if it fails so that a transaction which
+	// can't be replaced is eventually abandoned.
+	pending.resubmissions++
+	pending.lastSubmissionBlock = blockNumber
+
+	if tm.maxGasPrice != nil && gasPrice.Cmp(tm.maxGasPrice) > 0 {
+		gasPrice = new(big.Int).Set(tm.maxGasPrice)
+
+		minimumGasPrice := bumpGasPrice(
+			previous.GasPrice(),
+			minimumGasPriceBumpPercent,
+		)
+		if gasPrice.Cmp(minimumGasPrice) < 0 {
+			logger.Warningf(
+				"skipping resubmission of transaction [%v] with nonce [%v]; "+
+					"replacement gas price [%v] would exceed the maximum "+
+					"gas price [%v]",
+				previous.Hash().Hex(),
+				pending.nonce,
+				minimumGasPrice,
+				tm.maxGasPrice,
+			)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		transactionCallTimeout,
+	)
+	defer cancel()
+
+	replacement, err := tm.signer.signTransaction(
+		ctx,
+		types.NewTransaction(
+			previous.Nonce(),
+			*previous.To(),
+			previous.Value(),
+			previous.Gas(),
+			gasPrice,
+			previous.Data(),
+		),
+	)
+	if err != nil {
+		logger.Errorf(
+			"could not sign replacement of transaction [%v]: [%v]",
+			previous.Hash().Hex(),
+			err,
+		)
+		return
+	}
+
+	if err := tm.sendTransaction(ctx, replacement); err != nil {
+		logger.Warningf(
+			"could not resubmit transaction [%v] with gas price [%v]: [%v]",
+			previous.Hash().Hex(),
+			gasPrice,
+			err,
+		)
+		return
+	}
+
+	pending.attempts = append(pending.attempts, replacement)
+
+	logger.Infof(
+		"transaction [%v] with nonce [%v] not mined after [%v] blocks; "+
+			"resubmitted as [%v] with gas price [%v]",
+		previous.Hash().Hex(),
+		pending.nonce,
+		tm.config.resubmitAfterBlocks(),
+		replacement.Hash().Hex(),
+		gasPrice,
+	)
+}
+
+// bumpGasPrice returns the given gas price increased by the given percentage.
+func bumpGasPrice(gasPrice *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(gasPrice, big.NewInt(int64(100+percent)))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// complete stops tracking the given transaction and delivers its outcome.
+//
+// If the transaction has been abandoned, the next nonce known by the
+// transaction manager is dropped and the pending nonce reported by the chain
+// is used again. Abandoned transaction may eventually fall out of the
+// mempool; if the manager kept counting nonces from above it, all subsequent
+// transactions would wait for the gap to be filled forever.
+func (tm *transactionManager) complete(
+	pending *pendingTransaction,
+	outcome transactionOutcome,
+) {
+	tm.mutex.Lock()
+	for _, account := range tm.accounts {
+		if account.pending[pending.nonce] == pending {
+			delete(account.pending, pending.nonce)
+
+			if outcome.receipt == nil {
+				account.nonceKnown = false
+			}
+		}
+	}
+	tm.mutex.Unlock()
+
+	pending.outcome <- outcome
+}
+
+// managedBackend is a contract backend routing nonce resolution and
+// transaction submission through the transaction manager.
+type managedBackend struct {
+	bind.ContractBackend
+
+	manager        *transactionManager
+	bindingAccount common.Address
+}
+
+// operatorAccount returns the operator account if the given account is the
+// binding account and the given account otherwise.
+func (mb *managedBackend) operatorAccount(account common.Address) common.Address {
+	if account == mb.bindingAccount {
+		return mb.manager.signer.address()
+	}
+
+	return account
+}
+
+func (mb *managedBackend) CallContract(
+	ctx context.Context,
+	call goethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	call.From = mb.operatorAccount(call.From)
+	return mb.ContractBackend.CallContract(ctx, call, blockNumber)
+}
+
+func (mb *managedBackend) EstimateGas(
+	ctx context.Context,
+	call goethereum.CallMsg,
+) (uint64, error) {
+	call.From = mb.operatorAccount(call.From)
+	return mb.ContractBackend.EstimateGas(ctx, call)
+}
+
+func (mb *managedBackend) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	return mb.manager.pendingNonceAt(ctx, mb.operatorAccount(account))
+}
+
+func (mb *managedBackend) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	if mb.bindingAccount != mb.manager.signer.address() {
+		sender, err := transactionSender(transaction)
+		if err != nil {
+			return fmt.Errorf(
+				"could not determine sender of transaction [%v]: [%v]",
+				transaction.Hash().Hex(),
+				err,
+			)
+		}
+
+		if sender == mb.bindingAccount {
+			resigned, err := mb.manager.signer.signTransaction(
+				ctx,
+				transaction,
+			)
+			if err != nil {
+				return err
+			}
+
+			if err := mb.manager.sendTransaction(ctx, resigned); err != nil {
+				return err
+			}
+
+			mb.manager.recordResigned(transaction, resigned)
+			return nil
+		}
+	}
+
+	return mb.manager.sendTransaction(ctx, transaction)
+}
@@ -0,0 +1,474 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestPendingNonceAtUsesLocallyTrackedNonce(t *testing.T) {
+	backend := newMockTransactionBackend()
+	key := newTestAccountKey(t)
+	manager := newTransactionManager(
+		backend,
+		backend,
+		&keySigner{key},
+		TransactionsConfig{},
+		nil,
+	)
+
+	backend.pendingNonce = 5
+
+	nonce, err := manager.pendingNonceAt(context.Background(), key.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 5 {
+		t.Fatalf("unexpected nonce\nexpected: [%v]\nactual:   [%v]", 5, nonce)
+	}
+
+	// The transaction is sent but the client still reports the old pending
+	// nonce, for example because it is behind a load balancer.
+	err = manager.sendTransaction(
+		context.Background(),
+		signTestTransaction(t, key, 5, big.NewInt(10)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce, err = manager.pendingNonceAt(context.Background(), key.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 6 {
+		t.Fatalf("unexpected nonce\nexpected: [%v]\nactual:   [%v]", 6, nonce)
+	}
+}
+
+func TestResubmitStuckTransactionWithHigherGasPrice(t *testing.T) {
+	backend := newMockTransactionBackend()
+	key := newTestAccountKey(t)
+	manager := newTransactionManager(
+		backend,
+		backend,
+		&keySigner{key},
+		TransactionsConfig{
+			ResubmitAfterBlocks: 2,
+			GasPriceBumpPercent: 50,
+		},
+		nil,
+	)
+
+	outcomeChannel, err := manager.submit(
+		100,
+		func() (*types.Transaction, error) {
+			return signTestTransaction(t, key, 0, big.NewInt(10)), nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager.checkPending(101)
+	if len(backend.sentTransactions()) != 0 {
+		t.Fatalf("transaction resubmitted too early")
+	}
+
+	manager.checkPending(102)
+	sent := backend.sentTransactions()
+	if len(sent) != 1 {
+		t.Fatalf(
+			"unexpected number of resubmissions\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(sent),
+		)
+	}
+
+	replacement := sent[0]
+	if replacement.Nonce() != 0 {
+		t.Errorf(
+			"unexpected replacement nonce\nexpected: [%v]\nactual:   [%v]",
+			0,
+			replacement.Nonce(),
+		)
+	}
+	if replacement.GasPrice().Cmp(big.NewInt(15)) != 0 {
+		t.Errorf(
+			"unexpected replacement gas price\nexpected: [%v]\nactual:   [%v]",
+			15,
+			replacement.GasPrice(),
+		)
+	}
+
+	backend.mine(replacement.Hash(), 103, types.ReceiptStatusSuccessful)
+	manager.checkPending(103)
+
+	select {
+	case outcome := <-outcomeChannel:
+		if outcome.err != nil {
+			t.Fatalf("unexpected error: [%v]", outcome.err)
+		}
+		if outcome.receipt.TxHash != replacement.Hash() {
+			t.Errorf(
+				"unexpected mined transaction\nexpected: [%v]\nactual:   [%v]",
+				replacement.Hash().Hex(),
+				outcome.receipt.TxHash.Hex(),
+			)
+		}
+	default:
+		t.Fatal("expected transaction outcome")
+	}
+}
+
+func TestResubmitStuckTransactionWithCappedGasPrice(t *testing.T) {
+	var tests = map[string]struct {
+		maxGasPrice           int64
+		expectedResubmissions int
+		expectedGasPrice      int64
+	}{
+		"bumped gas price below the maximum": {
+			maxGasPrice:           20,
+			expectedResubmissions: 1,
+			expectedGasPrice:      15,
+		},
+		"bumped gas price capped at the maximum": {
+			maxGasPrice:           12,
+			expectedResubmissions: 1,
+			expectedGasPrice:      12,
+		},
+		"maximum too low for a replacement": {
+			maxGasPrice:           10,
+			expectedResubmissions: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			backend := newMockTransactionBackend()
+			key := newTestAccountKey(t)
+			manager := newTransactionManager(
+				backend,
+				backend,
+				&keySigner{key},
+				TransactionsConfig{
+					ResubmitAfterBlocks: 1,
+					GasPriceBumpPercent: 50,
+				},
+				big.NewInt(test.maxGasPrice),
+			)
+
+			_, err := manager.submit(
+				100,
+				func() (*types.Transaction, error) {
+					return signTestTransaction(t, key, 0, big.NewInt(10)), nil
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			manager.checkPending(101)
+
+			sent := backend.sentTransactions()
+			if len(sent) != test.expectedResubmissions {
+				t.Fatalf(
+					"unexpected number of resubmissions\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedResubmissions,
+					len(sent),
+				)
+			}
+			if len(sent) == 0 {
+				return
+			}
+
+			if sent[0].GasPrice().Cmp(big.NewInt(test.expectedGasPrice)) != 0 {
+				t.Errorf(
+					"unexpected replacement gas price\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedGasPrice,
+					sent[0].GasPrice(),
+				)
+			}
+		})
+	}
+}
+
+func TestAbandonTransactionAfterMaxResubmissions(t *testing.T) {
+	backend := newMockTransactionBackend()
+	key := newTestAccountKey(t)
+	manager := newTransactionManager(
+		backend,
+		backend,
+		&keySigner{key},
+		TransactionsConfig{
+			ResubmitAfterBlocks: 1,
+			MaxResubmissions:    2,
+		},
+		nil,
+	)
+
+	outcomeChannel, err := manager.submit(
+		100,
+		func() (*types.Transaction, error) {
+			return signTestTransaction(t, key, 0, big.NewInt(100)), nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for block := uint64(101); block <= 103; block++ {
+		manager.checkPending(block)
+	}
+
+	if len(backend.sentTransactions()) != 2 {
+		t.Fatalf(
+			"unexpected number of resubmissions\nexpected: [%v]\nactual:   [%v]",
+			2,
+			len(backend.sentTransactions()),
+		)
+	}
+
+	select {
+	case outcome := <-outcomeChannel:
+		if outcome.err == nil {
+			t.Fatal("expected abandoned transaction error")
+		}
+	default:
+		t.Fatal("expected transaction outcome")
+	}
+}
+
+func TestSubmitAfterAbandonedTransactionUsesChainNonce(t *testing.T) {
+	backend := newMockTransactionBackend()
+	key := newTestAccountKey(t)
+	manager := newTransactionManager(
+		backend,
+		backend,
+		&keySigner{key},
+		TransactionsConfig{
+			ResubmitAfterBlocks: 1,
+			MaxResubmissions:    1,
+		},
+		nil,
+	)
+
+	backend.pendingNonce = 5
+
+	outcomeChannel, err := manager.submit(
+		100,
+		func() (*types.Transaction, error) {
+			transaction := signTestTransaction(t, key, 5, big.NewInt(100))
+			return transaction, manager.sendTransaction(
+				context.Background(),
+				transaction,
+			)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce, err := manager.pendingNonceAt(context.Background(), key.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 6 {
+		t.Fatalf("unexpected nonce\nexpected: [%v]\nactual:   [%v]", 6, nonce)
+	}
+
+	for block := uint64(101); block <= 102; block++ {
+		manager.checkPending(block)
+	}
+
+	select {
+	case outcome := <-outcomeChannel:
+		if outcome.err == nil {
+			t.Fatal("expected abandoned transaction error")
+		}
+	default:
+		t.Fatal("expected transaction outcome")
+	}
+
+	// The abandoned transaction fell out of the mempool, so the next
+	// transaction must fill the gap it left.
+	nonce, err = manager.pendingNonceAt(context.Background(), key.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 5 {
+		t.Fatalf("unexpected nonce\nexpected: [%v]\nactual:   [%v]", 5, nonce)
+	}
+
+	err = manager.sendTransaction(
+		context.Background(),
+		signTestTransaction(t, key, nonce, big.NewInt(100)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce, err = manager.pendingNonceAt(context.Background(), key.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 6 {
+		t.Fatalf("unexpected nonce\nexpected: [%v]\nactual:   [%v]", 6, nonce)
+	}
+}
+
+func TestRevertedTransactionOutcome(t *testing.T) {
+	backend := newMockTransactionBackend()
+	key := newTestAccountKey(t)
+	manager := newTransactionManager(
+		backend,
+		backend,
+		&keySigner{key},
+		TransactionsConfig{},
+		nil,
+	)
+
+	transaction := signTestTransaction(t, key, 0, big.NewInt(10))
+	outcomeChannel, err := manager.submit(
+		100,
+		func() (*types.Transaction, error) {
+			return transaction, nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend.mine(transaction.Hash(), 101, types.ReceiptStatusFailed)
+	manager.checkPending(101)
+
+	select {
+	case outcome := <-outcomeChannel:
+		if outcome.err == nil {
+			t.Fatal("expected reverted transaction error")
+		}
+	default:
+		t.Fatal("expected transaction outcome")
+	}
+}
+
+func newTestAccountKey(t *testing.T) *keystore.Key {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+}
+
+func signTestTransaction(
+	t *testing.T,
+	key *keystore.Key,
+	nonce uint64,
+	gasPrice *big.Int,
+) *types.Transaction {
+	transaction, err := types.SignTx(
+		types.NewTransaction(
+			nonce,
+			common.HexToAddress("0x0b185C37E1C9D01437c800a8B60fA0845742c271"),
+			big.NewInt(0),
+			21000,
+			gasPrice,
+			[]byte{0x01},
+		),
+		types.HomesteadSigner{},
+		key.PrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transaction
+}
+
+type mockTransactionBackend struct {
+	bind.ContractTransactor
+	bind.DeployBackend
+
+	mutex        sync.Mutex
+	pendingNonce uint64
+	sent         []*types.Transaction
+	receipts     map[common.Hash]*types.Receipt
+}
+
+func newMockTransactionBackend() *mockTransactionBackend {
+	return &mockTransactionBackend{
+		receipts: make(map[common.Hash]*types.Receipt),
+	}
+}
+
+func (mtb *mockTransactionBackend) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	mtb.mutex.Lock()
+	defer mtb.mutex.Unlock()
+
+	return mtb.pendingNonce, nil
+}
+
+func (mtb *mockTransactionBackend) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	mtb.mutex.Lock()
+	defer mtb.mutex.Unlock()
+
+	mtb.sent = append(mtb.sent, transaction)
+	return nil
+}
+
+func (mtb *mockTransactionBackend) TransactionReceipt(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Receipt, error) {
+	mtb.mutex.Lock()
+	defer mtb.mutex.Unlock()
+
+	receipt, ok := mtb.receipts[hash]
+	if !ok {
+		return nil, goethereum.NotFound
+	}
+
+	return receipt, nil
+}
+
+func (mtb *mockTransactionBackend) mine(
+	hash common.Hash,
+	blockNumber uint64,
+	status uint64,
+) {
+	mtb.mutex.Lock()
+	defer mtb.mutex.Unlock()
+
+	mtb.receipts[hash] = &types.Receipt{
+		TxHash:      hash,
+		Status:      status,
+		BlockNumber: new(big.Int).SetUint64(blockNumber),
+	}
+}
+
+func (mtb *mockTransactionBackend) sentTransactions() []*types.Transaction {
+	mtb.mutex.Lock()
+	defer mtb.mutex.Unlock()
+
+	return append([]*types.Transaction{}, mtb.sent...)
+}
@@ -1,6 +1,7 @@
 package ethereum
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
@@ -9,13 +10,33 @@ import (
 
 func (euc *ethereumUtilityChain) Genesis() error {
 	// expressed in gas units
-	dkgGasEstimate, err := euc.keepRandomBeaconOperatorContract.DkgGasEstimate()
+	var dkgGasEstimate *big.Int
+	err := euc.withRetry(
+		context.Background(),
+		gasEstimateCallType,
+		func(ctx context.Context) (err error) {
+			dkgGasEstimate, err = euc.keepRandomBeaconOperatorCaller.DkgGasEstimate(
+				callOptions(ctx),
+			)
+			return err
+		},
+	)
 	if err != nil {
 		return err
 	}
 
 	// expressed in wei
-	gasPrice, err := euc.keepRandomBeaconOperatorContract.GasPriceCeiling()
+	var gasPrice *big.Int
+	err = euc.withRetry(
+		context.Background(),
+		configCallType,
+		func(ctx context.Context) (err error) {
+			gasPrice, err = euc.keepRandomBeaconOperatorCaller.GasPriceCeiling(
+				callOptions(ctx),
+			)
+			return err
+		},
+	)
 	if err != nil {
 		return err
 	}
@@ -30,7 +51,18 @@ func (euc *ethereumUtilityChain) RequestRelayEntry() *async.EventEntryGeneratedP
 	promise := &async.EventEntryGeneratedPromise{}
 
 	callbackGas := big.NewInt(0) // no callback
-	payment, err := euc.keepRandomBeaconServiceContract.EntryFeeEstimate(callbackGas)
+	var payment *big.Int
+	err := euc.withRetry(
+		context.Background(),
+		gasEstimateCallType,
+		func(ctx context.Context) (err error) {
+			payment, err = euc.keepRandomBeaconServiceCaller.EntryFeeEstimate(
+				callOptions(ctx),
+				callbackGas,
+			)
+			return err
+		},
+	)
 	if err != nil {
 		promise.Fail(err)
 		return promise
@@ -2,6 +2,7 @@ package local
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"encoding/binary"
@@ -52,6 +53,7 @@ type Chain interface {
 type localGroup struct {
 	groupPublicKey          []byte
 	registrationBlockHeight uint64
+	resultSubmission        *event.DKGResultSubmission
 }
 
 type localChain struct {
@@ -62,6 +64,7 @@ type localChain struct {
 	lastSubmittedDKGResult           *relaychain.DKGResult
 	lastSubmittedDKGResultSignatures map[relaychain.GroupMemberIndex][]byte
 	lastSubmittedRelayEntry          []byte
+	submittedRelayEntries            []*event.EntrySubmitted
 
 	handlerMutex                  sync.Mutex
 	relayEntryHandlers            map[int]func(entry *event.EntrySubmitted)
@@ -136,6 +139,18 @@ func (c *localChain) GetSubmittedTickets() ([]uint64, error) {
 	return tickets, nil
 }
 
+// GetTicketSubmissionEconomics returns zero cost and zero reward as
+// submitting tickets to the local chain is free.
+func (c *localChain) GetTicketSubmissionEconomics() (
+	*relaychain.TicketSubmissionEconomics,
+	error,
+) {
+	return &relaychain.TicketSubmissionEconomics{
+		SubmissionCost: big.NewInt(0),
+		ExpectedReward: big.NewInt(0),
+	}, nil
+}
+
 func (c *localChain) GetSelectedParticipants() ([]relaychain.StakerAddress, error) {
 	c.ticketsMutex.Lock()
 	defer c.ticketsMutex.Unlock()
@@ -174,6 +189,7 @@ func (c *localChain) SubmitRelayEntry(newEntry []byte) *async.EventEntrySubmitte
 	}
 
 	entry := &event.EntrySubmitted{
+		Entry:       newEntry,
 		BlockNumber: currentBlock,
 	}
 
@@ -188,6 +204,7 @@ func (c *localChain) SubmitRelayEntry(newEntry []byte) *async.EventEntrySubmitte
 	relayEntryPromise.Fulfill(entry)
 
 	c.lastSubmittedRelayEntry = newEntry
+	c.submittedRelayEntries = append(c.submittedRelayEntries, entry)
 
 	return relayEntryPromise
 }
@@ -209,6 +226,29 @@ func (c *localChain) OnRelayEntrySubmitted(
 	}), nil
 }
 
+// PastRelayEntryRequests returns no requests since relay entries are never
+// requested on the local chain.
+func (c *localChain) PastRelayEntryRequests(
+	ctx context.Context,
+	fromBlock uint64,
+) ([]*event.Request, error) {
+	return nil, nil
+}
+
+func (c *localChain) PastRelayEntriesSubmitted(
+	ctx context.Context,
+	fromBlock uint64,
+) ([]*event.EntrySubmitted, error) {
+	submissions := make([]*event.EntrySubmitted, 0)
+	for _, submission := range c.submittedRelayEntries {
+		if submission.BlockNumber >= fromBlock {
+			submissions = append(submissions, submission)
+		}
+	}
+
+	return submissions, nil
+}
+
 func (c *localChain) GetLastRelayEntry() []byte {
 	return c.lastSubmittedRelayEntry
 }
@@ -230,6 +270,14 @@ func (c *localChain) OnRelayEntryRequested(
 	}), nil
 }
 
+// OnRelayEntryRequestRemoved never invokes the handler since the local chain
+// is never reorganized.
+func (c *localChain) OnRelayEntryRequestRemoved(
+	handler func(request *event.Request),
+) (subscription.EventSubscription, error) {
+	return subscription.NewEventSubscription(func() {}), nil
+}
+
 func (c *localChain) OnGroupSelectionStarted(
 	handler func(entry *event.GroupSelectionStart),
 ) (subscription.EventSubscription, error) {
@@ -247,6 +295,23 @@ func (c *localChain) OnGroupSelectionStarted(
 	}), nil
 }
 
+// PastGroupSelectionsStarted returns no group selections since past group
+// selections are not tracked by the local chain.
+func (c *localChain) PastGroupSelectionsStarted(
+	ctx context.Context,
+	fromBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	return nil, nil
+}
+
+// OnGroupSelectionStartRemoved never invokes the handler since the local
+// chain is never reorganized.
+func (c *localChain) OnGroupSelectionStartRemoved(
+	handler func(entry *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	return subscription.NewEventSubscription(func() {}), nil
+}
+
 func (c *localChain) OnGroupRegistered(
 	handler func(groupRegistration *event.GroupRegistration),
 ) (subscription.EventSubscription, error) {
@@ -310,6 +375,9 @@ func ConnectWithKey(
 			ResultPublicationBlockStep: resultPublicationBlockStep,
 			MinimumStake:               minimumStake,
 			RelayEntryTimeout:          resultPublicationBlockStep * uint64(groupSize),
+			DKGTimeout:                 relayconfig.DefaultDKGTimeout,
+			GroupSelectionTiming:       relayconfig.DefaultGroupSelectionTiming(),
+			DKGTiming:                  relayconfig.DefaultDKGTiming(),
 		},
 		relayEntryHandlers:       make(map[int]func(request *event.EntrySubmitted)),
 		relayRequestHandlers:     make(map[int]func(request *event.Request)),
@@ -385,6 +453,28 @@ func (c *localChain) SubmitDKGResult(
 		return dkgResultPublicationPromise
 	}
 
+	// Same as the on-chain contract, do not accept the result twice. The
+	// promise is fulfilled with the existing submission instead.
+	for _, group := range c.groups {
+		if !bytes.Equal(group.groupPublicKey, resultToPublish.GroupPublicKey) {
+			continue
+		}
+
+		if group.resultSubmission == nil {
+			dkgResultPublicationPromise.Fail(fmt.Errorf(
+				"group with public key [%x] is already registered",
+				resultToPublish.GroupPublicKey,
+			))
+			return dkgResultPublicationPromise
+		}
+
+		err := dkgResultPublicationPromise.Fulfill(group.resultSubmission)
+		if err != nil {
+			logger.Errorf("failed to fulfill promise: [%v].", err)
+		}
+		return dkgResultPublicationPromise
+	}
+
 	currentBlock, err := c.blockCounter.CurrentBlock()
 	if err != nil {
 		dkgResultPublicationPromise.Fail(fmt.Errorf("cannot read current block"))
@@ -401,6 +491,7 @@ func (c *localChain) SubmitDKGResult(
 	myGroup := localGroup{
 		groupPublicKey:          resultToPublish.GroupPublicKey,
 		registrationBlockHeight: currentBlock,
+		resultSubmission:        dkgResultPublicationEvent,
 	}
 	c.groups = append(c.groups, myGroup)
 	c.lastSubmittedDKGResult = resultToPublish
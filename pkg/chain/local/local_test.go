@@ -618,6 +618,68 @@ func TestLocalSubmitDKGResult(t *testing.T) {
 	}
 }
 
+func TestLocalSubmitDKGResultAlreadySubmitted(t *testing.T) {
+	localChain := Connect(10, 4, big.NewInt(200)).(*localChain)
+
+	chainHandle := localChain.ThresholdRelay()
+
+	result := &relaychain.DKGResult{
+		GroupPublicKey: []byte{11},
+	}
+
+	signatures := map[relaychain.GroupMemberIndex][]byte{
+		1: []byte{101},
+		2: []byte{102},
+		3: []byte{103},
+		4: []byte{104},
+	}
+
+	firstSubmission := make(chan *event.DKGResultSubmission, 1)
+	chainHandle.SubmitDKGResult(1, result, signatures).OnSuccess(
+		func(submission *event.DKGResultSubmission) {
+			firstSubmission <- submission
+		},
+	)
+
+	secondSubmission := make(chan *event.DKGResultSubmission, 1)
+	chainHandle.SubmitDKGResult(2, result, signatures).OnSuccess(
+		func(submission *event.DKGResultSubmission) {
+			secondSubmission <- submission
+		},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var first, second *event.DKGResultSubmission
+	select {
+	case first = <-firstSubmission:
+	case <-ctx.Done():
+		t.Fatal("first submission promise has not been fulfilled")
+	}
+	select {
+	case second = <-secondSubmission:
+	case <-ctx.Done():
+		t.Fatal("second submission promise has not been fulfilled")
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf(
+			"unexpected submission\nexpected: [%+v]\nactual:   [%+v]",
+			first,
+			second,
+		)
+	}
+
+	if len(localChain.groups) != 2 {
+		t.Errorf(
+			"unexpected number of groups\nexpected: [%v]\nactual:   [%v]",
+			2,
+			len(localChain.groups),
+		)
+	}
+}
+
 func TestLocalSubmitDKGResultWithSignatures(t *testing.T) {
 	groupSize := 5
 	honestThreshold := 3
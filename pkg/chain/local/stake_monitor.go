@@ -3,10 +3,13 @@ package local
 import (
 	"fmt"
 	"math/big"
+	"math/rand"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 // StakeMonitor implements `chain.StakeMonitor` interface and works
@@ -14,13 +17,19 @@ import (
 type StakeMonitor struct {
 	minimumStake *big.Int
 	stakers      []*localStaker
+
+	handlerMutex         sync.Mutex
+	stakeChangedHandlers map[int]func(change *chain.StakeChange)
+	undelegationHandlers map[int]func(undelegation *chain.Undelegation)
 }
 
 // NewStakeMonitor creates a new instance of `StakeMonitor` test stub.
 func NewStakeMonitor(minimumStake *big.Int) *StakeMonitor {
 	return &StakeMonitor{
-		minimumStake: minimumStake,
-		stakers:      make([]*localStaker, 0),
+		minimumStake:         minimumStake,
+		stakers:              make([]*localStaker, 0),
+		stakeChangedHandlers: make(map[int]func(change *chain.StakeChange)),
+		undelegationHandlers: make(map[int]func(undelegation *chain.Undelegation)),
 	}
 }
 
@@ -84,6 +93,8 @@ func (lsm *StakeMonitor) StakeTokens(address string) error {
 
 	stakerLocal.stake = new(big.Int).Mul(big.NewInt(5), lsm.minimumStake)
 
+	lsm.notifyStakeChanged(address)
+
 	return nil
 }
 
@@ -102,9 +113,76 @@ func (lsm *StakeMonitor) UnstakeTokens(address string) error {
 
 	stakerLocal.stake = big.NewInt(0)
 
+	lsm.notifyStakeChanged(address)
+
 	return nil
 }
 
+// UndelegateTokens starts undelegation of the stake of the provided address.
+// The stake remains active but the address is no longer eligible for work
+// selection.
+func (lsm *StakeMonitor) UndelegateTokens(address string) error {
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("not a valid ethereum address: %v", address)
+	}
+
+	lsm.handlerMutex.Lock()
+	defer lsm.handlerMutex.Unlock()
+
+	for _, handler := range lsm.undelegationHandlers {
+		go handler(&chain.Undelegation{Operator: address})
+	}
+
+	return nil
+}
+
+// OnStakeChanged registers a callback invoked when tokens are staked or
+// unstaked with StakeTokens and UnstakeTokens.
+func (lsm *StakeMonitor) OnStakeChanged(
+	handler func(change *chain.StakeChange),
+) (subscription.EventSubscription, error) {
+	lsm.handlerMutex.Lock()
+	defer lsm.handlerMutex.Unlock()
+
+	handlerID := rand.Int()
+	lsm.stakeChangedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		lsm.handlerMutex.Lock()
+		defer lsm.handlerMutex.Unlock()
+
+		delete(lsm.stakeChangedHandlers, handlerID)
+	}), nil
+}
+
+// OnUndelegation registers a callback invoked when undelegation is started
+// with UndelegateTokens.
+func (lsm *StakeMonitor) OnUndelegation(
+	handler func(undelegation *chain.Undelegation),
+) (subscription.EventSubscription, error) {
+	lsm.handlerMutex.Lock()
+	defer lsm.handlerMutex.Unlock()
+
+	handlerID := rand.Int()
+	lsm.undelegationHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		lsm.handlerMutex.Lock()
+		defer lsm.handlerMutex.Unlock()
+
+		delete(lsm.undelegationHandlers, handlerID)
+	}), nil
+}
+
+func (lsm *StakeMonitor) notifyStakeChanged(address string) {
+	lsm.handlerMutex.Lock()
+	defer lsm.handlerMutex.Unlock()
+
+	for _, handler := range lsm.stakeChangedHandlers {
+		go handler(&chain.StakeChange{Operator: address})
+	}
+}
+
 type localStaker struct {
 	address string
 	stake   *big.Int
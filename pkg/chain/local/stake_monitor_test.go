@@ -5,6 +5,9 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/chain"
 )
 
 func TestDetectInvalidAddress(t *testing.T) {
@@ -158,3 +161,69 @@ func TestStake(t *testing.T) {
 		)
 	}
 }
+
+func TestNotifiesAboutStakeChanges(t *testing.T) {
+	monitor := NewStakeMonitor(big.NewInt(200))
+	address := "0x524f2e0176350d950fa630d9a5a59a0a190daf48"
+
+	changes := make(chan *chain.StakeChange, 2)
+	stakeSubscription, err := monitor.OnStakeChanged(
+		func(change *chain.StakeChange) {
+			changes <- change
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stakeSubscription.Unsubscribe()
+
+	undelegations := make(chan *chain.Undelegation, 1)
+	undelegationSubscription, err := monitor.OnUndelegation(
+		func(undelegation *chain.Undelegation) {
+			undelegations <- undelegation
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer undelegationSubscription.Unsubscribe()
+
+	if err := monitor.StakeTokens(address); err != nil {
+		t.Fatal(err)
+	}
+	if err := monitor.UnstakeTokens(address); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case change := <-changes:
+			if change.Operator != address {
+				t.Errorf(
+					"unexpected operator\nexpected: %v\nactual:   %v\n",
+					address,
+					change.Operator,
+				)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected stake change notification")
+		}
+	}
+
+	if err := monitor.UndelegateTokens(address); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case undelegation := <-undelegations:
+		if undelegation.Operator != address {
+			t.Errorf(
+				"unexpected operator\nexpected: %v\nactual:   %v\n",
+				address,
+				undelegation.Operator,
+			)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected undelegation notification")
+	}
+}
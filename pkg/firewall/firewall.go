@@ -1,8 +1,11 @@
 package firewall
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/keep-network/keep-common/pkg/cache"
@@ -30,17 +33,63 @@ const MinimumStakeCachePeriod = 12 * time.Hour
 var errNoMinimumStake = fmt.Errorf("remote peer has no minimum stake")
 
 // MinimumStakePolicy is a net.Firewall rule making sure the remote peer
-// has a minimum stake of KEEP.
-func MinimumStakePolicy(stakeMonitor chain.StakeMonitor) net.Firewall {
-	return &minimumStakePolicy{
+// has a minimum stake of KEEP. The cached result for the remote peer is
+// invalidated as soon as its stake changes or starts undelegating. Stake
+// changes are watched until the given context is done.
+func MinimumStakePolicy(
+	ctx context.Context,
+	stakeMonitor chain.StakeMonitor,
+) (net.Firewall, error) {
+	policy := &minimumStakePolicy{
 		stakeMonitor: stakeMonitor,
 		cache:        cache.NewTimeCache(MinimumStakeCachePeriod),
+		invalidated:  make(map[string]bool),
+		stakeChanges: make(map[string]uint64),
 	}
+
+	stakeChangedSubscription, err := stakeMonitor.OnStakeChanged(
+		func(change *chain.StakeChange) {
+			policy.invalidate(change.Operator)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not watch stake changes: [%v]", err)
+	}
+
+	undelegationSubscription, err := stakeMonitor.OnUndelegation(
+		func(undelegation *chain.Undelegation) {
+			policy.invalidate(undelegation.Operator)
+		},
+	)
+	if err != nil {
+		stakeChangedSubscription.Unsubscribe()
+		return nil, fmt.Errorf("could not watch stake undelegation: [%v]", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		stakeChangedSubscription.Unsubscribe()
+		undelegationSubscription.Unsubscribe()
+	}()
+
+	return policy, nil
 }
 
 type minimumStakePolicy struct {
 	stakeMonitor chain.StakeMonitor
 	cache        *cache.TimeCache
+
+	// Cached addresses whose stake changed since they have been added to
+	// the cache. The time cache does not support removing entries so they
+	// are checked on the chain again until they expire from the cache.
+	invalidatedMutex sync.Mutex
+	invalidated      map[string]bool
+
+	// Number of stake changes seen for each address. A result read from the
+	// chain is cached only if the stake of the address has not changed
+	// while it was read, so that a stale result does not replace the
+	// invalidation. Guarded by invalidatedMutex.
+	stakeChanges map[string]uint64
 }
 
 func (msp *minimumStakePolicy) Validate(
@@ -61,10 +110,12 @@ func (msp *minimumStakePolicy) Validate(
 	// HasMinimumStake was executed, we have to ask the chain about the current
 	// status.
 	msp.cache.Sweep()
-	if msp.cache.Has(address) {
+	if msp.isCached(address) {
 		return nil
 	}
 
+	stakeChanges := msp.stakeChangesOf(address)
+
 	hasMinimumStake, err := msp.stakeMonitor.HasMinimumStake(address)
 	if err != nil {
 		return fmt.Errorf(
@@ -78,8 +129,96 @@ func (msp *minimumStakePolicy) Validate(
 	}
 
 	// Add this address to the cache. We'll not hit HasMinimumStake again
-	// for the entire caching period.
-	msp.cache.Add(address)
+	// for the entire caching period unless the stake changes.
+	msp.revalidate(address, stakeChanges)
 
 	return nil
 }
+
+// isCached checks if the given address is in the cache and its stake has not
+// changed since it has been added there.
+func (msp *minimumStakePolicy) isCached(address string) bool {
+	msp.invalidatedMutex.Lock()
+	defer msp.invalidatedMutex.Unlock()
+
+	if !msp.cache.Has(address) {
+		delete(msp.invalidated, address)
+		return false
+	}
+
+	return !msp.invalidated[address]
+}
+
+// stakeChangesOf returns the number of stake changes seen so far for the
+// given address.
+func (msp *minimumStakePolicy) stakeChangesOf(address string) uint64 {
+	msp.invalidatedMutex.Lock()
+	defer msp.invalidatedMutex.Unlock()
+
+	return msp.stakeChanges[address]
+}
+
+// invalidate marks the cached result for the given address as no longer
+// valid.
+func (msp *minimumStakePolicy) invalidate(address string) {
+	msp.invalidatedMutex.Lock()
+	defer msp.invalidatedMutex.Unlock()
+
+	msp.stakeChanges[address]++
+
+	if msp.cache.Has(address) {
+		msp.invalidated[address] = true
+	}
+}
+
+// revalidate caches the result for the given address as valid after it has
+// been confirmed on the chain, unless the stake of the address changed since
+// the given number of stake changes has been read; the result confirmed on
+// the chain may be already stale then.
+func (msp *minimumStakePolicy) revalidate(address string, stakeChanges uint64) {
+	msp.invalidatedMutex.Lock()
+	defer msp.invalidatedMutex.Unlock()
+
+	if msp.stakeChanges[address] != stakeChanges {
+		return
+	}
+
+	msp.cache.Add(address)
+	delete(msp.invalidated, address)
+}
+
+// AllowObservers wraps the given policy so that remote peers identified by one
+// of the given observer addresses are accepted without checking them against
+// the policy. Observers do not stake KEEP and never take part in a protocol;
+// they only follow and forward network messages. Observers can connect only
+// to peers which list them, so they are meant for private deployments where
+// the operators of all the peers agree on the observers.
+func AllowObservers(observers []string, policy net.Firewall) net.Firewall {
+	allowed := make(map[string]bool, len(observers))
+	for _, observer := range observers {
+		allowed[strings.ToLower(observer)] = true
+	}
+
+	return &observersPolicy{
+		observers: allowed,
+		policy:    policy,
+	}
+}
+
+type observersPolicy struct {
+	observers map[string]bool
+	policy    net.Firewall
+}
+
+func (op *observersPolicy) Validate(
+	remotePeerPublicKey *ecdsa.PublicKey,
+) error {
+	networkPublicKey := key.NetworkPublic(*remotePeerPublicKey)
+	address := key.NetworkPubKeyToEthAddress(&networkPublicKey)
+
+	if op.observers[strings.ToLower(address)] {
+		return nil
+	}
+
+	return op.policy.Validate(remotePeerPublicKey)
+}
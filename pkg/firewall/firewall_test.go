@@ -1,11 +1,14 @@
 package firewall
 
 import (
+	"context"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/keep-network/keep-common/pkg/cache"
+	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/local"
 	"github.com/keep-network/keep-core/pkg/net/key"
 )
@@ -100,3 +103,199 @@ func TestCachesActiveKeepMembers(t *testing.T) {
 		)
 	}
 }
+
+func TestInvalidatesCacheWhenStakeChanges(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	stakeMonitor := local.NewStakeMonitor(minimumStake)
+	policy, err := MinimumStakePolicy(ctx, stakeMonitor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, remotePeerPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remotePeerAddress := key.NetworkPubKeyToEthAddress(remotePeerPublicKey)
+	stakeMonitor.StakeTokens(remotePeerAddress)
+
+	if err := policy.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+	); err != nil {
+		t.Fatalf("validation should pass: [%v]", err)
+	}
+
+	stakeMonitor.UnstakeTokens(remotePeerAddress)
+
+	// the stake change is delivered asynchronously
+	deadline := time.Now().Add(time.Second)
+	for {
+		err := policy.Validate(key.NetworkKeyToECDSAKey(remotePeerPublicKey))
+		if err == errNoMinimumStake {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf(
+				"unexpected validation error\nactual:   [%v]\nexpected: [%v]",
+				err,
+				errNoMinimumStake,
+			)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDoesNotCacheResultWhenStakeChangesWhileChecking(t *testing.T) {
+	localStakeMonitor := local.NewStakeMonitor(minimumStake)
+	stakeMonitor := &checkingStakeMonitor{StakeMonitor: localStakeMonitor}
+	policy := &minimumStakePolicy{
+		stakeMonitor: stakeMonitor,
+		cache:        cache.NewTimeCache(cachingPeriod),
+		invalidated:  make(map[string]bool),
+		stakeChanges: make(map[string]uint64),
+	}
+
+	_, remotePeerPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remotePeerAddress := key.NetworkPubKeyToEthAddress(remotePeerPublicKey)
+	localStakeMonitor.StakeTokens(remotePeerAddress)
+
+	// the stake changes after the chain confirmed the minimum stake but
+	// before the result is cached
+	stakeMonitor.onCheck = func() {
+		policy.invalidate(remotePeerAddress)
+	}
+	if err := policy.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+	); err != nil {
+		t.Fatalf("validation should pass: [%v]", err)
+	}
+
+	stakeMonitor.onCheck = nil
+	if err := policy.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+	); err != nil {
+		t.Fatalf("validation should pass: [%v]", err)
+	}
+
+	if stakeMonitor.checks != 2 {
+		t.Errorf(
+			"unexpected number of stake checks\nactual:   [%v]\nexpected: [%v]",
+			stakeMonitor.checks,
+			2,
+		)
+	}
+}
+
+func TestStopsWatchingStakeChangesWhenContextIsDone(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	stakeMonitor := local.NewStakeMonitor(minimumStake)
+	policy, err := MinimumStakePolicy(ctx, stakeMonitor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, remotePeerPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remotePeerAddress := key.NetworkPubKeyToEthAddress(remotePeerPublicKey)
+	stakeMonitor.StakeTokens(remotePeerAddress)
+	// let the stake change be delivered before the result is cached
+	time.Sleep(100 * time.Millisecond)
+
+	if err := policy.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+	); err != nil {
+		t.Fatalf("validation should pass: [%v]", err)
+	}
+
+	cancelCtx()
+	// subscriptions are cancelled asynchronously
+	time.Sleep(100 * time.Millisecond)
+
+	stakeMonitor.UnstakeTokens(remotePeerAddress)
+	time.Sleep(100 * time.Millisecond)
+
+	// the stake change is not delivered so the cached result is still used
+	if err := policy.Validate(
+		key.NetworkKeyToECDSAKey(remotePeerPublicKey),
+	); err != nil {
+		t.Fatalf("validation should pass: [%v]", err)
+	}
+}
+
+func TestAllowsObservers(t *testing.T) {
+	stakeMonitor := local.NewStakeMonitor(minimumStake)
+	policy := &minimumStakePolicy{
+		stakeMonitor: stakeMonitor,
+		cache:        cache.NewTimeCache(cachingPeriod),
+	}
+
+	_, observerPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	observerAddress := key.NetworkPubKeyToEthAddress(observerPublicKey)
+
+	_, remotePeerPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	observersPolicy := AllowObservers(
+		[]string{strings.ToUpper(observerAddress)},
+		policy,
+	)
+
+	var tests = map[string]struct {
+		publicKey     *key.NetworkPublic
+		expectedError error
+	}{
+		"observer with no stake": {
+			publicKey:     observerPublicKey,
+			expectedError: nil,
+		},
+		"peer with no stake": {
+			publicKey:     remotePeerPublicKey,
+			expectedError: errNoMinimumStake,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := observersPolicy.Validate(
+				key.NetworkKeyToECDSAKey(test.publicKey),
+			)
+			if err != test.expectedError {
+				t.Errorf(
+					"unexpected validation error\nactual:   [%v]\nexpected: [%v]",
+					err,
+					test.expectedError,
+				)
+			}
+		})
+	}
+}
+
+type checkingStakeMonitor struct {
+	chain.StakeMonitor
+
+	checks  int
+	onCheck func()
+}
+
+func (csm *checkingStakeMonitor) HasMinimumStake(address string) (bool, error) {
+	csm.checks++
+
+	hasMinimumStake, err := csm.StakeMonitor.HasMinimumStake(address)
+	if csm.onCheck != nil {
+		csm.onCheck()
+	}
+	return hasMinimumStake, err
+}
@@ -16,6 +16,17 @@ func AssertDkgResultPublished(t *testing.T, testResult *Result) {
 	}
 }
 
+// AssertDkgResultNotPublished checks if no DKG result has been published to
+// the chain.
+func AssertDkgResultNotPublished(t *testing.T, testResult *Result) {
+	if testResult.dkgResult != nil {
+		t.Fatalf(
+			"expected dkg result not to be published; is: [%v]",
+			testResult.dkgResult,
+		)
+	}
+}
+
 // AssertSuccessfulSignersCount checks the number of successful signers. It does
 // not check which particular signers were successful.
 func AssertSuccessfulSignersCount(
@@ -87,6 +98,71 @@ func AssertMemberFailuresCount(
 	}
 }
 
+// AssertKeyGenerationCompletedEarly checks if the key generation executed by
+// the given members ended before the active blocks of all its phases were
+// over.
+func AssertKeyGenerationCompletedEarly(
+	t *testing.T,
+	testResult *Result,
+	members ...group.MemberIndex,
+) {
+	for _, memberIndex := range members {
+		endBlockHeight, scheduledEndBlockHeight, ok :=
+			keyGenerationEnd(t, testResult, memberIndex)
+		if ok && endBlockHeight >= scheduledEndBlockHeight {
+			t.Errorf(
+				"member [%v] did not complete key generation early\n"+
+					"scheduled end: [%v]\nactual end:    [%v]",
+				memberIndex,
+				scheduledEndBlockHeight,
+				endBlockHeight,
+			)
+		}
+	}
+}
+
+// AssertKeyGenerationWaitedForActiveBlocks checks if the key generation
+// executed by the given members ended exactly when the active blocks of all
+// its phases were over.
+func AssertKeyGenerationWaitedForActiveBlocks(
+	t *testing.T,
+	testResult *Result,
+	members ...group.MemberIndex,
+) {
+	for _, memberIndex := range members {
+		endBlockHeight, scheduledEndBlockHeight, ok :=
+			keyGenerationEnd(t, testResult, memberIndex)
+		if ok && endBlockHeight != scheduledEndBlockHeight {
+			t.Errorf(
+				"member [%v] did not wait for active blocks\n"+
+					"scheduled end: [%v]\nactual end:    [%v]",
+				memberIndex,
+				scheduledEndBlockHeight,
+				endBlockHeight,
+			)
+		}
+	}
+}
+
+// keyGenerationEnd returns the block at which the key generation executed by
+// the given member ended and the block at which it is scheduled to end if
+// all its phases wait for their active blocks to be over.
+func keyGenerationEnd(
+	t *testing.T,
+	testResult *Result,
+	memberIndex group.MemberIndex,
+) (uint64, uint64, bool) {
+	transcript, ok := testResult.transcripts[memberIndex]
+	if !ok || transcript.KeyGeneration == nil {
+		t.Errorf("no key generation transcript of member [%v]", memberIndex)
+		return 0, 0, false
+	}
+
+	return transcript.KeyGeneration.Machine().EndBlockHeight,
+		transcript.StartBlockHeight + testResult.dkgTiming.KeyGenerationBlocks(),
+		true
+}
+
 func containsMemberIndex(
 	index group.MemberIndex,
 	indexes []group.MemberIndex,
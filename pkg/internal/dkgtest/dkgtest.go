@@ -13,11 +13,14 @@ import (
 	"time"
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	dkgResult "github.com/keep-network/keep-core/pkg/beacon/relay/dkg/result"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	"github.com/keep-network/keep-core/pkg/chain"
 	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
 	"github.com/keep-network/keep-core/pkg/internal/interception"
 	"github.com/keep-network/keep-core/pkg/net/key"
@@ -33,6 +36,9 @@ type Result struct {
 	dkgResultSignatures map[group.MemberIndex][]byte
 	signers             []*dkg.ThresholdSigner
 	memberFailures      []error
+	transcripts         map[group.MemberIndex]*dkg.Transcript
+	signing             chain.Signing
+	dkgTiming           config.DKGTiming
 }
 
 // GetSigners returns all signers created from DKG protocol execution.
@@ -42,6 +48,25 @@ func (r *Result) GetSigners() []*dkg.ThresholdSigner {
 	return r.signers
 }
 
+// GetTranscript returns the transcript of DKG executed by the member with the
+// given index.
+func (r *Result) GetTranscript(memberIndex group.MemberIndex) *dkg.Transcript {
+	return r.transcripts[memberIndex]
+}
+
+// ReplayTranscript replays DKG recorded in the transcript of the member with
+// the given index.
+func (r *Result) ReplayTranscript(
+	memberIndex group.MemberIndex,
+) (*dkg.ReplayReport, error) {
+	transcript, ok := r.transcripts[memberIndex]
+	if !ok {
+		return nil, fmt.Errorf("no transcript of member [%v]", memberIndex)
+	}
+
+	return dkg.Replay(transcript, r.signing)
+}
+
 // RandomSeed generates a random DKG seed value. It is important to do not
 // reuse the same seed value between integration tests run in parallel.
 // Broadcast channel name contains a seed to avoid mixing up channel messages
@@ -63,11 +88,89 @@ func RunTest(
 	seed *big.Int,
 	rules interception.Rules,
 ) (*Result, error) {
-	privateKey, publicKey, err := operator.GenerateKeyPair()
+	return RunTestWithWaitingMembers(groupSize, honestThreshold, seed, rules)
+}
+
+// RunTestWithWaitingMembers executes the full DKG roundtrip test the same way
+// as RunTest does but the given members wait for the active blocks of all
+// the phases to be over instead of completing phases early.
+func RunTestWithWaitingMembers(
+	groupSize int,
+	honestThreshold int,
+	seed *big.Int,
+	rules interception.Rules,
+	waitingMembers ...group.MemberIndex,
+) (*Result, error) {
+	chain, network, selectedStakers, err := setUp(
+		groupSize,
+		honestThreshold,
+		rules,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		return nil, err
+	}
+
+	return executeDKG(
+		seed,
+		chain,
+		network,
+		selectedStakers,
+		blockCounter,
+		waitingMembers,
+	)
+}
+
+// RunTestWithWallClock executes the full DKG roundtrip test the same way as
+// RunTest does but the key generation and the result signing are measured
+// with a wall clock producing a new block each time the given block time
+// elapses. The result is submitted to the chain the same way as in RunTest.
+func RunTestWithWallClock(
+	groupSize int,
+	honestThreshold int,
+	seed *big.Int,
+	rules interception.Rules,
+	blockTime time.Duration,
+) (*Result, error) {
+	chain, network, selectedStakers, err := setUp(
+		groupSize,
+		honestThreshold,
+		rules,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	clock, err := state.NewWallClock(time.Now(), blockTime)
 	if err != nil {
 		return nil, err
 	}
 
+	return executeDKG(seed, chain, network, selectedStakers, clock, nil)
+}
+
+// setUp connects to the local chain and the local network applying the given
+// interception rules. All the members of the group are controlled by the same
+// operator selected as the staker for all the seats in the group.
+func setUp(
+	groupSize int,
+	honestThreshold int,
+	rules interception.Rules,
+) (
+	chainLocal.Chain,
+	interception.Network,
+	[]relaychain.StakerAddress,
+	error,
+) {
+	privateKey, publicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	_, networkPublicKey := key.OperatorKeyToNetworkKey(privateKey, publicKey)
 
 	network := interception.NewNetwork(
@@ -91,7 +194,7 @@ func RunTest(
 		selectedStakers[i] = address
 	}
 
-	return executeDKG(seed, chain, network, selectedStakers)
+	return chain, network, selectedStakers, nil
 }
 
 func executeDKG(
@@ -99,6 +202,8 @@ func executeDKG(
 	chain chainLocal.Chain,
 	network interception.Network,
 	selectedStakers []relaychain.StakerAddress,
+	clock state.Clock,
+	waitingMembers []group.MemberIndex,
 ) (*Result, error) {
 	relayConfig, err := chain.ThresholdRelay().GetConfig()
 	if err != nil {
@@ -127,10 +232,13 @@ func executeDKG(
 
 	var memberFailures []error
 
+	var transcriptsMutex sync.Mutex
+	transcripts := make(map[group.MemberIndex]*dkg.Transcript)
+
 	var wg sync.WaitGroup
 	wg.Add(relayConfig.GroupSize)
 
-	currentBlockHeight, err := blockCounter.CurrentBlock()
+	currentBlockHeight, err := clock.CurrentBlock()
 	if err != nil {
 		return nil, err
 	}
@@ -147,21 +255,52 @@ func executeDKG(
 		chain.Signing(),
 	)
 
+	// All the members are controlled by the same operator so they share the
+	// verification of messages as members controlled by one node do.
+	verificationCache := gjkr.NewVerificationCache()
+
 	for i := 0; i < relayConfig.GroupSize; i++ {
 		i := i // capture for goroutine
 		go func() {
+			transcriptRecorder := dkg.NewTranscriptRecorder(clock)
+
+			var relayChain relaychain.Interface = chain.ThresholdRelay()
+			if containsMemberIndex(group.MemberIndex(i+1), waitingMembers) {
+				relayChain = &waitingRelayChain{relayChain}
+			}
+
 			signer, err := dkg.ExecuteDKG(
-				seed,
-				uint8(i),
-				relayConfig.GroupSize,
-				relayConfig.DishonestThreshold(),
-				membershipValidator,
-				startBlockHeight,
+				context.Background(),
+				clock,
 				blockCounter,
-				chain.ThresholdRelay(),
+				relayChain,
 				chain.Signing(),
 				broadcastChannel,
+				dkg.ExecutionConfig{
+					Seed:                seed,
+					Index:               uint8(i),
+					GroupSize:           relayConfig.GroupSize,
+					DishonestThreshold:  relayConfig.DishonestThreshold(),
+					MembershipValidator: membershipValidator,
+					StartBlockHeight:    startBlockHeight,
+					TranscriptRecorder:  transcriptRecorder,
+					VerificationCache:   verificationCache,
+				},
 			)
+
+			transcript := &dkg.Transcript{
+				Seed:               seed,
+				Index:              uint8(i),
+				SelectedStakers:    selectedStakers,
+				GroupSize:          relayConfig.GroupSize,
+				DishonestThreshold: relayConfig.DishonestThreshold(),
+				StartBlockHeight:   startBlockHeight,
+			}
+			transcriptRecorder.Record(transcript)
+			transcriptsMutex.Lock()
+			transcripts[group.MemberIndex(i+1)] = transcript
+			transcriptsMutex.Unlock()
+
 			if signer != nil {
 				signersMutex.Lock()
 				signers = append(signers, signer)
@@ -191,6 +330,9 @@ func executeDKG(
 			dkgResultSignatures,
 			signers,
 			memberFailures,
+			transcripts,
+			chain.Signing(),
+			relayConfig.DKGTiming,
 		}, nil
 
 	case <-ctx.Done():
@@ -200,6 +342,27 @@ func executeDKG(
 			nil,
 			signers,
 			memberFailures,
+			transcripts,
+			chain.Signing(),
+			relayConfig.DKGTiming,
 		}, nil
 	}
 }
+
+// waitingRelayChain is a relay chain whose configuration makes members wait
+// for the active blocks of all the DKG phases to be over.
+type waitingRelayChain struct {
+	relaychain.Interface
+}
+
+func (wrc *waitingRelayChain) GetConfig() (*config.Chain, error) {
+	chainConfig, err := wrc.Interface.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	waitingConfig := *chainConfig
+	waitingConfig.DKGTiming.WaitForActiveBlocks = true
+
+	return &waitingConfig, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 
 	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
 	netLocal "github.com/keep-network/keep-core/pkg/net/local"
@@ -62,6 +63,24 @@ func RunTest(
 	threshold int,
 	rules interception.Rules,
 	previousEntry []byte,
+) (*Result, error) {
+	participants := make([][]*dkg.ThresholdSigner, len(signers))
+	for i, signer := range signers {
+		participants[i] = []*dkg.ThresholdSigner{signer}
+	}
+
+	return RunCombinedTest(participants, threshold, rules, previousEntry)
+}
+
+// RunCombinedTest executes the full relay entry signing roundtrip test just
+// like RunTest but signers are grouped into participants. Each participant
+// signs the entry for all of its signers at once, as an operator holding
+// several seats in the group does.
+func RunCombinedTest(
+	participants [][]*dkg.ThresholdSigner,
+	threshold int,
+	rules interception.Rules,
+	previousEntry []byte,
 ) (*Result, error) {
 	privateKey, publicKey, err := operator.GenerateKeyPair()
 	if err != nil {
@@ -75,13 +94,18 @@ func RunTest(
 		rules,
 	)
 
-	chain := chainLocal.ConnectWithKey(len(signers), threshold, minimumStake, privateKey)
+	groupSize := 0
+	for _, signers := range participants {
+		groupSize += len(signers)
+	}
+
+	chain := chainLocal.ConnectWithKey(groupSize, threshold, minimumStake, privateKey)
 
-	return executeSigning(signers, threshold, chain, network, previousEntry)
+	return executeSigning(participants, threshold, chain, network, previousEntry)
 }
 
 func executeSigning(
-	signers []*dkg.ThresholdSigner,
+	participants [][]*dkg.ThresholdSigner,
 	threshold int,
 	chain chainLocal.Chain,
 	network interception.Network,
@@ -117,7 +141,7 @@ func executeSigning(
 	var signerFailures []error
 
 	var wg sync.WaitGroup
-	wg.Add(len(signers))
+	wg.Add(len(participants))
 
 	currentBlockHeight, err := blockCounter.CurrentBlock()
 	if err != nil {
@@ -130,25 +154,26 @@ func executeSigning(
 
 	entry.RegisterUnmarshallers(broadcastChannel)
 
-	for _, signer := range signers {
-		go func(signer *dkg.ThresholdSigner) {
+	for _, signers := range participants {
+		go func(signers []*dkg.ThresholdSigner) {
 			err := entry.SignAndSubmit(
+				context.Background(),
 				blockCounter,
 				broadcastChannel,
 				chain.ThresholdRelay(),
 				previousEntry,
 				threshold,
-				signer,
+				signers,
 				startBlockHeight,
 			)
 			if err != nil {
-				fmt.Printf("[signer:%v %v] failed with: [%v]\n", signer.MemberID(), previousEntry, err)
+				fmt.Printf("[signers:%v %v] failed with: [%v]\n", memberIDs(signers), previousEntry, err)
 				signerFailuresMutex.Lock()
 				signerFailures = append(signerFailures, err)
 				signerFailuresMutex.Unlock()
 			}
 			wg.Done()
-		}(signer)
+		}(signers)
 	}
 	wg.Wait()
 
@@ -174,3 +199,11 @@ func executeSigning(
 		}, nil
 	}
 }
+
+func memberIDs(signers []*dkg.ThresholdSigner) []group.MemberIndex {
+	memberIDs := make([]group.MemberIndex, len(signers))
+	for i, signer := range signers {
+		memberIDs[i] = signer.MemberID()
+	}
+	return memberIDs
+}
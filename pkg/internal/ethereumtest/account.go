@@ -0,0 +1,36 @@
+package ethereumtest
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// NewAccount generates a new account and stores its key, encrypted with the
+// given password, in a key file in the given directory. The key and the path
+// of the key file are returned.
+func NewAccount(dir string, password string) (*keystore.Key, string, error) {
+	store := keystore.NewKeyStore(
+		dir,
+		keystore.LightScryptN,
+		keystore.LightScryptP,
+	)
+
+	account, err := store.NewAccount(password)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not create account: [%v]", err)
+	}
+
+	keyJSON, err := ioutil.ReadFile(account.URL.Path)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not read key file: [%v]", err)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not decrypt account key: [%v]", err)
+	}
+
+	return key, account.URL.Path, nil
+}
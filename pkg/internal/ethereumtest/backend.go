@@ -0,0 +1,167 @@
+// Package ethereumtest provides a simulated Ethereum chain served over
+// WebSocket JSON-RPC and helpers deploying Keep contracts to it. It allows
+// the Ethereum chain adapter to be tested end-to-end, through the same
+// connection path the client uses with a live node.
+package ethereumtest
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// blockGasLimit is the gas limit of simulated blocks. It is high enough to
+// deploy the largest of Keep contracts.
+const blockGasLimit = 20000000
+
+// AccountBalance is the balance, in wei, each account passed to NewBackend
+// starts with.
+var AccountBalance = new(big.Int).Mul(big.NewInt(1000000), big.NewInt(params.Ether))
+
+// Backend is a simulated Ethereum chain served over WebSocket JSON-RPC.
+// Every accepted transaction is mined immediately in its own block. Blocks
+// without transactions are mined with Mine or AutoMine.
+type Backend struct {
+	simulated *backends.SimulatedBackend
+	server    *rpc.Server
+	http      *httptest.Server
+
+	// miningMutex serializes transaction submission and mining so that
+	// every transaction is validated against the state it is mined on.
+	miningMutex sync.Mutex
+}
+
+// NewBackend starts a simulated chain with the given accounts funded with
+// AccountBalance each.
+func NewBackend(accounts ...common.Address) (*Backend, error) {
+	alloc := make(core.GenesisAlloc, len(accounts))
+	for _, account := range accounts {
+		alloc[account] = core.GenesisAccount{Balance: AccountBalance}
+	}
+
+	backend := &Backend{
+		simulated: backends.NewSimulatedBackend(alloc, blockGasLimit),
+		server:    rpc.NewServer(),
+	}
+
+	if err := backend.server.RegisterName("eth", &ethAPI{backend}); err != nil {
+		return nil, fmt.Errorf("could not register eth API: [%v]", err)
+	}
+	if err := backend.server.RegisterName("net", &netAPI{backend}); err != nil {
+		return nil, fmt.Errorf("could not register net API: [%v]", err)
+	}
+
+	backend.http = httptest.NewServer(
+		backend.server.WebsocketHandler([]string{"*"}),
+	)
+
+	return backend, nil
+}
+
+// URL returns the WebSocket URL the simulated chain is served at.
+func (b *Backend) URL() string {
+	return "ws" + strings.TrimPrefix(b.http.URL, "http")
+}
+
+// Dial connects a new client to the simulated chain.
+func (b *Backend) Dial() (*ethclient.Client, error) {
+	return ethclient.Dial(b.URL())
+}
+
+// ChainID returns the chain ID of the simulated chain.
+func (b *Backend) ChainID() *big.Int {
+	return b.simulated.Blockchain().Config().ChainID
+}
+
+// CurrentBlock returns the number of the latest mined block.
+func (b *Backend) CurrentBlock() uint64 {
+	return b.simulated.Blockchain().CurrentBlock().NumberU64()
+}
+
+// Mine mines the given number of blocks without transactions.
+func (b *Backend) Mine(blocks int) {
+	b.miningMutex.Lock()
+	defer b.miningMutex.Unlock()
+
+	for i := 0; i < blocks; i++ {
+		b.simulated.Commit()
+	}
+}
+
+// AutoMine mines a block every given interval until the context is done.
+func (b *Backend) AutoMine(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				b.Mine(1)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Close stops serving the simulated chain and closes all client connections.
+func (b *Backend) Close() {
+	b.http.Close()
+	b.server.Stop()
+	b.simulated.Close()
+}
+
+// sendTransaction validates the given transaction and mines it in a new
+// block. The simulated backend panics on transactions it can't include in
+// a block, so the nonce is checked upfront and all the other failures are
+// recovered from and returned as errors.
+func (b *Backend) sendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) (err error) {
+	b.miningMutex.Lock()
+	defer b.miningMutex.Unlock()
+
+	sender, err := types.Sender(types.NewEIP155Signer(b.ChainID()), transaction)
+	if err != nil {
+		return fmt.Errorf("invalid sender: [%v]", err)
+	}
+
+	nonce, err := b.simulated.PendingNonceAt(ctx, sender)
+	if err != nil {
+		return err
+	}
+	if transaction.Nonce() < nonce {
+		return fmt.Errorf("nonce too low")
+	}
+	if transaction.Nonce() > nonce {
+		return fmt.Errorf("nonce too high")
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			b.simulated.Rollback()
+			err = fmt.Errorf("transaction rejected: [%v]", recovered)
+		}
+	}()
+
+	if err := b.simulated.SendTransaction(ctx, transaction); err != nil {
+		return err
+	}
+	b.simulated.Commit()
+
+	return nil
+}
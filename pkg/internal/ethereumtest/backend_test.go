@@ -0,0 +1,298 @@
+package ethereumtest
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// loggerBytecode deploys a contract emitting an empty log on every call.
+// Init code copies the 6-byte runtime code `PUSH1 0 PUSH1 0 LOG0 STOP` to
+// memory and returns it.
+var loggerBytecode = hexutil.MustDecode("0x6006600c60003960066000f360006000a000")
+
+func TestBackendMinesTransactions(t *testing.T) {
+	backend, client, key := newTestBackend(t)
+	defer backend.Close()
+	defer client.Close()
+
+	ctx := context.Background()
+
+	recipient := common.HexToAddress("0x524f2e0176350d950fa630d9a5a59a0a190daf48")
+	transaction := signTransaction(
+		t,
+		backend,
+		key,
+		types.NewTransaction(0, recipient, big.NewInt(1000), 21000, big.NewInt(1), nil),
+	)
+
+	if err := client.SendTransaction(ctx, transaction); err != nil {
+		t.Fatal(err)
+	}
+
+	receipt, err := client.TransactionReceipt(ctx, transaction.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Errorf("unexpected receipt status: [%v]", receipt.Status)
+	}
+	if receipt.BlockNumber.Uint64() != 1 {
+		t.Errorf(
+			"unexpected receipt block\nexpected: [%v]\nactual:   [%v]",
+			1,
+			receipt.BlockNumber,
+		)
+	}
+
+	balance, err := client.BalanceAt(ctx, recipient, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf(
+			"unexpected balance\nexpected: [%v]\nactual:   [%v]",
+			1000,
+			balance,
+		)
+	}
+
+	nonce, err := client.PendingNonceAt(ctx, key.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 1 {
+		t.Errorf("unexpected nonce\nexpected: [%v]\nactual:   [%v]", 1, nonce)
+	}
+}
+
+func TestBackendRejectsInvalidTransactions(t *testing.T) {
+	backend, client, key := newTestBackend(t)
+	defer backend.Close()
+	defer client.Close()
+
+	recipient := common.HexToAddress("0x524f2e0176350d950fa630d9a5a59a0a190daf48")
+
+	var tests = map[string]struct {
+		transaction   *types.Transaction
+		expectedError string
+	}{
+		"nonce too high": {
+			transaction:   types.NewTransaction(5, recipient, big.NewInt(1), 21000, big.NewInt(1), nil),
+			expectedError: "nonce too high",
+		},
+		"insufficient funds": {
+			transaction: types.NewTransaction(
+				0,
+				recipient,
+				new(big.Int).Mul(AccountBalance, big.NewInt(2)),
+				21000,
+				big.NewInt(1),
+				nil,
+			),
+			expectedError: "transaction rejected",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := client.SendTransaction(
+				context.Background(),
+				signTransaction(t, backend, key, test.transaction),
+			)
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+
+	if current := backend.CurrentBlock(); current != 0 {
+		t.Errorf("rejected transactions mined at block [%v]", current)
+	}
+}
+
+func TestBackendNotifiesAboutHeadersAndLogs(t *testing.T) {
+	backend, client, key := newTestBackend(t)
+	defer backend.Close()
+	defer client.Close()
+
+	ctx := context.Background()
+
+	deployment := signTransaction(
+		t,
+		backend,
+		key,
+		types.NewContractCreation(0, big.NewInt(0), 100000, big.NewInt(1), loggerBytecode),
+	)
+	if err := client.SendTransaction(ctx, deployment); err != nil {
+		t.Fatal(err)
+	}
+	contract := crypto.CreateAddress(key.Address, 0)
+
+	headers := make(chan *types.Header, 10)
+	headSubscription, err := client.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer headSubscription.Unsubscribe()
+
+	query := ethereum.FilterQuery{Addresses: []common.Address{contract}}
+	logs := make(chan types.Log, 10)
+	logSubscription, err := client.SubscribeFilterLogs(ctx, query, logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logSubscription.Unsubscribe()
+
+	call := signTransaction(
+		t,
+		backend,
+		key,
+		types.NewTransaction(1, contract, big.NewInt(0), 100000, big.NewInt(1), nil),
+	)
+	if err := client.SendTransaction(ctx, call); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case header := <-headers:
+		if header.Number.Uint64() != 2 {
+			t.Errorf(
+				"unexpected header\nexpected: [%v]\nactual:   [%v]",
+				2,
+				header.Number,
+			)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected new header notification")
+	}
+
+	select {
+	case log := <-logs:
+		if log.TxHash != call.Hash() {
+			t.Errorf(
+				"unexpected log transaction\nexpected: [%v]\nactual:   [%v]",
+				call.Hash().Hex(),
+				log.TxHash.Hex(),
+			)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected log notification")
+	}
+
+	filteredLogs, err := client.FilterLogs(ctx, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filteredLogs) != 1 {
+		t.Errorf(
+			"unexpected number of logs\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(filteredLogs),
+		)
+	}
+
+	backend.Mine(3)
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Number.Uint64() != 5 {
+		t.Errorf(
+			"unexpected latest block\nexpected: [%v]\nactual:   [%v]",
+			5,
+			header.Number,
+		)
+	}
+}
+
+func TestLinkedBytecode(t *testing.T) {
+	library := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	placeholder := libraryPlaceholder("GroupSelection")
+
+	artifact := &Artifact{
+		ContractName: "Operator",
+		Bytecode:     "0x6001" + placeholder + "6002",
+	}
+
+	bytecode, err := artifact.linkedBytecode(map[string]common.Address{
+		"GroupSelection": library,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "0x6001" + strings.ToLower(library.Hex()[2:]) + "6002"
+	if hexutil.Encode(bytecode) != expected {
+		t.Errorf(
+			"unexpected bytecode\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			hexutil.Encode(bytecode),
+		)
+	}
+
+	_, err = artifact.linkedBytecode(map[string]common.Address{})
+	if err == nil {
+		t.Errorf("expected error for unlinked library")
+	}
+}
+
+func newTestBackend(t *testing.T) (*Backend, *ethclient.Client, *keystore.Key) {
+	dir, err := ioutil.TempDir("", "ethereumtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, _, err := NewAccount(dir, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend, err := NewBackend(key.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := backend.Dial()
+	if err != nil {
+		backend.Close()
+		t.Fatal(err)
+	}
+
+	return backend, client, key
+}
+
+func signTransaction(
+	t *testing.T,
+	backend *Backend,
+	key *keystore.Key,
+	transaction *types.Transaction,
+) *types.Transaction {
+	signed, err := types.SignTx(
+		transaction,
+		types.NewEIP155Signer(backend.ChainID()),
+		key.PrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed
+}
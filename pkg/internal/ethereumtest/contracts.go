@@ -0,0 +1,140 @@
+package ethereumtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ArtifactsDirEnv is the environment variable pointing to the directory with
+// Truffle artifacts of compiled contracts. If it is not set, artifacts are
+// expected in solidity/build/truffle of the repository, where
+// `npm run compile` puts them.
+const ArtifactsDirEnv = "KEEP_CONTRACT_ARTIFACTS"
+
+// ArtifactsDir returns the directory with Truffle artifacts of compiled
+// contracts.
+func ArtifactsDir() string {
+	if dir := os.Getenv(ArtifactsDirEnv); dir != "" {
+		return dir
+	}
+
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(
+		filepath.Dir(file),
+		"..", "..", "..",
+		"solidity", "build", "truffle",
+	)
+}
+
+// Artifact is a compiled contract as stored in a Truffle artifact file.
+type Artifact struct {
+	ContractName string          `json:"contractName"`
+	ABI          json.RawMessage `json:"abi"`
+	Bytecode     string          `json:"bytecode"`
+}
+
+// Artifacts are compiled contracts by contract name.
+type Artifacts map[string]*Artifact
+
+// LoadArtifacts reads Truffle artifacts of the given contracts from the given
+// directory.
+func LoadArtifacts(dir string, contractNames ...string) (Artifacts, error) {
+	artifacts := make(Artifacts, len(contractNames))
+	for _, contractName := range contractNames {
+		path := filepath.Join(dir, contractName+".json")
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not read artifact of [%v]: [%v]",
+				contractName,
+				err,
+			)
+		}
+
+		artifact := &Artifact{}
+		if err := json.Unmarshal(content, artifact); err != nil {
+			return nil, fmt.Errorf(
+				"could not parse artifact of [%v]: [%v]",
+				contractName,
+				err,
+			)
+		}
+
+		artifacts[contractName] = artifact
+	}
+
+	return artifacts, nil
+}
+
+// parsedABI returns the parsed ABI of the contract.
+func (a *Artifact) parsedABI() (abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(string(a.ABI)))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf(
+			"could not parse ABI of [%v]: [%v]",
+			a.ContractName,
+			err,
+		)
+	}
+
+	return parsed, nil
+}
+
+// linkedBytecode returns the bytecode of the contract with placeholders of
+// the given libraries replaced with their addresses. An error is returned if
+// the contract references a library which is not given.
+func (a *Artifact) linkedBytecode(
+	libraries map[string]common.Address,
+) ([]byte, error) {
+	bytecode := a.Bytecode
+	for name, address := range libraries {
+		bytecode = strings.Replace(
+			bytecode,
+			libraryPlaceholder(name),
+			strings.ToLower(address.Hex()[2:]),
+			-1,
+		)
+	}
+
+	if index := strings.Index(bytecode, "__"); index != -1 {
+		end := index + 40
+		if end > len(bytecode) {
+			end = len(bytecode)
+		}
+		return nil, fmt.Errorf(
+			"contract [%v] references unknown library [%v]",
+			a.ContractName,
+			strings.Trim(bytecode[index:end], "_"),
+		)
+	}
+
+	return hexutil.Decode(bytecode)
+}
+
+// libraryPlaceholder returns the placeholder Truffle puts in the bytecode in
+// place of the address of the given library: the library name surrounded
+// with underscores to the length of an address.
+func libraryPlaceholder(name string) string {
+	placeholder := "__" + name
+	if len(placeholder) > 40 {
+		return placeholder[:40]
+	}
+
+	return placeholder + strings.Repeat("_", 40-len(placeholder))
+}
+
+// waitContext returns a context for waiting for a transaction to be mined.
+func waitContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), transactionWaitTimeout)
+}
@@ -0,0 +1,407 @@
+package ethereumtest
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// transactionWaitTimeout is the maximum time a transaction sent by the
+// deployment is waited for to be mined.
+const transactionWaitTimeout = 30 * time.Second
+
+// Parameters of the deployed contracts, following the local network
+// migration of the contracts.
+var (
+	initializationPeriod  = big.NewInt(1)
+	undelegationPeriod    = big.NewInt(10)
+	dkgContributionMargin = big.NewInt(5)
+	withdrawalDelay       = big.NewInt(1)
+)
+
+// Libraries lists libraries linked into the deployed contracts, in the
+// order they are deployed.
+var Libraries = []string{
+	"ModUtils",
+	"AltBn128",
+	"BLS",
+	"GroupSelection",
+	"Groups",
+	"DKGResultVerification",
+	"Reimbursements",
+}
+
+// Contracts lists contracts whose artifacts are needed by Deploy, including
+// libraries.
+var Contracts = []string{
+	"ModUtils",
+	"AltBn128",
+	"BLS",
+	"GroupSelection",
+	"Groups",
+	"DKGResultVerification",
+	"Reimbursements",
+	"KeepToken",
+	"Registry",
+	"TokenStaking",
+	"KeepRandomBeaconServiceImplV1",
+	"KeepRandomBeaconService",
+	"KeepRandomBeaconOperatorStub",
+}
+
+// Deployment is a set of Keep contracts deployed to the simulated chain.
+// Administrative transactions are sent from the deployer account, which
+// also owns all the tokens and acts as the owner, beneficiary and authorizer
+// of every stake.
+type Deployment struct {
+	client    *ethclient.Client
+	deployer  *keystore.Key
+	addresses map[string]common.Address
+	contracts map[string]*bind.BoundContract
+}
+
+// Deploy deploys the random beacon contracts to the given simulated chain
+// from the given deployer account. The operator contract is deployed from
+// its development stub so that the group size and threshold can be set to
+// the given values.
+func Deploy(
+	backend *Backend,
+	artifacts Artifacts,
+	deployer *keystore.Key,
+	groupSize int,
+	groupThreshold int,
+) (*Deployment, error) {
+	client, err := backend.Dial()
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to the chain: [%v]", err)
+	}
+
+	deployment := &Deployment{
+		client:    client,
+		deployer:  deployer,
+		addresses: make(map[string]common.Address),
+		contracts: make(map[string]*bind.BoundContract),
+	}
+
+	for _, library := range Libraries {
+		if err := deployment.deploy(artifacts, library, library); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := deployment.deploy(artifacts, "KeepToken", "KeepToken"); err != nil {
+		return nil, err
+	}
+	if err := deployment.deploy(artifacts, "Registry", "Registry"); err != nil {
+		return nil, err
+	}
+	if err := deployment.deploy(
+		artifacts,
+		"TokenStaking",
+		"TokenStaking",
+		deployment.addresses["KeepToken"],
+		deployment.addresses["Registry"],
+		initializationPeriod,
+		undelegationPeriod,
+	); err != nil {
+		return nil, err
+	}
+
+	serviceImplementation := artifacts["KeepRandomBeaconServiceImplV1"]
+	if err := deployment.deploy(
+		artifacts,
+		"KeepRandomBeaconServiceImplV1",
+		"KeepRandomBeaconServiceImplV1",
+	); err != nil {
+		return nil, err
+	}
+
+	serviceImplementationABI, err := serviceImplementation.parsedABI()
+	if err != nil {
+		return nil, err
+	}
+	initializeData, err := serviceImplementationABI.Pack(
+		"initialize",
+		dkgContributionMargin,
+		withdrawalDelay,
+		deployment.addresses["Registry"],
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not pack service initialization: [%v]", err)
+	}
+
+	if err := deployment.deploy(
+		artifacts,
+		"KeepRandomBeaconService",
+		"KeepRandomBeaconService",
+		deployment.addresses["KeepRandomBeaconServiceImplV1"],
+		initializeData,
+	); err != nil {
+		return nil, err
+	}
+	// The service is used through its proxy, with the implementation ABI.
+	deployment.contracts["KeepRandomBeaconService"] = bind.NewBoundContract(
+		deployment.addresses["KeepRandomBeaconService"],
+		serviceImplementationABI,
+		client,
+		client,
+		client,
+	)
+
+	if err := deployment.deploy(
+		artifacts,
+		"KeepRandomBeaconOperator",
+		"KeepRandomBeaconOperatorStub",
+		deployment.addresses["KeepRandomBeaconService"],
+		deployment.addresses["TokenStaking"],
+	); err != nil {
+		return nil, err
+	}
+
+	operatorAddress := deployment.addresses["KeepRandomBeaconOperator"]
+	steps := []struct {
+		contract string
+		method   string
+		args     []interface{}
+	}{
+		{"Registry", "approveOperatorContract", []interface{}{operatorAddress}},
+		{"Registry", "setOperatorContractUpgrader", []interface{}{
+			deployment.addresses["KeepRandomBeaconService"],
+			deployer.Address,
+		}},
+		{"KeepRandomBeaconService", "addOperatorContract", []interface{}{
+			operatorAddress,
+		}},
+		{"KeepRandomBeaconOperator", "setGroupSize", []interface{}{
+			big.NewInt(int64(groupSize)),
+		}},
+		{"KeepRandomBeaconOperator", "setGroupThreshold", []interface{}{
+			big.NewInt(int64(groupThreshold)),
+		}},
+	}
+	for _, step := range steps {
+		if err := deployment.transact(
+			step.contract,
+			nil,
+			step.method,
+			step.args...,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	return deployment, nil
+}
+
+// ContractAddresses returns addresses of the deployed contracts keyed with
+// contract names used in the client configuration.
+func (d *Deployment) ContractAddresses() map[string]string {
+	addresses := make(map[string]string)
+	for _, name := range []string{
+		"KeepRandomBeaconOperator",
+		"KeepRandomBeaconService",
+		"TokenStaking",
+	} {
+		addresses[name] = d.addresses[name].Hex()
+	}
+
+	return addresses
+}
+
+// Stake delegates the minimum stake to the given operator and authorizes
+// the operator contract to use it. The stake is initialized once the next
+// block is mined.
+func (d *Deployment) Stake(operator common.Address) error {
+	var minimumStake *big.Int
+	if err := d.call("TokenStaking", &minimumStake, "minimumStake"); err != nil {
+		return err
+	}
+
+	delegation := make([]byte, 0, 60)
+	delegation = append(delegation, d.deployer.Address.Bytes()...) // beneficiary
+	delegation = append(delegation, operator.Bytes()...)
+	delegation = append(delegation, d.deployer.Address.Bytes()...) // authorizer
+
+	if err := d.transact(
+		"KeepToken",
+		nil,
+		"approveAndCall",
+		d.addresses["TokenStaking"],
+		minimumStake,
+		delegation,
+	); err != nil {
+		return err
+	}
+
+	return d.transact(
+		"TokenStaking",
+		nil,
+		"authorizeOperatorContract",
+		operator,
+		d.addresses["KeepRandomBeaconOperator"],
+	)
+}
+
+// Genesis triggers the selection of the first group, paying the required
+// DKG fee.
+func (d *Deployment) Genesis() error {
+	var gasPriceCeiling, dkgGasEstimate *big.Int
+	if err := d.call(
+		"KeepRandomBeaconOperator",
+		&gasPriceCeiling,
+		"gasPriceCeiling",
+	); err != nil {
+		return err
+	}
+	if err := d.call(
+		"KeepRandomBeaconOperator",
+		&dkgGasEstimate,
+		"dkgGasEstimate",
+	); err != nil {
+		return err
+	}
+
+	return d.transact(
+		"KeepRandomBeaconOperator",
+		new(big.Int).Mul(gasPriceCeiling, dkgGasEstimate),
+		"genesis",
+	)
+}
+
+// RequestRelayEntry requests a new relay entry, paying the required fee.
+func (d *Deployment) RequestRelayEntry() error {
+	var entryFee *big.Int
+	if err := d.call(
+		"KeepRandomBeaconService",
+		&entryFee,
+		"entryFeeEstimate",
+		big.NewInt(0),
+	); err != nil {
+		return err
+	}
+
+	return d.transact(
+		"KeepRandomBeaconService",
+		entryFee,
+		"requestRelayEntry",
+	)
+}
+
+// Close closes the connection of the deployment to the chain.
+func (d *Deployment) Close() {
+	d.client.Close()
+}
+
+// deploy deploys the contract from the given artifact under the given name
+// and waits until it is mined. All the already deployed contracts are
+// available for linking as libraries.
+func (d *Deployment) deploy(
+	artifacts Artifacts,
+	name string,
+	artifactName string,
+	args ...interface{},
+) error {
+	artifact, ok := artifacts[artifactName]
+	if !ok {
+		return fmt.Errorf("no artifact of [%v]", artifactName)
+	}
+
+	parsedABI, err := artifact.parsedABI()
+	if err != nil {
+		return err
+	}
+
+	bytecode, err := artifact.linkedBytecode(d.addresses)
+	if err != nil {
+		return err
+	}
+
+	address, transaction, contract, err := bind.DeployContract(
+		bind.NewKeyedTransactor(d.deployer.PrivateKey),
+		parsedABI,
+		bytecode,
+		d.client,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("could not deploy [%v]: [%v]", name, err)
+	}
+
+	ctx, cancel := waitContext()
+	defer cancel()
+
+	if _, err := bind.WaitDeployed(ctx, d.client, transaction); err != nil {
+		return fmt.Errorf("deployment of [%v] failed: [%v]", name, err)
+	}
+
+	d.addresses[name] = address
+	d.contracts[name] = contract
+
+	return nil
+}
+
+// transact sends a transaction calling the given method of the given
+// contract from the deployer account and waits until it is mined.
+func (d *Deployment) transact(
+	contract string,
+	value *big.Int,
+	method string,
+	args ...interface{},
+) error {
+	options := bind.NewKeyedTransactor(d.deployer.PrivateKey)
+	options.Value = value
+
+	transaction, err := d.contracts[contract].Transact(options, method, args...)
+	if err != nil {
+		return fmt.Errorf(
+			"could not call [%v] of [%v]: [%v]",
+			method,
+			contract,
+			err,
+		)
+	}
+
+	ctx, cancel := waitContext()
+	defer cancel()
+
+	receipt, err := bind.WaitMined(ctx, d.client, transaction)
+	if err != nil {
+		return fmt.Errorf(
+			"call of [%v] of [%v] not mined: [%v]",
+			method,
+			contract,
+			err,
+		)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("call of [%v] of [%v] reverted", method, contract)
+	}
+
+	return nil
+}
+
+// call reads the result of the given constant method of the given contract.
+func (d *Deployment) call(
+	contract string,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	err := d.contracts[contract].Call(&bind.CallOpts{}, result, method, args...)
+	if err != nil {
+		return fmt.Errorf(
+			"could not call [%v] of [%v]: [%v]",
+			method,
+			contract,
+			err,
+		)
+	}
+
+	return nil
+}
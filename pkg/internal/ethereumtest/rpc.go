@@ -0,0 +1,284 @@
+package ethereumtest
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ethAPI serves the subset of the eth JSON-RPC namespace used by
+// go-ethereum's ethclient for contract calls, transactions, logs and new
+// block headers.
+type ethAPI struct {
+	backend *Backend
+}
+
+// callArgs are arguments of eth_call and eth_estimateGas.
+type callArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     hexutil.Bytes   `json:"data"`
+}
+
+func (ca callArgs) toCallMsg() ethereum.CallMsg {
+	return ethereum.CallMsg{
+		From:     ca.From,
+		To:       ca.To,
+		Gas:      uint64(ca.Gas),
+		GasPrice: (*big.Int)(ca.GasPrice),
+		Value:    (*big.Int)(ca.Value),
+		Data:     ca.Data,
+	}
+}
+
+// blockNumber converts the given JSON-RPC block number to the block number
+// accepted by the simulated backend, where nil stands for the latest block.
+func blockNumber(number rpc.BlockNumber) *big.Int {
+	if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
+		return nil
+	}
+
+	return big.NewInt(number.Int64())
+}
+
+func (api *ethAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.backend.ChainID())
+}
+
+func (api *ethAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(api.backend.CurrentBlock())
+}
+
+// GetBlockByNumber returns the header of the given block. Transactions are
+// never included; ethclient needs only headers to follow the chain.
+func (api *ethAPI) GetBlockByNumber(
+	ctx context.Context,
+	number rpc.BlockNumber,
+	fullTransactions bool,
+) (*types.Header, error) {
+	header, err := api.backend.simulated.HeaderByNumber(ctx, blockNumber(number))
+	if err != nil {
+		return nil, nil
+	}
+
+	return header, nil
+}
+
+// GetBlockByHash returns the header of the given block. Transactions are
+// never included; ethclient needs only headers to follow the chain.
+func (api *ethAPI) GetBlockByHash(
+	ctx context.Context,
+	hash common.Hash,
+	fullTransactions bool,
+) (*types.Header, error) {
+	header, err := api.backend.simulated.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, nil
+	}
+
+	return header, nil
+}
+
+func (api *ethAPI) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	gasPrice, err := api.backend.simulated.SuggestGasPrice(ctx)
+	return (*hexutil.Big)(gasPrice), err
+}
+
+func (api *ethAPI) GetBalance(
+	ctx context.Context,
+	address common.Address,
+	number rpc.BlockNumber,
+) (*hexutil.Big, error) {
+	balance, err := api.backend.simulated.BalanceAt(
+		ctx,
+		address,
+		blockNumber(number),
+	)
+	return (*hexutil.Big)(balance), err
+}
+
+func (api *ethAPI) GetTransactionCount(
+	ctx context.Context,
+	address common.Address,
+	number rpc.BlockNumber,
+) (hexutil.Uint64, error) {
+	if number == rpc.PendingBlockNumber {
+		nonce, err := api.backend.simulated.PendingNonceAt(ctx, address)
+		return hexutil.Uint64(nonce), err
+	}
+
+	nonce, err := api.backend.simulated.NonceAt(ctx, address, blockNumber(number))
+	return hexutil.Uint64(nonce), err
+}
+
+func (api *ethAPI) GetCode(
+	ctx context.Context,
+	address common.Address,
+	number rpc.BlockNumber,
+) (hexutil.Bytes, error) {
+	if number == rpc.PendingBlockNumber {
+		return api.backend.simulated.PendingCodeAt(ctx, address)
+	}
+
+	return api.backend.simulated.CodeAt(ctx, address, blockNumber(number))
+}
+
+// Call executes the given call against the latest or pending state. The
+// simulated backend does not support calls against historical blocks.
+func (api *ethAPI) Call(
+	ctx context.Context,
+	args callArgs,
+	number rpc.BlockNumber,
+) (hexutil.Bytes, error) {
+	if number == rpc.PendingBlockNumber {
+		return api.backend.simulated.PendingCallContract(ctx, args.toCallMsg())
+	}
+
+	return api.backend.simulated.CallContract(
+		ctx,
+		args.toCallMsg(),
+		blockNumber(number),
+	)
+}
+
+func (api *ethAPI) EstimateGas(
+	ctx context.Context,
+	args callArgs,
+) (hexutil.Uint64, error) {
+	gas, err := api.backend.simulated.EstimateGas(ctx, args.toCallMsg())
+	return hexutil.Uint64(gas), err
+}
+
+// SendRawTransaction mines the given transaction in a new block.
+func (api *ethAPI) SendRawTransaction(
+	ctx context.Context,
+	encoded hexutil.Bytes,
+) (common.Hash, error) {
+	transaction := new(types.Transaction)
+	if err := rlp.DecodeBytes(encoded, transaction); err != nil {
+		return common.Hash{}, err
+	}
+
+	if err := api.backend.sendTransaction(ctx, transaction); err != nil {
+		return common.Hash{}, err
+	}
+
+	return transaction.Hash(), nil
+}
+
+func (api *ethAPI) GetTransactionReceipt(
+	ctx context.Context,
+	hash common.Hash,
+) (*types.Receipt, error) {
+	return api.backend.simulated.TransactionReceipt(ctx, hash)
+}
+
+func (api *ethAPI) GetLogs(
+	ctx context.Context,
+	criteria filters.FilterCriteria,
+) ([]types.Log, error) {
+	logs, err := api.backend.simulated.FilterLogs(
+		ctx,
+		ethereum.FilterQuery(criteria),
+	)
+	if logs == nil {
+		logs = []types.Log{}
+	}
+
+	return logs, err
+}
+
+// NewHeads notifies the subscriber about every new block header.
+func (api *ethAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+
+	headers := make(chan *types.Header)
+	subscription, err := api.backend.simulated.SubscribeNewHead(
+		context.Background(),
+		headers,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcSubscription := notifier.CreateSubscription()
+
+	go func() {
+		defer subscription.Unsubscribe()
+
+		for {
+			select {
+			case header := <-headers:
+				_ = notifier.Notify(rpcSubscription.ID, header)
+			case <-rpcSubscription.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSubscription, nil
+}
+
+// Logs notifies the subscriber about every new log matching the criteria.
+func (api *ethAPI) Logs(
+	ctx context.Context,
+	criteria filters.FilterCriteria,
+) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+
+	logs := make(chan types.Log)
+	subscription, err := api.backend.simulated.SubscribeFilterLogs(
+		context.Background(),
+		ethereum.FilterQuery(criteria),
+		logs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcSubscription := notifier.CreateSubscription()
+
+	go func() {
+		defer subscription.Unsubscribe()
+
+		for {
+			select {
+			case log := <-logs:
+				_ = notifier.Notify(rpcSubscription.ID, &log)
+			case <-rpcSubscription.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSubscription, nil
+}
+
+// netAPI serves the net JSON-RPC namespace.
+type netAPI struct {
+	backend *Backend
+}
+
+func (api *netAPI) Version() string {
+	return api.backend.ChainID().String()
+}
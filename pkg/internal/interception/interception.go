@@ -63,7 +63,7 @@ func (c *channel) Send(ctx context.Context, m net.TaggedMarshaler) error {
 		return nil
 	}
 
-	return c.delegate.Send(ctx, c.rules(m))
+	return c.delegate.Send(ctx, altered)
 }
 
 func (c *channel) Recv(ctx context.Context, handler func(m net.Message)) {
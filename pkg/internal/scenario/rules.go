@@ -0,0 +1,349 @@
+package scenario
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"time"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg/result"
+	resultpb "github.com/keep-network/keep-core/pkg/beacon/relay/dkg/result/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	gjkrpb "github.com/keep-network/keep-core/pkg/beacon/relay/gjkr/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/internal/interception"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// phaseMessages are types of messages broadcast in phases, keyed by the phase.
+var phaseMessages = map[int][]string{
+	1: {(&gjkr.EphemeralPublicKeyMessage{}).Type()},
+	3: {
+		(&gjkr.MemberCommitmentsMessage{}).Type(),
+		(&gjkr.PeerSharesMessage{}).Type(),
+	},
+	4:                      {(&gjkr.SecretSharesAccusationsMessage{}).Type()},
+	7:                      {(&gjkr.MemberPublicKeySharePointsMessage{}).Type()},
+	8:                      {(&gjkr.PointsAccusationsMessage{}).Type()},
+	10:                     {(&gjkr.MisbehavedEphemeralKeysMessage{}).Type()},
+	resultPublicationPhase: {(&result.DKGResultHashSignatureMessage{}).Type()},
+	SigningPhase:           {(&entry.SignatureShareMessage{}).Type()},
+}
+
+// rules returns interception rules applying the given behaviours to messages
+// sent by misbehaving members.
+func rules(behaviours []*Behaviour) interception.Rules {
+	return func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+		sender, ok := msg.(interface{ SenderID() group.MemberIndex })
+		if !ok {
+			return msg
+		}
+
+		for _, behaviour := range behaviours {
+			if behaviour.Member != sender.SenderID() ||
+				!behaviour.appliesTo(msg) {
+				continue
+			}
+
+			alteredMsg, err := behaviour.apply(msg)
+			if err != nil {
+				fmt.Printf(
+					"[member:%v] could not [%v] message of type [%v]: [%v]\n",
+					behaviour.Member,
+					behaviour.Action,
+					msg.Type(),
+					err,
+				)
+				continue
+			}
+
+			if alteredMsg == nil {
+				return nil
+			}
+			msg = alteredMsg
+		}
+
+		return msg
+	}
+}
+
+// appliesTo returns true if the behaviour alters the given message.
+func (b *Behaviour) appliesTo(msg net.TaggedMarshaler) bool {
+	// Only shares are altered in phase 3; commitments are broadcast.
+	if b.Phase == 3 &&
+		b.Action != Drop &&
+		b.Action != Delay &&
+		msg.Type() != (&gjkr.PeerSharesMessage{}).Type() {
+		return false
+	}
+
+	for _, messageType := range phaseMessages[b.Phase] {
+		if msg.Type() == messageType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apply returns the message altered according to the behaviour or nil if
+// the message should be dropped.
+func (b *Behaviour) apply(msg net.TaggedMarshaler) (net.TaggedMarshaler, error) {
+	switch b.Action {
+	case Drop:
+		return nil, nil
+
+	case Delay:
+		delay, err := b.delay()
+		if err != nil {
+			return nil, err
+		}
+		time.Sleep(delay)
+		return msg, nil
+
+	case Corrupt:
+		switch message := msg.(type) {
+		case *gjkr.PeerSharesMessage:
+			return b.alterShares(message, func(
+				shares map[uint32]*gjkrpb.PeerShares_Shares,
+				targets []uint32,
+			) {
+				for _, target := range targets {
+					shares[target] = &gjkrpb.PeerShares_Shares{
+						EncryptedShareS: corrupted(shares[target].EncryptedShareS),
+						EncryptedShareT: corrupted(shares[target].EncryptedShareT),
+					}
+				}
+			})
+
+		case *result.DKGResultHashSignatureMessage:
+			bytes, err := message.Marshal()
+			if err != nil {
+				return nil, err
+			}
+
+			pbMsg := &resultpb.DKGResultHashSignature{}
+			if err := pbMsg.Unmarshal(bytes); err != nil {
+				return nil, err
+			}
+			pbMsg.Signature = corrupted(pbMsg.Signature)
+
+			return altered(&result.DKGResultHashSignatureMessage{}, pbMsg)
+
+		case *entry.SignatureShareMessage:
+			_, share, err := bn256.RandomG1(rand.Reader)
+			if err != nil {
+				return nil, err
+			}
+
+			return entry.NewSignatureShareMessage(
+				message.SenderID(),
+				share.Marshal(),
+			), nil
+		}
+
+	case Equivocate:
+		switch message := msg.(type) {
+		case *gjkr.EphemeralPublicKeyMessage:
+			return b.alterPublicKeys(message, func(
+				publicKeys map[uint32][]byte,
+				targets []uint32,
+			) {
+				original := make(map[uint32][]byte)
+				for receiver, publicKey := range publicKeys {
+					original[receiver] = publicKey
+				}
+
+				for target, source := range rotation(targets, original) {
+					publicKeys[target] = original[source]
+				}
+			})
+
+		case *gjkr.PeerSharesMessage:
+			return b.alterShares(message, func(
+				shares map[uint32]*gjkrpb.PeerShares_Shares,
+				targets []uint32,
+			) {
+				original := make(map[uint32]*gjkrpb.PeerShares_Shares)
+				receivers := make(map[uint32][]byte)
+				for receiver, share := range shares {
+					original[receiver] = share
+					receivers[receiver] = nil
+				}
+
+				for target, source := range rotation(targets, receivers) {
+					shares[target] = original[source]
+				}
+			})
+		}
+
+	case Subset:
+		receivers := make(map[uint32]bool)
+		for _, member := range b.To {
+			receivers[uint32(member)] = true
+		}
+
+		switch message := msg.(type) {
+		case *gjkr.EphemeralPublicKeyMessage:
+			return b.alterPublicKeys(message, func(
+				publicKeys map[uint32][]byte,
+				targets []uint32,
+			) {
+				for receiver := range publicKeys {
+					if !receivers[receiver] {
+						delete(publicKeys, receiver)
+					}
+				}
+			})
+
+		case *gjkr.PeerSharesMessage:
+			return b.alterShares(message, func(
+				shares map[uint32]*gjkrpb.PeerShares_Shares,
+				targets []uint32,
+			) {
+				for receiver := range shares {
+					if !receivers[receiver] {
+						delete(shares, receiver)
+					}
+				}
+			})
+		}
+	}
+
+	return nil, fmt.Errorf("message is not supported")
+}
+
+// alterPublicKeys alters ephemeral public keys the member generated for
+// other members. The alter function receives the keys keyed by the receiver
+// and receivers targeted by the behaviour.
+func (b *Behaviour) alterPublicKeys(
+	message *gjkr.EphemeralPublicKeyMessage,
+	alter func(publicKeys map[uint32][]byte, targets []uint32),
+) (net.TaggedMarshaler, error) {
+	bytes, err := message.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	pbMsg := &gjkrpb.EphemeralPublicKey{}
+	if err := pbMsg.Unmarshal(bytes); err != nil {
+		return nil, err
+	}
+
+	alter(
+		pbMsg.EphemeralPublicKeys,
+		b.targets(receiversOf(pbMsg.EphemeralPublicKeys)),
+	)
+
+	return altered(&gjkr.EphemeralPublicKeyMessage{}, pbMsg)
+}
+
+// alterShares alters encrypted shares the member calculated for other
+// members. The alter function receives the shares keyed by the receiver and
+// receivers targeted by the behaviour.
+func (b *Behaviour) alterShares(
+	message *gjkr.PeerSharesMessage,
+	alter func(shares map[uint32]*gjkrpb.PeerShares_Shares, targets []uint32),
+) (net.TaggedMarshaler, error) {
+	bytes, err := message.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	pbMsg := &gjkrpb.PeerShares{}
+	if err := pbMsg.Unmarshal(bytes); err != nil {
+		return nil, err
+	}
+
+	receivers := make(map[uint32][]byte)
+	for receiver := range pbMsg.Shares {
+		receivers[receiver] = nil
+	}
+
+	alter(pbMsg.Shares, b.targets(receiversOf(receivers)))
+
+	return altered(&gjkr.PeerSharesMessage{}, pbMsg)
+}
+
+// targets returns receivers of the message to which the behaviour applies.
+func (b *Behaviour) targets(receivers []uint32) []uint32 {
+	if len(b.To) == 0 {
+		return receivers
+	}
+
+	targets := make([]uint32, 0)
+	for _, receiver := range receivers {
+		for _, member := range b.To {
+			if receiver == uint32(member) {
+				targets = append(targets, receiver)
+			}
+		}
+	}
+
+	return targets
+}
+
+// rotation maps each of the targets to the receiver following it, so that
+// targets receive values prepared for other receivers.
+func rotation(targets []uint32, values map[uint32][]byte) map[uint32]uint32 {
+	receivers := receiversOf(values)
+
+	sources := make(map[uint32]uint32)
+	for _, target := range targets {
+		position := sort.Search(len(receivers), func(i int) bool {
+			return receivers[i] >= target
+		})
+		sources[target] = receivers[(position+1)%len(receivers)]
+	}
+
+	return sources
+}
+
+// receiversOf returns receivers of the given values in ascending order.
+func receiversOf(values map[uint32][]byte) []uint32 {
+	receivers := make([]uint32, 0, len(values))
+	for receiver := range values {
+		receivers = append(receivers, receiver)
+	}
+
+	sort.Slice(receivers, func(i, j int) bool {
+		return receivers[i] < receivers[j]
+	})
+
+	return receivers
+}
+
+// corrupted returns a copy of the given bytes with the first byte altered.
+func corrupted(bytes []byte) []byte {
+	corrupted := make([]byte, len(bytes))
+	copy(corrupted, bytes)
+	if len(corrupted) > 0 {
+		corrupted[0] ^= 0xff
+	}
+
+	return corrupted
+}
+
+// altered returns the message of the given type with the content of the
+// given protobuf message.
+func altered(
+	message interface {
+		net.TaggedMarshaler
+		Unmarshal(bytes []byte) error
+	},
+	pbMsg interface{ Marshal() ([]byte, error) },
+) (net.TaggedMarshaler, error) {
+	bytes, err := pbMsg.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := message.Unmarshal(bytes); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
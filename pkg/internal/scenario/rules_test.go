@@ -0,0 +1,244 @@
+package scenario
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
+	gjkrpb "github.com/keep-network/keep-core/pkg/beacon/relay/gjkr/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+func TestRulesAlterPublicKeys(t *testing.T) {
+	publicKeys := make(map[uint32][]byte)
+	for _, receiver := range []uint32{1, 3, 4} {
+		keyPair, err := ephemeral.GenerateKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKeys[receiver] = keyPair.PublicKey.Marshal()
+	}
+
+	var tests = map[string]struct {
+		behaviour *Behaviour
+		// expectedSources maps receivers to receivers of the original
+		// message whose keys they are expected to get.
+		expectedSources map[uint32]uint32
+		expectedDropped bool
+	}{
+		"drop": {
+			behaviour:       &Behaviour{Member: 2, Phase: 1, Action: Drop},
+			expectedDropped: true,
+		},
+		"other member": {
+			behaviour:       &Behaviour{Member: 3, Phase: 1, Action: Drop},
+			expectedSources: map[uint32]uint32{1: 1, 3: 3, 4: 4},
+		},
+		"other phase": {
+			behaviour:       &Behaviour{Member: 2, Phase: 3, Action: Drop},
+			expectedSources: map[uint32]uint32{1: 1, 3: 3, 4: 4},
+		},
+		"subset": {
+			behaviour: &Behaviour{
+				Member: 2,
+				Phase:  1,
+				Action: Subset,
+				To:     []group.MemberIndex{1, 4},
+			},
+			expectedSources: map[uint32]uint32{1: 1, 4: 4},
+		},
+		"equivocate to all members": {
+			behaviour:       &Behaviour{Member: 2, Phase: 1, Action: Equivocate},
+			expectedSources: map[uint32]uint32{1: 3, 3: 4, 4: 1},
+		},
+		"equivocate to one member": {
+			behaviour: &Behaviour{
+				Member: 2,
+				Phase:  1,
+				Action: Equivocate,
+				To:     []group.MemberIndex{4},
+			},
+			expectedSources: map[uint32]uint32{1: 1, 3: 3, 4: 1},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			msg, err := altered(
+				&gjkr.EphemeralPublicKeyMessage{},
+				&gjkrpb.EphemeralPublicKey{
+					SenderID:            2,
+					EphemeralPublicKeys: publicKeys,
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			alteredMsg := rules([]*Behaviour{test.behaviour})(msg)
+
+			if test.expectedDropped {
+				if alteredMsg != nil {
+					t.Errorf("message has not been dropped")
+				}
+				return
+			}
+
+			pbMsg := &gjkrpb.EphemeralPublicKey{}
+			unmarshalPb(t, alteredMsg, pbMsg)
+
+			if len(test.expectedSources) != len(pbMsg.EphemeralPublicKeys) {
+				t.Fatalf(
+					"unexpected number of public keys\nexpected: [%v]\nactual:   [%v]",
+					len(test.expectedSources),
+					len(pbMsg.EphemeralPublicKeys),
+				)
+			}
+			for receiver, source := range test.expectedSources {
+				if !bytes.Equal(
+					publicKeys[source],
+					pbMsg.EphemeralPublicKeys[receiver],
+				) {
+					t.Errorf(
+						"member [%v] has not received key prepared for member [%v]",
+						receiver,
+						source,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestRulesAlterShares(t *testing.T) {
+	shares := map[uint32]*gjkrpb.PeerShares_Shares{
+		1: {EncryptedShareS: []byte{1, 1}, EncryptedShareT: []byte{1, 2}},
+		3: {EncryptedShareS: []byte{3, 1}, EncryptedShareT: []byte{3, 2}},
+		4: {EncryptedShareS: []byte{4, 1}, EncryptedShareT: []byte{4, 2}},
+	}
+
+	var tests = map[string]struct {
+		behaviours        []*Behaviour
+		expectedSources   map[uint32]uint32
+		expectedCorrupted []uint32
+	}{
+		"corrupt for all members": {
+			behaviours:        []*Behaviour{{Member: 2, Phase: 3, Action: Corrupt}},
+			expectedSources:   map[uint32]uint32{1: 1, 3: 3, 4: 4},
+			expectedCorrupted: []uint32{1, 3, 4},
+		},
+		"corrupt for one member": {
+			behaviours: []*Behaviour{{
+				Member: 2,
+				Phase:  3,
+				Action: Corrupt,
+				To:     []group.MemberIndex{3},
+			}},
+			expectedSources:   map[uint32]uint32{1: 1, 3: 3, 4: 4},
+			expectedCorrupted: []uint32{3},
+		},
+		"subset": {
+			behaviours: []*Behaviour{{
+				Member: 2,
+				Phase:  3,
+				Action: Subset,
+				To:     []group.MemberIndex{3},
+			}},
+			expectedSources: map[uint32]uint32{3: 3},
+		},
+		"equivocate to one member": {
+			behaviours: []*Behaviour{{
+				Member: 2,
+				Phase:  3,
+				Action: Equivocate,
+				To:     []group.MemberIndex{1},
+			}},
+			expectedSources: map[uint32]uint32{1: 3, 3: 3, 4: 4},
+		},
+		"stacked behaviours": {
+			behaviours: []*Behaviour{{
+				Member: 2,
+				Phase:  3,
+				Action: Subset,
+				To:     []group.MemberIndex{1, 3},
+			}, {
+				Member: 2,
+				Phase:  3,
+				Action: Corrupt,
+			}},
+			expectedSources:   map[uint32]uint32{1: 1, 3: 3},
+			expectedCorrupted: []uint32{1, 3},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			msg, err := altered(
+				&gjkr.PeerSharesMessage{},
+				&gjkrpb.PeerShares{SenderID: 2, Shares: shares},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pbMsg := &gjkrpb.PeerShares{}
+			unmarshalPb(t, rules(test.behaviours)(msg), pbMsg)
+
+			if len(test.expectedSources) != len(pbMsg.Shares) {
+				t.Fatalf(
+					"unexpected number of shares\nexpected: [%v]\nactual:   [%v]",
+					len(test.expectedSources),
+					len(pbMsg.Shares),
+				)
+			}
+
+			corruptedReceivers := make(map[uint32]bool)
+			for _, receiver := range test.expectedCorrupted {
+				corruptedReceivers[receiver] = true
+			}
+
+			for receiver, source := range test.expectedSources {
+				expectedS := shares[source].EncryptedShareS
+				expectedT := shares[source].EncryptedShareT
+				if corruptedReceivers[receiver] {
+					expectedS = corrupted(expectedS)
+					expectedT = corrupted(expectedT)
+				}
+
+				actual := pbMsg.Shares[receiver]
+				if !bytes.Equal(expectedS, actual.EncryptedShareS) ||
+					!bytes.Equal(expectedT, actual.EncryptedShareT) {
+					t.Errorf(
+						"unexpected shares of member [%v]\nexpected: [%v %v]\nactual:   [%v %v]",
+						receiver,
+						expectedS,
+						expectedT,
+						actual.EncryptedShareS,
+						actual.EncryptedShareT,
+					)
+				}
+			}
+		})
+	}
+}
+
+func unmarshalPb(
+	t *testing.T,
+	msg net.TaggedMarshaler,
+	pbMsg interface{ Unmarshal([]byte) error },
+) {
+	if msg == nil {
+		t.Fatal("message has been dropped")
+	}
+
+	bytes, err := msg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pbMsg.Unmarshal(bytes); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,119 @@
+package scenario
+
+import (
+	"crypto/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/internal/dkgtest"
+	"github.com/keep-network/keep-core/pkg/internal/entrytest"
+)
+
+// Run executes DKG and, if expected, relay entry signing of the scenario and
+// checks the outcome against the expected one.
+func Run(t *testing.T, scenario *Scenario) {
+	dkgResult, err := dkgtest.RunTest(
+		scenario.GroupSize,
+		scenario.HonestThreshold,
+		dkgtest.RandomSeed(t),
+		rules(scenario.DKG),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := dkgResult.ReplayTranscript(scenario.observer())
+	if err != nil {
+		t.Fatalf("could not replay dkg transcript: [%v]", err)
+	}
+
+	disqualified, inactive := misbehavingMembers(report)
+	assertMembers(t, "disqualified", scenario.Expect.Disqualified, disqualified)
+	assertMembers(t, "inactive", scenario.Expect.Inactive, inactive)
+
+	if !scenario.Expect.ResultPublished {
+		dkgtest.AssertDkgResultNotPublished(t, dkgResult)
+		return
+	}
+
+	dkgtest.AssertDkgResultPublished(t, dkgResult)
+	dkgtest.AssertMisbehavingMembers(
+		t,
+		dkgResult,
+		append(disqualified, inactive...)...,
+	)
+
+	if scenario.Expect.EntryPublished == nil {
+		return
+	}
+
+	_, previousEntry, err := bn256.RandomG1(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signingResult, err := entrytest.RunTest(
+		dkgResult.GetSigners(),
+		scenario.HonestThreshold,
+		rules(scenario.Signing),
+		previousEntry.Marshal(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *scenario.Expect.EntryPublished {
+		entrytest.AssertEntryPublished(t, signingResult)
+	} else {
+		entrytest.AssertEntryNotPublished(t, signingResult)
+	}
+}
+
+// misbehavingMembers returns members disqualified and members marked as
+// inactive in the replayed key generation, in ascending order.
+func misbehavingMembers(
+	report *dkg.ReplayReport,
+) (disqualified, inactive []group.MemberIndex) {
+	disqualified = make([]group.MemberIndex, 0)
+	inactive = make([]group.MemberIndex, 0)
+
+	for _, decision := range report.KeyGeneration.Decisions {
+		if decision.Inactive {
+			inactive = append(inactive, decision.MemberID)
+		} else {
+			disqualified = append(disqualified, decision.MemberID)
+		}
+	}
+
+	return sorted(disqualified), sorted(inactive)
+}
+
+func assertMembers(
+	t *testing.T,
+	description string,
+	expected []group.MemberIndex,
+	actual []group.MemberIndex,
+) {
+	expected = sorted(append([]group.MemberIndex{}, expected...))
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf(
+			"unexpected %v members\nexpected: %v\nactual:   %v",
+			description,
+			expected,
+			actual,
+		)
+	}
+}
+
+func sorted(members []group.MemberIndex) []group.MemberIndex {
+	sort.Slice(members, func(i, j int) bool {
+		return members[i] < members[j]
+	})
+	return members
+}
@@ -0,0 +1,291 @@
+// Package scenario provides declarative adversarial scenarios of DKG and
+// relay entry signing executed with the dkgtest and entrytest engines.
+//
+// Scenario describes how members misbehave in protocol phases and what the
+// outcome of the protocol execution is expected to be. Scenarios are written
+// in YAML:
+//
+//	name: member 2 sends corrupted shares to member 3
+//	groupSize: 5
+//	honestThreshold: 3
+//	dkg:
+//	  - member: 2
+//	    phase: 3
+//	    action: corrupt
+//	    to: [3]
+//	signing:
+//	  - member: 1
+//	    action: drop
+//	expect:
+//	  resultPublished: true
+//	  disqualified: [2]
+//	  inactive: []
+//	  entryPublished: true
+//
+// DKG behaviours refer to phases of the protocol in which members broadcast
+// messages: 1, 3, 4, 7, 8 and 10 of GJKR key generation and 13 of the DKG
+// result publication. Signing behaviours refer to the only phase of relay
+// entry signing.
+//
+// The following actions are supported:
+//   - drop: the message is not sent,
+//   - delay: the message is sent after the given delay, e.g. 10s; the member
+//     stalls for that time,
+//   - corrupt: shares in the phase 3 message, the DKG result signature in
+//     phase 13 or the relay entry signature share are replaced with invalid
+//     ones; in phase 3, only shares for members listed in `to` are corrupted
+//     if the list is not empty,
+//   - equivocate: members listed in `to`, or all members if the list is
+//     empty, receive values the member prepared for other members; it applies
+//     to phases 1 and 3,
+//   - subset: only members listed in `to` receive values prepared for them;
+//     it applies to phases 1 and 3.
+//
+// Messages of other phases are broadcast to all members with the same
+// content, so they can not be equivocated or sent to a subset of members.
+//
+// Disqualified and inactive members are those the protocol marked as such
+// in the key generation, as seen by the honest member with the lowest index.
+// Relay entry signing is executed with signers produced by DKG if the
+// expected entry publication is set.
+package scenario
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+// Supported actions of misbehaving members.
+const (
+	Drop       = "drop"
+	Delay      = "delay"
+	Corrupt    = "corrupt"
+	Equivocate = "equivocate"
+	Subset     = "subset"
+)
+
+// SigningPhase is the phase of the relay entry signing behaviours.
+const SigningPhase = 0
+
+// resultPublicationPhase is the phase of DKG in which the result is signed.
+const resultPublicationPhase = 13
+
+// Scenario is an adversarial scenario of DKG and relay entry signing.
+type Scenario struct {
+	Name            string
+	Description     string
+	GroupSize       int `yaml:"groupSize"`
+	HonestThreshold int `yaml:"honestThreshold"`
+
+	// DKG are behaviours of members in DKG phases.
+	DKG []*Behaviour `yaml:"dkg"`
+	// Signing are behaviours of members in relay entry signing.
+	Signing []*Behaviour `yaml:"signing"`
+
+	Expect Expectation
+}
+
+// Behaviour is the way the member misbehaves in the protocol phase.
+type Behaviour struct {
+	Member group.MemberIndex
+	// Phase is the phase of DKG; it is not set for signing behaviours.
+	Phase  int
+	Action string
+	// To lists members to which the action applies, where supported.
+	To []group.MemberIndex
+	// Delay is the delay of the message, in the time.Duration format.
+	Delay string
+}
+
+// Expectation is the expected outcome of the scenario.
+type Expectation struct {
+	ResultPublished bool                `yaml:"resultPublished"`
+	Disqualified    []group.MemberIndex `yaml:"disqualified"`
+	Inactive        []group.MemberIndex `yaml:"inactive"`
+	// EntryPublished, if set, is whether the relay entry is expected to be
+	// published.
+	EntryPublished *bool `yaml:"entryPublished"`
+}
+
+// Parse parses and validates the scenario written in YAML.
+func Parse(bytes []byte) (*Scenario, error) {
+	scenario := &Scenario{}
+	if err := yaml.UnmarshalStrict(bytes, scenario); err != nil {
+		return nil, fmt.Errorf("could not parse scenario: [%v]", err)
+	}
+
+	if err := scenario.validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario [%v]: [%v]", scenario.Name, err)
+	}
+
+	return scenario, nil
+}
+
+// Load reads all scenarios from YAML files matching the given pattern.
+func Load(pattern string) ([]*Scenario, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("could not find scenarios: [%v]", err)
+	}
+
+	scenarios := make([]*Scenario, 0, len(paths))
+	for _, path := range paths {
+		bytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read scenario: [%v]", err)
+		}
+
+		scenario, err := Parse(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("[%v]: %v", path, err)
+		}
+
+		scenarios = append(scenarios, scenario)
+	}
+
+	return scenarios, nil
+}
+
+func (s *Scenario) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("name is not set")
+	}
+
+	if s.HonestThreshold < 1 || s.HonestThreshold > s.GroupSize {
+		return fmt.Errorf(
+			"honest threshold [%v] is not in the range [1, %v]",
+			s.HonestThreshold,
+			s.GroupSize,
+		)
+	}
+
+	for _, behaviour := range s.DKG {
+		if err := s.validateBehaviour(behaviour, false); err != nil {
+			return err
+		}
+	}
+	for _, behaviour := range s.Signing {
+		if err := s.validateBehaviour(behaviour, true); err != nil {
+			return err
+		}
+	}
+
+	if len(s.Signing) > 0 && s.Expect.EntryPublished == nil {
+		return fmt.Errorf("expected relay entry publication is not set")
+	}
+	if s.Expect.EntryPublished != nil && !s.Expect.ResultPublished {
+		return fmt.Errorf("relay entry is signed only if dkg result is published")
+	}
+
+	for _, members := range [][]group.MemberIndex{
+		s.Expect.Disqualified,
+		s.Expect.Inactive,
+	} {
+		for _, member := range members {
+			if err := s.validateMember(member); err != nil {
+				return fmt.Errorf("invalid expectation: [%v]", err)
+			}
+		}
+	}
+
+	if s.observer() == 0 {
+		return fmt.Errorf("there is no member behaving honestly in DKG")
+	}
+
+	return nil
+}
+
+func (s *Scenario) validateBehaviour(behaviour *Behaviour, signing bool) error {
+	if err := s.validateMember(behaviour.Member); err != nil {
+		return fmt.Errorf("invalid behaviour: [%v]", err)
+	}
+
+	for _, member := range behaviour.To {
+		if err := s.validateMember(member); err != nil {
+			return fmt.Errorf("invalid behaviour receiver: [%v]", err)
+		}
+	}
+
+	if signing {
+		if behaviour.Phase != SigningPhase {
+			return fmt.Errorf("phase of a signing behaviour is set")
+		}
+	} else if _, ok := phaseMessages[behaviour.Phase]; !ok ||
+		behaviour.Phase == SigningPhase {
+		return fmt.Errorf("phase [%v] is not supported", behaviour.Phase)
+	}
+
+	switch behaviour.Action {
+	case Drop:
+	case Delay:
+		if _, err := behaviour.delay(); err != nil {
+			return err
+		}
+	case Corrupt:
+		if behaviour.Phase != 3 &&
+			behaviour.Phase != resultPublicationPhase &&
+			behaviour.Phase != SigningPhase {
+			return fmt.Errorf(
+				"corrupt is not supported in phase [%v]",
+				behaviour.Phase,
+			)
+		}
+	case Equivocate, Subset:
+		if behaviour.Phase != 1 && behaviour.Phase != 3 {
+			return fmt.Errorf(
+				"messages of phase [%v] are broadcast with the same "+
+					"content to all members; [%v] is not supported",
+				behaviour.Phase,
+				behaviour.Action,
+			)
+		}
+	default:
+		return fmt.Errorf("action [%v] is not supported", behaviour.Action)
+	}
+
+	return nil
+}
+
+func (s *Scenario) validateMember(member group.MemberIndex) error {
+	if member < 1 || int(member) > s.GroupSize {
+		return fmt.Errorf(
+			"member [%v] is not in the range [1, %v]",
+			member,
+			s.GroupSize,
+		)
+	}
+
+	return nil
+}
+
+// observer returns the member with the lowest index which behaves honestly
+// in DKG or zero if there is no such member.
+func (s *Scenario) observer() group.MemberIndex {
+	misbehaving := make(map[group.MemberIndex]bool)
+	for _, behaviour := range s.DKG {
+		misbehaving[behaviour.Member] = true
+	}
+
+	for member := 1; member <= s.GroupSize; member++ {
+		if !misbehaving[group.MemberIndex(member)] {
+			return group.MemberIndex(member)
+		}
+	}
+
+	return 0
+}
+
+func (b *Behaviour) delay() (time.Duration, error) {
+	delay, err := time.ParseDuration(b.Delay)
+	if err != nil {
+		return 0, fmt.Errorf("invalid delay [%v]: [%v]", b.Delay, err)
+	}
+
+	return delay, nil
+}
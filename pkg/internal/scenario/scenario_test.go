@@ -0,0 +1,307 @@
+package scenario
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+)
+
+func TestParse(t *testing.T) {
+	var tests = map[string]struct {
+		scenario      string
+		expectedError bool
+	}{
+		"valid scenario": {
+			scenario: `
+name: valid
+groupSize: 5
+honestThreshold: 3
+dkg:
+  - member: 2
+    phase: 3
+    action: corrupt
+    to: [3]
+  - member: 4
+    phase: 4
+    action: delay
+    delay: 10s
+signing:
+  - member: 1
+    action: drop
+expect:
+  resultPublished: true
+  disqualified: [2]
+  inactive: [4]
+  entryPublished: true
+`,
+			expectedError: false,
+		},
+		"unknown field": {
+			scenario: `
+name: unknown field
+groupSize: 5
+honestThreshold: 3
+members: 5
+`,
+			expectedError: true,
+		},
+		"no name": {
+			scenario: `
+groupSize: 5
+honestThreshold: 3
+`,
+			expectedError: true,
+		},
+		"honest threshold greater than group size": {
+			scenario: `
+name: honest threshold greater than group size
+groupSize: 5
+honestThreshold: 6
+`,
+			expectedError: true,
+		},
+		"member out of group": {
+			scenario: `
+name: member out of group
+groupSize: 5
+honestThreshold: 3
+dkg:
+  - member: 6
+    phase: 1
+    action: drop
+`,
+			expectedError: true,
+		},
+		"receiver out of group": {
+			scenario: `
+name: receiver out of group
+groupSize: 5
+honestThreshold: 3
+dkg:
+  - member: 1
+    phase: 1
+    action: subset
+    to: [2, 6]
+`,
+			expectedError: true,
+		},
+		"phase with no messages": {
+			scenario: `
+name: phase with no messages
+groupSize: 5
+honestThreshold: 3
+dkg:
+  - member: 1
+    phase: 2
+    action: drop
+`,
+			expectedError: true,
+		},
+		"dkg behaviour with no phase": {
+			scenario: `
+name: dkg behaviour with no phase
+groupSize: 5
+honestThreshold: 3
+dkg:
+  - member: 1
+    action: drop
+`,
+			expectedError: true,
+		},
+		"signing behaviour with phase": {
+			scenario: `
+name: signing behaviour with phase
+groupSize: 5
+honestThreshold: 3
+signing:
+  - member: 1
+    phase: 1
+    action: drop
+expect:
+  resultPublished: true
+  entryPublished: true
+`,
+			expectedError: true,
+		},
+		"unknown action": {
+			scenario: `
+name: unknown action
+groupSize: 5
+honestThreshold: 3
+dkg:
+  - member: 1
+    phase: 1
+    action: replay
+`,
+			expectedError: true,
+		},
+		"invalid delay": {
+			scenario: `
+name: invalid delay
+groupSize: 5
+honestThreshold: 3
+dkg:
+  - member: 1
+    phase: 1
+    action: delay
+    delay: 10
+`,
+			expectedError: true,
+		},
+		"corrupted accusations": {
+			scenario: `
+name: corrupted accusations
+groupSize: 5
+honestThreshold: 3
+dkg:
+  - member: 1
+    phase: 4
+    action: corrupt
+`,
+			expectedError: true,
+		},
+		"broadcast message sent to subset": {
+			scenario: `
+name: broadcast message sent to subset
+groupSize: 5
+honestThreshold: 3
+dkg:
+  - member: 1
+    phase: 7
+    action: subset
+    to: [2]
+`,
+			expectedError: true,
+		},
+		"signing with no expected entry publication": {
+			scenario: `
+name: signing with no expected entry publication
+groupSize: 5
+honestThreshold: 3
+signing:
+  - member: 1
+    action: drop
+expect:
+  resultPublished: true
+`,
+			expectedError: true,
+		},
+		"expected entry publication with no result": {
+			scenario: `
+name: expected entry publication with no result
+groupSize: 5
+honestThreshold: 3
+expect:
+  resultPublished: false
+  entryPublished: false
+`,
+			expectedError: true,
+		},
+		"expected misbehaving member out of group": {
+			scenario: `
+name: expected misbehaving member out of group
+groupSize: 5
+honestThreshold: 3
+expect:
+  inactive: [6]
+`,
+			expectedError: true,
+		},
+		"no honest member": {
+			scenario: `
+name: no honest member
+groupSize: 2
+honestThreshold: 1
+dkg:
+  - member: 1
+    phase: 1
+    action: drop
+  - member: 2
+    phase: 1
+    action: drop
+`,
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := Parse([]byte(test.scenario))
+
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected error: [%v]\nactual error:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestParseBehaviours(t *testing.T) {
+	scenario, err := Parse([]byte(`
+name: behaviours
+groupSize: 5
+honestThreshold: 3
+dkg:
+  - member: 2
+    phase: 1
+    action: subset
+    to: [1, 3]
+signing:
+  - member: 4
+    action: corrupt
+expect:
+  resultPublished: true
+  disqualified: [2]
+  entryPublished: false
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDKG := []*Behaviour{
+		{
+			Member: 2,
+			Phase:  1,
+			Action: Subset,
+			To:     []group.MemberIndex{1, 3},
+		},
+	}
+	if !reflect.DeepEqual(expectedDKG, scenario.DKG) {
+		t.Errorf(
+			"unexpected dkg behaviours\nexpected: [%+v]\nactual:   [%+v]",
+			expectedDKG[0],
+			scenario.DKG[0],
+		)
+	}
+
+	expectedSigning := []*Behaviour{{Member: 4, Action: Corrupt}}
+	if !reflect.DeepEqual(expectedSigning, scenario.Signing) {
+		t.Errorf(
+			"unexpected signing behaviours\nexpected: [%+v]\nactual:   [%+v]",
+			expectedSigning[0],
+			scenario.Signing[0],
+		)
+	}
+
+	entryPublished := false
+	expectedExpectation := Expectation{
+		ResultPublished: true,
+		Disqualified:    []group.MemberIndex{2},
+		EntryPublished:  &entryPublished,
+	}
+	if !reflect.DeepEqual(expectedExpectation, scenario.Expect) {
+		t.Errorf(
+			"unexpected expectation\nexpected: [%+v]\nactual:   [%+v]",
+			expectedExpectation,
+			scenario.Expect,
+		)
+	}
+
+	if scenario.observer() != group.MemberIndex(1) {
+		t.Errorf("unexpected observer [%v]", scenario.observer())
+	}
+}
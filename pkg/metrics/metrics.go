@@ -0,0 +1,99 @@
+// Package metrics serves metrics of the client in the Prometheus text format
+// on the /metrics endpoint. Metrics are defined and updated by the packages
+// they describe and registered in the default Prometheus registry, under
+// the common Namespace.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var logger = log.Logger("keep-metrics")
+
+// Namespace is the namespace of all metrics of the client.
+const Namespace = "keep"
+
+// DefaultHost is the host metrics are served on if none is configured.
+const DefaultHost = "localhost"
+
+// Endpoint is the path metrics are served on.
+const Endpoint = "/metrics"
+
+// shutdownTimeout is the maximum time the server waits for scrapes being
+// served to complete when it is shut down.
+const shutdownTimeout = 5 * time.Second
+
+// Config contains the configuration of the metrics endpoint.
+type Config struct {
+	// Port is the port metrics are served on. Metrics are not served if
+	// the port is not set.
+	Port int
+	// Host is the host metrics are served on. Defaults to localhost.
+	Host string
+}
+
+// host returns the host metrics are served on.
+func (c *Config) host() string {
+	if c.Host == "" {
+		return DefaultHost
+	}
+
+	return c.Host
+}
+
+// Start starts serving metrics in the background if it is enabled in the
+// given config. Metrics are no longer served when the given context is done.
+func Start(ctx context.Context, config Config) error {
+	if config.Port == 0 {
+		logger.Infof("metrics endpoint disabled")
+		return nil
+	}
+
+	address := net.JoinHostPort(config.host(), strconv.Itoa(config.Port))
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf(
+			"could not listen on [%v] for metrics: [%v]",
+			address,
+			err,
+		)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(Endpoint, promhttp.Handler())
+
+	server := &http.Server{Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(
+			context.Background(),
+			shutdownTimeout,
+		)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Warningf("could not shut down metrics endpoint: [%v]", err)
+		}
+	}()
+
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			logger.Errorf("metrics endpoint failed: [%v]", err)
+		}
+	}()
+
+	logger.Infof("serving metrics on [%v%v]", listener.Addr(), Endpoint)
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServesMetrics(t *testing.T) {
+	// find a free port for the endpoint
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := Start(ctx, Config{Port: port}); err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := http.Get(fmt.Sprintf("http://localhost:%v%v", port, Endpoint))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: [%v]", response.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), "go_goroutines") {
+		t.Errorf("expected Go runtime metrics; got:\n%s", body)
+	}
+}
@@ -0,0 +1,305 @@
+// Package batch provides a broadcast channel which lets several local
+// participants of a protocol, e.g. several members of one group controlled by
+// the same operator, share one network broadcast channel. Messages sent by
+// all the local participants at about the same time are combined into a
+// single network message, so the network and the receivers deal with one
+// message instead of one message per participant. Batches received are
+// unpacked for every message handler and their messages are delivered as if
+// they were sent separately.
+//
+// Only the network traffic is shared by the channel. Every participant still
+// receives all the messages, exactly as it would without batches; sharing the
+// verification of the messages is up to the participants.
+//
+// Peers which do not support batches drop them, so batches should be sent
+// only when all the peers support them. A message which is not sent along
+// with other messages is published unchanged.
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/internal"
+)
+
+var logger = log.Logger("keep-net-batch")
+
+const (
+	// flushDelay is the time the channel waits for more messages after the
+	// last message has been sent before an incomplete batch is published.
+	// Participants send their messages as soon as they enter the same
+	// protocol phase, so messages sent close to each other end up in the same
+	// batch.
+	flushDelay = 100 * time.Millisecond
+)
+
+type pendingMessage struct {
+	ctx       context.Context
+	message   net.TaggedMarshaler
+	payload   []byte
+	published chan error
+}
+
+// Channel is a broadcast channel combining messages sent by local
+// participants into batches. Messages received from the network which are
+// not batches are delivered to the local participants unchanged so Channel
+// stays compatible with peers not batching their messages.
+type Channel struct {
+	channel      net.BroadcastChannel
+	participants int
+
+	counter uint64
+
+	unmarshalersMutex  sync.Mutex
+	unmarshalersByType map[string]func() net.TaggedUnmarshaler
+
+	pendingMutex    sync.Mutex
+	pendingMessages []*pendingMessage
+	flushTimer      *time.Timer
+}
+
+// NewChannel returns a batching channel on top of the given broadcast
+// channel, shared by the given number of participants. A batch is complete,
+// and it is published immediately, once every participant sent a message.
+func NewChannel(
+	channel net.BroadcastChannel,
+	participants int,
+) (*Channel, error) {
+	err := channel.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+		return &Message{}
+	})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not register batch message unmarshaler: [%v]",
+			err,
+		)
+	}
+
+	c := &Channel{
+		channel:            channel,
+		participants:       participants,
+		unmarshalersByType: make(map[string]func() net.TaggedUnmarshaler),
+	}
+
+	c.flushTimer = time.AfterFunc(flushDelay, c.flush)
+	c.flushTimer.Stop()
+
+	return c, nil
+}
+
+func (c *Channel) nextSeqno() uint64 {
+	return atomic.AddUint64(&c.counter, 1)
+}
+
+// Name returns the name of the underlying broadcast channel.
+func (c *Channel) Name() string {
+	return c.channel.Name()
+}
+
+// Send adds the message to the batch and waits until the batch is published.
+// The batch is published as soon as it is complete or when no other message
+// has been sent for a while. It returns the error of publishing the batch.
+// The batch is retransmitted until contexts of all the messages in the batch
+// are done. If no other message is sent before the batch is published, the
+// message is published alone, without the batch.
+func (c *Channel) Send(ctx context.Context, message net.TaggedMarshaler) error {
+	payload, err := message.Marshal()
+	if err != nil {
+		return err
+	}
+
+	pending := &pendingMessage{
+		ctx:       ctx,
+		message:   message,
+		payload:   payload,
+		published: make(chan error, 1),
+	}
+
+	c.pendingMutex.Lock()
+	c.pendingMessages = append(c.pendingMessages, pending)
+	complete := len(c.pendingMessages) >= c.participants
+	if complete {
+		c.flushTimer.Stop()
+	} else {
+		c.flushTimer.Reset(flushDelay)
+	}
+	c.pendingMutex.Unlock()
+
+	if complete {
+		c.flush()
+	}
+
+	select {
+	case err := <-pending.published:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flush publishes all the messages sent since the last flush as one batch
+// and notifies their senders about the result. A single message is published
+// unchanged.
+func (c *Channel) flush() {
+	c.pendingMutex.Lock()
+	pendingMessages := c.pendingMessages
+	c.pendingMessages = nil
+	c.pendingMutex.Unlock()
+
+	if len(pendingMessages) == 0 {
+		return
+	}
+
+	err := c.publish(pendingMessages)
+	for _, pending := range pendingMessages {
+		pending.published <- err
+	}
+}
+
+func (c *Channel) publish(pendingMessages []*pendingMessage) error {
+	if len(pendingMessages) == 1 {
+		pending := pendingMessages[0]
+		if err := c.channel.Send(pending.ctx, pending.message); err != nil {
+			return fmt.Errorf(
+				"could not publish message in channel [%v]: [%v]",
+				c.Name(),
+				err,
+			)
+		}
+		return nil
+	}
+
+	batch := &Message{
+		messages: make([]*batchedMessage, len(pendingMessages)),
+	}
+	contexts := make([]context.Context, len(pendingMessages))
+	for i, pendingMessage := range pendingMessages {
+		batch.messages[i] = &batchedMessage{
+			messageType: pendingMessage.message.Type(),
+			seqno:       c.nextSeqno(),
+			payload:     pendingMessage.payload,
+		}
+		contexts[i] = pendingMessage.ctx
+	}
+
+	logger.Debugf(
+		"publishing batch of [%v] messages in channel [%v]",
+		len(batch.messages),
+		c.Name(),
+	)
+
+	if err := c.channel.Send(allDone(contexts), batch); err != nil {
+		return fmt.Errorf(
+			"could not publish batch of [%v] messages in channel [%v]: [%v]",
+			len(batch.messages),
+			c.Name(),
+			err,
+		)
+	}
+
+	return nil
+}
+
+// allDone returns a context which is done when all the given contexts are
+// done.
+func allDone(contexts []context.Context) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		defer cancel()
+		for _, c := range contexts {
+			<-c.Done()
+		}
+	}()
+
+	return ctx
+}
+
+// Recv installs a message handler that will receive messages from the
+// channel for the entire lifetime of the provided context. Messages sent
+// in a batch are delivered one by one. Each handler is installed in the
+// underlying channel separately, so retransmissions are filtered out for
+// each handler on its own, the same way as for handlers installed in the
+// underlying channel directly.
+func (c *Channel) Recv(ctx context.Context, handler func(m net.Message)) {
+	c.channel.Recv(ctx, func(message net.Message) {
+		c.handle(message, handler)
+	})
+}
+
+// handle unpacks the batch received from the underlying channel and delivers
+// its messages to the given handler. Messages which are not batches are
+// delivered unchanged.
+func (c *Channel) handle(message net.Message, handler func(m net.Message)) {
+	batch, ok := message.Payload().(*Message)
+	if !ok {
+		handler(message)
+		return
+	}
+
+	for _, batched := range batch.messages {
+		unmarshaled, err := c.unmarshal(batched)
+		if err != nil {
+			logger.Warningf(
+				"could not unpack message from batch sent by [%v]: [%v]",
+				message.TransportSenderID(),
+				err,
+			)
+			continue
+		}
+
+		handler(internal.BasicMessage(
+			message.TransportSenderID(),
+			unmarshaled,
+			batched.messageType,
+			message.SenderPublicKey(),
+			batched.seqno,
+		))
+	}
+}
+
+func (c *Channel) unmarshal(batched *batchedMessage) (interface{}, error) {
+	c.unmarshalersMutex.Lock()
+	unmarshaler, found := c.unmarshalersByType[batched.messageType]
+	c.unmarshalersMutex.Unlock()
+
+	if !found {
+		return nil, fmt.Errorf(
+			"couldn't find unmarshaler for type %s",
+			batched.messageType,
+		)
+	}
+
+	unmarshaled := unmarshaler()
+	if err := unmarshaled.Unmarshal(batched.payload); err != nil {
+		return nil, err
+	}
+
+	return unmarshaled, nil
+}
+
+// RegisterUnmarshaler registers the unmarshaler for messages sent in batches
+// and for messages sent separately through the underlying channel.
+func (c *Channel) RegisterUnmarshaler(
+	unmarshaler func() net.TaggedUnmarshaler,
+) error {
+	tpe := unmarshaler().Type()
+
+	c.unmarshalersMutex.Lock()
+	c.unmarshalersByType[tpe] = unmarshaler
+	c.unmarshalersMutex.Unlock()
+
+	return c.channel.RegisterUnmarshaler(unmarshaler)
+}
+
+// SetFilter registers the filter in the underlying channel.
+func (c *Channel) SetFilter(filter net.BroadcastChannelFilter) error {
+	return c.channel.SetFilter(filter)
+}
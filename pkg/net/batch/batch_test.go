@@ -0,0 +1,355 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/internal/pbutils"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestDeliverMessagesSentInBatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	channelName := "batch test"
+
+	sender, err := NewChannel(broadcastChannel(t, channelName), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender.RegisterUnmarshaler(newTestMessage)
+
+	receiver, err := NewChannel(broadcastChannel(t, channelName), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver.RegisterUnmarshaler(newTestMessage)
+
+	// Network messages are observed on a channel which does not unpack
+	// batches.
+	observer := broadcastChannel(t, channelName)
+	observer.RegisterUnmarshaler(func() net.TaggedUnmarshaler {
+		return &Message{}
+	})
+	networkMessages := collect(ctx, observer)
+
+	receivedMessages := collect(ctx, receiver)
+
+	expectedContents := []string{"seat 1", "seat 2", "seat 3"}
+	sendConcurrently(t, ctx, sender, expectedContents)
+
+	received := receivedMessages.wait(t, len(expectedContents))
+
+	var contents []string
+	seqnos := make(map[uint64]bool)
+	for _, message := range received {
+		contents = append(contents, message.Payload().(*testMessage).content)
+		seqnos[message.Seqno()] = true
+	}
+	sort.Strings(contents)
+
+	if !reflect.DeepEqual(expectedContents, contents) {
+		t.Errorf(
+			"unexpected messages\nexpected: [%v]\nactual:   [%v]",
+			expectedContents,
+			contents,
+		)
+	}
+
+	if len(seqnos) != len(expectedContents) {
+		t.Errorf(
+			"unexpected number of unique sequence numbers\n"+
+				"expected: [%v]\nactual:   [%v]",
+			len(expectedContents),
+			len(seqnos),
+		)
+	}
+
+	networkMessages.wait(t, 1)
+	if count := networkMessages.count(); count != 1 {
+		t.Errorf(
+			"unexpected number of network messages\n"+
+				"expected: [1]\nactual:   [%v]",
+			count,
+		)
+	}
+}
+
+func TestDeliverMessagesSentWithoutBatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	channelName := "no batch test"
+
+	sender := broadcastChannel(t, channelName)
+	sender.RegisterUnmarshaler(newTestMessage)
+
+	receiver, err := NewChannel(broadcastChannel(t, channelName), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver.RegisterUnmarshaler(newTestMessage)
+
+	receivedMessages := collect(ctx, receiver)
+
+	if err := sender.Send(ctx, &testMessage{"member 1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	received := receivedMessages.wait(t, 1)
+
+	content := received[0].Payload().(*testMessage).content
+	if content != "member 1" {
+		t.Errorf(
+			"unexpected message\nexpected: [%v]\nactual:   [%v]",
+			"member 1",
+			content,
+		)
+	}
+}
+
+func TestPublishSingleMessageWithoutBatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	channelName := "single message test"
+
+	sender, err := NewChannel(broadcastChannel(t, channelName), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender.RegisterUnmarshaler(newTestMessage)
+
+	// The receiver does not support batches.
+	receiver := broadcastChannel(t, channelName)
+	receiver.RegisterUnmarshaler(newTestMessage)
+
+	receivedMessages := collect(ctx, receiver)
+
+	if err := sender.Send(ctx, &testMessage{"seat 1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	received := receivedMessages.wait(t, 1)
+
+	content := received[0].Payload().(*testMessage).content
+	if content != "seat 1" {
+		t.Errorf(
+			"unexpected message\nexpected: [%v]\nactual:   [%v]",
+			"seat 1",
+			content,
+		)
+	}
+}
+
+func TestRedeliverRetransmittedBatchToNewHandler(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	channelName := "retransmission test"
+
+	sender, err := NewChannel(broadcastChannel(t, channelName), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender.RegisterUnmarshaler(newTestMessage)
+
+	receiver, err := NewChannel(broadcastChannel(t, channelName), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver.RegisterUnmarshaler(newTestMessage)
+
+	firstHandlerCtx, cancelFirstHandler := context.WithCancel(ctx)
+	firstHandlerMessages := collect(firstHandlerCtx, receiver)
+
+	sendConcurrently(t, ctx, sender, []string{"seat 1", "seat 2"})
+
+	firstHandlerMessages.wait(t, 2)
+	cancelFirstHandler()
+
+	// A handler installed later, e.g. by the next protocol state, receives
+	// the messages again when the batch is retransmitted.
+	secondHandlerMessages := collect(ctx, receiver)
+	secondHandlerMessages.wait(t, 2)
+}
+
+func TestSendReturnsPublishError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	publishErr := fmt.Errorf("publish failed")
+
+	sender, err := NewChannel(
+		&failingBroadcastChannel{
+			BroadcastChannel: broadcastChannel(t, "publish error test"),
+			err:              publishErr,
+		},
+		2,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender.RegisterUnmarshaler(newTestMessage)
+
+	errors := make(chan error, 2)
+	for _, content := range []string{"seat 1", "seat 2"} {
+		go func(content string) {
+			errors <- sender.Send(ctx, &testMessage{content})
+		}(content)
+	}
+
+	for i := 0; i < 2; i++ {
+		err := <-errors
+		if err == nil || !strings.Contains(err.Error(), publishErr.Error()) {
+			t.Errorf(
+				"unexpected error\nexpected: [%v]\nactual:   [%v]",
+				publishErr,
+				err,
+			)
+		}
+	}
+}
+
+func TestMessageRoundtrip(t *testing.T) {
+	message := &Message{
+		messages: []*batchedMessage{
+			{messageType: "type 1", seqno: 1, payload: []byte{0x01}},
+			{messageType: "type 2", seqno: 2, payload: []byte{0x02, 0x03}},
+		},
+	}
+	unmarshaled := &Message{}
+
+	err := pbutils.RoundTrip(message, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(message, unmarshaled) {
+		t.Fatalf(
+			"unexpected content of unmarshaled message\n"+
+				"expected: [%v]\nactual:   [%v]",
+			message,
+			unmarshaled,
+		)
+	}
+}
+
+func broadcastChannel(t *testing.T, name string) net.BroadcastChannel {
+	channel, err := local.Connect().BroadcastChannelFor(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return channel
+}
+
+type messageCollector struct {
+	mutex    sync.Mutex
+	messages []net.Message
+	ctx      context.Context
+}
+
+// sendConcurrently sends messages with the given contents the way several
+// participants do, each one from its own goroutine.
+func sendConcurrently(
+	t *testing.T,
+	ctx context.Context,
+	channel *Channel,
+	contents []string,
+) {
+	errors := make(chan error, len(contents))
+	for _, content := range contents {
+		go func(content string) {
+			errors <- channel.Send(ctx, &testMessage{content})
+		}(content)
+	}
+
+	for range contents {
+		if err := <-errors; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// failingBroadcastChannel is a broadcast channel failing to publish all the
+// messages with the configured error.
+type failingBroadcastChannel struct {
+	net.BroadcastChannel
+
+	err error
+}
+
+func (fbc *failingBroadcastChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+) error {
+	return fbc.err
+}
+
+func collect(ctx context.Context, channel net.BroadcastChannel) *messageCollector {
+	collector := &messageCollector{ctx: ctx}
+	channel.Recv(ctx, func(message net.Message) {
+		collector.mutex.Lock()
+		defer collector.mutex.Unlock()
+		collector.messages = append(collector.messages, message)
+	})
+	return collector
+}
+
+func (mc *messageCollector) count() int {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+	return len(mc.messages)
+}
+
+// wait waits until the collector receives the given number of messages and
+// then waits a bit more to catch unexpected messages.
+func (mc *messageCollector) wait(t *testing.T, count int) []net.Message {
+	for mc.count() < count {
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-mc.ctx.Done():
+			t.Fatalf(
+				"expected [%v] messages, received [%v]",
+				count,
+				mc.count(),
+			)
+		}
+	}
+
+	time.Sleep(2 * flushDelay)
+
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+	return mc.messages
+}
+
+type testMessage struct {
+	content string
+}
+
+func newTestMessage() net.TaggedUnmarshaler {
+	return &testMessage{}
+}
+
+func (tm *testMessage) Type() string {
+	return "batch/test_message"
+}
+
+func (tm *testMessage) Marshal() ([]byte, error) {
+	return []byte(tm.content), nil
+}
+
+func (tm *testMessage) Unmarshal(bytes []byte) error {
+	tm.content = string(bytes)
+	return nil
+}
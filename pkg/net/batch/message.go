@@ -0,0 +1,56 @@
+package batch
+
+import (
+	"github.com/keep-network/keep-core/pkg/net/batch/gen/pb"
+)
+
+// Message is a network message carrying a batch of messages sent by local
+// participants.
+type Message struct {
+	messages []*batchedMessage
+}
+
+type batchedMessage struct {
+	messageType string
+	seqno       uint64
+	payload     []byte
+}
+
+// Type returns a string describing a Message's type.
+func (*Message) Type() string {
+	return "net/batch"
+}
+
+// Marshal converts this Message to a byte array suitable for network
+// communication.
+func (m *Message) Marshal() ([]byte, error) {
+	messages := make([]*pb.Batch_Message, len(m.messages))
+	for i, message := range m.messages {
+		messages[i] = &pb.Batch_Message{
+			Type:           message.messageType,
+			SequenceNumber: message.seqno,
+			Payload:        message.payload,
+		}
+	}
+
+	return (&pb.Batch{Messages: messages}).Marshal()
+}
+
+// Unmarshal converts a byte array produced by Marshal to a Message.
+func (m *Message) Unmarshal(bytes []byte) error {
+	pbBatch := pb.Batch{}
+	if err := pbBatch.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	m.messages = make([]*batchedMessage, len(pbBatch.Messages))
+	for i, message := range pbBatch.Messages {
+		m.messages[i] = &batchedMessage{
+			messageType: message.Type,
+			seqno:       message.SequenceNumber,
+			payload:     message.Payload,
+		}
+	}
+
+	return nil
+}
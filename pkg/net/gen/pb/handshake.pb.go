@@ -36,6 +36,9 @@ type HandshakeEnvelope struct {
 	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 	// Peer id of the message creator
 	PeerID []byte `protobuf:"bytes,3,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	// Signature of the operator attesting the network key of the message
+	// creator acts on its behalf. Empty if the network key is the operator key.
+	OperatorAttestation []byte `protobuf:"bytes,4,opt,name=operatorAttestation,proto3" json:"operatorAttestation,omitempty"`
 }
 
 func (m *HandshakeEnvelope) Reset()      { *m = HandshakeEnvelope{} }
@@ -91,6 +94,13 @@ func (m *HandshakeEnvelope) GetPeerID() []byte {
 	return nil
 }
 
+func (m *HandshakeEnvelope) GetOperatorAttestation() []byte {
+	if m != nil {
+		return m.OperatorAttestation
+	}
+	return nil
+}
+
 // act1Message is sent in the first handshake act by the initiator to the
 // responder. It contains randomly generated `nonce1`, an 8-byte (64-bit)
 // unsigned integer.
@@ -258,23 +268,24 @@ func init() {
 func init() { proto.RegisterFile("pb/handshake.proto", fileDescriptor_73dffe19bde0f856) }
 
 var fileDescriptor_73dffe19bde0f856 = []byte{
-	// 241 bytes of a gzipped FileDescriptorProto
+	// 269 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2a, 0x48, 0xd2, 0xcf,
 	0x48, 0xcc, 0x4b, 0x29, 0xce, 0x48, 0xcc, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62,
-	0xce, 0x4b, 0x2d, 0x51, 0x4a, 0xe6, 0x12, 0xf4, 0x80, 0x89, 0xbb, 0xe6, 0x95, 0xa5, 0xe6, 0xe4,
-	0x17, 0xa4, 0x0a, 0x49, 0x70, 0xb1, 0xe7, 0xa6, 0x16, 0x17, 0x27, 0xa6, 0xa7, 0x4a, 0x30, 0x2a,
-	0x30, 0x6a, 0xf0, 0x04, 0xc1, 0xb8, 0x42, 0x32, 0x5c, 0x9c, 0xc5, 0x99, 0xe9, 0x79, 0x89, 0x25,
-	0xa5, 0x45, 0xa9, 0x12, 0x4c, 0x60, 0x39, 0x84, 0x80, 0x90, 0x18, 0x17, 0x5b, 0x41, 0x6a, 0x6a,
-	0x91, 0xa7, 0x8b, 0x04, 0x33, 0x58, 0x0a, 0xca, 0x53, 0x52, 0xe6, 0xe2, 0x76, 0x4c, 0x2e, 0x31,
-	0xf4, 0x85, 0x1a, 0x22, 0xc2, 0xc5, 0x9a, 0x97, 0x9f, 0x97, 0x0c, 0x33, 0x1c, 0xc2, 0x51, 0x72,
-	0x04, 0x2b, 0x32, 0xc2, 0xab, 0x08, 0x64, 0x7f, 0x72, 0x46, 0x62, 0x4e, 0x4e, 0x6a, 0x5e, 0x3a,
-	0xdc, 0x7e, 0xb8, 0x80, 0x92, 0x36, 0xd8, 0x08, 0x63, 0x5f, 0x84, 0x63, 0x11, 0x8a, 0x19, 0xd1,
-	0x14, 0x3b, 0x59, 0x5c, 0x78, 0x28, 0xc7, 0x70, 0xe3, 0xa1, 0x1c, 0xc3, 0x87, 0x87, 0x72, 0x8c,
-	0x0d, 0x8f, 0xe4, 0x18, 0x57, 0x3c, 0x92, 0x63, 0x3c, 0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39,
-	0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x5f, 0x3c, 0x92, 0x63, 0xf8, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63,
-	0x39, 0x86, 0x0b, 0x8f, 0xe5, 0x18, 0x6e, 0x3c, 0x96, 0x63, 0x88, 0x62, 0x2a, 0x48, 0x4a, 0x62,
-	0x03, 0x87, 0x9f, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0x37, 0x4c, 0x1a, 0x5a, 0x55, 0x01, 0x00,
-	0x00,
+	0xce, 0x4b, 0x2d, 0x51, 0x9a, 0xca, 0xc8, 0x25, 0xe8, 0x01, 0x93, 0x70, 0xcd, 0x2b, 0x4b, 0xcd,
+	0xc9, 0x2f, 0x48, 0x15, 0x92, 0xe0, 0x62, 0xcf, 0x4d, 0x2d, 0x2e, 0x4e, 0x4c, 0x4f, 0x95, 0x60,
+	0x54, 0x60, 0xd4, 0xe0, 0x09, 0x82, 0x71, 0x85, 0x64, 0xb8, 0x38, 0x8b, 0x33, 0xd3, 0xf3, 0x12,
+	0x4b, 0x4a, 0x8b, 0x52, 0x25, 0x98, 0xc0, 0x72, 0x08, 0x01, 0x21, 0x31, 0x2e, 0xb6, 0x82, 0xd4,
+	0xd4, 0x22, 0x4f, 0x17, 0x09, 0x66, 0xb0, 0x14, 0x94, 0x27, 0x64, 0xc0, 0x25, 0x9c, 0x5f, 0x90,
+	0x5a, 0x94, 0x58, 0x92, 0x5f, 0xe4, 0x58, 0x52, 0x92, 0x5a, 0x5c, 0x92, 0x58, 0x92, 0x99, 0x9f,
+	0x27, 0xc1, 0x02, 0x56, 0x84, 0x4d, 0x4a, 0x49, 0x99, 0x8b, 0xdb, 0x31, 0xb9, 0xc4, 0xd0, 0x17,
+	0x6a, 0xad, 0x08, 0x17, 0x6b, 0x5e, 0x7e, 0x5e, 0x32, 0xcc, 0x39, 0x10, 0x8e, 0x92, 0x23, 0x58,
+	0x91, 0x11, 0x5e, 0x45, 0x20, 0x17, 0x27, 0x67, 0x24, 0xe6, 0xe4, 0xa4, 0xe6, 0xa5, 0xc3, 0x5d,
+	0x0c, 0x17, 0x50, 0xd2, 0x06, 0x1b, 0x61, 0xec, 0x8b, 0xf0, 0x1e, 0x42, 0x31, 0x23, 0x9a, 0x62,
+	0x27, 0x8b, 0x0b, 0x0f, 0xe5, 0x18, 0x6e, 0x3c, 0x94, 0x63, 0xf8, 0xf0, 0x50, 0x8e, 0xb1, 0xe1,
+	0x91, 0x1c, 0xe3, 0x8a, 0x47, 0x72, 0x8c, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8,
+	0xe0, 0x91, 0x1c, 0xe3, 0x8b, 0x47, 0x72, 0x0c, 0x1f, 0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7,
+	0x70, 0xe1, 0xb1, 0x1c, 0xc3, 0x8d, 0xc7, 0x72, 0x0c, 0x51, 0x4c, 0x05, 0x49, 0x49, 0x6c, 0xe0,
+	0x20, 0x37, 0x06, 0x0c, 0x00, 0xc6, 0x39, 0x79, 0x47, 0x88, 0x01, 0x00, 0x00,
 }
 
 func (this *HandshakeEnvelope) Equal(that interface{}) bool {
@@ -305,6 +316,9 @@ func (this *HandshakeEnvelope) Equal(that interface{}) bool {
 	if !bytes.Equal(this.PeerID, that1.PeerID) {
 		return false
 	}
+	if !bytes.Equal(this.OperatorAttestation, that1.OperatorAttestation) {
+		return false
+	}
 	return true
 }
 func (this *Act1Message) Equal(that interface{}) bool {
@@ -386,11 +400,12 @@ func (this *HandshakeEnvelope) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 8)
 	s = append(s, "&pb.HandshakeEnvelope{")
 	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
 	s = append(s, "Signature: "+fmt.Sprintf("%#v", this.Signature)+",\n")
 	s = append(s, "PeerID: "+fmt.Sprintf("%#v", this.PeerID)+",\n")
+	s = append(s, "OperatorAttestation: "+fmt.Sprintf("%#v", this.OperatorAttestation)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -453,6 +468,13 @@ func (m *HandshakeEnvelope) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.OperatorAttestation) > 0 {
+		i -= len(m.OperatorAttestation)
+		copy(dAtA[i:], m.OperatorAttestation)
+		i = encodeVarintHandshake(dAtA, i, uint64(len(m.OperatorAttestation)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.PeerID) > 0 {
 		i -= len(m.PeerID)
 		copy(dAtA[i:], m.PeerID)
@@ -603,6 +625,10 @@ func (m *HandshakeEnvelope) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovHandshake(uint64(l))
 	}
+	l = len(m.OperatorAttestation)
+	if l > 0 {
+		n += 1 + l + sovHandshake(uint64(l))
+	}
 	return n
 }
 
@@ -663,6 +689,7 @@ func (this *HandshakeEnvelope) String() string {
 		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
 		`Signature:` + fmt.Sprintf("%v", this.Signature) + `,`,
 		`PeerID:` + fmt.Sprintf("%v", this.PeerID) + `,`,
+		`OperatorAttestation:` + fmt.Sprintf("%v", this.OperatorAttestation) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -837,6 +864,40 @@ func (m *HandshakeEnvelope) Unmarshal(dAtA []byte) error {
 				m.PeerID = []byte{}
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OperatorAttestation", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandshake
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandshake
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OperatorAttestation = append(m.OperatorAttestation[:0], dAtA[iNdEx:postIndex]...)
+			if m.OperatorAttestation == nil {
+				m.OperatorAttestation = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandshake(dAtA[iNdEx:])
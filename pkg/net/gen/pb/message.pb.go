@@ -175,6 +175,9 @@ func (m *UnicastNetworkMessage) GetSignature() []byte {
 
 type Identity struct {
 	PubKey []byte `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	// Signature of the operator attesting the network key acts on its behalf.
+	// Empty if the network key is the operator key.
+	OperatorAttestation []byte `protobuf:"bytes,2,opt,name=operator_attestation,json=operatorAttestation,proto3" json:"operator_attestation,omitempty"`
 }
 
 func (m *Identity) Reset()      { *m = Identity{} }
@@ -216,6 +219,13 @@ func (m *Identity) GetPubKey() []byte {
 	return nil
 }
 
+func (m *Identity) GetOperatorAttestation() []byte {
+	if m != nil {
+		return m.OperatorAttestation
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BroadcastNetworkMessage)(nil), "net.BroadcastNetworkMessage")
 	proto.RegisterType((*UnicastNetworkMessage)(nil), "net.UnicastNetworkMessage")
@@ -225,24 +235,26 @@ func init() {
 func init() { proto.RegisterFile("pb/message.proto", fileDescriptor_8447775385e7eb85) }
 
 var fileDescriptor_8447775385e7eb85 = []byte{
-	// 272 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x90, 0xb1, 0x4a, 0x03, 0x41,
-	0x10, 0x86, 0x77, 0x92, 0x90, 0xe8, 0x12, 0x44, 0x16, 0x34, 0x57, 0xc8, 0x10, 0x4e, 0x90, 0x54,
-	0x5a, 0xd8, 0x58, 0xa7, 0x13, 0x31, 0xc5, 0x81, 0x8d, 0x8d, 0xec, 0xe6, 0x86, 0x70, 0xc4, 0xec,
-	0xad, 0xbb, 0x7b, 0xc8, 0x61, 0x63, 0x67, 0xeb, 0x63, 0xf8, 0x28, 0x96, 0x57, 0xa6, 0xf4, 0xf6,
-	0x1a, 0xcb, 0x3c, 0x82, 0x70, 0x1a, 0x04, 0x7b, 0xbb, 0xf9, 0xbf, 0x19, 0x98, 0x8f, 0x9f, 0xef,
-	0x1b, 0x75, 0xb6, 0x22, 0xe7, 0xe4, 0x82, 0x4e, 0x8d, 0xcd, 0x7d, 0x2e, 0xba, 0x9a, 0x7c, 0xfc,
-	0x02, 0x7c, 0x34, 0xb5, 0xb9, 0x4c, 0xe7, 0xd2, 0xf9, 0x19, 0xf9, 0xc7, 0xdc, 0x2e, 0xaf, 0xbf,
-	0xcf, 0xc4, 0x21, 0xef, 0x3b, 0xd2, 0x29, 0xd9, 0x08, 0xc6, 0x30, 0x19, 0x26, 0x3f, 0x49, 0x44,
-	0x7c, 0x60, 0x64, 0x79, 0x9f, 0xcb, 0x34, 0xea, 0xb4, 0x8b, 0x6d, 0x14, 0x82, 0xf7, 0x7c, 0x69,
-	0x28, 0xea, 0xb6, 0xb8, 0x9d, 0xc5, 0x09, 0xdf, 0x73, 0xf4, 0x50, 0x90, 0x9e, 0xd3, 0xac, 0x58,
-	0x29, 0xb2, 0x51, 0x6f, 0x0c, 0x93, 0x5e, 0xf2, 0x87, 0xc6, 0x4f, 0xfc, 0xe0, 0x46, 0x67, 0xff,
-	0xa6, 0x71, 0xc4, 0x77, 0x5d, 0xb6, 0xd0, 0xd2, 0x17, 0x96, 0x5a, 0x83, 0x61, 0xf2, 0x0b, 0xe2,
-	0x63, 0xbe, 0x73, 0x99, 0x92, 0xf6, 0x99, 0x2f, 0xc5, 0x88, 0x0f, 0x4c, 0xa1, 0xee, 0x96, 0x54,
-	0x6e, 0x1f, 0x9a, 0x42, 0x5d, 0x51, 0x39, 0xbd, 0xa8, 0x6a, 0x64, 0xeb, 0x1a, 0xd9, 0xa6, 0x46,
-	0x78, 0x0e, 0x08, 0x6f, 0x01, 0xe1, 0x3d, 0x20, 0x54, 0x01, 0xe1, 0x23, 0x20, 0x7c, 0x06, 0x64,
-	0x9b, 0x80, 0xf0, 0xda, 0x20, 0xab, 0x1a, 0x64, 0xeb, 0x06, 0xd9, 0x6d, 0xc7, 0x28, 0xd5, 0x6f,
-	0x1b, 0x3f, 0xff, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x67, 0x49, 0x47, 0x0c, 0x85, 0x01, 0x00, 0x00,
+	// 295 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x91, 0x3f, 0x4b, 0x73, 0x31,
+	0x14, 0xc6, 0x93, 0xb6, 0xb4, 0xef, 0x1b, 0x8a, 0x48, 0xfc, 0xd3, 0x3b, 0xc8, 0xa1, 0x74, 0x90,
+	0x4e, 0x8a, 0xb8, 0xb8, 0xda, 0x4d, 0xc4, 0x0e, 0x05, 0x1d, 0x5c, 0x4a, 0xd2, 0x7b, 0x28, 0x97,
+	0xda, 0x24, 0x26, 0xe7, 0x22, 0x17, 0x17, 0x37, 0x57, 0x3f, 0x86, 0x1f, 0xc5, 0xb1, 0x63, 0x47,
+	0x9b, 0x2e, 0x8e, 0xfd, 0x08, 0xc2, 0xb5, 0xa5, 0xe0, 0xee, 0x96, 0xe7, 0xf7, 0x0b, 0x3c, 0x0f,
+	0x1c, 0xb1, 0xeb, 0xf4, 0xe9, 0x14, 0x43, 0x50, 0x63, 0x3c, 0x71, 0xde, 0x92, 0x95, 0x55, 0x83,
+	0xd4, 0x79, 0xe5, 0xa2, 0xd5, 0xf3, 0x56, 0xa5, 0x23, 0x15, 0xa8, 0x8f, 0xf4, 0x64, 0xfd, 0xe4,
+	0xe6, 0xe7, 0x9b, 0x3c, 0x14, 0xf5, 0x80, 0x26, 0x45, 0x9f, 0xf0, 0x36, 0xef, 0x36, 0x07, 0xeb,
+	0x24, 0x13, 0xd1, 0x70, 0xaa, 0x78, 0xb0, 0x2a, 0x4d, 0x2a, 0xa5, 0xd8, 0x44, 0x29, 0x45, 0x8d,
+	0x0a, 0x87, 0x49, 0xb5, 0xc4, 0xe5, 0x5b, 0x1e, 0x8b, 0x9d, 0x80, 0x8f, 0x39, 0x9a, 0x11, 0xf6,
+	0xf3, 0xa9, 0x46, 0x9f, 0xd4, 0xda, 0xbc, 0x5b, 0x1b, 0xfc, 0xa2, 0x9d, 0x67, 0x71, 0x70, 0x6b,
+	0xb2, 0x3f, 0x9b, 0x71, 0x24, 0xfe, 0x87, 0x6c, 0x6c, 0x14, 0xe5, 0x1e, 0xcb, 0x05, 0xcd, 0xc1,
+	0x16, 0x74, 0xee, 0xc4, 0xbf, 0xab, 0x14, 0x0d, 0x65, 0x54, 0xc8, 0x96, 0x68, 0xb8, 0x5c, 0x0f,
+	0x27, 0x58, 0x6c, 0x0a, 0x5d, 0xae, 0xaf, 0xb1, 0x90, 0x67, 0x62, 0xdf, 0x3a, 0xf4, 0x8a, 0xac,
+	0x1f, 0x2a, 0x22, 0x0c, 0xa4, 0x28, 0xb3, 0x66, 0xdd, 0xbe, 0xb7, 0x71, 0x97, 0x5b, 0xd5, 0xbb,
+	0x98, 0x2d, 0x80, 0xcd, 0x17, 0xc0, 0x56, 0x0b, 0xe0, 0x2f, 0x11, 0xf8, 0x7b, 0x04, 0xfe, 0x11,
+	0x81, 0xcf, 0x22, 0xf0, 0xcf, 0x08, 0xfc, 0x2b, 0x02, 0x5b, 0x45, 0xe0, 0x6f, 0x4b, 0x60, 0xb3,
+	0x25, 0xb0, 0xf9, 0x12, 0xd8, 0x7d, 0xc5, 0x69, 0x5d, 0x2f, 0x8f, 0x74, 0xfe, 0x3d, 0x00, 0x31,
+	0xe9, 0x43, 0x02, 0xb8, 0x01, 0x00, 0x00,
 }
 
 func (this *BroadcastNetworkMessage) Equal(that interface{}) bool {
@@ -333,6 +345,9 @@ func (this *Identity) Equal(that interface{}) bool {
 	if !bytes.Equal(this.PubKey, that1.PubKey) {
 		return false
 	}
+	if !bytes.Equal(this.OperatorAttestation, that1.OperatorAttestation) {
+		return false
+	}
 	return true
 }
 func (this *BroadcastNetworkMessage) GoString() string {
@@ -365,9 +380,10 @@ func (this *Identity) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
+	s := make([]string, 0, 6)
 	s = append(s, "&pb.Identity{")
 	s = append(s, "PubKey: "+fmt.Sprintf("%#v", this.PubKey)+",\n")
+	s = append(s, "OperatorAttestation: "+fmt.Sprintf("%#v", this.OperatorAttestation)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -499,6 +515,13 @@ func (m *Identity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.OperatorAttestation) > 0 {
+		i -= len(m.OperatorAttestation)
+		copy(dAtA[i:], m.OperatorAttestation)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.OperatorAttestation)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.PubKey) > 0 {
 		i -= len(m.PubKey)
 		copy(dAtA[i:], m.PubKey)
@@ -579,6 +602,10 @@ func (m *Identity) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovMessage(uint64(l))
 	}
+	l = len(m.OperatorAttestation)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
 	return n
 }
 
@@ -620,6 +647,7 @@ func (this *Identity) String() string {
 	}
 	s := strings.Join([]string{`&Identity{`,
 		`PubKey:` + fmt.Sprintf("%v", this.PubKey) + `,`,
+		`OperatorAttestation:` + fmt.Sprintf("%v", this.OperatorAttestation) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1058,6 +1086,40 @@ func (m *Identity) Unmarshal(dAtA []byte) error {
 				m.PubKey = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OperatorAttestation", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OperatorAttestation = append(m.OperatorAttestation[:0], dAtA[iNdEx:postIndex]...)
+			if m.OperatorAttestation == nil {
+				m.OperatorAttestation = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMessage(dAtA[iNdEx:])
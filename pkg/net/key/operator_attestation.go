@@ -0,0 +1,73 @@
+package key
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// operatorAttestationSize is the size of an operator attestation, which is
+// an Ethereum signature in the [R || S || V] format.
+const operatorAttestationSize = 65
+
+// OperatorAttestationMessage returns the message the operator signs to attest
+// that the given network key acts on its behalf. The message is human
+// readable so that it can be reviewed when it is approved in an external
+// signer. It has to be signed the same way as by the eth_sign JSON-RPC method.
+//
+// Operators whose key is held by an external signer can not use it as the
+// network key, because the network layer signs with the network key directly.
+// Such operators use a separate network key instead, attested by the operator
+// key. Peers treat a network key with a valid attestation as if it was the
+// operator key, so that they check the stake of the operator and validate
+// group membership against it.
+func OperatorAttestationMessage(networkPublicKey *NetworkPublic) []byte {
+	return []byte(fmt.Sprintf(
+		"Keep network key: 0x%x",
+		Marshal(networkPublicKey),
+	))
+}
+
+// RecoverOperatorKey recovers the operator key from the given attestation of
+// the given network key. An attestation of another network key recovers an
+// unrelated key, so the recovered key has to be checked, e.g. by the firewall
+// validating its stake, before it is trusted.
+func RecoverOperatorKey(
+	networkPublicKey *NetworkPublic,
+	attestation []byte,
+) (*NetworkPublic, error) {
+	if len(attestation) != operatorAttestationSize {
+		return nil, fmt.Errorf(
+			"operator attestation should have [%v] bytes; has: [%v]",
+			operatorAttestationSize,
+			len(attestation),
+		)
+	}
+
+	// Attestations use V of 27 or 28 as on-chain signatures do, while
+	// go-ethereum/crypto expects V of 0 or 1.
+	signature := make([]byte, operatorAttestationSize)
+	copy(signature, attestation)
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+
+	operatorPublicKey, err := crypto.Ecrecover(
+		accounts.TextHash(OperatorAttestationMessage(networkPublicKey)),
+		signature,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid operator attestation: [%v]", err)
+	}
+
+	// The key recovered by go-ethereum references its own secp256k1 curve
+	// which is not recognized by libp2p so it is parsed into a network key.
+	networkKey, err := btcec.ParsePubKey(operatorPublicKey, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("invalid operator attestation: [%v]", err)
+	}
+
+	return (*NetworkPublic)(networkKey), nil
+}
@@ -0,0 +1,107 @@
+package key
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestRecoverOperatorKey(t *testing.T) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, expectedOperatorKey := OperatorKeyToNetworkKey(
+		operatorPrivateKey,
+		operatorPublicKey,
+	)
+
+	_, networkPublicKey, err := GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attestation := attest(t, operatorPrivateKey, networkPublicKey)
+
+	recoveredOperatorKey, err := RecoverOperatorKey(networkPublicKey, attestation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(Marshal(expectedOperatorKey), Marshal(recoveredOperatorKey)) {
+		t.Errorf(
+			"unexpected operator key\nexpected: [%x]\nactual:   [%x]",
+			Marshal(expectedOperatorKey),
+			Marshal(recoveredOperatorKey),
+		)
+	}
+}
+
+func TestRecoverOperatorKeyFromAttestationOfOtherNetworkKey(t *testing.T) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, operatorKey := OperatorKeyToNetworkKey(
+		operatorPrivateKey,
+		operatorPublicKey,
+	)
+
+	_, attestedNetworkKey, err := GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherNetworkKey, err := GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attestation := attest(t, operatorPrivateKey, attestedNetworkKey)
+
+	recoveredKey, err := RecoverOperatorKey(otherNetworkKey, attestation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Equal(Marshal(operatorKey), Marshal(recoveredKey)) {
+		t.Errorf("attestation of other network key recovered the operator key")
+	}
+}
+
+func TestRecoverOperatorKeyWithInvalidAttestationSize(t *testing.T) {
+	_, networkPublicKey, err := GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = RecoverOperatorKey(networkPublicKey, make([]byte, 64))
+
+	expectedError := "operator attestation should have [65] bytes; has: [64]"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func attest(
+	t *testing.T,
+	operatorPrivateKey *operator.PrivateKey,
+	networkPublicKey *NetworkPublic,
+) []byte {
+	attestation, err := crypto.Sign(
+		accounts.TextHash(OperatorAttestationMessage(networkPublicKey)),
+		operatorPrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attestation[64] += 27
+
+	return attestation
+}
@@ -24,11 +24,13 @@ const maxFrameSize = 1024
 type authenticatedConnection struct {
 	net.Conn
 
-	localPeerID         peer.ID
-	localPeerPrivateKey libp2pcrypto.PrivKey
+	localPeerID              peer.ID
+	localPeerPrivateKey      libp2pcrypto.PrivKey
+	localOperatorAttestation []byte
 
-	remotePeerID        peer.ID
-	remotePeerPublicKey libp2pcrypto.PubKey
+	remotePeerID            peer.ID
+	remotePeerPublicKey     libp2pcrypto.PubKey
+	remoteOperatorPublicKey *key.NetworkPublic
 
 	firewall keepNet.Firewall
 }
@@ -39,17 +41,22 @@ type authenticatedConnection struct {
 // knowledge of the remotePeerID (passed in as empty string). On success running
 // the responder side of the handshake, it returns a fully-authenticated
 // connection, which grants access to the network.
+//
+// The operator attestation of the local network key is sent to the remote
+// peer in the handshake; it is empty if the network key is the operator key.
 func newAuthenticatedInboundConnection(
 	unauthenticatedConn net.Conn,
 	localPeerID peer.ID,
 	privateKey libp2pcrypto.PrivKey,
+	operatorAttestation []byte,
 	firewall keepNet.Firewall,
 ) (*authenticatedConnection, error) {
 	ac := &authenticatedConnection{
-		Conn:                unauthenticatedConn,
-		localPeerID:         localPeerID,
-		localPeerPrivateKey: privateKey,
-		firewall:            firewall,
+		Conn:                     unauthenticatedConn,
+		localPeerID:              localPeerID,
+		localPeerPrivateKey:      privateKey,
+		localOperatorAttestation: operatorAttestation,
+		firewall:                 firewall,
 	}
 
 	if err := ac.runHandshakeAsResponder(); err != nil {
@@ -76,6 +83,7 @@ func newAuthenticatedOutboundConnection(
 	unauthenticatedConn net.Conn,
 	localPeerID peer.ID,
 	privateKey libp2pcrypto.PrivKey,
+	operatorAttestation []byte,
 	remotePeerID peer.ID,
 	firewall keepNet.Firewall,
 ) (*authenticatedConnection, error) {
@@ -88,12 +96,13 @@ func newAuthenticatedOutboundConnection(
 	}
 
 	ac := &authenticatedConnection{
-		Conn:                unauthenticatedConn,
-		localPeerID:         localPeerID,
-		localPeerPrivateKey: privateKey,
-		remotePeerID:        remotePeerID,
-		remotePeerPublicKey: remotePublicKey,
-		firewall:            firewall,
+		Conn:                     unauthenticatedConn,
+		localPeerID:              localPeerID,
+		localPeerPrivateKey:      privateKey,
+		localOperatorAttestation: operatorAttestation,
+		remotePeerID:             remotePeerID,
+		remotePeerPublicKey:      remotePublicKey,
+		firewall:                 firewall,
 	}
 
 	if err := ac.runHandshakeAsInitiator(); err != nil {
@@ -109,13 +118,34 @@ func newAuthenticatedOutboundConnection(
 	return ac, nil
 }
 
+// checkFirewallRules validates the operator the remote peer acts on behalf
+// of, as established in the handshake, against the firewall rules.
 func (ac *authenticatedConnection) checkFirewallRules() error {
-	networkKey, ok := ac.remotePeerPublicKey.(*key.NetworkPublic)
-	if !ok {
-		return fmt.Errorf("unexpected type of remote peer's public key")
+	return ac.firewall.Validate(
+		key.NetworkKeyToECDSAKey(ac.remoteOperatorPublicKey),
+	)
+}
+
+// recoverRemoteOperatorKey establishes the operator the remote peer acts on
+// behalf of from the operator attestation the remote peer sent in the
+// handshake envelope.
+func (ac *authenticatedConnection) recoverRemoteOperatorKey(
+	envelope *pb.HandshakeEnvelope,
+) error {
+	operatorPublicKey, err := recoverOperatorKey(
+		ac.remotePeerPublicKey,
+		envelope.GetOperatorAttestation(),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not recover operator of remote peer [%v]: [%v]",
+			ac.remotePeerID,
+			err,
+		)
 	}
 
-	return ac.firewall.Validate(key.NetworkKeyToECDSAKey(networkKey))
+	ac.remoteOperatorPublicKey = operatorPublicKey
+	return nil
 }
 
 func (ac *authenticatedConnection) runHandshakeAsInitiator() error {
@@ -187,9 +217,10 @@ func (ac *authenticatedConnection) initiatorSendAct1(
 	}
 
 	act1Envelope := &pb.HandshakeEnvelope{
-		Message:   act1WireMessage,
-		PeerID:    []byte(ac.localPeerID),
-		Signature: signedAct1Message,
+		Message:             act1WireMessage,
+		PeerID:              []byte(ac.localPeerID),
+		Signature:           signedAct1Message,
+		OperatorAttestation: ac.localOperatorAttestation,
 	}
 
 	if err := initiatorConnectionWriter.WriteMsg(act1Envelope); err != nil {
@@ -222,6 +253,10 @@ func (ac *authenticatedConnection) initiatorReceiveAct2(
 		return nil, err
 	}
 
+	if err := ac.recoverRemoteOperatorKey(&act2Envelope); err != nil {
+		return nil, err
+	}
+
 	if err := act2Message.Unmarshal(act2Envelope.Message); err != nil {
 		return nil, err
 	}
@@ -338,6 +373,10 @@ func (ac *authenticatedConnection) responderReceiveAct1(
 		return nil, err
 	}
 
+	if err := ac.recoverRemoteOperatorKey(&act1Envelope); err != nil {
+		return nil, err
+	}
+
 	if err := act1Message.Unmarshal(act1Envelope.Message); err != nil {
 		return nil, err
 	}
@@ -358,9 +397,10 @@ func (ac *authenticatedConnection) responderSendAct2(
 	}
 
 	act2Envelope := &pb.HandshakeEnvelope{
-		Message:   act2WireMessage,
-		PeerID:    []byte(ac.localPeerID),
-		Signature: signedAct2Message,
+		Message:             act2WireMessage,
+		PeerID:              []byte(ac.localPeerID),
+		Signature:           signedAct2Message,
+		OperatorAttestation: ac.localOperatorAttestation,
 	}
 
 	if err := responderConnectionWriter.WriteMsg(act2Envelope); err != nil {
@@ -463,3 +503,10 @@ func (ac *authenticatedConnection) RemotePeer() peer.ID {
 func (ac *authenticatedConnection) RemotePublicKey() libp2pcrypto.PubKey {
 	return ac.remotePeerPublicKey
 }
+
+// RemoteOperatorPublicKey retrieves the public key of the operator the remote
+// peer acts on behalf of. It is the remote public key unless the remote
+// network key is attested by a separate operator key.
+func (ac *authenticatedConnection) RemoteOperatorPublicKey() *key.NetworkPublic {
+	return ac.remoteOperatorPublicKey
+}
@@ -10,11 +10,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
 	protoio "github.com/gogo/protobuf/io"
 	keepNet "github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/gen/pb"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/net/security/handshake"
+	"github.com/keep-network/keep-core/pkg/operator"
 	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 )
@@ -52,6 +55,7 @@ func TestPinnedAndMessageKeyMismatch(t *testing.T) {
 		responderConn,
 		responder.peerID,
 		responder.privKey,
+		nil,
 		firewall,
 	)
 	if err == nil {
@@ -153,6 +157,74 @@ func TestHandshake(t *testing.T) {
 	}
 }
 
+func TestHandshakeWithOperatorAttestation(t *testing.T) {
+	initiator := createAttestedTestConnectionConfig(t)
+	responder := createAttestedTestConnectionConfig(t)
+
+	// only operator keys meet firewall rules, network keys do not
+	firewall := newMockFirewall()
+	firewall.updatePeer(initiator.operatorPubKey, true)
+	firewall.updatePeer(responder.operatorPubKey, true)
+
+	authnInboundConn, authnOutboundConn, inboundError, outboundError :=
+		connectInitiatorAndResponder(initiator, responder, firewall, t)
+	if inboundError != nil {
+		t.Fatal(inboundError)
+	}
+	if outboundError != nil {
+		t.Fatal(outboundError)
+	}
+
+	if !reflect.DeepEqual(
+		key.Marshal(initiator.operatorPubKey),
+		key.Marshal(authnInboundConn.RemoteOperatorPublicKey()),
+	) {
+		t.Errorf(
+			"unexpected initiator operator key\nexpected: [%x]\nactual:   [%x]",
+			key.Marshal(initiator.operatorPubKey),
+			key.Marshal(authnInboundConn.RemoteOperatorPublicKey()),
+		)
+	}
+
+	if !reflect.DeepEqual(
+		key.Marshal(responder.operatorPubKey),
+		key.Marshal(authnOutboundConn.RemoteOperatorPublicKey()),
+	) {
+		t.Errorf(
+			"unexpected responder operator key\nexpected: [%x]\nactual:   [%x]",
+			key.Marshal(responder.operatorPubKey),
+			key.Marshal(authnOutboundConn.RemoteOperatorPublicKey()),
+		)
+	}
+}
+
+func TestHandshakeWithOperatorAttestationBlockedByFirewallRules(t *testing.T) {
+	initiator := createAttestedTestConnectionConfig(t)
+	responder := createAttestedTestConnectionConfig(t)
+
+	// the network key of the initiator meets firewall rules but the operator
+	// key it acts on behalf of does not
+	firewall := newMockFirewall()
+	firewall.updatePeer(initiator.pubKey, true)
+	firewall.updatePeer(responder.operatorPubKey, true)
+
+	_, _, inboundError, outboundError :=
+		connectInitiatorAndResponder(initiator, responder, firewall, t)
+
+	if inboundError != nil {
+		t.Fatal(inboundError)
+	}
+
+	expectedOutboundError := fmt.Errorf("connection handshake failed: [remote peer does not meet firewall criteria]")
+	if !reflect.DeepEqual(expectedOutboundError, outboundError) {
+		t.Fatalf(
+			"unexpected outbound connection error\nexpected: %v\nactual: %v",
+			expectedOutboundError,
+			outboundError,
+		)
+	}
+}
+
 func TestHandshakeInitiatorBlockedByFirewallRules(t *testing.T) {
 	_, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
@@ -229,22 +301,31 @@ func connectInitiatorAndResponder(
 		initiatorConn net.Conn,
 		initiatorPeerID peer.ID,
 		initiatorPrivKey libp2pcrypto.PrivKey,
+		initiatorOperatorAttestation []byte,
 		responderPeerID peer.ID,
 	) {
 		authnOutboundConn, outboundError = newAuthenticatedOutboundConnection(
 			initiatorConn,
 			initiatorPeerID,
 			initiatorPrivKey,
+			initiatorOperatorAttestation,
 			responderPeerID,
 			firewall,
 		)
 		done <- struct{}{}
-	}(initiatorConn, initiator.peerID, initiator.privKey, responder.peerID)
+	}(
+		initiatorConn,
+		initiator.peerID,
+		initiator.privKey,
+		initiator.operatorAttestation,
+		responder.peerID,
+	)
 
 	authnInboundConn, inboundError = newAuthenticatedInboundConnection(
 		responderConn,
 		responder.peerID,
 		responder.privKey,
+		responder.operatorAttestation,
 		firewall,
 	)
 
@@ -257,6 +338,9 @@ type testConnectionConfig struct {
 	privKey *key.NetworkPrivate
 	pubKey  *key.NetworkPublic
 	peerID  peer.ID
+
+	operatorAttestation []byte
+	operatorPubKey      *key.NetworkPublic
 }
 
 func createTestConnectionConfig(t *testing.T) *testConnectionConfig {
@@ -269,7 +353,52 @@ func createTestConnectionConfig(t *testing.T) *testConnectionConfig {
 		t.Fatal(err)
 	}
 
-	return &testConnectionConfig{privKey, pubKey, peerID}
+	return &testConnectionConfig{
+		privKey:        privKey,
+		pubKey:         pubKey,
+		peerID:         peerID,
+		operatorPubKey: pubKey,
+	}
+}
+
+// createAttestedTestConnectionConfig creates a connection config with
+// a network key attested by a separate operator key.
+func createAttestedTestConnectionConfig(t *testing.T) *testConnectionConfig {
+	config := createTestConnectionConfig(t)
+
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.operatorAttestation = attestNetworkKey(
+		t,
+		operatorPrivateKey,
+		config.pubKey,
+	)
+	_, config.operatorPubKey = key.OperatorKeyToNetworkKey(
+		operatorPrivateKey,
+		operatorPublicKey,
+	)
+
+	return config
+}
+
+func attestNetworkKey(
+	t *testing.T,
+	operatorPrivateKey *operator.PrivateKey,
+	networkPublicKey *key.NetworkPublic,
+) []byte {
+	attestation, err := crypto.Sign(
+		accounts.TextHash(key.OperatorAttestationMessage(networkPublicKey)),
+		operatorPrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attestation[64] += 27
+
+	return attestation
 }
 
 // Connect an initiator and responder via a full duplex network connection (reads
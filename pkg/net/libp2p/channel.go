@@ -14,7 +14,6 @@ import (
 	"github.com/keep-network/keep-core/pkg/net/internal"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
-	crypto "github.com/libp2p/go-libp2p-core/crypto"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -179,7 +178,13 @@ func (c *channel) publishToPubSub(message *pb.BroadcastNetworkMessage) error {
 	c.pubsubMutex.Lock()
 	defer c.pubsubMutex.Unlock()
 
-	return c.pubsub.Publish(c.name, messageBytes)
+	if err := c.pubsub.Publish(c.name, messageBytes); err != nil {
+		return err
+	}
+
+	observePubsubMessage(c.name, directionSent, len(messageBytes))
+
+	return nil
 }
 
 func (c *channel) handleMessages(ctx context.Context) {
@@ -230,6 +235,8 @@ func (c *channel) incomingMessageWorker(ctx context.Context) {
 }
 
 func (c *channel) processPubsubMessage(pubsubMessage *pubsub.Message) error {
+	observePubsubMessage(c.name, directionReceived, len(pubsubMessage.Data))
+
 	var messageProto pb.BroadcastNetworkMessage
 	if err := proto.Unmarshal(pubsubMessage.Data, &messageProto); err != nil {
 		return err
@@ -270,20 +277,13 @@ func (c *channel) processContainerMessage(
 		)
 	}
 
-	networkKey := key.Libp2pKeyToNetworkKey(senderIdentifier.pubKey)
-	if networkKey == nil {
-		return fmt.Errorf(
-			"sender [%v] with key [%v] is not of correct type",
-			senderIdentifier.id,
-			senderIdentifier.pubKey,
-		)
-	}
-
+	// Protocols identify the sender by the key of the operator the sender
+	// acts on behalf of, not by its network key.
 	netMessage := internal.BasicMessage(
 		senderIdentifier.id,
 		unmarshaled,
 		string(message.Type),
-		key.Marshal(networkKey),
+		key.Marshal(senderIdentifier.operatorPublicKey),
 		message.SequenceNumber,
 	)
 
@@ -332,7 +332,7 @@ func (c *channel) SetFilter(filter net.BroadcastChannelFilter) error {
 
 func createTopicValidator(filter net.BroadcastChannelFilter) pubsub.Validator {
 	return func(_ context.Context, _ peer.ID, message *pubsub.Message) bool {
-		authorPublicKey, err := extractPublicKey(message.GetFrom())
+		authorPublicKey, err := extractOperatorPublicKey(message)
 		if err != nil {
 			logger.Warningf(
 				"could not retrieve message author public key: [%v]",
@@ -344,16 +344,28 @@ func createTopicValidator(filter net.BroadcastChannelFilter) pubsub.Validator {
 	}
 }
 
-func extractPublicKey(peer peer.ID) (*ecdsa.PublicKey, error) {
-	publicKey, err := peer.ExtractPublicKey()
-	if err != nil {
+// extractOperatorPublicKey returns the key of the operator the author of the
+// given message acts on behalf of. The operator key is established from the
+// sender identity carried by the message, which has to match the author of
+// the message.
+func extractOperatorPublicKey(message *pubsub.Message) (*ecdsa.PublicKey, error) {
+	var messageProto pb.BroadcastNetworkMessage
+	if err := proto.Unmarshal(message.Data, &messageProto); err != nil {
+		return nil, err
+	}
+
+	senderIdentifier := &identity{}
+	if err := senderIdentifier.Unmarshal(messageProto.Sender); err != nil {
 		return nil, err
 	}
 
-	secp256k1PublicKey, ok := publicKey.(*crypto.Secp256k1PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("public key is of type other than Secp256k1")
+	if senderIdentifier.id != message.GetFrom() {
+		return nil, fmt.Errorf(
+			"message author [%v] does not match sender [%v]",
+			message.GetFrom(),
+			senderIdentifier.id,
+		)
 	}
 
-	return key.NetworkKeyToECDSAKey(secp256k1PublicKey), nil
+	return key.NetworkKeyToECDSAKey(senderIdentifier.operatorPublicKey), nil
 }
@@ -14,6 +14,9 @@ import (
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	"github.com/keep-network/keep-core/pkg/operator"
 	crypto "github.com/libp2p/go-libp2p-core/crypto"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -154,9 +157,11 @@ func TestUnregisterWhenHandling(t *testing.T) {
 }
 
 func TestCreateTopicValidator(t *testing.T) {
+	privateKeys := make([]crypto.PrivKey, 5)
 	publicKeys := make([]crypto.PubKey, 5)
 	for i := range publicKeys {
-		_, publicKey, _ := crypto.GenerateSecp256k1Key(rand.Reader)
+		privateKey, publicKey, _ := crypto.GenerateSecp256k1Key(rand.Reader)
+		privateKeys[i] = privateKey
 		publicKeys[i] = publicKey
 	}
 
@@ -173,12 +178,14 @@ func TestCreateTopicValidator(t *testing.T) {
 	validator := createTopicValidator(filter)
 
 	expectedResults := []bool{true, false, false, true, false}
-	for i, publicKey := range publicKeys {
-		authorID, _ := peer.IDFromPublicKey(publicKey)
-		authorIDBytes, _ := authorID.Marshal()
-		message := &pubsubpb.Message{From: authorIDBytes}
+	for i, privateKey := range privateKeys {
+		author, err := createIdentity(privateKey, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		message := newTopicValidatorTestMessage(t, author, author.id)
 
-		actualResult := validator(nil, peer.ID(i), &pubsub.Message{Message: message})
+		actualResult := validator(nil, peer.ID(i), message)
 
 		if expectedResults[i] != actualResult {
 			t.Errorf(
@@ -192,6 +199,95 @@ func TestCreateTopicValidator(t *testing.T) {
 	}
 }
 
+func TestCreateTopicValidatorWithOperatorAttestation(t *testing.T) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPrivateKey, networkPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	author, err := createIdentity(
+		networkPrivateKey,
+		attestNetworkKey(t, operatorPrivateKey, networkPublicKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, otherPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPeerID, err := peer.IDFromPublicKey(otherPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// only the operator is authorized, the network key of the author is not
+	validator := createTopicValidator(func(publicKey *ecdsa.PublicKey) bool {
+		return toEncodedBytes(publicKey) == toEncodedBytes(operatorPublicKey)
+	})
+
+	var tests = map[string]struct {
+		from           peer.ID
+		expectedResult bool
+	}{
+		"message from the author": {
+			from:           author.id,
+			expectedResult: true,
+		},
+		"message from other peer carrying the author identity": {
+			from:           otherPeerID,
+			expectedResult: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			message := newTopicValidatorTestMessage(t, author, test.from)
+
+			actualResult := validator(nil, test.from, message)
+
+			if test.expectedResult != actualResult {
+				t.Errorf(
+					"unexpected result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedResult,
+					actualResult,
+				)
+			}
+		})
+	}
+}
+
+func newTopicValidatorTestMessage(
+	t *testing.T,
+	author *identity,
+	from peer.ID,
+) *pubsub.Message {
+	authorBytes, err := author.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := (&pb.BroadcastNetworkMessage{Sender: authorBytes}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromBytes, err := from.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &pubsub.Message{
+		Message: &pubsubpb.Message{From: fromBytes, Data: data},
+	}
+}
+
 func toEcdsaPublicKey(publicKey crypto.PubKey) *ecdsa.PublicKey {
 	secp256k1PublicKey, _ := publicKey.(*crypto.Secp256k1PublicKey)
 	return (*btcec.PublicKey)(secp256k1PublicKey).ToECDSA()
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/net/key"
 
 	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	peer "github.com/libp2p/go-libp2p-core/peer"
@@ -15,15 +16,28 @@ import (
 //
 // Consumers of the net package require an ID to register with protocol level
 // IDs, as well as a public key for authentication.
+//
+// The network key of the identity is either the operator key or a separate
+// key attested by the operator key. In both cases, operatorPublicKey is the
+// key of the operator the identity acts on behalf of.
 type identity struct {
 	id      peer.ID
 	pubKey  libp2pcrypto.PubKey
 	privKey libp2pcrypto.PrivKey
+
+	operatorAttestation []byte
+	operatorPublicKey   *key.NetworkPublic
 }
 
 type networkIdentity peer.ID
 
-func createIdentity(privateKey libp2pcrypto.PrivKey) (*identity, error) {
+// createIdentity creates an identity with the given network private key and
+// an optional attestation of the network key by the operator key. Without
+// the attestation, the network key is the operator key.
+func createIdentity(
+	privateKey libp2pcrypto.PrivKey,
+	operatorAttestation []byte,
+) (*identity, error) {
 	peerID, err := peer.IDFromPublicKey(privateKey.GetPublic())
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -31,7 +45,40 @@ func createIdentity(privateKey libp2pcrypto.PrivKey) (*identity, error) {
 		)
 	}
 
-	return &identity{peerID, privateKey.GetPublic(), privateKey}, nil
+	operatorPublicKey, err := recoverOperatorKey(
+		privateKey.GetPublic(),
+		operatorAttestation,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &identity{
+		id:                  peerID,
+		pubKey:              privateKey.GetPublic(),
+		privKey:             privateKey,
+		operatorAttestation: operatorAttestation,
+		operatorPublicKey:   operatorPublicKey,
+	}, nil
+}
+
+// recoverOperatorKey returns the key of the operator the given network key
+// acts on behalf of. If the attestation is empty, the network key is the
+// operator key.
+func recoverOperatorKey(
+	networkPublicKey libp2pcrypto.PubKey,
+	operatorAttestation []byte,
+) (*key.NetworkPublic, error) {
+	networkKey := key.Libp2pKeyToNetworkKey(networkPublicKey)
+	if networkKey == nil {
+		return nil, fmt.Errorf("unexpected type of network public key")
+	}
+
+	if len(operatorAttestation) == 0 {
+		return networkKey, nil
+	}
+
+	return key.RecoverOperatorKey(networkKey, operatorAttestation)
 }
 
 func (ni networkIdentity) String() string {
@@ -55,7 +102,10 @@ func (i *identity) Marshal() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return (&pb.Identity{PubKey: pubKeyBytes}).Marshal()
+	return (&pb.Identity{
+		PubKey:              pubKeyBytes,
+		OperatorAttestation: i.operatorAttestation,
+	}).Marshal()
 }
 
 func (i *identity) Unmarshal(bytes []byte) error {
@@ -78,5 +128,14 @@ func (i *identity) Unmarshal(bytes []byte) error {
 	}
 	i.id = pid
 
+	i.operatorPublicKey, err = recoverOperatorKey(
+		i.pubKey,
+		pbIdentity.OperatorAttestation,
+	)
+	if err != nil {
+		return err
+	}
+	i.operatorAttestation = pbIdentity.OperatorAttestation
+
 	return nil
 }
@@ -60,6 +60,16 @@ type Config struct {
 	Peers              []string
 	Port               int
 	AnnouncedAddresses []string
+	// Observers lists addresses of observer clients accepted as peers even
+	// though they have no minimum stake. An observer logs its address, which
+	// is not related to any operator, when it starts. Observers follow and
+	// forward network messages but never take part in a protocol.
+	Observers []string
+	// BatchMessages lets the client combine messages of its several members
+	// of one group into one network message. Clients which do not support
+	// batches drop them, so batching should be enabled only once all the
+	// peers support it.
+	BatchMessages bool
 }
 
 type provider struct {
@@ -129,8 +139,20 @@ func (p *provider) BroadcastChannelForwarderFor(name string) {
 	}
 }
 
+func (p *provider) Close() error {
+	logger.Infof("closing libp2p host")
+
+	if err := p.routing.Close(); err != nil {
+		logger.Warningf("could not close DHT: [%v]", err)
+	}
+
+	return p.host.Close()
+}
+
 type connectionManager struct {
 	host.Host
+
+	operatorKeys *operatorKeys
 }
 
 func (cm *connectionManager) ConnectedPeers() []string {
@@ -141,6 +163,9 @@ func (cm *connectionManager) ConnectedPeers() []string {
 	return peers
 }
 
+// GetPeerPublicKey returns the public key of the operator the given connected
+// peer acts on behalf of. It is the network key of the peer unless the network
+// key is attested by a separate operator key.
 func (cm *connectionManager) GetPeerPublicKey(connectedPeer string) (*key.NetworkPublic, error) {
 	peerID, err := peer.IDB58Decode(connectedPeer)
 	if err != nil {
@@ -151,6 +176,10 @@ func (cm *connectionManager) GetPeerPublicKey(connectedPeer string) (*key.Networ
 		)
 	}
 
+	if operatorKey, found := cm.operatorKeys.get(peerID); found {
+		return operatorKey, nil
+	}
+
 	peerPublicKey, err := peerID.ExtractPublicKey()
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -193,6 +222,7 @@ func (cm *connectionManager) AddrStrings() []string {
 // ConnectOptions allows to set various options used by libp2p.
 type ConnectOptions struct {
 	RoutingTableRefreshPeriod time.Duration
+	OperatorAttestation       []byte
 }
 
 func defaultConnectOptions() *ConnectOptions {
@@ -220,6 +250,15 @@ func WithRoutingTableRefreshPeriod(period time.Duration) ConnectOption {
 	}
 }
 
+// WithOperatorAttestation sets the attestation of the static network key by
+// the operator key, for operators whose network key is not the operator key.
+// Peers act towards the client as if it was using the operator key.
+func WithOperatorAttestation(attestation []byte) ConnectOption {
+	return func(options *ConnectOptions) {
+		options.OperatorAttestation = attestation
+	}
+}
+
 // Connect connects to a libp2p network based on the provided config. The
 // connection is managed in part by the passed context, and provides access to
 // the functionality specified in the net.Provider interface.
@@ -237,23 +276,33 @@ func Connect(
 	connectOptions := defaultConnectOptions()
 	connectOptions.apply(options...)
 
-	identity, err := createIdentity(staticKey)
+	firewall = &instrumentedFirewall{firewall}
+
+	go removeStalePubsubTopics(ctx)
+
+	identity, err := createIdentity(
+		staticKey,
+		connectOptions.OperatorAttestation,
+	)
 	if err != nil {
 		return nil, err
 	}
 
+	operatorKeys := newOperatorKeys()
+
 	host, err := discoverAndListen(
 		ctx,
 		identity,
 		config.Port,
 		config.AnnouncedAddresses,
 		firewall,
+		operatorKeys,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	host.Network().Notify(buildNotifiee())
+	host.Network().Notify(buildNotifiee(operatorKeys))
 
 	broadcastChannelManager, err := newChannelManager(ctx, identity, host, ticker)
 	if err != nil {
@@ -294,7 +343,7 @@ func Connect(
 		return nil, fmt.Errorf("Failed to bootstrap nodes with err: %v", err)
 	}
 
-	provider.connectionManager = &connectionManager{provider.host}
+	provider.connectionManager = &connectionManager{provider.host, operatorKeys}
 
 	// Instantiates and starts the connection management background process
 	watchtower.NewGuard(
@@ -310,6 +359,7 @@ func discoverAndListen(
 	port int,
 	announcedAddresses []string,
 	firewall net.Firewall,
+	operatorKeys *operatorKeys,
 ) (host.Host, error) {
 	var err error
 
@@ -321,7 +371,9 @@ func discoverAndListen(
 
 	transport, err := newEncryptedAuthenticatedTransport(
 		identity.privKey,
+		identity.operatorAttestation,
 		firewall,
+		operatorKeys,
 	)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -432,10 +484,11 @@ func extractMultiAddrFromPeers(peers []string) ([]peerstore.PeerInfo, error) {
 	return peerInfos, nil
 }
 
-func buildNotifiee() libp2pnet.Notifiee {
+func buildNotifiee(operatorKeys *operatorKeys) libp2pnet.Notifiee {
 	notifyBundle := &libp2pnet.NotifyBundle{}
 
-	notifyBundle.ConnectedF = func(_ libp2pnet.Network, connection libp2pnet.Conn) {
+	notifyBundle.ConnectedF = func(network libp2pnet.Network, connection libp2pnet.Conn) {
+		connectedPeers.Set(float64(len(network.Peers())))
 		logger.Infof(
 			"established connection to [%v]",
 			multiaddressWithIdentity(
@@ -444,7 +497,11 @@ func buildNotifiee() libp2pnet.Notifiee {
 			),
 		)
 	}
-	notifyBundle.DisconnectedF = func(_ libp2pnet.Network, connection libp2pnet.Conn) {
+	notifyBundle.DisconnectedF = func(network libp2pnet.Network, connection libp2pnet.Conn) {
+		connectedPeers.Set(float64(len(network.Peers())))
+		if network.Connectedness(connection.RemotePeer()) != libp2pnet.Connected {
+			operatorKeys.remove(connection.RemotePeer())
+		}
 		logger.Infof(
 			"disconnected from [%v]",
 			multiaddressWithIdentity(
@@ -88,7 +88,7 @@ func TestSendReceive(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	identity, err := createIdentity(privKey)
+	identity, err := createIdentity(privKey, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
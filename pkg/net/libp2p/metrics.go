@@ -0,0 +1,147 @@
+package libp2p
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/keep-network/keep-core/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+var (
+	connectedPeers = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "network",
+			Name:      "connected_peers",
+			Help:      "Number of peers the client is connected to.",
+		},
+	)
+
+	pubsubMessages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "network",
+			Name:      "pubsub_messages_total",
+			Help: "Pubsub messages sent and received on the given topic. " +
+				"Topics without traffic for 6 hours are removed.",
+		},
+		[]string{"topic", "direction"},
+	)
+
+	pubsubBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "network",
+			Name:      "pubsub_bytes_total",
+			Help: "Bytes of pubsub messages sent and received on the given " +
+				"topic. Topics without traffic for 6 hours are removed.",
+		},
+		[]string{"topic", "direction"},
+	)
+
+	firewallRejections = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "network",
+			Name:      "firewall_rejections_total",
+			Help:      "Remote peers rejected by the firewall.",
+		},
+	)
+)
+
+const (
+	directionSent     = "sent"
+	directionReceived = "received"
+)
+
+const (
+	// pubsubTopicStaleness is the time after which metrics of a pubsub topic
+	// without any traffic are removed. Most of the topics are used by a
+	// single DKG or group, so keeping metrics of all of them would grow the
+	// number of exported series without bound.
+	pubsubTopicStaleness = 6 * time.Hour
+	// pubsubTopicCheckTick is the amount of time between periodic checks of
+	// pubsub topics for staleness.
+	pubsubTopicCheckTick = 10 * time.Minute
+)
+
+var (
+	pubsubTopicsMutex sync.Mutex
+	// pubsubTopics holds the time of the last message on each pubsub topic
+	// metrics are exported for.
+	pubsubTopics = make(map[string]time.Time)
+)
+
+func init() {
+	prometheus.MustRegister(
+		connectedPeers,
+		pubsubMessages,
+		pubsubBytes,
+		firewallRejections,
+	)
+}
+
+// observePubsubMessage records a pubsub message of the given size sent or
+// received on the given topic.
+func observePubsubMessage(topic string, direction string, size int) {
+	pubsubTopicsMutex.Lock()
+	defer pubsubTopicsMutex.Unlock()
+
+	pubsubTopics[topic] = time.Now()
+
+	pubsubMessages.WithLabelValues(topic, direction).Inc()
+	pubsubBytes.WithLabelValues(topic, direction).Add(float64(size))
+}
+
+// removeStalePubsubTopics periodically removes metrics of pubsub topics which
+// had no traffic for pubsubTopicStaleness, until the given context is done.
+func removeStalePubsubTopics(ctx context.Context) {
+	ticker := time.NewTicker(pubsubTopicCheckTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			pubsubTopicsMutex.Lock()
+			for topic, lastMessage := range pubsubTopics {
+				if now.Sub(lastMessage) < pubsubTopicStaleness {
+					continue
+				}
+
+				for _, direction := range []string{
+					directionSent,
+					directionReceived,
+				} {
+					pubsubMessages.DeleteLabelValues(topic, direction)
+					pubsubBytes.DeleteLabelValues(topic, direction)
+				}
+				delete(pubsubTopics, topic)
+			}
+			pubsubTopicsMutex.Unlock()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// instrumentedFirewall counts remote peers rejected by the wrapped firewall,
+// both when connecting and in periodic checks of connected peers.
+type instrumentedFirewall struct {
+	firewall net.Firewall
+}
+
+func (inf *instrumentedFirewall) Validate(
+	remotePeerPublicKey *ecdsa.PublicKey,
+) error {
+	err := inf.firewall.Validate(remotePeerPublicKey)
+	if err != nil {
+		firewallRejections.Inc()
+	}
+
+	return err
+}
@@ -13,7 +13,7 @@ func TestSignAndVerify(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	identity, err := createIdentity(privateKey)
+	identity, err := createIdentity(privateKey, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -46,7 +46,7 @@ func TestAllFieldsButSignatureAreTaken(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	identity, err := createIdentity(privateKey)
+	identity, err := createIdentity(privateKey, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
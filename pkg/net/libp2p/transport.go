@@ -3,10 +3,12 @@ package libp2p
 import (
 	"context"
 	"net"
+	"sync"
 
 	secio "github.com/libp2p/go-libp2p-secio"
 
 	keepNet "github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
 	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/sec"
@@ -22,15 +24,19 @@ var _ sec.SecureConn = (*authenticatedConnection)(nil)
 
 // transport constructs an encrypted and authenticated connection for a peer.
 type transport struct {
-	localPeerID     peer.ID
-	privateKey      libp2pcrypto.PrivKey
-	firewall        keepNet.Firewall
-	encryptionLayer sec.SecureTransport
+	localPeerID         peer.ID
+	privateKey          libp2pcrypto.PrivKey
+	operatorAttestation []byte
+	firewall            keepNet.Firewall
+	encryptionLayer     sec.SecureTransport
+	operatorKeys        *operatorKeys
 }
 
 func newEncryptedAuthenticatedTransport(
 	pk libp2pcrypto.PrivKey,
+	operatorAttestation []byte,
 	firewall keepNet.Firewall,
+	operatorKeys *operatorKeys,
 ) (*transport, error) {
 	id, err := peer.IDFromPrivateKey(pk)
 	if err != nil {
@@ -43,10 +49,12 @@ func newEncryptedAuthenticatedTransport(
 	}
 
 	return &transport{
-		localPeerID:     id,
-		privateKey:      pk,
-		firewall:        firewall,
-		encryptionLayer: encryptionLayer,
+		localPeerID:         id,
+		privateKey:          pk,
+		operatorAttestation: operatorAttestation,
+		firewall:            firewall,
+		encryptionLayer:     encryptionLayer,
+		operatorKeys:        operatorKeys,
 	}, nil
 }
 
@@ -60,12 +68,23 @@ func (t *transport) SecureInbound(
 		return nil, err
 	}
 
-	return newAuthenticatedInboundConnection(
+	authenticatedConnection, err := newAuthenticatedInboundConnection(
 		encryptedConnection,
 		t.localPeerID,
 		t.privateKey,
+		t.operatorAttestation,
 		t.firewall,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	t.operatorKeys.add(
+		authenticatedConnection.RemotePeer(),
+		authenticatedConnection.RemoteOperatorPublicKey(),
+	)
+
+	return authenticatedConnection, nil
 }
 
 // SecureOutbound secures an outbound connection.
@@ -83,11 +102,58 @@ func (t *transport) SecureOutbound(
 		return nil, err
 	}
 
-	return newAuthenticatedOutboundConnection(
+	authenticatedConnection, err := newAuthenticatedOutboundConnection(
 		encryptedConnection,
 		t.localPeerID,
 		t.privateKey,
+		t.operatorAttestation,
 		remotePeerID,
 		t.firewall,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	t.operatorKeys.add(
+		authenticatedConnection.RemotePeer(),
+		authenticatedConnection.RemoteOperatorPublicKey(),
+	)
+
+	return authenticatedConnection, nil
+}
+
+// operatorKeys keeps keys of operators the connected peers act on behalf of,
+// as established in the connection handshake. The key of a peer is removed
+// once the last connection with the peer is closed.
+type operatorKeys struct {
+	mutex sync.RWMutex
+	keys  map[peer.ID]*key.NetworkPublic
+}
+
+func newOperatorKeys() *operatorKeys {
+	return &operatorKeys{
+		keys: make(map[peer.ID]*key.NetworkPublic),
+	}
+}
+
+func (ok *operatorKeys) add(peerID peer.ID, operatorKey *key.NetworkPublic) {
+	ok.mutex.Lock()
+	defer ok.mutex.Unlock()
+
+	ok.keys[peerID] = operatorKey
+}
+
+func (ok *operatorKeys) remove(peerID peer.ID) {
+	ok.mutex.Lock()
+	defer ok.mutex.Unlock()
+
+	delete(ok.keys, peerID)
+}
+
+func (ok *operatorKeys) get(peerID peer.ID) (*key.NetworkPublic, bool) {
+	ok.mutex.RLock()
+	defer ok.mutex.RUnlock()
+
+	operatorKey, found := ok.keys[peerID]
+	return operatorKey, found
 }
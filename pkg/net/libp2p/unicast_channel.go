@@ -265,20 +265,13 @@ func (uc *unicastChannel) processMessage(message *pb.UnicastNetworkMessage) erro
 		return err
 	}
 
-	networkKey := key.Libp2pKeyToNetworkKey(senderIdentifier.pubKey)
-	if networkKey == nil {
-		return fmt.Errorf(
-			"sender [%v] with key [%v] is not of correct type",
-			senderIdentifier.id,
-			senderIdentifier.pubKey,
-		)
-	}
-
+	// Protocols identify the sender by the key of the operator the sender
+	// acts on behalf of, not by its network key.
 	uc.deliver(internal.BasicMessage(
 		senderIdentifier.id,
 		unmarshaled,
 		string(message.Type),
-		key.Marshal(networkKey),
+		key.Marshal(senderIdentifier.operatorPublicKey),
 		uint64(0),
 	))
 
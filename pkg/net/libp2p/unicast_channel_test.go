@@ -162,7 +162,7 @@ func withNetwork(
 		t.Fatal(err)
 	}
 
-	identity1, err := createIdentity(privKey1)
+	identity1, err := createIdentity(privKey1, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -181,7 +181,7 @@ func withNetwork(
 		t.Fatal(err)
 	}
 
-	identity2, err := createIdentity(privKey2)
+	identity2, err := createIdentity(privKey2, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -65,6 +65,9 @@ type Provider interface {
 
 	// BroadcastChannelForwarderFor creates a message relay for given channel name.
 	BroadcastChannelForwarderFor(name string)
+
+	// Close disconnects from all peers and stops the provider.
+	Close() error
 }
 
 // ConnectionManager is an interface which exposes peers a client is connected
@@ -10,7 +10,15 @@ import (
 type Ticker struct {
 	ticks         <-chan uint64
 	handlersMutex sync.Mutex
-	handlers      map[context.Context]func()
+	handlers      []*tickHandler
+}
+
+// tickHandler is a handler called on each tick until its context is done.
+// Several handlers may share the same context, e.g. when more than one
+// message is sent in the same protocol phase.
+type tickHandler struct {
+	ctx    context.Context
+	handle func()
 }
 
 // NewTicker creates and starts a new Ticker for the provided channel.
@@ -19,7 +27,7 @@ type Ticker struct {
 func NewTicker(ticks <-chan uint64) *Ticker {
 	ticker := &Ticker{
 		ticks:    ticks,
-		handlers: make(map[context.Context]func()),
+		handlers: make([]*tickHandler, 0),
 	}
 
 	go ticker.start()
@@ -56,25 +64,27 @@ func (t *Ticker) start() {
 	for range t.ticks {
 		t.handlersMutex.Lock()
 
-		for ctx, handler := range t.handlers {
-			if ctx.Err() != nil {
-				delete(t.handlers, ctx)
+		activeHandlers := t.handlers[:0]
+		for _, handler := range t.handlers {
+			if handler.ctx.Err() != nil {
 				continue
 			}
 
-			handler()
+			handler.handle()
+			activeHandlers = append(activeHandlers, handler)
 		}
+		t.handlers = activeHandlers
 
 		t.handlersMutex.Unlock()
 	}
 
-	for ctx := range t.handlers {
-		delete(t.handlers, ctx)
-	}
+	t.handlersMutex.Lock()
+	t.handlers = nil
+	t.handlersMutex.Unlock()
 }
 
 func (t *Ticker) onTick(ctx context.Context, handler func()) {
 	t.handlersMutex.Lock()
-	t.handlers[ctx] = handler
+	t.handlers = append(t.handlers, &tickHandler{ctx, handler})
 	t.handlersMutex.Unlock()
 }
@@ -25,6 +25,31 @@ func TestOnTick(t *testing.T) {
 	}
 }
 
+func TestOnTickSharedContext(t *testing.T) {
+	ticks := make(chan uint64)
+	ticker := NewTicker(ticks)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tickCount1 := 0
+	ticker.onTick(ctx, func() { tickCount1++ })
+
+	tickCount2 := 0
+	ticker.onTick(ctx, func() { tickCount2++ })
+
+	ticks <- 1
+	ticks <- 2
+	time.Sleep(10 * time.Millisecond)
+
+	if tickCount1 != 2 {
+		t.Errorf("expected [2] executions of the first handler, had [%v]", tickCount1)
+	}
+	if tickCount2 != 2 {
+		t.Errorf("expected [2] executions of the second handler, had [%v]", tickCount2)
+	}
+}
+
 func TestOnTickTimeTicker(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 105*time.Millisecond)
 	defer cancel()
@@ -0,0 +1,232 @@
+// Package status implements a read-only HTTP API exposing what a running
+// client is currently doing. All responses are JSON documents.
+//
+// The following endpoints are served:
+//   - /groups - groups and memberships held by the client,
+//   - /pending - group selections and relay requests in progress,
+//   - /peers - peers the client is connected to,
+//   - /block - the current block,
+//   - /phases - current phases of DKG and signing state machines.
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+	"github.com/keep-network/keep-core/pkg/chain"
+	keepNet "github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+var logger = log.Logger("keep-status")
+
+// DefaultHost is the host the API is bound to if none is configured. The API
+// is served only locally by default.
+const DefaultHost = "localhost"
+
+// shutdownTimeout is the maximum time the server waits for requests being
+// served to complete when it is shut down.
+const shutdownTimeout = 5 * time.Second
+
+// Config contains the configuration of the status API.
+type Config struct {
+	// Port is the port the API is served on. The API is disabled if the
+	// port is not set.
+	Port int
+	// Host is the host the API is bound to. Defaults to localhost.
+	Host string
+}
+
+// host returns the host the API is bound to.
+func (c *Config) host() string {
+	if c.Host == "" {
+		return DefaultHost
+	}
+
+	return c.Host
+}
+
+// Pending describes work started in response to chain events which is still
+// in progress.
+type Pending struct {
+	GroupSelections []string `json:"groupSelections"`
+	RelayRequests   []string `json:"relayRequests"`
+}
+
+// Peer describes a peer the client is connected to.
+type Peer struct {
+	ID              string `json:"id"`
+	OperatorAddress string `json:"operatorAddress"`
+}
+
+// Block describes the current block seen by the client.
+type Block struct {
+	Number uint64 `json:"number"`
+}
+
+// Start starts serving the status API in the background if it is enabled in
+// the given config. The API is stopped when the given context is done. The
+// beacon status may be nil if the client does not take part in the random
+// beacon, e.g. in the observer mode; groups and pending work are reported
+// as empty then.
+func Start(
+	ctx context.Context,
+	config Config,
+	beaconStatus *beacon.Status,
+	connectionManager keepNet.ConnectionManager,
+	blockCounter chain.BlockCounter,
+) error {
+	if config.Port == 0 {
+		logger.Infof("status API disabled")
+		return nil
+	}
+
+	address := net.JoinHostPort(config.host(), strconv.Itoa(config.Port))
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf(
+			"could not listen on [%v] for the status API: [%v]",
+			address,
+			err,
+		)
+	}
+
+	server := &http.Server{
+		Handler: newHandler(beaconStatus, connectionManager, blockCounter),
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(
+			context.Background(),
+			shutdownTimeout,
+		)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Warningf("could not shut down the status API: [%v]", err)
+		}
+	}()
+
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			logger.Errorf("status API failed: [%v]", err)
+		}
+	}()
+
+	logger.Infof("serving status API on [%v]", listener.Addr())
+
+	return nil
+}
+
+func newHandler(
+	beaconStatus *beacon.Status,
+	connectionManager keepNet.ConnectionManager,
+	blockCounter chain.BlockCounter,
+) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle("/groups", readOnly(func() (interface{}, error) {
+		if beaconStatus == nil {
+			return []*beacon.GroupMemberships{}, nil
+		}
+
+		return beaconStatus.Groups(), nil
+	}))
+
+	mux.Handle("/pending", readOnly(func() (interface{}, error) {
+		pending := &Pending{
+			GroupSelections: []string{},
+			RelayRequests:   []string{},
+		}
+		if beaconStatus != nil {
+			pending.GroupSelections = beaconStatus.PendingGroupSelections()
+			pending.RelayRequests = beaconStatus.PendingRelayRequests()
+		}
+
+		return pending, nil
+	}))
+
+	mux.Handle("/peers", readOnly(func() (interface{}, error) {
+		peers := make([]*Peer, 0)
+		for _, connectedPeer := range connectionManager.ConnectedPeers() {
+			peer := &Peer{ID: connectedPeer}
+
+			publicKey, err := connectionManager.GetPeerPublicKey(connectedPeer)
+			if err != nil {
+				logger.Warningf(
+					"could not get public key of peer [%v]: [%v]",
+					connectedPeer,
+					err,
+				)
+			} else {
+				peer.OperatorAddress = key.NetworkPubKeyToEthAddress(publicKey)
+			}
+
+			peers = append(peers, peer)
+		}
+
+		return peers, nil
+	}))
+
+	mux.Handle("/block", readOnly(func() (interface{}, error) {
+		currentBlock, err := blockCounter.CurrentBlock()
+		if err != nil {
+			return nil, fmt.Errorf("could not read current block: [%v]", err)
+		}
+
+		return &Block{Number: currentBlock}, nil
+	}))
+
+	mux.Handle("/phases", readOnly(func() (interface{}, error) {
+		return state.InFlightPhases(), nil
+	}))
+
+	return mux
+}
+
+// readOnly returns a handler serving the value returned by the given
+// function as JSON. Only GET requests are accepted.
+func readOnly(value func() (interface{}, error)) http.Handler {
+	return http.HandlerFunc(func(
+		writer http.ResponseWriter,
+		request *http.Request,
+	) {
+		if request.Method != http.MethodGet {
+			writer.Header().Set("Allow", http.MethodGet)
+			http.Error(
+				writer,
+				"method not allowed",
+				http.StatusMethodNotAllowed,
+			)
+			return
+		}
+
+		response, err := value()
+		if err != nil {
+			logger.Errorf("could not serve [%v]: [%v]", request.URL.Path, err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(writer).Encode(response); err != nil {
+			logger.Warningf(
+				"could not write response to [%v]: [%v]",
+				request.URL.Path,
+				err,
+			)
+		}
+	})
+}
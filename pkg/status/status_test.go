@@ -0,0 +1,147 @@
+package status
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/net/key"
+)
+
+func TestServesStatus(t *testing.T) {
+	_, peerPublicKey, err := key.GenerateStaticNetworkKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	connectionManager := &stubConnectionManager{
+		peers: map[string]*key.NetworkPublic{"peer-1": peerPublicKey},
+	}
+
+	blockCounter, err := chainLocal.Connect(5, 3, big.NewInt(200)).BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(newHandler(nil, connectionManager, blockCounter))
+	defer server.Close()
+
+	var tests = map[string]struct {
+		method           string
+		path             string
+		expectedStatus   int
+		expectedResponse string
+	}{
+		"groups": {
+			method:           http.MethodGet,
+			path:             "/groups",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: "[]",
+		},
+		"pending": {
+			method:           http.MethodGet,
+			path:             "/pending",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: `{"groupSelections":[],"relayRequests":[]}`,
+		},
+		"peers": {
+			method:         http.MethodGet,
+			path:           "/peers",
+			expectedStatus: http.StatusOK,
+			expectedResponse: fmt.Sprintf(
+				`[{"id":"peer-1","operatorAddress":"%v"}]`,
+				key.NetworkPubKeyToEthAddress(peerPublicKey),
+			),
+		},
+		"block": {
+			method:           http.MethodGet,
+			path:             "/block",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: `{"number":`,
+		},
+		"phases": {
+			method:           http.MethodGet,
+			path:             "/phases",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: "[]",
+		},
+		"write request": {
+			method:           http.MethodPost,
+			path:             "/groups",
+			expectedStatus:   http.StatusMethodNotAllowed,
+			expectedResponse: "method not allowed",
+		},
+		"unknown endpoint": {
+			method:         http.MethodGet,
+			path:           "/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			request, err := http.NewRequest(
+				test.method,
+				server.URL+test.path,
+				nil,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+
+			if response.StatusCode != test.expectedStatus {
+				t.Errorf(
+					"unexpected status\nexpected: [%v]\nactual:   [%v]",
+					test.expectedStatus,
+					response.StatusCode,
+				)
+			}
+
+			body, err := ioutil.ReadAll(response.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(string(body), test.expectedResponse) {
+				t.Errorf(
+					"unexpected response\nexpected: [%v]\nactual:   [%v]",
+					test.expectedResponse,
+					string(body),
+				)
+			}
+		})
+	}
+}
+
+type stubConnectionManager struct {
+	peers map[string]*key.NetworkPublic
+}
+
+func (scm *stubConnectionManager) ConnectedPeers() []string {
+	peers := make([]string, 0, len(scm.peers))
+	for peer := range scm.peers {
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+func (scm *stubConnectionManager) GetPeerPublicKey(
+	connectedPeer string,
+) (*key.NetworkPublic, error) {
+	return scm.peers[connectedPeer], nil
+}
+
+func (scm *stubConnectionManager) DisconnectPeer(connectedPeer string) {}
+
+func (scm *stubConnectionManager) AddrStrings() []string {
+	return []string{}
+}